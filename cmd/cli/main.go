@@ -2,34 +2,122 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/google/uuid"
+	"github.com/mattn/go-isatty"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 
 	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	"kodevibe/pkg/codeowners"
 	"kodevibe/pkg/config"
+	"kodevibe/pkg/dashboard"
+	"kodevibe/pkg/doctor"
 	"kodevibe/pkg/fix"
+	"kodevibe/pkg/hooks"
+	"kodevibe/pkg/integrations"
 	"kodevibe/pkg/report"
 	"kodevibe/pkg/scanner"
+	"kodevibe/pkg/scoring"
 	"kodevibe/pkg/server"
+	"kodevibe/pkg/tui"
+	"kodevibe/pkg/vibes"
 	"kodevibe/pkg/watch"
 )
 
+// Exit codes, as documented in the README. Usage and internal errors use the
+// codes of sysexits.h.
+const (
+	// exitCodeIssues is returned in CI mode when the scan found blocking issues
+	exitCodeIssues = 1
+	// exitCodeIncomplete is returned when a scan was interrupted and only partial results were reported
+	exitCodeIncomplete = 2
+	// exitCodeRequiredVibes is returned when a vibe named in --require-vibes did not run or examined no files
+	exitCodeRequiredVibes = 3
+	// exitCodeNoFiles is returned with --fail-on-no-files when the paths and filters matched no files
+	exitCodeNoFiles = 4
+	// exitCodeUsage is returned for invalid flags, arguments or configuration
+	exitCodeUsage = 64
+	// exitCodeInternal is returned when the scan or its report failed
+	exitCodeInternal = 70
+	// exitCodeTimeout is returned when --timeout ended the scan, as timeout(1) does
+	exitCodeTimeout = 124
+)
+
+// exitError is an error the command exits with a specific code for
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+func (e *exitError) Unwrap() error { return e.err }
+
+// usageError marks an error in the flags, arguments or configuration
+func usageError(err error) error {
+	return &exitError{code: exitCodeUsage, err: err}
+}
+
+// internalError marks an error of a scan that could not run or be reported
+func internalError(err error) error {
+	return &exitError{code: exitCodeInternal, err: err}
+}
+
+// silentExit ends a command that has already reported why it failed with
+// code, so neither cobra nor main prints an error or usage after it
+func silentExit(code int) error {
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	return &exitError{code: code}
+}
+
+// exitCode is the code the process exits with after a command failed with
+// err. Other errors exit with 1, as they always have.
+func exitCode(err error) int {
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return 1
+}
+
 var (
 	cfgFile   string
 	verbose   bool
 	quiet     bool
 	configMgr *config.Manager
 	logger    *logrus.Logger
+
+	// configErr records why the config file could not be loaded; see
+	// checkConfig
+	configErr error
 )
 
+// requiresConfigAnnotation marks commands that refuse to run on the built-in
+// defaults when the config file is invalid, since that would silently change
+// what they scan and how they gate
+const requiresConfigAnnotation = "kodevibe/requires-config"
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "kodevibe",
@@ -50,35 +138,49 @@ Goes far beyond secret detection to catch all common development pitfalls before
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		var exit *exitError
+		if !errors.As(err, &exit) || exit.err != nil {
+			fmt.Println(err)
+		}
+		os.Exit(exitCode(err))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRunE = checkConfig
+
+	// Flag parsing errors are usage errors for every command
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError(err)
+	})
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .kodevibe.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .kodevibe/config.yaml, then .kodevibe.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "quiet output")
 
 	// Initialize logger
 	logger = logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
+	utils.InstallRedaction(logger)
 
 	// Add commands
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(hooksCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(suppressionsCmd)
 	rootCmd.AddCommand(fixCmd)
 	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(planCmd)
 	rootCmd.AddCommand(profileCmd)
 	rootCmd.AddCommand(watchCmd)
 	rootCmd.AddCommand(serverCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(languagesCmd)
 }
 
 func initConfig() {
@@ -98,12 +200,8 @@ func initConfig() {
 
 	viper.AutomaticEnv()
 
-	if err := configMgr.LoadConfig(cfgFile); err != nil {
-		// Use default config if loading fails
-		if verbose {
-			fmt.Printf("Warning: Could not load config: %v\n", err)
-		}
-	}
+	// checkConfig decides whether the command may go on with the defaults
+	configErr = configMgr.LoadConfig(cfgFile)
 
 	// Set log level
 	if verbose {
@@ -133,18 +231,53 @@ Examples:
 }
 
 func init() {
-	scanCmd.Flags().StringSlice("vibes", []string{}, "Comma-separated list of vibes to run (security,code,performance,file,git,dependency,documentation)")
+	scanCmd.Annotations = map[string]string{requiresConfigAnnotation: "true"}
+	scanCmd.Flags().StringSlice("vibes", []string{}, "Comma-separated list of vibes to run (security,code,performance,file,git,dependency,documentation), or \"all\" for every vibe and \"default\" for config-enabled ones")
 	scanCmd.Flags().StringSlice("exclude", []string{}, "Additional file patterns to exclude")
-	scanCmd.Flags().String("min-severity", "info", "Minimum severity level (error, warning, info)")
-	scanCmd.Flags().String("format", "text", "Output format (text, json, html, junit)")
+	scanCmd.Flags().StringSlice("languages", []string{}, "Only analyze files of these languages (e.g. go,ts); see 'kodevibe languages'")
+	scanCmd.Flags().String("min-severity", "info", "Minimum severity level (critical, error, warning, info)")
+	scanCmd.Flags().String("format", "text", "Output format (text, json, ndjson, sarif, html, xml, junit, csv, defectdojo); a comma-separated list with --output-dir")
 	scanCmd.Flags().String("output", "", "Output file path")
+	scanCmd.Flags().StringSlice("csv-columns", []string{}, "Columns of --format csv, in order (e.g. file,line,severity,rule,confidence,message)")
+	scanCmd.Flags().String("path-base", "", "Report file paths relative to this directory (default: repository root; \"absolute\" for absolute paths)")
+	scanCmd.Flags().Bool("standalone", false, "With --format html, write a single offline file with the scan data embedded and charts pre-rendered")
 	scanCmd.Flags().Bool("ci", false, "CI mode - exit with non-zero code on issues")
 	scanCmd.Flags().Bool("strict", false, "Strict mode - fail on any issues")
+	scanCmd.Flags().StringSlice("fail-on", []string{}, "In CI mode, fail on issues of these severities (default error, which includes critical; e.g. error,warning)")
+	scanCmd.Flags().Int("allow-new", 0, "In CI mode, pass with up to this many failing issues of any severity (default: ci_cd.allow_new.total)")
+	scanCmd.Flags().Int("allow-new-errors", 0, "In CI mode, pass with up to this many failing errors")
+	scanCmd.Flags().Int("allow-new-warnings", 0, "In CI mode, pass with up to this many failing warnings (with --fail-on warning)")
+	scanCmd.Flags().Int("allow-new-info", 0, "In CI mode, pass with up to this many failing info issues (with --fail-on info)")
 	scanCmd.Flags().Bool("staged", false, "Only scan staged files")
 	scanCmd.Flags().String("diff", "", "Scan changes compared to specified commit/branch")
 	scanCmd.Flags().Int("timeout", 300, "Timeout in seconds")
+	scanCmd.Flags().Float64("discovery-budget", models.DefaultDiscoveryBudget, "Share of --timeout file discovery may use, from 0 to 1 (0 = all of it; default: scanner.discovery_budget)")
+	scanCmd.Flags().String("concurrency", "", "Vibes checked at once: a number, or \"auto\" to size it to the CPUs (default: scanner.concurrency, auto)")
 	scanCmd.Flags().Bool("report", false, "Generate detailed report")
 	scanCmd.Flags().Bool("cache", true, "Enable caching")
+	scanCmd.Flags().Int("max-depth", 0, "Maximum directory depth to scan below each path (0 = unlimited)")
+	scanCmd.Flags().String("package", "", "Scan only a single package (Go import path or directory)")
+	scanCmd.Flags().String("annotate", "", "Also print inline annotations for a CI system (github), to stderr")
+	scanCmd.Flags().String("todo-max-age", "", "Flag TODO/FIXME comments older than this (by git blame) as warnings, e.g. 90d")
+	scanCmd.Flags().String("repos", "", "Scan every repository listed in this file (one path or git URL per line) into a combined report")
+	scanCmd.Flags().StringSlice("require-vibes", []string{}, "Fail the scan if any of these vibes did not run, examined no files or did not finish (e.g. security,code)")
+	scanCmd.Flags().Int("repo-concurrency", scanner.DefaultRepoConcurrency, "Maximum number of repositories scanned at once with --repos")
+	scanCmd.Flags().Bool("fail-on-no-files", false, "Fail (exit code 4) when the paths, excludes and --languages match no files (default: scanner.fail_on_no_files)")
+	scanCmd.Flags().Bool("history", false, "Also check the lines past commits added for secrets, reporting the commit that introduced each")
+	scanCmd.Flags().Bool("git-history", false, "Same as --history")
+	scanCmd.Flags().String("since", "", "With --history, only walk commits after this ref (e.g. v1.2.0 or origin/main)")
+	scanCmd.Flags().Int("max-commits", 1000, "With --history, walk at most this many of the most recent commits (0 = all)")
+	scanCmd.Flags().Float64("sample", 0, "Scan only this percent of the files (e.g. 10) for a quick, extrapolated score")
+	scanCmd.Flags().Int64("sample-seed", 0, "With --sample, choose a different sample; the same seed always picks the same files")
+	scanCmd.Flags().StringArray("metadata", []string{}, "Add key=value to the report's provenance; operator, host, ci_system, ci_run_url and commit override the collected values (repeatable)")
+	scanCmd.Flags().Int("max-report-bytes", 0, "Split a report over this size into numbered pages with an index (0 = no limit; default: reporting.max_report_bytes)")
+	scanCmd.Flags().String("output-dir", "", "Write a kodevibe-report.<ext> file per --format to this directory, generating the formats concurrently")
+	scanCmd.Flags().Bool("tui", false, "Browse the findings interactively after scanning, marking issues to suppress or auto-fix")
+	scanCmd.Flags().StringSlice("owner", []string{}, "Only report issues in files these CODEOWNERS owners own (e.g. @org/team; \"unowned\" for files nobody owns)")
+	scanCmd.Flags().String("group-by", "", "Group text report issues by \"type\" or \"owner\" (default: reporting.group_by, type)")
+	scanCmd.Flags().String("baseline", "", "Leave out the issues recorded in this baseline file (default: .kodevibe/baseline.json, when it exists)")
+	scanCmd.Flags().Bool("write-baseline", false, "Record every current issue in the baseline file, so later scans only report new ones")
+	scanCmd.Flags().Bool("notify", false, "Post a summary to Slack when the scan finds blocking (--fail-on) issues; needs integrations.slack.enabled")
 }
 
 func runScan(cmd *cobra.Command, args []string) error {
@@ -159,6 +292,7 @@ func runScan(cmd *cobra.Command, args []string) error {
 	// Get flags
 	vibesFlag, _ := cmd.Flags().GetStringSlice("vibes")
 	excludeFlag, _ := cmd.Flags().GetStringSlice("exclude")
+	languagesFlag, _ := cmd.Flags().GetStringSlice("languages")
 	minSeverity, _ := cmd.Flags().GetString("min-severity")
 	outputFormat, _ := cmd.Flags().GetString("format")
 	outputFile, _ := cmd.Flags().GetString("output")
@@ -169,30 +303,152 @@ func runScan(cmd *cobra.Command, args []string) error {
 	timeoutSecs, _ := cmd.Flags().GetInt("timeout")
 	generateReport, _ := cmd.Flags().GetBool("report")
 	enableCache, _ := cmd.Flags().GetBool("cache")
+	maxDepth, _ := cmd.Flags().GetInt("max-depth")
+	packageFlag, _ := cmd.Flags().GetString("package")
+	annotate, _ := cmd.Flags().GetString("annotate")
+	todoMaxAge, _ := cmd.Flags().GetString("todo-max-age")
+	reposFile, _ := cmd.Flags().GetString("repos")
+	repoConcurrency, _ := cmd.Flags().GetInt("repo-concurrency")
+	requireVibesFlag, _ := cmd.Flags().GetStringSlice("require-vibes")
+	csvColumns, _ := cmd.Flags().GetStringSlice("csv-columns")
+	pathBase, _ := cmd.Flags().GetString("path-base")
+	tuiMode, _ := cmd.Flags().GetBool("tui")
+	standalone, _ := cmd.Flags().GetBool("standalone")
+	failOnFlag, _ := cmd.Flags().GetStringSlice("fail-on")
+	failOnNoFiles, _ := cmd.Flags().GetBool("fail-on-no-files")
+	metadataFlag, _ := cmd.Flags().GetStringArray("metadata")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	ownerFlag, _ := cmd.Flags().GetStringSlice("owner")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	baselineFlag, _ := cmd.Flags().GetString("baseline")
+	writeBaseline, _ := cmd.Flags().GetBool("write-baseline")
+	notify, _ := cmd.Flags().GetBool("notify")
+
+	failOn, err := parseSeverities(failOnFlag)
+	if err != nil {
+		return usageError(fmt.Errorf("invalid --fail-on: %w", err))
+	}
+	metadata, err := report.ParseMetadata(metadataFlag)
+	if err != nil {
+		return usageError(fmt.Errorf("invalid --metadata: %w", err))
+	}
+	formats, err := reportFormats(outputFormat, outputFile, outputDir)
+	if err != nil {
+		return usageError(err)
+	}
+	if reposFile != "" && outputDir != "" {
+		return usageError(fmt.Errorf("--output-dir cannot be combined with --repos"))
+	}
+
+	if tuiMode {
+		if reposFile != "" {
+			return usageError(fmt.Errorf("--tui cannot be combined with --repos"))
+		}
+		if !isatty.IsTerminal(os.Stdin.Fd()) || !isatty.IsTerminal(os.Stdout.Fd()) {
+			return usageError(fmt.Errorf("--tui requires an interactive terminal"))
+		}
+	}
+
+	if reposFile != "" && (len(args) > 0 || packageFlag != "") {
+		return usageError(fmt.Errorf("--repos cannot be combined with scan paths or --package"))
+	}
+
+	// Focus on a single package instead of the given paths
+	headerPaths := paths
+	if packageFlag != "" {
+		if len(args) > 0 {
+			return usageError(fmt.Errorf("--package cannot be combined with scan paths"))
+		}
+
+		packageFiles, err := scanner.ResolvePackage(context.Background(), packageFlag)
+		if err != nil {
+			return usageError(err)
+		}
+		paths = packageFiles
+		headerPaths = []string{packageFlag}
+	}
+
+	if _, err := vibes.LanguageExtensions(languagesFlag); err != nil {
+		return usageError(err)
+	}
+
+	if annotate != "" && annotate != report.AnnotateGitHub {
+		return usageError(fmt.Errorf("unsupported --annotate value %q (supported: %s)", annotate, report.AnnotateGitHub))
+	}
 
 	// Parse vibes
 	var vibes []models.VibeType
 	if len(vibesFlag) > 0 {
 		for _, vibeStr := range vibesFlag {
 			for _, v := range strings.Split(vibeStr, ",") {
-				vibe := strings.TrimSpace(v)
-				vibes = append(vibes, models.VibeType(vibe))
+				if vibe := strings.TrimSpace(v); vibe != "" {
+					vibes = append(vibes, models.VibeType(vibe))
+				}
 			}
 		}
 	}
 
 	// Create scanner
 	cfg := configMgr.GetConfig()
+	if err := applyCSVColumns(cfg, csvColumns); err != nil {
+		return usageError(err)
+	}
+	if err := applyAllowNewFlags(cmd, &cfg.CICD.AllowNew); err != nil {
+		return usageError(err)
+	}
+	if err := applyGroupBy(cfg, groupBy); err != nil {
+		return usageError(err)
+	}
+	if pathBase != "" {
+		cfg.Reporting.PathBase = pathBase
+	}
+	if standalone {
+		cfg.Reporting.Standalone = true
+	}
+	maxReportBytes, err := reportByteLimit(cmd, cfg)
+	if err != nil {
+		return usageError(err)
+	}
 	if !enableCache {
 		cfg.Advanced.CacheEnabled = false
 	}
 
+	if cmd.Flags().Changed("max-depth") {
+		cfg.Scanner.MaxDepth = maxDepth
+	}
+	if cmd.Flags().Changed("concurrency") {
+		cfg.Scanner.Concurrency, _ = cmd.Flags().GetString("concurrency")
+	}
+	if cmd.Flags().Changed("discovery-budget") {
+		budget, _ := cmd.Flags().GetFloat64("discovery-budget")
+		if budget < 0 || budget > 1 {
+			return usageError(fmt.Errorf("--discovery-budget must be between 0 and 1, got %g", budget))
+		}
+		cfg.Scanner.DiscoveryBudget = budget
+	}
+
 	// Add exclude patterns
 	cfg.Exclude.Files = append(cfg.Exclude.Files, excludeFlag...)
 
+	// Date TODO comments; an invalid age is reported when the code vibe is configured
+	if todoMaxAge != "" {
+		if cfg.Vibes == nil {
+			cfg.Vibes = make(map[models.VibeType]models.VibeConfig)
+		}
+		codeConfig := cfg.Vibes[models.VibeTypeCode]
+		if codeConfig.Settings == nil {
+			codeConfig.Settings = make(map[string]interface{})
+		}
+		codeConfig.Settings["todo_max_age"] = todoMaxAge
+		cfg.Vibes[models.VibeTypeCode] = codeConfig
+	}
+
 	scannerInstance, err := scanner.NewScanner(cfg, logger)
 	if err != nil {
-		return fmt.Errorf("failed to create scanner: %w", err)
+		return usageError(fmt.Errorf("failed to create scanner: %w", err))
+	}
+	if err := scannerInstance.ValidateVibes(vibes); err != nil {
+		return usageError(err)
 	}
 
 	// Convert vibes to strings
@@ -205,46 +461,120 @@ func runScan(cmd *cobra.Command, args []string) error {
 	request := &models.ScanRequest{
 		Paths:      paths,
 		Vibes:      vibeStrings,
+		Languages:  languagesFlag,
 		Config:     cfg,
 		StagedOnly: stagedOnly,
 		DiffTarget: diffTarget,
-		Format:     models.ReportFormat(outputFormat),
+		Format:     models.ReportFormat(formats[0]),
 		CreatedAt:  time.Now(),
 	}
+	history, _ := cmd.Flags().GetBool("history")
+	gitHistory, _ := cmd.Flags().GetBool("git-history")
+	if history || gitHistory {
+		request.History = &models.HistoryOptions{}
+		request.History.Since, _ = cmd.Flags().GetString("since")
+		request.History.MaxCommits, _ = cmd.Flags().GetInt("max-commits")
+	}
+	if cmd.Flags().Changed("sample") {
+		request.Sample = &models.SampleOptions{}
+		request.Sample.Percent, _ = cmd.Flags().GetFloat64("sample")
+		request.Sample.Seed, _ = cmd.Flags().GetInt64("sample-seed")
+	}
+	for _, required := range requireVibesFlag {
+		for _, v := range strings.Split(required, ",") {
+			if vibe := strings.TrimSpace(v); vibe != "" {
+				request.RequiredVibes = append(request.RequiredVibes, vibe)
+			}
+		}
+	}
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSecs)*time.Second)
 	defer cancel()
 
+	// Scan each listed repository independently into one combined report
+	if reposFile != "" {
+		return runMultiRepoScan(ctx, scannerInstance, request, reposFile, repoConcurrency, minSeverity, ownerFlag, outputFormat, outputFile, ciMode, strictMode, failOn)
+	}
+
 	// Show header
-	showScanHeader(paths, vibes)
+	showScanHeader(headerPaths, vibes)
 
-	// Run scan
+	// Run scan; a timeout still yields the issues found so far
 	result, err := scannerInstance.Scan(ctx, request)
+	incomplete := errors.Is(err, scanner.ErrScanIncomplete)
+	missingVibes := errors.Is(err, scanner.ErrRequiredVibesMissing)
+	if err != nil && !incomplete && !missingVibes {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return &exitError{code: exitCodeTimeout, err: fmt.Errorf("scan timed out: %w", err)}
+		}
+		return internalError(fmt.Errorf("scan failed: %w", err))
+	}
+	scanErr := err
+
+	// Nothing scanned is not the same as clean; say so on stderr so it survives --format json
+	noFiles, _ := result.Metadata[scanner.NoFilesScannedKey].(scanner.NoFilesWarning)
+	if noFiles.Reason != "" {
+		printNoFilesWarning(noFiles)
+	}
+	if !cmd.Flags().Changed("fail-on-no-files") {
+		failOnNoFiles = cfg.Scanner.FailOnNoFiles
+	}
+
+	// Drop issues recorded in the suppressions file
+	suppressionsPath := config.NewProjectLayout(".").SuppressionsPath()
+	suppressions, err := scanner.LoadSuppressions(suppressionsPath)
 	if err != nil {
-		return fmt.Errorf("scan failed: %w", err)
+		return usageError(err)
+	}
+	var suppressed int
+	result.Issues, suppressed = suppressions.Filter(result.Issues)
+	if suppressed > 0 {
+		if result.Metadata == nil {
+			result.Metadata = make(map[string]interface{})
+		}
+		result.Metadata["suppressed_issues"] = suppressed
+	}
+
+	// Drop issues accepted in the baseline, recording them first if asked
+	if err := applyBaseline(result, baselineFlag, writeBaseline); err != nil {
+		return usageError(err)
 	}
 
-	// Filter by severity
-	filteredIssues := filterIssuesBySeverity(result.Issues, minSeverity)
+	// Filter by severity and owner
+	filteredIssues := codeowners.Filter(filterIssuesBySeverity(result.Issues, minSeverity), ownerFlag)
 	result.Issues = filteredIssues
-	result.Summary = generateSummary(filteredIssues)
+	resummarize(result, cfg)
+	result.ReproducibilityHash = result.ComputeReproducibilityHash()
+	result.Provenance = report.NewProvenance(cfg, rootCmd.Version, result.Commit, metadata)
+
+	if tuiMode {
+		return browseFindings(cfg, result, suppressions, suppressionsPath)
+	}
 
 	// Generate output
 	reporter := report.NewReporter(cfg)
-	output, err := reporter.Generate(result, outputFormat)
-	if err != nil {
-		return fmt.Errorf("failed to generate report: %w", err)
+	if outputDir != "" {
+		if err := writeReportFormats(reporter, result, formats, outputDir, maxReportBytes, cfg.Reporting.ReportConcurrency, os.Stdout); err != nil {
+			return internalError(err)
+		}
+	} else {
+		output, err := reporter.Generate(result, outputFormat)
+		if err != nil {
+			return internalError(fmt.Errorf("failed to generate report: %w", err))
+		}
+		if err := writeReport(reporter, result, outputFormat, output, outputFile, maxReportBytes, os.Stdout); err != nil {
+			return internalError(err)
+		}
 	}
 
-	// Write output
-	if outputFile != "" {
-		if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
+	// Print CI annotations so findings appear inline on pull requests. The
+	// runner reads workflow commands from stderr too, and stdout may hold a
+	// JSON, SARIF or CSV report that must stay parseable.
+	if annotate == report.AnnotateGitHub {
+		if err := report.WriteGitHubAnnotations(os.Stderr, result.Issues, os.Getenv("GITHUB_WORKSPACE")); err != nil {
+			return internalError(err)
 		}
-		fmt.Printf("Report written to %s\n", outputFile)
-	} else {
-		fmt.Print(output)
 	}
 
 	// Show summary
@@ -265,12 +595,134 @@ func runScan(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Slack is notified of exactly the scans the CI gate fails
+	blocking := (ciMode || notify) && ciFailure(result.Issues, strictMode, failOn, cfg.CICD.AllowNew)
+	if notify {
+		notifySlack(cfg.Integrations.Slack, result, blocking)
+	}
+
+	if failOnNoFiles && noFiles.Reason != "" {
+		return silentExit(exitCodeNoFiles)
+	}
+
+	// A required vibe that didn't run fails the scan whatever it found
+	if missingVibes {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", scanErr)
+		return silentExit(exitCodeRequiredVibes)
+	}
+
 	// Handle CI mode
-	if ciMode {
-		if strictMode && len(result.Issues) > 0 {
-			os.Exit(1)
-		} else if result.Summary.ErrorIssues > 0 {
-			os.Exit(1)
+	if ciMode && blocking {
+		return silentExit(exitCodeIssues)
+	}
+
+	if incomplete {
+		if errors.Is(scanErr, context.DeadlineExceeded) {
+			return silentExit(exitCodeTimeout)
+		}
+		return silentExit(exitCodeIncomplete)
+	}
+
+	return nil
+}
+
+// applyBaseline drops the issues recorded in the baseline file: path, or the
+// project's when it exists. With write, it first records every issue in it.
+func applyBaseline(result *models.ScanResult, path string, write bool) error {
+	explicit := path != ""
+	if !explicit {
+		path = config.NewProjectLayout(".").BaselinePath()
+	}
+
+	var baseline *scanner.Baseline
+	switch {
+	case write:
+		baseline = scanner.NewBaseline(result.Issues, ".")
+		if err := baseline.Save(path); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "📌 Recorded %d issue(s) in baseline %s\n", len(result.Issues), path)
+	case !explicit:
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+		fallthrough
+	default:
+		loaded, err := scanner.LoadBaseline(path)
+		if err != nil {
+			return err
+		}
+		baseline = loaded
+	}
+
+	var baselined int
+	result.Issues, baselined = baseline.Filter(result.Issues, ".")
+	if baselined > 0 {
+		if result.Metadata == nil {
+			result.Metadata = make(map[string]interface{})
+		}
+		result.Metadata["baselined_issues"] = baselined
+	}
+	return nil
+}
+
+// browseFindings opens the interactive browser over a scan's issues, then
+// records the issues marked for suppression and applies the marked fixes
+func browseFindings(cfg *models.Configuration, result *models.ScanResult, suppressions *scanner.Suppressions, suppressionsPath string) error {
+	fixer := fix.NewFixer(cfg, logger)
+	fixRules := make(map[string]bool)
+	for _, rule := range fixer.GetAvailableFixRules() {
+		fixRules[rule] = true
+	}
+
+	browser := tui.NewBrowser(result.Issues, func(issue models.Issue) bool {
+		return fixRules[issue.Rule]
+	})
+	browser.ClearScreen = true
+
+	decisions, err := browser.Run(os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if decisions == nil {
+		fmt.Println("Quit without applying any changes")
+		return nil
+	}
+
+	added := 0
+	for _, decision := range decisions.Suppress {
+		if suppressions.Add(decision.Issue, decision.Reason) {
+			added++
+		}
+	}
+	if added > 0 {
+		if err := suppressions.Save(suppressionsPath); err != nil {
+			return err
+		}
+		fmt.Printf("🔇 Suppressed %d issue(s) in %s\n", added, suppressionsPath)
+	}
+
+	// Fix each file once per rule, in a stable order
+	fixes := make(map[string]map[string]bool)
+	for _, issue := range decisions.Fix {
+		if fixes[issue.File] == nil {
+			fixes[issue.File] = make(map[string]bool)
+		}
+		fixes[issue.File][issue.Rule] = true
+	}
+	files := make([]string, 0, len(fixes))
+	for file := range fixes {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		rules := make([]string, 0, len(fixes[file]))
+		for rule := range fixes[file] {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+		if err := fixer.Fix([]string{file}, true, true, rules); err != nil {
+			return fmt.Errorf("failed to fix %s: %w", file, err)
 		}
 	}
 
@@ -293,7 +745,7 @@ Examples:
 func init() {
 	installCmd.Flags().Bool("hooks", false, "Install git hooks")
 	installCmd.Flags().Bool("config-only", false, "Install configuration file only")
-	installCmd.Flags().String("config-path", ".kodevibe.yaml", "Path for configuration file")
+	installCmd.Flags().String("config-path", filepath.Join(config.ProjectDir, config.ProjectConfigFile), "Path for configuration file")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
@@ -314,7 +766,6 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		if err := installGitHooks(); err != nil {
 			return fmt.Errorf("failed to install git hooks: %w", err)
 		}
-		fmt.Println("✅ Git hooks installed")
 	}
 
 	fmt.Println("🎉 KodeVibe installation complete!")
@@ -325,10 +776,19 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 // hooksCmd represents the hooks command
 var hooksCmd = &cobra.Command{
-	Use:   "hooks [install|uninstall|test]",
+	Use:   "hooks [install|uninstall|test|config]",
 	Short: "Manage git hooks",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runHooks,
+	Long: `Manage the pre-commit and pre-push hooks.
+
+The scan each hook runs comes from ci_cd.git_hooks in the configuration;
+by default pre-commit blocks on errors in the staged files and pre-push on
+any issue. Rerun 'hooks install' after changing it.
+
+Examples:
+  kodevibe hooks install   # Write the hooks from the configuration
+  kodevibe hooks config    # Show what each hook runs and whether it is up to date`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHooks,
 }
 
 func runHooks(cmd *cobra.Command, args []string) error {
@@ -341,6 +801,8 @@ func runHooks(cmd *cobra.Command, args []string) error {
 		return uninstallGitHooks()
 	case "test":
 		return testGitHooks()
+	case "config":
+		return showHooksConfig()
 	default:
 		return fmt.Errorf("unknown action: %s", action)
 	}
@@ -348,10 +810,22 @@ func runHooks(cmd *cobra.Command, args []string) error {
 
 // configCmd represents the config command
 var configCmd = &cobra.Command{
-	Use:   "config [show|validate|init]",
+	Use:   "config [show|validate|init|print|schema]",
 	Short: "Manage configuration",
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  runConfig,
+	Long: `Manage configuration.
+
+Examples:
+  kodevibe config print                       # Effective configuration as YAML
+  kodevibe config print --format json         # Effective configuration as JSON
+  kodevibe config print --for src/app/main.go # Configuration that applies to a file
+  kodevibe config schema > kodevibe.schema.json # JSON Schema for editor validation`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfig,
+}
+
+func init() {
+	configCmd.Flags().String("format", "yaml", "Output format for print (yaml, json)")
+	configCmd.Flags().String("for", "", "Print the effective configuration for this file or directory")
 }
 
 func runConfig(cmd *cobra.Command, args []string) error {
@@ -366,12 +840,96 @@ func runConfig(cmd *cobra.Command, args []string) error {
 	case "validate":
 		return validateConfig()
 	case "init":
-		return config.CreateDefaultConfig(".kodevibe.yaml")
+		return config.CreateDefaultConfig(filepath.Join(config.ProjectDir, config.ProjectConfigFile))
+	case "print":
+		format, _ := cmd.Flags().GetString("format")
+		forPath, _ := cmd.Flags().GetString("for")
+		return printConfig(format, forPath)
+	case "schema":
+		return printConfigSchema()
 	default:
 		return fmt.Errorf("unknown action: %s", action)
 	}
 }
 
+// suppressionsCmd represents the suppressions command
+var suppressionsCmd = &cobra.Command{
+	Use:   "suppressions [list|import-detect-secrets] [baseline]",
+	Short: "Manage suppressed findings",
+	Long: `Manage the findings suppressed in .kodevibe/suppressions.yaml.
+
+import-detect-secrets adds every secret in a detect-secrets baseline
+(default .secrets.baseline) as a suppression matched by file and secret
+hash, so already-triaged secrets are not reported again. Secrets audited
+as real are skipped.
+
+Examples:
+  kodevibe suppressions list
+  kodevibe suppressions import-detect-secrets
+  kodevibe suppressions import-detect-secrets ci/.secrets.baseline --audited-only`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSuppressions,
+}
+
+func init() {
+	suppressionsCmd.Flags().Bool("audited-only", false, "Only import secrets audited as false positives")
+	suppressionsCmd.Flags().Bool("pin-lines", false, "Only suppress imported secrets on their recorded line")
+}
+
+func runSuppressions(cmd *cobra.Command, args []string) error {
+	suppressionsPath := config.NewProjectLayout(".").SuppressionsPath()
+	suppressions, err := scanner.LoadSuppressions(suppressionsPath)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		for _, suppression := range suppressions.Suppressions {
+			target := suppression.Rule
+			if suppression.SecretHash != "" {
+				target = "secret " + suppression.SecretHash
+			}
+			location := suppression.File
+			if suppression.Line > 0 {
+				location = fmt.Sprintf("%s:%d", location, suppression.Line)
+			}
+			fmt.Printf("%s %s", location, target)
+			if suppression.Reason != "" {
+				fmt.Printf(" (%s)", suppression.Reason)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("%d suppression(s) in %s\n", len(suppressions.Suppressions), suppressionsPath)
+		return nil
+	case "import-detect-secrets":
+		baselinePath := scanner.DefaultDetectSecretsBaseline
+		if len(args) > 1 {
+			baselinePath = args[1]
+		}
+		baseline, err := scanner.LoadDetectSecretsBaseline(baselinePath)
+		if err != nil {
+			return err
+		}
+
+		auditedOnly, _ := cmd.Flags().GetBool("audited-only")
+		pinLines, _ := cmd.Flags().GetBool("pin-lines")
+		added, skipped := suppressions.ImportDetectSecrets(baseline, scanner.DetectSecretsImportOptions{
+			AuditedOnly: auditedOnly,
+			PinLines:    pinLines,
+		})
+		if added > 0 {
+			if err := suppressions.Save(suppressionsPath); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("🔇 Imported %d secret(s) from %s into %s (%d skipped)\n", added, baselinePath, suppressionsPath, skipped)
+		return nil
+	default:
+		return fmt.Errorf("unknown action: %s", args[0])
+	}
+}
+
 // fixCmd represents the fix command
 var fixCmd = &cobra.Command{
 	Use:   "fix [paths...]",
@@ -381,7 +939,8 @@ var fixCmd = &cobra.Command{
 Examples:
   kodevibe fix                        # Fix issues in current directory
   kodevibe fix src/                   # Fix issues in specific directory
-  kodevibe fix --auto --backup        # Auto-fix with backup`,
+  kodevibe fix --auto --backup        # Auto-fix with backup
+  kodevibe fix --dry-run              # Show the fixes as a diff without writing them`,
 	Args: cobra.ArbitraryArgs,
 	RunE: runFix,
 }
@@ -390,12 +949,14 @@ func init() {
 	fixCmd.Flags().Bool("auto", false, "Automatically fix without prompting")
 	fixCmd.Flags().Bool("backup", true, "Create backup before fixing")
 	fixCmd.Flags().StringSlice("rules", []string{}, "Specific rules to fix")
+	fixCmd.Flags().Bool("dry-run", false, "Print the fixes as a unified diff instead of writing them")
 }
 
 func runFix(cmd *cobra.Command, args []string) error {
 	autoFix, _ := cmd.Flags().GetBool("auto")
 	createBackup, _ := cmd.Flags().GetBool("backup")
 	rules, _ := cmd.Flags().GetStringSlice("rules")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	paths := args
 	if len(paths) == 0 {
@@ -404,6 +965,7 @@ func runFix(cmd *cobra.Command, args []string) error {
 
 	cfg := configMgr.GetConfig()
 	fixer := fix.NewFixer(cfg, logger)
+	fixer.SetDryRun(dryRun)
 
 	return fixer.Fix(paths, autoFix, createBackup, rules)
 }
@@ -416,25 +978,359 @@ var reportCmd = &cobra.Command{
 }
 
 func init() {
-	reportCmd.Flags().String("input", "", "Input scan result file")
-	reportCmd.Flags().String("format", "html", "Report format (html, pdf, json)")
+	reportCmd.Flags().String("input", "", "Scan result file (.json, or .ndjson/.jsonl issues; - for NDJSON on stdin)")
+	reportCmd.Flags().String("format", "html", "Report format (text, json, ndjson, sarif, html, xml, junit, csv, defectdojo); a comma-separated list with --output-dir")
 	reportCmd.Flags().String("output", "", "Output file path")
+	reportCmd.Flags().String("output-dir", "", "Write a kodevibe-report.<ext> file per --format to this directory, generating the formats concurrently")
+	reportCmd.Flags().StringSlice("csv-columns", []string{}, "Columns of --format csv, in order (e.g. file,line,severity,rule,confidence,message)")
+	reportCmd.Flags().String("path-base", "", "Report file paths relative to this directory (default: repository root; \"absolute\" for absolute paths)")
+	reportCmd.Flags().Bool("standalone", false, "With --format html, write a single offline file with the scan data embedded and charts pre-rendered")
+	reportCmd.Flags().Bool("store", false, "Also keep the report in the report store, keyed by its reproducibility hash")
+	reportCmd.Flags().Int("max-report-bytes", 0, "Split a report over this size into numbered pages with an index (0 = no limit; default: reporting.max_report_bytes)")
+	reportCmd.Flags().StringArray("metadata", []string{}, "Add key=value to the report's provenance; operator, host, ci_system, ci_run_url and commit override the collected values (repeatable)")
+	reportCmd.Flags().StringSlice("owner", []string{}, "Only report issues in files these CODEOWNERS owners own (e.g. @org/team; \"unowned\" for files nobody owns)")
+	reportCmd.Flags().String("group-by", "", "Group text report issues by \"type\" or \"owner\" (default: reporting.group_by, type)")
 }
 
 func runReport(cmd *cobra.Command, args []string) error {
 	inputFile, _ := cmd.Flags().GetString("input")
 	format, _ := cmd.Flags().GetString("format")
 	outputFile, _ := cmd.Flags().GetString("output")
+	storeReport, _ := cmd.Flags().GetBool("store")
+	csvColumns, _ := cmd.Flags().GetStringSlice("csv-columns")
+	pathBase, _ := cmd.Flags().GetString("path-base")
+	standalone, _ := cmd.Flags().GetBool("standalone")
+	metadataFlag, _ := cmd.Flags().GetStringArray("metadata")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	ownerFlag, _ := cmd.Flags().GetStringSlice("owner")
+	groupBy, _ := cmd.Flags().GetString("group-by")
 
 	if inputFile == "" {
 		return fmt.Errorf("input file is required")
 	}
+	formats, err := reportFormats(format, outputFile, outputDir)
+	if err != nil {
+		return err
+	}
+	if storeReport && len(formats) > 1 {
+		return fmt.Errorf("--store takes a single --format")
+	}
+	metadata, err := report.ParseMetadata(metadataFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --metadata: %w", err)
+	}
+
+	result, err := loadScanResult(inputFile)
+	if err != nil {
+		return err
+	}
+
+	cfg := configMgr.GetConfig()
+	if err := applyCSVColumns(cfg, csvColumns); err != nil {
+		return err
+	}
+	if err := applyGroupBy(cfg, groupBy); err != nil {
+		return err
+	}
+	if pathBase != "" {
+		cfg.Reporting.PathBase = pathBase
+	}
+	if standalone {
+		cfg.Reporting.Standalone = true
+	}
+	maxReportBytes, err := reportByteLimit(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	result.Issues = codeowners.Filter(result.Issues, ownerFlag)
+	resummarize(result, cfg)
+	result.ReproducibilityHash = result.ComputeReproducibilityHash()
+	result.Provenance = report.NewProvenance(cfg, rootCmd.Version, result.Commit, metadata)
+
+	reporter := report.NewReporter(cfg)
+	if outputDir != "" {
+		return writeReportFormats(reporter, result, formats, outputDir, maxReportBytes, cfg.Reporting.ReportConcurrency, os.Stderr)
+	}
+	output, err := reporter.Generate(result, format)
+	if err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+
+	if storeReport {
+		storeConfig := cfg.Reporting.Store
+		if storeConfig.Dir == "" {
+			return fmt.Errorf("--store requires reporting.store.dir to be set")
+		}
+		store := report.NewStore(storeConfig.Dir, storeConfig.MaxAge, storeConfig.MaxEntries)
+		hash, err := store.Save(result, format, []byte(output))
+		if err != nil {
+			return fmt.Errorf("failed to store report: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Report stored as %s\n", hash)
+	}
+
+	return writeReport(reporter, result, format, output, outputFile, maxReportBytes, os.Stderr)
+}
+
+// writeReport prints a generated report or writes it to outputFile. A report
+// over maxBytes is written as numbered pages with an index instead, and
+// can't go to stdout.
+func writeReport(reporter *report.Reporter, result *models.ScanResult, format, output, outputFile string, maxBytes int, status io.Writer) error {
+	oversize := maxBytes > 0 && len(output) > maxBytes
+	if outputFile == "" {
+		if oversize {
+			return fmt.Errorf("the report is %d bytes, over the %d-byte limit: use --output to write it in pages", len(output), maxBytes)
+		}
+		fmt.Print(output)
+		return nil
+	}
+	if !oversize {
+		if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Fprintf(status, "Report written to %s\n", outputFile)
+		return nil
+	}
 
-	// TODO: Implement report generation from file
-	fmt.Printf("Generating %s report from %s to %s\n", format, inputFile, outputFile)
+	index, err := reporter.WritePaged(result, format, outputFile, maxBytes)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(status, "Report split into %d pages of at most %d bytes; index written to %s\n",
+		len(index.Pages), maxBytes, report.IndexPath(outputFile))
+	return nil
+}
+
+// reportFormats splits a --format list such as "json,html,sarif". More than
+// one format needs --output-dir, which --output can't be combined with.
+func reportFormats(format, outputFile, outputDir string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(format, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f != "" && !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	switch {
+	case len(formats) == 0:
+		return nil, fmt.Errorf("--format must name at least one format")
+	case outputDir != "" && outputFile != "":
+		return nil, fmt.Errorf("--output and --output-dir cannot be combined")
+	case len(formats) > 1 && outputDir == "":
+		return nil, fmt.Errorf("several formats need --output-dir to write them to")
+	}
+	return formats, nil
+}
+
+// writeReportFormats writes a report per format to dir and lists the files
+// on status. Formats that failed are reported, after the others are written.
+func writeReportFormats(reporter *report.Reporter, result *models.ScanResult, formats []string, dir string, maxBytes, concurrency int, status io.Writer) error {
+	written, err := reporter.WriteFormats(result, formats, dir, maxBytes, concurrency)
+	for _, w := range written {
+		switch {
+		case w.Err != nil:
+		case w.Index != nil:
+			fmt.Fprintf(status, "%s report split into %d pages; index written to %s\n", w.Format, len(w.Index.Pages), report.IndexPath(w.Path))
+		default:
+			fmt.Fprintf(status, "%s report written to %s\n", w.Format, w.Path)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write reports: %w", err)
+	}
 	return nil
 }
 
+// reportByteLimit returns the --max-report-bytes limit, or reporting.max_report_bytes
+func reportByteLimit(cmd *cobra.Command, cfg *models.Configuration) (int, error) {
+	if !cmd.Flags().Changed("max-report-bytes") {
+		return cfg.Reporting.MaxReportBytes, nil
+	}
+	limit, _ := cmd.Flags().GetInt("max-report-bytes")
+	if limit < 0 {
+		return 0, fmt.Errorf("--max-report-bytes must not be negative")
+	}
+	return limit, nil
+}
+
+// applyCSVColumns overrides reporting.csv_columns with the --csv-columns flag
+func applyCSVColumns(cfg *models.Configuration, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	if err := report.ValidateCSVColumns(columns); err != nil {
+		return err
+	}
+	cfg.Reporting.CSVColumns = columns
+	return nil
+}
+
+// applyGroupBy overrides reporting.group_by with the --group-by flag
+func applyGroupBy(cfg *models.Configuration, groupBy string) error {
+	switch groupBy {
+	case "":
+	case models.GroupByType, models.GroupByOwner:
+		cfg.Reporting.GroupBy = groupBy
+	default:
+		return fmt.Errorf("unsupported --group-by value %q (supported: %s, %s)", groupBy, models.GroupByType, models.GroupByOwner)
+	}
+	return nil
+}
+
+// loadNDJSONResult synthesizes a scan result from a stream of issues, one per line
+func loadNDJSONResult(inputFile string) (*models.ScanResult, error) {
+	input := os.Stdin
+	if inputFile != "-" {
+		switch strings.ToLower(filepath.Ext(inputFile)) {
+		case ".ndjson", ".jsonl":
+		default:
+			return nil, fmt.Errorf("unsupported input file %s: expected .ndjson or .jsonl", inputFile)
+		}
+
+		file, err := os.Open(inputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	issues, malformed, err := report.ReadIssuesNDJSON(input)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lineErr := range malformed {
+		fmt.Fprintf(os.Stderr, "⚠️  Skipping malformed issue at %s: %v\n", inputFile, lineErr)
+	}
+	if len(malformed) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Skipped %d malformed line(s), read %d issue(s)\n", len(malformed), len(issues))
+	}
+
+	files := make(map[string]bool)
+	for _, issue := range issues {
+		files[issue.File] = true
+	}
+
+	scanID := uuid.New().String()
+	now := time.Now()
+	return &models.ScanResult{
+		SchemaVersion: models.ScanResultSchemaVersion,
+		ScanID:        scanID,
+		ID:            scanID,
+		StartTime:     now,
+		EndTime:       now,
+		Timestamp:     now,
+		FilesScanned:  len(files),
+		Issues:        issues,
+	}, nil
+}
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan [flags]",
+	Short: "Show a remediation plan ranked by score impact",
+	Long: `Group the findings of a previous scan by rule and rank them by how much
+fixing each group would raise the score, so the cheapest wins come first.`,
+	RunE: runPlan,
+}
+
+func init() {
+	planCmd.Flags().String("input", "", "Scan result file (.json, or .ndjson/.jsonl issues; - for NDJSON on stdin)")
+	planCmd.Flags().String("format", "text", "Output format (text, json)")
+	planCmd.Flags().String("output", "", "Output file path")
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	inputFile, _ := cmd.Flags().GetString("input")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	if inputFile == "" {
+		return fmt.Errorf("input file is required")
+	}
+
+	result, err := loadScanResult(inputFile)
+	if err != nil {
+		return err
+	}
+
+	cfg := configMgr.GetConfig()
+	plan, err := scoring.BuildPlan(result, scoring.ScoringConfig{Grades: cfg.Reporting.GradeThresholds})
+	if err != nil {
+		return fmt.Errorf("failed to build plan: %w", err)
+	}
+
+	var output string
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode plan: %w", err)
+		}
+		output = string(data) + "\n"
+	case "text":
+		output = formatPlan(plan)
+	default:
+		return fmt.Errorf("unsupported plan format: %s", format)
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Plan written to %s\n", outputFile)
+	} else {
+		fmt.Print(output)
+	}
+
+	return nil
+}
+
+// formatPlan renders a plan as a numbered list, one step per rule
+func formatPlan(plan *scoring.Plan) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Current score: %.1f (%s)\n", plan.CurrentScore, plan.CurrentGrade)
+	if len(plan.Steps) == 0 {
+		b.WriteString("Nothing to fix.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Fixing everything: %.1f (%s)\n\n", plan.ProjectedScore, plan.ProjectedGrade)
+
+	for i, step := range plan.Steps {
+		files := "file"
+		if step.Files != 1 {
+			files = "files"
+		}
+		fmt.Fprintf(&b, "%2d. %s [%s, %d %s]\n", i+1, step.Summary(), step.Severity, step.Files, files)
+	}
+
+	return b.String()
+}
+
+// loadScanResult reads a scan result saved with --format json, or synthesizes
+// one from an NDJSON issue stream
+func loadScanResult(inputFile string) (*models.ScanResult, error) {
+	if ext := strings.ToLower(filepath.Ext(inputFile)); inputFile == "-" || ext == ".ndjson" || ext == ".jsonl" {
+		return loadNDJSONResult(inputFile)
+	}
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer file.Close()
+
+	result, err := report.ReadScanResultJSON(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scan result %s: %w", inputFile, err)
+	}
+	return result, nil
+}
+
 // profileCmd represents the profile command
 var profileCmd = &cobra.Command{
 	Use:   "profile [flags]",
@@ -460,18 +1356,32 @@ func runProfile(cmd *cobra.Command, args []string) error {
 var watchCmd = &cobra.Command{
 	Use:   "watch [paths...]",
 	Short: "Watch files for changes and scan automatically",
-	Args:  cobra.ArbitraryArgs,
-	RunE:  runWatch,
+	Long: `Watch files for changes and scan automatically.
+
+The watcher keeps what it found in .kodevibe/watch-state.json. When it
+restarts, it shows the last known findings at once and scans only the files
+added or changed since (compared by mtime, then content hash). Use --fresh
+to rescan everything, or set watch.state: false to keep no state.
+
+Changes are scanned once they settle for --debounce (default 300ms), so an
+editor's burst of events on save becomes one scan of just the changed files.
+Each scan logs the issues that are new and those resolved since the last.`,
+	Args:        cobra.ArbitraryArgs,
+	RunE:        runWatch,
+	Annotations: map[string]string{requiresConfigAnnotation: "true"},
 }
 
 func init() {
 	watchCmd.Flags().Bool("auto-fix", false, "Automatically fix issues when detected")
 	watchCmd.Flags().StringSlice("vibes", []string{}, "Vibes to run on file changes")
+	watchCmd.Flags().Bool("fresh", false, "Ignore the saved watch state and rescan every file")
+	watchCmd.Flags().Duration("debounce", 0, "Wait for file changes to settle this long, then scan the changed files together (default: watch.debounce, 300ms)")
 }
 
 func runWatch(cmd *cobra.Command, args []string) error {
 	autoFix, _ := cmd.Flags().GetBool("auto-fix")
 	vibes, _ := cmd.Flags().GetStringSlice("vibes")
+	fresh, _ := cmd.Flags().GetBool("fresh")
 
 	paths := args
 	if len(paths) == 0 {
@@ -480,15 +1390,31 @@ func runWatch(cmd *cobra.Command, args []string) error {
 
 	cfg := configMgr.GetConfig()
 	watcher := watch.NewWatcher(cfg, logger)
+	watcher.SetFresh(fresh)
+	watcher.SetToolVersion(rootCmd.Version)
+	if cmd.Flags().Changed("debounce") {
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+		if debounce < 0 {
+			return fmt.Errorf("--debounce must not be negative")
+		}
+		watcher.SetDebounceInterval(debounce)
+	}
 
 	return watcher.Watch(paths, autoFix, vibes)
 }
 
 // serverCmd represents the server command
 var serverCmd = &cobra.Command{
-	Use:   "server [flags]",
-	Short: "Start KodeVibe HTTP server",
-	RunE:  runServer,
+	Use:     "server [flags]",
+	Aliases: []string{"serve"},
+	Short:   "Start KodeVibe HTTP server",
+	Long: `Start the KodeVibe HTTP server with the scan API, webhooks and dashboard.
+
+With --dashboard-only, only the real-time dashboard runs. It has no scan
+endpoints and is fed scan results that CI pushes to POST /api/push with
+"Authorization: Bearer <server.auth.secret>".`,
+	RunE:        runServer,
+	Annotations: map[string]string{requiresConfigAnnotation: "true"},
 }
 
 func init() {
@@ -497,6 +1423,8 @@ func init() {
 	serverCmd.Flags().Bool("tls", false, "Enable TLS")
 	serverCmd.Flags().String("cert", "", "TLS certificate file")
 	serverCmd.Flags().String("key", "", "TLS key file")
+	serverCmd.Flags().Bool("dashboard-only", false, "Only run the real-time dashboard, fed by results pushed to /api/push")
+	serverCmd.Flags().Duration("shutdown-timeout", 0, "How long to wait for in-flight requests and scans on SIGINT/SIGTERM (default: server.shutdown_timeout)")
 }
 
 func runServer(cmd *cobra.Command, args []string) error {
@@ -507,10 +1435,54 @@ func runServer(cmd *cobra.Command, args []string) error {
 	keyFile, _ := cmd.Flags().GetString("key")
 
 	cfg := configMgr.GetConfig()
-	cfg.Advanced.MaxConcurrency = 20 // Increase for server mode
+
+	if dashboardOnly, _ := cmd.Flags().GetBool("dashboard-only"); dashboardOnly {
+		return runDashboardOnly(cfg, host, port, tlsEnabled, certFile, keyFile)
+	}
+
+	timeout := cfg.Server.ShutdownTimeout
+	if cmd.Flags().Changed("shutdown-timeout") {
+		timeout, _ = cmd.Flags().GetDuration("shutdown-timeout")
+	}
+
+	// Shut down gracefully on SIGINT/SIGTERM; a second signal kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
 	srv := server.NewServer(cfg, logger)
-	return srv.Start(host, port, tlsEnabled, certFile, keyFile)
+	return srv.Run(ctx, host, port, tlsEnabled, certFile, keyFile, timeout)
+}
+
+// runDashboardOnly serves the real-time dashboard and the authenticated push
+// endpoint, without any endpoint that starts a scan
+func runDashboardOnly(cfg *models.Configuration, host string, port int, tlsEnabled bool, certFile, keyFile string) error {
+	auth := cfg.Server.Auth
+	if !auth.Enabled || auth.Secret == "" {
+		return fmt.Errorf("--dashboard-only needs server.auth.enabled and server.auth.secret, the token clients push results with")
+	}
+
+	realtime := dashboard.NewRealtimeDashboard(port)
+	realtime.SetAddr(fmt.Sprintf("%s:%d", host, port))
+	realtime.SetWebSocketConfig(cfg.Server.Monitoring.WebSocket)
+	if err := realtime.SetHistoryDedupe(cfg.Server.Monitoring.HistoryDedupe); err != nil {
+		return err
+	}
+	if err := realtime.EnablePush(auth.Secret); err != nil {
+		return err
+	}
+
+	logger.Infof("Dashboard-only mode: push scan results to %s", dashboard.PushPath)
+	if tlsEnabled {
+		if certFile == "" || keyFile == "" {
+			return fmt.Errorf("TLS certificate and key files are required for TLS mode")
+		}
+		return realtime.StartTLS(certFile, keyFile)
+	}
+	return realtime.Start()
 }
 
 // updateCmd represents the update command
@@ -538,6 +1510,114 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [paths...]",
+	Short: "Diagnose setup problems",
+	Long: `Check git, configuration, write access for hooks and cache, and that
+scan paths are readable, then print a pass/warn/fail report with hints.
+
+Examples:
+  kodevibe doctor           # Check the current project
+  kodevibe doctor src lib   # Also check that src and lib can be scanned`,
+	RunE: runDoctor,
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	results := doctor.Run(context.Background(), doctor.Options{
+		Dir:        ".",
+		ConfigFile: cfgFile,
+		Paths:      args,
+	})
+
+	fmt.Println("🩺 KodeVibe Doctor")
+	fmt.Println()
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	warnings, failures := 0, 0
+	for _, result := range results {
+		switch result.Status {
+		case doctor.StatusPass:
+			fmt.Printf("%s %s: %s\n", green("✅"), result.Name, result.Message)
+		case doctor.StatusWarn:
+			warnings++
+			fmt.Printf("%s %s: %s\n", yellow("⚠️ "), result.Name, result.Message)
+		case doctor.StatusFail:
+			failures++
+			fmt.Printf("%s %s: %s\n", red("❌"), result.Name, result.Message)
+		}
+		if result.Hint != "" && result.Status != doctor.StatusPass {
+			fmt.Printf("   💡 %s\n", result.Hint)
+		}
+	}
+
+	fmt.Println()
+	if failures > 0 {
+		return fmt.Errorf("doctor found %d problem(s) and %d warning(s)", failures, warnings)
+	}
+	fmt.Printf("🎉 No problems found (%d warning(s))\n", warnings)
+	return nil
+}
+
+// runMultiRepoScan scans every repository in reposFile and writes a combined report
+func runMultiRepoScan(ctx context.Context, scannerInstance *scanner.Scanner, request *models.ScanRequest, reposFile string, concurrency int, minSeverity string, owners []string, outputFormat, outputFile string, ciMode, strictMode bool, failOn []models.SeverityLevel) error {
+	file, err := os.Open(reposFile)
+	if err != nil {
+		return usageError(fmt.Errorf("failed to open repository list: %w", err))
+	}
+	targets, err := scanner.ParseRepoList(file)
+	file.Close()
+	if err != nil {
+		return usageError(err)
+	}
+
+	cfg := configMgr.GetConfig()
+	result := &models.MultiRepoResult{StartTime: time.Now()}
+	result.Repos = scannerInstance.ScanRepos(ctx, targets, request, concurrency)
+	result.EndTime = time.Now()
+	result.Duration = result.EndTime.Sub(result.StartTime)
+
+	// Apply the same severity and owner filters and scoring as a single-repo scan
+	failing := false
+	for _, repo := range result.Repos {
+		if repo.Result == nil {
+			logger.Warnf("Skipping %s: %s", repo.Name, repo.Error)
+			continue
+		}
+		repo.Result.Issues = codeowners.Filter(filterIssuesBySeverity(repo.Result.Issues, minSeverity), owners)
+		resummarize(repo.Result, cfg)
+		repo.Result.ReproducibilityHash = repo.Result.ComputeReproducibilityHash()
+
+		if ciMode && ciFailure(repo.Result.Issues, strictMode, failOn, cfg.CICD.AllowNew) {
+			failing = true
+		}
+	}
+	result.Rollup = scanner.RollupRepos(result.Repos, cfg.Reporting.GradeThresholds)
+
+	output, err := report.NewReporter(cfg).GenerateMultiRepo(result, outputFormat)
+	if err != nil {
+		return internalError(fmt.Errorf("failed to generate report: %w", err))
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+			return internalError(fmt.Errorf("failed to write output file: %w", err))
+		}
+		fmt.Printf("Report written to %s\n", outputFile)
+	} else {
+		fmt.Print(output)
+	}
+
+	if ciMode && (failing || result.Rollup.ReposFailed > 0) {
+		return silentExit(exitCodeIssues)
+	}
+
+	return nil
+}
+
 // Helper functions
 
 func showScanHeader(paths []string, vibes []models.VibeType) {
@@ -570,15 +1650,56 @@ func showScanSummary(result *models.ScanResult, duration time.Duration) {
 		fmt.Printf("ℹ️  Info: %s\n", blue(result.Summary.InfoIssues))
 	}
 
+	if len(result.Summary.EscalatedRules) > 0 {
+		rules := make([]string, 0, len(result.Summary.EscalatedRules))
+		for rule := range result.Summary.EscalatedRules {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+		for _, rule := range rules {
+			fmt.Printf("🔺 Escalated %s (%d occurrences)\n", rule, result.Summary.EscalatedRules[rule])
+		}
+	}
+
 	fmt.Printf("📈 Score: %.1f (%s)\n", result.Summary.Score, result.Summary.Grade)
+	if sample := result.Summary.Sample; sample != nil {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("🎲 %s\n", yellow(fmt.Sprintf("Sampled %g%% of files (%d of %d): the score is an estimate with confidence %.2f",
+			sample.Percent, sample.FilesSampled, sample.FilesDiscovered, sample.Confidence)))
+	}
+	if partial, _ := result.Metadata["partial"].(bool); partial {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		hint := "raise --timeout to scan everything"
+		if result.Metadata[scanner.IncompletePhaseKey] == scanner.PhaseDiscovery {
+			hint = "file discovery ran out of its share of the time; raise --timeout or --discovery-budget, or narrow the paths"
+		}
+		fmt.Printf("⏳ %s\n", yellow(fmt.Sprintf("Scan incomplete (%v during %v): results are partial, %s",
+			result.Metadata["incomplete_reason"], result.Metadata[scanner.IncompletePhaseKey], hint)))
+	}
 	fmt.Println(strings.Repeat("=", 50))
 }
 
+// resummarize recomputes the summary of result after its issues were
+// filtered, keeping what only the scan knows: the escalated rules, the inline
+// suppression counts and the sample, whose extrapolation it applies again
+func resummarize(result *models.ScanResult, cfg *models.Configuration) {
+	previous := result.Summary
+	result.Summary = scanner.Summarize(result.Issues, cfg.Reporting.GradeThresholds)
+	result.Summary.EscalatedRules = previous.EscalatedRules
+	result.Summary.InlineSuppressed, result.Summary.InlineSuppressedByRule = previous.InlineSuppressed, previous.InlineSuppressedByRule
+	if sample := previous.Sample; sample != nil {
+		result.Summary.Sample = sample
+		result.Summary.Score = sample.Extrapolate(result.Summary.Score)
+		result.Summary.Grade = models.GradeForScore(result.Summary.Score, cfg.Reporting.GradeThresholds)
+	}
+}
+
 func filterIssuesBySeverity(issues []models.Issue, minSeverity string) []models.Issue {
 	severityMap := map[string]int{
-		"info":    0,
-		"warning": 1,
-		"error":   2,
+		"info":     0,
+		"warning":  1,
+		"error":    2,
+		"critical": 3,
 	}
 
 	minLevel := severityMap[minSeverity]
@@ -593,48 +1714,124 @@ func filterIssuesBySeverity(issues []models.Issue, minSeverity string) []models.
 	return filtered
 }
 
-func generateSummary(issues []models.Issue) models.ScanSummary {
-	summary := models.ScanSummary{
-		TotalIssues:      len(issues),
-		IssuesByType:     make(map[models.VibeType]int),
-		IssuesBySeverity: make(map[models.SeverityLevel]int),
+// printNoFilesWarning explains on stderr why a scan examined no files
+func printNoFilesWarning(warning scanner.NoFilesWarning) {
+	fmt.Fprintf(os.Stderr, "⚠️  No files were scanned: %s\n", warning.Reason)
+	fmt.Fprintf(os.Stderr, "   paths: %s\n", strings.Join(warning.Paths, ", "))
+	if len(warning.Languages) > 0 {
+		fmt.Fprintf(os.Stderr, "   languages: %s\n", strings.Join(warning.Languages, ", "))
+	}
+	if len(warning.ExcludeFiles) > 0 {
+		fmt.Fprintf(os.Stderr, "   exclude.files: %s\n", strings.Join(warning.ExcludeFiles, ", "))
+	}
+	if len(warning.ExcludePatterns) > 0 {
+		fmt.Fprintf(os.Stderr, "   exclude.patterns: %s\n", strings.Join(warning.ExcludePatterns, ", "))
+	}
+	if len(warning.ExcludePaths) > 0 {
+		fmt.Fprintf(os.Stderr, "   exclude.paths: %s\n", strings.Join(warning.ExcludePaths, ", "))
 	}
+	fmt.Fprintln(os.Stderr, "   The score below covers no code; use --fail-on-no-files to fail instead.")
+}
 
-	for _, issue := range issues {
-		summary.IssuesByType[issue.Type]++
-		summary.IssuesBySeverity[issue.Severity]++
+// notifySlack posts the scan summary to Slack when the scan found blocking
+// issues, those failing the CI gate. Posting failures only warn, so a
+// notification never fails a scan.
+func notifySlack(cfg models.SlackConfig, result *models.ScanResult, blocking bool) {
+	if !cfg.Enabled {
+		logger.Warn("--notify needs integrations.slack.enabled; not posting to Slack")
+		return
+	}
+	if !blocking {
+		return
+	}
+	if err := integrations.PostScanResultToSlack(cfg, result); err != nil {
+		logger.Warnf("Failed to notify Slack: %v", err)
+		return
+	}
+	if !quiet {
+		fmt.Fprintln(os.Stderr, "📣 Posted the scan summary to Slack")
+	}
+}
 
-		switch issue.Severity {
-		case models.SeverityError:
-			summary.ErrorIssues++
-		case models.SeverityWarning:
-			summary.WarningIssues++
-		case models.SeverityInfo:
-			summary.InfoIssues++
-		}
+// ciFailure reports whether issues fail a CI run: any issue in strict mode,
+// otherwise any issue of a failOn severity, or any error when failOn is empty,
+// beyond what allowance lets through
+func ciFailure(issues []models.Issue, strict bool, failOn []models.SeverityLevel, allowance models.NewIssueAllowance) bool {
+	failing := ciFailingIssues(issues, strict, failOn)
+	if len(failing) == 0 {
+		return false
+	}
+	if allowance == (models.NewIssueAllowance{}) {
+		return true
 	}
+	exceeded := allowance.Exceeded(failing)
+	if len(exceeded) == 0 {
+		fmt.Fprintf(os.Stderr, "✅ %d failing issue(s) are within the new-issue allowance\n", len(failing))
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "❌ New issues exceed the allowance: %s\n", strings.Join(exceeded, "; "))
+	return true
+}
 
-	// Calculate score (100 - penalties)
-	summary.Score = 100.0 - float64(summary.ErrorIssues*10) - float64(summary.WarningIssues*5) - float64(summary.InfoIssues*1)
-	if summary.Score < 0 {
-		summary.Score = 0
+// ciFailingIssues returns the issues that count against a CI run. Critical
+// issues are at least errors, so failing on errors also fails on them.
+func ciFailingIssues(issues []models.Issue, strict bool, failOn []models.SeverityLevel) []models.Issue {
+	if strict {
+		return issues
 	}
+	if len(failOn) == 0 {
+		failOn = []models.SeverityLevel{models.SeverityError}
+	}
+	var failing []models.Issue
+	for _, issue := range issues {
+		for _, severity := range failOn {
+			if issue.Severity == severity || (severity == models.SeverityError && issue.Severity == models.SeverityCritical) {
+				failing = append(failing, issue)
+				break
+			}
+		}
+	}
+	return failing
+}
 
-	// Determine grade
-	switch {
-	case summary.Score >= 90:
-		summary.Grade = "A"
-	case summary.Score >= 80:
-		summary.Grade = "B"
-	case summary.Score >= 70:
-		summary.Grade = "C"
-	case summary.Score >= 60:
-		summary.Grade = "D"
-	default:
-		summary.Grade = "F"
+// applyAllowNewFlags overrides ci_cd.allow_new with the --allow-new flags that were set
+func applyAllowNewFlags(cmd *cobra.Command, allowance *models.NewIssueAllowance) error {
+	flags := map[string]**int{
+		"allow-new":          &allowance.Total,
+		"allow-new-errors":   &allowance.Error,
+		"allow-new-warnings": &allowance.Warning,
+		"allow-new-info":     &allowance.Info,
+	}
+	for name, field := range flags {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		value, _ := cmd.Flags().GetInt(name)
+		if value < 0 {
+			return fmt.Errorf("--%s must not be negative", name)
+		}
+		*field = &value
 	}
+	return nil
+}
 
-	return summary
+// parseSeverities reads comma-separated severity names
+func parseSeverities(values []string) ([]models.SeverityLevel, error) {
+	var severities []models.SeverityLevel
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			severity := models.SeverityLevel(strings.ToLower(strings.TrimSpace(name)))
+			switch severity {
+			case "":
+				continue
+			case models.SeverityCritical, models.SeverityError, models.SeverityWarning, models.SeverityInfo:
+				severities = append(severities, severity)
+			default:
+				return nil, fmt.Errorf("unknown severity %q (supported: critical, error, warning, info)", name)
+			}
+		}
+	}
+	return severities, nil
 }
 
 func vibeTypesToStrings(vibes []models.VibeType) []string {
@@ -645,52 +1842,62 @@ func vibeTypesToStrings(vibes []models.VibeType) []string {
 	return strs
 }
 
+// installGitHooks writes the pre-commit and pre-push hooks configured in ci_cd.git_hooks
 func installGitHooks() error {
-	if _, err := os.Stat(".git"); os.IsNotExist(err) {
-		return fmt.Errorf("not a git repository")
-	}
-
-	// Create hooks directory if it doesn't exist
-	hooksDir := ".git/hooks"
-	if err := os.MkdirAll(hooksDir, 0755); err != nil {
-		return fmt.Errorf("failed to create hooks directory: %w", err)
+	cfg := configMgr.GetConfig()
+	installed, err := hooks.Install(".", cfg.CICD.GitHooks)
+	if err != nil {
+		return err
 	}
 
-	// Pre-commit hook
-	preCommitHook := `#!/bin/bash
-echo "🌊 KodeVibe - Pre-commit scan..."
-kodevibe scan --vibes security,code,file --staged --ci
-exit $?
-`
-	preCommitPath := filepath.Join(hooksDir, "pre-commit")
-	if err := os.WriteFile(preCommitPath, []byte(preCommitHook), 0755); err != nil {
-		return fmt.Errorf("failed to write pre-commit hook: %w", err)
+	for _, name := range installed {
+		hook, _ := hooks.Hook(cfg.CICD.GitHooks, name)
+		fmt.Printf("✅ Installed %s hook: kodevibe %s\n", name, strings.Join(hooks.Command(hook), " "))
 	}
+	return nil
+}
 
-	// Pre-push hook
-	prePushHook := `#!/bin/bash
-echo "🌊 KodeVibe - Pre-push scan..."
-kodevibe scan --ci --strict
-exit $?
-`
-	prePushPath := filepath.Join(hooksDir, "pre-push")
-	if err := os.WriteFile(prePushPath, []byte(prePushHook), 0755); err != nil {
-		return fmt.Errorf("failed to write pre-push hook: %w", err)
+func uninstallGitHooks() error {
+	if err := hooks.Uninstall("."); err != nil {
+		return err
 	}
 
+	fmt.Println("✅ Git hooks uninstalled")
 	return nil
 }
 
-func uninstallGitHooks() error {
-	hooksToRemove := []string{".git/hooks/pre-commit", ".git/hooks/pre-push"}
+// showHooksConfig prints what each hook runs according to the configuration
+// and whether the installed script matches it
+func showHooksConfig() error {
+	cfg := configMgr.GetConfig()
+	if err := hooks.Validate(cfg.CICD.GitHooks); err != nil {
+		return err
+	}
 
-	for _, hook := range hooksToRemove {
-		if err := os.Remove(hook); err != nil && !os.IsNotExist(err) {
-			return fmt.Errorf("failed to remove %s: %w", hook, err)
+	for _, name := range hooks.Names {
+		hook, _ := hooks.Hook(cfg.CICD.GitHooks, name)
+		path := filepath.Join(".git", "hooks", name)
+
+		status := "not installed"
+		switch {
+		case !*hook.Enabled && hooks.Generated(path):
+			status = "disabled but installed, run 'kodevibe hooks install' to remove it"
+		case !*hook.Enabled:
+			status = "disabled"
+		case hooks.UpToDate(path, name, hook):
+			status = "installed"
+		case hooks.Generated(path):
+			status = "out of date, run 'kodevibe hooks install'"
+		default:
+			if _, err := os.Stat(path); err == nil {
+				status = "a different hook is installed"
+			}
 		}
-	}
 
-	fmt.Println("✅ Git hooks uninstalled")
+		fmt.Printf("%s (%s)\n", name, status)
+		fmt.Printf("  command: kodevibe %s\n", strings.Join(hooks.Command(hook), " "))
+	}
+	fmt.Println("\n💡 Set ci_cd.git_hooks.pre_commit / pre_push (enabled, vibes, fail_on, staged, args) in the config, then run 'kodevibe hooks install'")
 	return nil
 }
 
@@ -732,11 +1939,173 @@ func showConfig() error {
 	return nil
 }
 
+// printConfig writes the fully resolved configuration (defaults, file and environment merged)
+func printConfig(format, forPath string) error {
+	mgr := configMgr
+	if forPath != "" && cfgFile == "" {
+		configPath, err := config.FindConfigFile(forPath)
+		if err != nil {
+			return err
+		}
+		mgr = config.NewManager()
+		if err := mgr.LoadConfig(configPath); err != nil {
+			return fmt.Errorf("failed to load configuration for %s: %w", forPath, err)
+		}
+	}
+
+	loaded := mgr.GetConfig()
+	if loaded == nil {
+		return fmt.Errorf("no configuration loaded")
+	}
+	cfg := redactConfig(*loaded)
+
+	var data []byte
+	var err error
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		data, err = yaml.Marshal(cfg)
+	case "json":
+		data, err = json.MarshalIndent(cfg, "", "  ")
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+
+	source := mgr.ConfigFileUsed()
+	if source == "" {
+		source = "built-in defaults"
+	}
+	fmt.Fprintf(os.Stderr, "# effective configuration (source: %s)\n", source)
+
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// redactedValue replaces credentials that are set in printed configuration
+const redactedValue = "***"
+
+// redactConfig returns a copy of cfg with every credential that is set
+// replaced by redactedValue, so 'config print' output is safe to share
+func redactConfig(cfg models.Configuration) models.Configuration {
+	redact := func(value *string) {
+		if *value != "" {
+			*value = redactedValue
+		}
+	}
+
+	integrations := &cfg.Integrations
+	redact(&integrations.Slack.WebhookURL)
+	redact(&integrations.GitHub.Token)
+	redact(&integrations.GitHub.WebhookSecret)
+	redact(&integrations.Jira.Token)
+	redact(&integrations.Teams.WebhookURL)
+	redact(&integrations.Webhook.URL)
+	if len(integrations.Webhook.Headers) > 0 {
+		headers := make(map[string]string, len(integrations.Webhook.Headers))
+		for name, value := range integrations.Webhook.Headers {
+			redact(&value)
+			headers[name] = value
+		}
+		integrations.Webhook.Headers = headers
+	}
+	redact(&cfg.Advanced.AIAPIKey)
+	redact(&cfg.Server.Auth.Secret)
+	return cfg
+}
+
+// printConfigSchema writes the JSON Schema of the configuration file to stdout
+func printConfigSchema() error {
+	schema, err := config.Schema()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	_, err = os.Stdout.Write(append(data, '\n'))
+	return err
+}
+
 func validateConfig() error {
 	if err := config.ValidateConfigFile(cfgFile); err != nil {
+		var problems config.SchemaErrors
+		if errors.As(err, &problems) {
+			printConfigProblems(problems)
+			return fmt.Errorf("configuration validation failed")
+		}
 		return fmt.Errorf("configuration validation failed: %w", err)
 	}
 
 	fmt.Println("✅ Configuration is valid")
 	return nil
 }
+
+// printConfigProblems lists schema problems on stderr, one per line
+func printConfigProblems(problems config.SchemaErrors) {
+	fmt.Fprintf(os.Stderr, "❌ Found %d configuration problem(s):\n", len(problems))
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "  • %s\n", problem)
+	}
+}
+
+// checkConfig reports a config file that failed to load. Commands annotated
+// with requiresConfigAnnotation stop with exitCodeUsage; the others fall back
+// to the built-in defaults with a warning.
+func checkConfig(cmd *cobra.Command, args []string) error {
+	if configErr == nil {
+		return nil
+	}
+
+	var problems config.SchemaErrors
+	if cmd.Annotations[requiresConfigAnnotation] == "" {
+		switch {
+		case quiet:
+		case errors.As(configErr, &problems):
+			fmt.Fprintf(os.Stderr, "⚠️  Config has %d problem(s), using defaults; run 'kodevibe config validate' to list them\n", len(problems))
+		default:
+			fmt.Fprintf(os.Stderr, "⚠️  Could not load config, using defaults: %v\n", configErr)
+		}
+		return nil
+	}
+
+	if errors.As(configErr, &problems) {
+		printConfigProblems(problems)
+	} else {
+		fmt.Fprintf(os.Stderr, "❌ Could not load config: %v\n", configErr)
+	}
+	return silentExit(exitCodeUsage)
+}
+
+// languagesCmd represents the languages command
+var languagesCmd = &cobra.Command{
+	Use:   "languages",
+	Short: "List the languages KodeVibe can analyze",
+	Long: `List every language KodeVibe analyzes with its file extensions. Languages
+marked with language rules also get language-specific security and quality
+checks. Use the names with 'kodevibe scan --languages'.
+
+Examples:
+  kodevibe languages
+  kodevibe scan --languages go,ts`,
+	RunE: runLanguages,
+}
+
+func runLanguages(cmd *cobra.Command, args []string) error {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	fmt.Println("🌐 Supported Languages")
+	fmt.Println()
+	for _, language := range vibes.SupportedLanguages() {
+		marker := ""
+		if language.LanguageRules {
+			marker = green(" (language rules)")
+		}
+		fmt.Printf("  %-12s %s%s\n", language.Name, strings.Join(language.Extensions, ", "), marker)
+	}
+
+	return nil
+}
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"kodevibe/internal/utils"
 	"kodevibe/pkg/config"
 	"kodevibe/pkg/server"
 )
@@ -21,12 +24,14 @@ var (
 	certFile   string
 	keyFile    string
 	verbose    bool
+	shutdown   time.Duration
 	logger     *logrus.Logger
 )
 
 func main() {
 	logger = logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
+	utils.InstallRedaction(logger)
 
 	var rootCmd = &cobra.Command{
 		Use:   "kodevibe-server",
@@ -52,6 +57,7 @@ Features:
 	rootCmd.Flags().StringVar(&certFile, "cert", "", "TLS certificate file")
 	rootCmd.Flags().StringVar(&keyFile, "key", "", "TLS private key file")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose logging")
+	rootCmd.Flags().DurationVar(&shutdown, "shutdown-timeout", 0, "How long to wait for in-flight requests and scans on SIGINT/SIGTERM (default: server.shutdown_timeout)")
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -80,21 +86,23 @@ func runServer(cmd *cobra.Command, args []string) error {
 	// Create and start server
 	srv := server.NewServer(cfg, logger)
 
-	// Handle graceful shutdown
+	// Shut down gracefully on SIGINT/SIGTERM; a second signal kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-		<-sigChan
-
-		logger.Info("Shutdown signal received, stopping server...")
-		// TODO: Implement graceful shutdown
-		os.Exit(0)
+		<-ctx.Done()
+		stop()
 	}()
 
+	timeout := cfg.Server.ShutdownTimeout
+	if cmd.Flags().Changed("shutdown-timeout") {
+		timeout = shutdown
+	}
+
 	logger.Infof("🚀 Server starting on %s:%d", host, port)
 	if tlsEnabled {
 		logger.Info("🔒 TLS enabled")
 	}
 
-	return srv.Start(host, port, tlsEnabled, certFile, keyFile)
+	return srv.Run(ctx, host, port, tlsEnabled, certFile, keyFile, timeout)
 }
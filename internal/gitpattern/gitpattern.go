@@ -0,0 +1,112 @@
+// Package gitpattern translates the gitignore pattern syntax shared by
+// .kodevibeignore and CODEOWNERS files to regular expressions.
+package gitpattern
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Options adjusts Compile for the file format a pattern comes from
+type Options struct {
+	// Contents makes a pattern also match every path below a match, so a
+	// directory pattern covers the directory's files. A pattern ending in "/"
+	// then matches only paths below the directory.
+	Contents bool
+	// ShallowStar makes a trailing "/*" match only a directory's direct
+	// entries even with Contents, as in CODEOWNERS files
+	ShallowStar bool
+}
+
+// Compile translates a gitignore pattern (without a leading "!") to a
+// regular expression over slash-separated relative paths. A pattern with a
+// slash other than a trailing one is anchored to the root, others match at
+// any depth. "*", "?", "[...]" classes and "\" escapes never match across
+// "/"; "**" spans directories in a leading "**/", a trailing "/**" or a
+// middle "/**/". Without Contents a trailing "/" is dropped, and callers
+// check that a match is a directory.
+func Compile(pattern string, opts Options) *regexp.Regexp {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimRight(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	shallow := opts.ShallowStar && strings.HasSuffix(pattern, "/*")
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c, rest := runes[i], string(runes[i:]); {
+		case i == 0 && strings.HasPrefix(rest, "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case rest == "/**":
+			expr.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(rest, "/**/"):
+			expr.WriteString("/(?:.*/)?")
+			i += 3
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '\\' && i+1 < len(runes):
+			i++
+			expr.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case c == '[':
+			class, width := characterClass(runes[i:])
+			if width == 0 {
+				expr.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			expr.WriteString(class)
+			i += width - 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case !opts.Contents:
+		expr.WriteString("$")
+	case dirOnly:
+		expr.WriteString("/.*$")
+	case shallow:
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+	// Every metacharacter is quoted or translated, so the expression compiles
+	return regexp.MustCompile(expr.String())
+}
+
+// characterClass translates a bracket expression such as [a-z] or [!0-9] at
+// the start of runes, returning it and how many runes it spans, or a width of
+// 0 when the bracket is never closed
+func characterClass(runes []rune) (string, int) {
+	var class strings.Builder
+	class.WriteString("[")
+	i := 1
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		class.WriteString("^/")
+		i++
+	}
+	for start := i; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case c == ']' && i > start:
+			class.WriteString("]")
+			return class.String(), i + 1
+		case c == '-' && i > start && i+1 < len(runes) && runes[i+1] != ']':
+			class.WriteString("-")
+		case c == '\\' && i+1 < len(runes):
+			i++
+			class.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			class.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return "", 0
+}
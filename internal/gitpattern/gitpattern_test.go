@@ -0,0 +1,52 @@
+package gitpattern
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*.log", "logs/debug.log", true},
+		{"/root.txt", "sub/root.txt", false},
+		{"docs/*.md", "docs/sub/a.md", false},
+		{"**/fixtures", "a/b/fixtures", true},
+		{"a/**/z.go", "a/z.go", true},
+		{"a/**/z.go", "a/b/c/z.go", true},
+		{"gen/**", "gen/a/b.go", true},
+		{"[!abc].go", "d.go", true},
+		{"[!abc].go", "a/.go", false},
+		{"[oops", "[oops", true},
+		{`\*.go`, "a.go", false},
+		{"vendor", "vendor/lib.go", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, Compile(tt.pattern, Options{}).MatchString(tt.path), "%s against %s", tt.pattern, tt.path)
+	}
+}
+
+func TestCompile_Options(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    Options
+		path    string
+		match   bool
+	}{
+		{"vendor", Options{Contents: true}, "vendor/lib.go", true},
+		{"vendor", Options{Contents: true}, "vendor", true},
+		{"vendor/", Options{Contents: true}, "vendor", false},
+		{"vendor/", Options{Contents: true}, "a/vendor/lib.go", true},
+		{"docs/*", Options{Contents: true}, "docs/build/setup.md", true},
+		{"docs/*", Options{Contents: true, ShallowStar: true}, "docs/build/setup.md", false},
+		{"docs/*", Options{Contents: true, ShallowStar: true}, "docs/guide.md", true},
+		{"docs/*/", Options{Contents: true, ShallowStar: true}, "docs/build/setup.md", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, Compile(tt.pattern, tt.opts).MatchString(tt.path), "%s %+v against %s", tt.pattern, tt.opts, tt.path)
+	}
+}
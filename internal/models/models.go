@@ -1,6 +1,14 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +22,10 @@ type AnalysisResult struct {
 	Issues          []Issue       `json:"issues"`
 	Recommendations []string      `json:"recommendations"`
 	Timestamp       time.Time     `json:"timestamp"`
+	// Commit is the SHA of the commit that was analyzed, when known
+	Commit string `json:"commit,omitempty"`
+	// ResultHash identifies the findings, as ScanResult.ReproducibilityHash
+	ResultHash string `json:"result_hash,omitempty"`
 }
 
 // VibeResult represents the result of a specific vibe analysis
@@ -33,6 +45,22 @@ const (
 	SeverityCritical SeverityLevel = "critical"
 )
 
+// SeverityIgnore is only valid in severity_overrides, where it drops a
+// rule's findings
+const SeverityIgnore SeverityLevel = "ignore"
+
+// SeverityLevels are the severities an issue can have, most severe first
+var SeverityLevels = []SeverityLevel{SeverityCritical, SeverityError, SeverityWarning, SeverityInfo}
+
+// SeverityPenalties are the points one issue of each severity takes off a
+// score of 100; every score derived from issues uses them
+var SeverityPenalties = map[SeverityLevel]float64{
+	SeverityCritical: 25,
+	SeverityError:    10,
+	SeverityWarning:  5,
+	SeverityInfo:     1,
+}
+
 // VibeType represents the type of vibe check
 type VibeType string
 
@@ -46,37 +74,118 @@ const (
 	VibeTypeDocumentation VibeType = "documentation"
 )
 
+// Issue categories group findings across vibes for reports and integrations
+const (
+	CategorySecurity        = "security"
+	CategoryReadability     = "readability"
+	CategoryPerformance     = "performance"
+	CategoryMaintainability = "maintainability"
+	CategoryComplexity      = "complexity"
+	CategoryBestPractices   = "best-practices"
+	CategoryErrorHandling   = "error-handling"
+	CategoryDocumentation   = "documentation"
+	CategoryDependencies    = "dependencies"
+)
+
+// DefaultCategory returns the category for issues of a vibe that did not set one
+func DefaultCategory(vibeType VibeType) string {
+	switch vibeType {
+	case VibeTypeSecurity:
+		return CategorySecurity
+	case VibeTypePerformance:
+		return CategoryPerformance
+	case VibeTypeDocumentation:
+		return CategoryDocumentation
+	case VibeTypeDependency:
+		return CategoryDependencies
+	default:
+		return CategoryMaintainability
+	}
+}
+
 // Issue represents a detected issue in the code
 type Issue struct {
-	ID            string                 `json:"id" yaml:"id"`
-	Type          VibeType               `json:"type" yaml:"type"`
-	Severity      SeverityLevel          `json:"severity" yaml:"severity"`
-	Title         string                 `json:"title" yaml:"title"`
-	Message       string                 `json:"message" yaml:"message"`
-	File          string                 `json:"file" yaml:"file"`
-	Line          int                    `json:"line" yaml:"line"`
-	Column        int                    `json:"column" yaml:"column"`
-	Rule          string                 `json:"rule" yaml:"rule"`
-	Pattern       string                 `json:"pattern,omitempty" yaml:"pattern,omitempty"`
-	Context       string                 `json:"context,omitempty" yaml:"context,omitempty"`
-	Category      string                 `json:"category,omitempty" yaml:"category,omitempty"`
-	Fix           string                 `json:"fix,omitempty" yaml:"fix,omitempty"`
-	Fixable       bool                   `json:"fixable" yaml:"fixable"`
-	FixSuggestion string                 `json:"fix_suggestion,omitempty" yaml:"fix_suggestion,omitempty"`
-	Confidence    float64                `json:"confidence" yaml:"confidence"`
-	CreatedAt     time.Time              `json:"created_at" yaml:"created_at"`
-	Metadata      map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	ID       string        `json:"id" yaml:"id"`
+	Type     VibeType      `json:"type" yaml:"type"`
+	Severity SeverityLevel `json:"severity" yaml:"severity"`
+	Title    string        `json:"title" yaml:"title"`
+	Message  string        `json:"message" yaml:"message"`
+	// MessageTemplate is Message with its variable parts replaced by
+	// placeholders, so findings of the same kind group together
+	MessageTemplate string  `json:"message_template,omitempty" yaml:"message_template,omitempty"`
+	File            string  `json:"file" yaml:"file"`
+	Line            int     `json:"line" yaml:"line"`
+	Column          int     `json:"column" yaml:"column"`
+	Rule            string  `json:"rule" yaml:"rule"`
+	Pattern         string  `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Context         string  `json:"context,omitempty" yaml:"context,omitempty"`
+	Category        string  `json:"category,omitempty" yaml:"category,omitempty"`
+	Fix             string  `json:"fix,omitempty" yaml:"fix,omitempty"`
+	Fixable         bool    `json:"fixable" yaml:"fixable"`
+	FixSuggestion   string  `json:"fix_suggestion,omitempty" yaml:"fix_suggestion,omitempty"`
+	Confidence      float64 `json:"confidence" yaml:"confidence"`
+	// CWE is the weakness the finding is an instance of, such as CWE-89
+	CWE       string                 `json:"cwe,omitempty" yaml:"cwe,omitempty"`
+	CreatedAt time.Time              `json:"created_at" yaml:"created_at"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+var (
+	quotedPattern = regexp.MustCompile("'[^']*'|\"[^\"]*\"|`[^`]*`")
+	hashPattern   = regexp.MustCompile(`\b[0-9a-f]*[0-9][0-9a-f]*[a-f][0-9a-f]*\b|\b[0-9a-f]*[a-f][0-9a-f]*[0-9][0-9a-f]*\b`)
+	numberPattern = regexp.MustCompile(`\b[0-9]+(\.[0-9]+)?\b`)
+)
+
+// NormalizeMessage replaces the variable parts of an issue message, such as
+// quoted names, numbers and commit hashes, with placeholders:
+// "Line length (137) exceeds maximum (120)" becomes
+// "Line length ({n}) exceeds maximum ({n})".
+func NormalizeMessage(message string) string {
+	message = quotedPattern.ReplaceAllStringFunc(message, func(quoted string) string {
+		return quoted[:1] + "{s}" + quoted[len(quoted)-1:]
+	})
+	message = hashPattern.ReplaceAllStringFunc(message, func(word string) string {
+		if len(word) < 7 {
+			return word
+		}
+		return "{hash}"
+	})
+	return numberPattern.ReplaceAllString(message, "{n}")
 }
 
+// Template returns the issue's message template, deriving it from Message
+// when it is not set
+func (i Issue) Template() string {
+	if i.MessageTemplate != "" {
+		return i.MessageTemplate
+	}
+	return NormalizeMessage(i.Message)
+}
+
+// Fingerprint identifies a finding by its vibe, rule, file and message
+// template, so it stays the same when lines move or measured values change
+func (i Issue) Fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{string(i.Type), i.Rule, i.File, i.Template()}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScanResultSchemaVersion is the version of the saved scan result layout.
+// Bump it when a change would stop older results from loading as intended.
+const ScanResultSchemaVersion = 1
+
 // ScanResult represents the result of a complete scan
 type ScanResult struct {
-	ScanID        string                 `json:"scan_id" yaml:"scan_id"`
-	ID            string                 `json:"id" yaml:"id"`
-	StartTime     time.Time              `json:"start_time" yaml:"start_time"`
-	EndTime       time.Time              `json:"end_time" yaml:"end_time"`
-	Duration      time.Duration          `json:"duration" yaml:"duration"`
-	Timestamp     time.Time              `json:"timestamp" yaml:"timestamp"`
-	ProjectPath   string                 `json:"project_path" yaml:"project_path"`
+	// SchemaVersion is the ScanResultSchemaVersion the result was written with; results from before versioning have none
+	SchemaVersion int           `json:"schema_version,omitempty" yaml:"schema_version,omitempty"`
+	ScanID        string        `json:"scan_id" yaml:"scan_id"`
+	ID            string        `json:"id" yaml:"id"`
+	StartTime     time.Time     `json:"start_time" yaml:"start_time"`
+	EndTime       time.Time     `json:"end_time" yaml:"end_time"`
+	Duration      time.Duration `json:"duration" yaml:"duration"`
+	Timestamp     time.Time     `json:"timestamp" yaml:"timestamp"`
+	ProjectPath   string        `json:"project_path" yaml:"project_path"`
+	// Commit is the SHA of the commit checked out in the first scanned path, when it is a git repository
+	Commit        string                 `json:"commit,omitempty" yaml:"commit,omitempty"`
 	FilesScanned  int                    `json:"files_scanned" yaml:"files_scanned"`
 	FilesSkipped  int                    `json:"files_skipped" yaml:"files_skipped"`
 	Files         []string               `json:"files" yaml:"files"`
@@ -84,6 +193,51 @@ type ScanResult struct {
 	Summary       ScanSummary            `json:"summary" yaml:"summary"`
 	Configuration *Configuration         `json:"configuration,omitempty" yaml:"configuration,omitempty"`
 	Metadata      map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	// ReproducibilityHash identifies the findings independently of when and where the scan ran
+	ReproducibilityHash string `json:"reproducibility_hash,omitempty" yaml:"reproducibility_hash,omitempty"`
+	// Provenance records how, where and by whom the report was produced
+	Provenance *Provenance `json:"provenance,omitempty" yaml:"provenance,omitempty"`
+	// VibeRuns records what each vibe that ran actually examined
+	VibeRuns map[VibeType]VibeRun `json:"vibe_runs,omitempty" yaml:"vibe_runs,omitempty"`
+}
+
+// VibeRun describes one vibe's part of a scan. A vibe that examined no files
+// found nothing because it had nothing to look at, not because the code is clean.
+type VibeRun struct {
+	FilesExamined int  `json:"files_examined" yaml:"files_examined"`
+	Issues        int  `json:"issues" yaml:"issues"`
+	Incomplete    bool `json:"incomplete,omitempty" yaml:"incomplete,omitempty"`
+}
+
+// RepoScanResult is the outcome of scanning one repository in a multi-repo scan
+type RepoScanResult struct {
+	Name   string      `json:"name" yaml:"name"`
+	Source string      `json:"source" yaml:"source"`
+	Result *ScanResult `json:"result,omitempty" yaml:"result,omitempty"`
+	Error  string      `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// OrgRollup aggregates the repositories of a multi-repo scan
+type OrgRollup struct {
+	Repos            int                   `json:"repos" yaml:"repos"`
+	ReposScanned     int                   `json:"repos_scanned" yaml:"repos_scanned"`
+	ReposFailed      int                   `json:"repos_failed" yaml:"repos_failed"`
+	FilesScanned     int                   `json:"files_scanned" yaml:"files_scanned"`
+	TotalIssues      int                   `json:"total_issues" yaml:"total_issues"`
+	IssuesBySeverity map[SeverityLevel]int `json:"issues_by_severity" yaml:"issues_by_severity"`
+	AverageScore     float64               `json:"average_score" yaml:"average_score"`
+	Grade            string                `json:"grade" yaml:"grade"`
+	LowestScore      float64               `json:"lowest_score" yaml:"lowest_score"`
+	LowestRepo       string                `json:"lowest_repo,omitempty" yaml:"lowest_repo,omitempty"`
+}
+
+// MultiRepoResult is the combined result of scanning several repositories
+type MultiRepoResult struct {
+	StartTime time.Time        `json:"start_time" yaml:"start_time"`
+	EndTime   time.Time        `json:"end_time" yaml:"end_time"`
+	Duration  time.Duration    `json:"duration" yaml:"duration"`
+	Repos     []RepoScanResult `json:"repos" yaml:"repos"`
+	Rollup    OrgRollup        `json:"rollup" yaml:"rollup"`
 }
 
 // Configuration represents the KodeVibe configuration
@@ -99,16 +253,56 @@ type Configuration struct {
 	Languages    map[string]LanguageConfig `json:"languages" yaml:"languages"`
 	CICD         CICDConfig                `json:"ci_cd" yaml:"ci_cd"`
 	Reporting    ReportingConfig           `json:"reporting" yaml:"reporting"`
+	Linters      LintersConfig             `json:"linters" yaml:"linters"`
+	Watch        WatchConfig               `json:"watch" yaml:"watch"`
+}
+
+// WatchConfig configures kodevibe watch
+type WatchConfig struct {
+	// State persists what the watcher found, so a restart shows it at once
+	// and rescans only the files changed since
+	State bool `json:"state" yaml:"state"`
+	// StateFile is where the state is kept; empty means .kodevibe/watch-state.json
+	StateFile string `json:"state_file,omitempty" yaml:"state_file,omitempty"`
+	// MaxStateBytes bounds the state file by forgetting the files scanned
+	// longest ago; 0 means 4 MiB
+	MaxStateBytes int `json:"max_state_bytes,omitempty" yaml:"max_state_bytes,omitempty"`
+	// Debounce is how long file changes must settle before the changed files
+	// are scanned together; 0 means 300ms
+	Debounce time.Duration `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+}
+
+// LintersConfig turns off the KodeVibe rules that linters the project already
+// runs enforce, read from their configuration files, so findings aren't reported twice
+type LintersConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// ESLintConfig and GolangciConfig are config file paths; empty looks for
+	// the linter's usual config files in the scanned directories
+	ESLintConfig   string `json:"eslint_config,omitempty" yaml:"eslint_config,omitempty"`
+	GolangciConfig string `json:"golangci_config,omitempty" yaml:"golangci_config,omitempty"`
+	// Mapping lists the KodeVibe rules each linter rule covers, keyed by
+	// "eslint:<rule>" or "golangci:<linter>"; it adds to and overrides the
+	// built-in mapping, and an empty list drops a built-in entry
+	Mapping map[string][]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
 }
 
+// VibeLevels are the accepted values of a vibe's level, strictest first
+var VibeLevels = []string{"strict", "moderate", "relaxed"}
+
 // VibeConfig represents configuration for a specific vibe
 type VibeConfig struct {
-	Enabled      bool                   `json:"enabled" yaml:"enabled"`
-	Level        string                 `json:"level" yaml:"level"`
-	Rules        []string               `json:"rules,omitempty" yaml:"rules,omitempty"`
-	Checks       []string               `json:"checks,omitempty" yaml:"checks,omitempty"`
-	MaxThreshold int                    `json:"max_threshold,omitempty" yaml:"max_threshold,omitempty"`
-	Settings     map[string]interface{} `json:"settings,omitempty" yaml:"settings,omitempty"`
+	Enabled       bool                   `json:"enabled" yaml:"enabled"`
+	Level         string                 `json:"level" yaml:"level"`
+	Rules         []string               `json:"rules,omitempty" yaml:"rules,omitempty"`
+	Checks        []string               `json:"checks,omitempty" yaml:"checks,omitempty"`
+	MaxThreshold  int                    `json:"max_threshold,omitempty" yaml:"max_threshold,omitempty"`
+	Settings      map[string]interface{} `json:"settings,omitempty" yaml:"settings,omitempty"`
+	EscalateAfter map[string]int         `json:"escalate_after,omitempty" yaml:"escalate_after,omitempty"`
+	// SeverityOverrides replace the severity of a rule's findings, by rule;
+	// SeverityIgnore drops them
+	SeverityOverrides map[string]SeverityLevel `json:"severity_overrides,omitempty" yaml:"severity_overrides,omitempty"`
+	ExcludeTests      bool                     `json:"exclude_tests,omitempty" yaml:"exclude_tests,omitempty"`
+	TestPatterns      []string                 `json:"test_patterns,omitempty" yaml:"test_patterns,omitempty"`
 }
 
 // ProjectConfig represents project-specific configuration
@@ -160,11 +354,13 @@ type SlackConfig struct {
 
 // GitHubConfig represents GitHub integration configuration
 type GitHubConfig struct {
-	Enabled      bool   `json:"enabled" yaml:"enabled"`
-	Token        string `json:"token" yaml:"token"`
-	Owner        string `json:"owner" yaml:"owner"`
-	Repo         string `json:"repo" yaml:"repo"`
-	CreateIssues bool   `json:"create_issues" yaml:"create_issues"`
+	Enabled       bool   `json:"enabled" yaml:"enabled"`
+	Token         string `json:"token" yaml:"token"`
+	Owner         string `json:"owner" yaml:"owner"`
+	Repo          string `json:"repo" yaml:"repo"`
+	CreateIssues  bool   `json:"create_issues" yaml:"create_issues"`
+	WebhookSecret string `json:"-" yaml:"webhook_secret,omitempty"`
+	APIURL        string `json:"api_url,omitempty" yaml:"api_url,omitempty"`
 }
 
 // JiraConfig represents Jira integration configuration
@@ -191,18 +387,24 @@ type WebhookConfig struct {
 
 // AdvancedConfig represents advanced configuration options
 type AdvancedConfig struct {
-	EntropyAnalysis      bool              `json:"entropy_analysis" yaml:"entropy_analysis"`
-	EntropyThreshold     float64           `json:"entropy_threshold" yaml:"entropy_threshold"`
-	AIDetection          bool              `json:"ai_detection" yaml:"ai_detection"`
-	AIProvider           string            `json:"ai_provider" yaml:"ai_provider"`
-	AIModel              string            `json:"ai_model" yaml:"ai_model"`
-	ExternalScanners     []ExternalScanner `json:"external_scanners" yaml:"external_scanners"`
-	PerformanceProfiling bool              `json:"performance_profiling" yaml:"performance_profiling"`
-	CacheEnabled         bool              `json:"cache_enabled" yaml:"cache_enabled"`
-	CacheTTL             time.Duration     `json:"cache_ttl" yaml:"cache_ttl"`
-	MaxConcurrency       int               `json:"max_concurrency" yaml:"max_concurrency"`
-	Timeout              time.Duration     `json:"timeout" yaml:"timeout"`
-	CustomAnalyzers      []CustomAnalyzer  `json:"custom_analyzers" yaml:"custom_analyzers"`
+	EntropyAnalysis  bool              `json:"entropy_analysis" yaml:"entropy_analysis"`
+	EntropyThreshold float64           `json:"entropy_threshold" yaml:"entropy_threshold"`
+	AIDetection      bool              `json:"ai_detection" yaml:"ai_detection"`
+	AIProvider       string            `json:"ai_provider" yaml:"ai_provider"`
+	AIModel          string            `json:"ai_model" yaml:"ai_model"`
+	AIEndpoint       string            `json:"ai_endpoint,omitempty" yaml:"ai_endpoint,omitempty"`
+	AIAPIKey         string            `json:"-" yaml:"ai_api_key,omitempty"`
+	AITimeout        time.Duration     `json:"ai_timeout,omitempty" yaml:"ai_timeout,omitempty"`
+	AIMaxSnippets    int               `json:"ai_max_snippets,omitempty" yaml:"ai_max_snippets,omitempty"`
+	ExternalScanners []ExternalScanner `json:"external_scanners" yaml:"external_scanners"`
+	// ExternalConcurrency is how many external scanners may run at once
+	ExternalConcurrency  int              `json:"external_concurrency,omitempty" yaml:"external_concurrency,omitempty"`
+	PerformanceProfiling bool             `json:"performance_profiling" yaml:"performance_profiling"`
+	CacheEnabled         bool             `json:"cache_enabled" yaml:"cache_enabled"`
+	CacheTTL             time.Duration    `json:"cache_ttl" yaml:"cache_ttl"`
+	MaxConcurrency       int              `json:"max_concurrency" yaml:"max_concurrency"`
+	Timeout              time.Duration    `json:"timeout" yaml:"timeout"`
+	CustomAnalyzers      []CustomAnalyzer `json:"custom_analyzers" yaml:"custom_analyzers"`
 }
 
 // ExternalScanner represents external scanner configuration
@@ -211,6 +413,12 @@ type ExternalScanner struct {
 	Enabled bool     `json:"enabled" yaml:"enabled"`
 	Command string   `json:"command" yaml:"command"`
 	Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Format is the scanner's output on stdout: sarif (default) or ndjson issues
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Vibe is the vibe the scanner's findings are reported under (default security)
+	Vibe VibeType `json:"vibe,omitempty" yaml:"vibe,omitempty"`
+	// Timeout bounds one run of the scanner; 0 uses the default
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 }
 
 // CustomAnalyzer represents custom analyzer configuration
@@ -236,6 +444,67 @@ type CICDConfig struct {
 	GitLabCI      GitLabCIConfig      `json:"gitlab_ci" yaml:"gitlab_ci"`
 	Jenkins       JenkinsConfig       `json:"jenkins" yaml:"jenkins"`
 	QualityGates  QualityGatesConfig  `json:"quality_gates" yaml:"quality_gates"`
+	GitHooks      GitHooksConfig      `json:"git_hooks" yaml:"git_hooks"`
+	// AllowNew lets the CI gate pass with a few failing issues, e.g. in PR
+	// scans limited to the changed files
+	AllowNew NewIssueAllowance `json:"allow_new,omitempty" yaml:"allow_new,omitempty"`
+}
+
+// NewIssueAllowance is how many issues that would fail the CI gate it lets
+// through. A severity with no allowance of its own is only bounded by Total
+// when that is set, and allows none otherwise.
+type NewIssueAllowance struct {
+	Total   *int `json:"total,omitempty" yaml:"total,omitempty"`
+	Error   *int `json:"error,omitempty" yaml:"error,omitempty"`
+	Warning *int `json:"warning,omitempty" yaml:"warning,omitempty"`
+	Info    *int `json:"info,omitempty" yaml:"info,omitempty"`
+}
+
+// severity returns the allowance for one severity, or nil
+func (a NewIssueAllowance) severity(severity SeverityLevel) *int {
+	switch severity {
+	case SeverityError, SeverityCritical:
+		return a.Error
+	case SeverityWarning:
+		return a.Warning
+	case SeverityInfo:
+		return a.Info
+	}
+	return nil
+}
+
+// Exceeded describes how the failing issues go over the allowance, or
+// returns nil if they fit within it
+func (a NewIssueAllowance) Exceeded(failing []Issue) []string {
+	counts := make(map[SeverityLevel]int)
+	for _, issue := range failing {
+		severity := issue.Severity
+		if severity == SeverityCritical {
+			severity = SeverityError
+		}
+		counts[severity]++
+	}
+
+	var exceeded []string
+	for _, severity := range []SeverityLevel{SeverityError, SeverityWarning, SeverityInfo} {
+		count := counts[severity]
+		if count == 0 {
+			continue
+		}
+		limit := 0
+		if allowed := a.severity(severity); allowed != nil {
+			limit = *allowed
+		} else if a.Total != nil {
+			continue
+		}
+		if count > limit {
+			exceeded = append(exceeded, fmt.Sprintf("%d %s issue(s), %d allowed", count, severity, limit))
+		}
+	}
+	if a.Total != nil && len(failing) > *a.Total {
+		exceeded = append(exceeded, fmt.Sprintf("%d issue(s) in total, %d allowed", len(failing), *a.Total))
+	}
+	return exceeded
 }
 
 // GitHubActionsConfig represents GitHub Actions configuration
@@ -244,6 +513,69 @@ type GitHubActionsConfig struct {
 	FailOn  []SeverityLevel `json:"fail_on" yaml:"fail_on"`
 }
 
+// GitHooksConfig configures the scans run by the hooks 'kodevibe hooks install' writes
+type GitHooksConfig struct {
+	PreCommit GitHookConfig `json:"pre_commit" yaml:"pre_commit"`
+	PrePush   GitHookConfig `json:"pre_push" yaml:"pre_push"`
+}
+
+// GitHookConfig is the scan one git hook runs. Unset fields take the hook's default.
+type GitHookConfig struct {
+	// Enabled installs the hook
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Vibes are the vibes to run; empty runs the config-enabled ones
+	Vibes []string `json:"vibes,omitempty" yaml:"vibes,omitempty"`
+	// FailOn are the severities that block the commit or push
+	FailOn []SeverityLevel `json:"fail_on,omitempty" yaml:"fail_on,omitempty"`
+	// Staged scans only the staged files
+	Staged *bool `json:"staged,omitempty" yaml:"staged,omitempty"`
+	// Args are extra arguments passed to kodevibe scan
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// DefaultGitHooksConfig blocks commits on errors in the staged files and
+// pushes on any issue
+func DefaultGitHooksConfig() GitHooksConfig {
+	enabled, staged, unstaged := true, true, false
+	return GitHooksConfig{
+		PreCommit: GitHookConfig{
+			Enabled: &enabled,
+			Vibes:   []string{string(VibeTypeSecurity), string(VibeTypeCode), string(VibeTypeFile)},
+			FailOn:  []SeverityLevel{SeverityError},
+			Staged:  &staged,
+		},
+		PrePush: GitHookConfig{
+			Enabled: &enabled,
+			FailOn:  []SeverityLevel{SeverityError, SeverityWarning, SeverityInfo},
+			Staged:  &unstaged,
+		},
+	}
+}
+
+// WithDefaults fills unset fields of each hook from DefaultGitHooksConfig
+func (c GitHooksConfig) WithDefaults() GitHooksConfig {
+	defaults := DefaultGitHooksConfig()
+	c.PreCommit = c.PreCommit.withDefaults(defaults.PreCommit)
+	c.PrePush = c.PrePush.withDefaults(defaults.PrePush)
+	return c
+}
+
+func (c GitHookConfig) withDefaults(defaults GitHookConfig) GitHookConfig {
+	if c.Enabled == nil {
+		c.Enabled = defaults.Enabled
+	}
+	if c.Vibes == nil {
+		c.Vibes = defaults.Vibes
+	}
+	if c.FailOn == nil {
+		c.FailOn = defaults.FailOn
+	}
+	if c.Staged == nil {
+		c.Staged = defaults.Staged
+	}
+	return c
+}
+
 // GitLabCIConfig represents GitLab CI configuration
 type GitLabCIConfig struct {
 	Enabled bool `json:"enabled" yaml:"enabled"`
@@ -269,6 +601,56 @@ type ReportingConfig struct {
 	ReportPath      string            `json:"report_path" yaml:"report_path"`
 	Logging         LoggingConfig     `json:"logging" yaml:"logging"`
 	Templates       map[string]string `json:"templates,omitempty" yaml:"templates,omitempty"`
+	GradeThresholds []GradeThreshold  `json:"grade_thresholds,omitempty" yaml:"grade_thresholds,omitempty"`
+	// RuleHelpURL is the help link template for SARIF rules and HTML learn-more links; {rule} and {vibe} are substituted
+	RuleHelpURL string `json:"rule_help_url,omitempty" yaml:"rule_help_url,omitempty"`
+	// Store keeps generated reports keyed by their result's reproducibility hash
+	Store ReportStoreConfig `json:"store,omitempty" yaml:"store,omitempty"`
+	// CSVColumns selects and orders the columns of CSV reports
+	CSVColumns []string `json:"csv_columns,omitempty" yaml:"csv_columns,omitempty"`
+	// PathBase is the directory issue paths are reported relative to; empty
+	// means the repository root and "absolute" reports absolute paths
+	PathBase string `json:"path_base,omitempty" yaml:"path_base,omitempty"`
+	// Standalone makes HTML reports a single offline file with the scan data
+	// embedded and charts pre-rendered
+	Standalone bool `json:"standalone,omitempty" yaml:"standalone,omitempty"`
+	// MaxReportBytes splits larger reports written to a file into numbered
+	// pages with an index; 0 means no limit
+	MaxReportBytes int `json:"max_report_bytes,omitempty" yaml:"max_report_bytes,omitempty"`
+	// ReportConcurrency is how many formats --output-dir generates at once;
+	// 0 generates them all at once
+	ReportConcurrency int `json:"report_concurrency,omitempty" yaml:"report_concurrency,omitempty"`
+	// DefectDojo configures --format defectdojo
+	DefectDojo DefectDojoConfig `json:"defectdojo,omitempty" yaml:"defectdojo,omitempty"`
+	// GroupBy groups the issues of text reports by vibe type (the default) or
+	// by the owner CODEOWNERS gives their file
+	GroupBy string `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+}
+
+// Text report groupings of reporting.group_by
+const (
+	GroupByType  = "type"
+	GroupByOwner = "owner"
+)
+
+// DefectDojoConfig configures DefectDojo findings imports
+type DefectDojoConfig struct {
+	// SplitOccurrences reports each occurrence of a finding on its own rather
+	// than one finding per rule, message and file with every line listed
+	SplitOccurrences bool `json:"split_occurrences,omitempty" yaml:"split_occurrences,omitempty"`
+}
+
+// ReportStoreConfig configures the report store; zero limits keep reports forever
+type ReportStoreConfig struct {
+	Dir        string        `json:"dir,omitempty" yaml:"dir,omitempty"`
+	MaxAge     time.Duration `json:"max_age,omitempty" yaml:"max_age,omitempty"`
+	MaxEntries int           `json:"max_entries,omitempty" yaml:"max_entries,omitempty"`
+}
+
+// GradeThreshold maps a minimum score to a letter grade
+type GradeThreshold struct {
+	Grade    string  `json:"grade" yaml:"grade"`
+	MinScore float64 `json:"min_score" yaml:"min_score"`
 }
 
 // LoggingConfig represents logging configuration
@@ -279,16 +661,81 @@ type LoggingConfig struct {
 	File    string `json:"file" yaml:"file"`
 }
 
+// Provenance is the evidence block of a report: the tool, rules and
+// configuration that produced it, and when, where and by whom it was run
+type Provenance struct {
+	Tool        string `json:"tool" yaml:"tool"`
+	ToolVersion string `json:"tool_version" yaml:"tool_version"`
+	// RulesetVersion hashes the IDs and default severities of the rules the scan could emit
+	RulesetVersion string `json:"ruleset_version" yaml:"ruleset_version"`
+	// ConfigHash hashes the effective configuration
+	ConfigHash  string    `json:"config_hash" yaml:"config_hash"`
+	GeneratedAt time.Time `json:"generated_at" yaml:"generated_at"`
+	Operator    string    `json:"operator,omitempty" yaml:"operator,omitempty"`
+	Host        string    `json:"host,omitempty" yaml:"host,omitempty"`
+	CISystem    string    `json:"ci_system,omitempty" yaml:"ci_system,omitempty"`
+	CIRunURL    string    `json:"ci_run_url,omitempty" yaml:"ci_run_url,omitempty"`
+	Commit      string    `json:"commit,omitempty" yaml:"commit,omitempty"`
+	// Custom holds the --metadata fields that are not one of the above
+	Custom map[string]string `json:"custom,omitempty" yaml:"custom,omitempty"`
+}
+
 // ScanRequest represents a request to scan files
 type ScanRequest struct {
 	ID         string         `json:"id" yaml:"id"`
 	Paths      []string       `json:"paths" yaml:"paths"`
 	Vibes      []string       `json:"vibes" yaml:"vibes"`
+	Languages  []string       `json:"languages,omitempty" yaml:"languages,omitempty"`
 	Config     *Configuration `json:"config,omitempty" yaml:"config,omitempty"`
 	StagedOnly bool           `json:"staged_only" yaml:"staged_only"`
 	DiffTarget string         `json:"diff_target,omitempty" yaml:"diff_target,omitempty"`
 	Format     ReportFormat   `json:"format" yaml:"format"`
 	CreatedAt  time.Time      `json:"created_at" yaml:"created_at"`
+	// RequiredVibes fail the scan unless each of them ran to completion and examined at least one file
+	RequiredVibes []string `json:"required_vibes,omitempty" yaml:"required_vibes,omitempty"`
+	// History also checks the lines added by past commits for secrets
+	History *HistoryOptions `json:"history,omitempty" yaml:"history,omitempty"`
+	// Sample scans a deterministic fraction of the discovered files for a quick estimate
+	Sample *SampleOptions `json:"sample,omitempty" yaml:"sample,omitempty"`
+}
+
+// SampleOptions chooses the files a sampled scan checks
+type SampleOptions struct {
+	// Percent of the discovered files to scan, above 0 and at most 100
+	Percent float64 `json:"percent" yaml:"percent"`
+	// Seed picks a different sample of the same files; the same seed picks the same files
+	Seed int64 `json:"seed" yaml:"seed"`
+}
+
+// SampleSummary labels a result as coming from a sampled scan
+type SampleSummary struct {
+	Percent         float64 `json:"percent" yaml:"percent"`
+	Seed            int64   `json:"seed" yaml:"seed"`
+	FilesDiscovered int     `json:"files_discovered" yaml:"files_discovered"`
+	FilesSampled    int     `json:"files_sampled" yaml:"files_sampled"`
+	// Confidence in the extrapolated score, from 0 to 1; lower for smaller samples
+	Confidence float64 `json:"confidence" yaml:"confidence"`
+}
+
+// Extrapolate scales the penalties behind a score out of 100 from the
+// sampled files to every discovered file
+func (s SampleSummary) Extrapolate(score float64) float64 {
+	if s.FilesSampled == 0 || s.FilesSampled >= s.FilesDiscovered {
+		return score
+	}
+	penalty := (100 - score) * float64(s.FilesDiscovered) / float64(s.FilesSampled)
+	if penalty > 100 {
+		return 0
+	}
+	return 100 - penalty
+}
+
+// HistoryOptions bounds a scan of git history for secrets
+type HistoryOptions struct {
+	// Since limits the walk to commits after this ref; empty walks all of HEAD's history
+	Since string `json:"since,omitempty" yaml:"since,omitempty"`
+	// MaxCommits limits the walk to the most recent commits; 0 means no limit
+	MaxCommits int `json:"max_commits,omitempty" yaml:"max_commits,omitempty"`
 }
 
 // FixResult represents the result of an auto-fix operation
@@ -329,6 +776,8 @@ type ServerConfig struct {
 	RateLimit  RateLimitConfig  `json:"rate_limit" yaml:"rate_limit"`
 	CORS       CORSConfig       `json:"cors" yaml:"cors"`
 	Monitoring MonitoringConfig `json:"monitoring" yaml:"monitoring"`
+	// ShutdownTimeout is how long a stopping server waits for in-flight requests and scans
+	ShutdownTimeout time.Duration `json:"shutdown_timeout" yaml:"shutdown_timeout"`
 }
 
 // AuthConfig represents authentication configuration
@@ -361,6 +810,57 @@ type MonitoringConfig struct {
 	Grafana     bool   `json:"grafana" yaml:"grafana"`
 	HealthCheck bool   `json:"health_check" yaml:"health_check"`
 	MetricsPath string `json:"metrics_path" yaml:"metrics_path"`
+
+	WebSocket WebSocketConfig `json:"websocket" yaml:"websocket"`
+	// HistoryDedupe decides which dashboard history points replace earlier
+	// ones: "commit" (the default), "hash" or "none"
+	HistoryDedupe string `json:"history_dedupe,omitempty" yaml:"history_dedupe,omitempty"`
+}
+
+// Ways dashboard trend history drops repeated analyses
+const (
+	// HistoryDedupeCommit keeps only the latest analysis of each commit
+	HistoryDedupeCommit = "commit"
+	// HistoryDedupeHash keeps only the latest analysis with the same findings
+	HistoryDedupeHash = "hash"
+	// HistoryDedupeNone keeps every analysis
+	HistoryDedupeNone = "none"
+)
+
+// HistoryDedupeModes are the accepted server.monitoring.history_dedupe values
+var HistoryDedupeModes = []string{HistoryDedupeCommit, HistoryDedupeHash, HistoryDedupeNone}
+
+// WebSocketConfig tunes dashboard WebSocket connections. Proxies that buffer
+// traffic may delay pings; raise ReadTimeout (e.g. 120s) and keep PingInterval
+// well below it (e.g. 30s) to avoid reconnect loops.
+type WebSocketConfig struct {
+	PingInterval time.Duration `json:"ping_interval" yaml:"ping_interval"`
+	ReadTimeout  time.Duration `json:"read_timeout" yaml:"read_timeout"`
+	ReadLimit    int64         `json:"read_limit" yaml:"read_limit"`
+}
+
+// DefaultWebSocketConfig returns the dashboard WebSocket defaults
+func DefaultWebSocketConfig() WebSocketConfig {
+	return WebSocketConfig{
+		PingInterval: 54 * time.Second,
+		ReadTimeout:  60 * time.Second,
+		ReadLimit:    512,
+	}
+}
+
+// WithDefaults fills unset fields from DefaultWebSocketConfig
+func (c WebSocketConfig) WithDefaults() WebSocketConfig {
+	defaults := DefaultWebSocketConfig()
+	if c.PingInterval <= 0 {
+		c.PingInterval = defaults.PingInterval
+	}
+	if c.ReadTimeout <= 0 {
+		c.ReadTimeout = defaults.ReadTimeout
+	}
+	if c.ReadLimit <= 0 {
+		c.ReadLimit = defaults.ReadLimit
+	}
+	return c
 }
 
 // ReportFormat represents different report output formats
@@ -373,14 +873,111 @@ const (
 	ReportFormatXML   ReportFormat = "xml"
 	ReportFormatJUnit ReportFormat = "junit"
 	ReportFormatCSV   ReportFormat = "csv"
+	// ReportFormatDefectDojo is DefectDojo's Generic Findings Import JSON
+	ReportFormatDefectDojo ReportFormat = "defectdojo"
 )
 
 // ScannerConfig represents scanner configuration
 type ScannerConfig struct {
+	// Concurrency is the number of vibes checked at once, or "auto" to size it
+	// to the machine's CPUs. Left empty, MaxConcurrency is used when set and
+	// auto otherwise.
+	Concurrency string `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	// ConcurrencyPerCPU is how many checks auto runs per CPU; 0 means 1
+	ConcurrencyPerCPU int `json:"concurrency_per_cpu,omitempty" yaml:"concurrency_per_cpu,omitempty"`
+	// MaxConcurrency is the fixed concurrency when Concurrency is empty, and
+	// caps auto; 0 means no cap
 	MaxConcurrency  int      `json:"max_concurrency" yaml:"max_concurrency"`
 	Timeout         int      `json:"timeout" yaml:"timeout"`
 	EnabledVibes    []string `json:"enabled_vibes" yaml:"enabled_vibes"`
 	ExcludePatterns []string `json:"exclude_patterns" yaml:"exclude_patterns"`
+	MaxDepth        int      `json:"max_depth,omitempty" yaml:"max_depth,omitempty"`
+	// MaxIssuesPerFile caps the issues reported for one file, keeping the most
+	// severe; 0 means no limit
+	MaxIssuesPerFile int `json:"max_issues_per_file,omitempty" yaml:"max_issues_per_file,omitempty"`
+	// FailOnNoFiles fails a scan whose paths and filters match no files
+	FailOnNoFiles bool `json:"fail_on_no_files,omitempty" yaml:"fail_on_no_files,omitempty"`
+	// DiscoveryBudget is the share of the scan timeout file discovery may
+	// use, from 0 to 1, so a slow walk leaves time for the checks; 0 lets it
+	// use all of it
+	DiscoveryBudget float64 `json:"discovery_budget,omitempty" yaml:"discovery_budget,omitempty"`
+	// GeneratedFiles controls which vibes scan generated API stubs
+	GeneratedFiles GeneratedFilesConfig `json:"generated_files,omitempty" yaml:"generated_files,omitempty"`
+	// Routes choose the vibes that check matching files; the first route a
+	// file matches wins, and files no route matches get the usual vibes
+	Routes []VibeRoute `json:"routes,omitempty" yaml:"routes,omitempty"`
+	// CWEMapping sets the CWE of rules' findings, such as CWE-89, on top of
+	// the built-in mapping; an empty value maps a rule to none
+	CWEMapping map[string]string `json:"cwe_mapping,omitempty" yaml:"cwe_mapping,omitempty"`
+	// CodeOwners annotates findings with the owners of their files
+	CodeOwners CodeOwnersConfig `json:"codeowners,omitempty" yaml:"codeowners,omitempty"`
+}
+
+// CodeOwnersConfig controls which CODEOWNERS file assigns findings to owners
+type CodeOwnersConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// File is the CODEOWNERS file to read; empty looks for one in the
+	// repository root, .github/, .gitlab/ and docs/
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+// VibeRoute sends the files matching any of Files to Vibes only. Patterns
+// are matched like test file patterns; Vibes may include "all" and "default".
+type VibeRoute struct {
+	Files []string `json:"files" yaml:"files"`
+	Vibes []string `json:"vibes" yaml:"vibes"`
+}
+
+// ConcurrencyAuto is the scanner.concurrency value that sizes concurrency to the CPUs
+const ConcurrencyAuto = "auto"
+
+// DefaultDiscoveryBudget is the default share of the scan timeout for file discovery
+const DefaultDiscoveryBudget = 0.25
+
+// ResolveConcurrency returns the number of vibes checked at once on a machine
+// with cpus CPUs
+func (c ScannerConfig) ResolveConcurrency(cpus int) (int, error) {
+	value := strings.ToLower(strings.TrimSpace(c.Concurrency))
+	if value == "" {
+		if c.MaxConcurrency > 0 {
+			return c.MaxConcurrency, nil
+		}
+		value = ConcurrencyAuto
+	}
+	if c.MaxConcurrency < 0 {
+		return 0, fmt.Errorf("scanner.max_concurrency must not be negative")
+	}
+	if c.ConcurrencyPerCPU < 0 {
+		return 0, fmt.Errorf("scanner.concurrency_per_cpu must not be negative")
+	}
+
+	if value != ConcurrencyAuto {
+		concurrency, err := strconv.Atoi(value)
+		if err != nil || concurrency <= 0 {
+			return 0, fmt.Errorf("scanner.concurrency must be %q or a positive number, got %q", ConcurrencyAuto, c.Concurrency)
+		}
+		return concurrency, nil
+	}
+
+	perCPU := c.ConcurrencyPerCPU
+	if perCPU == 0 {
+		perCPU = 1
+	}
+	concurrency := cpus * perCPU
+	if c.MaxConcurrency > 0 && concurrency > c.MaxConcurrency {
+		concurrency = c.MaxConcurrency
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return concurrency, nil
+}
+
+// GeneratedFilesConfig extends the built-in generated-file patterns (protobuf,
+// thrift, openapi/swagger output) and lists the vibes that still scan such files
+type GeneratedFilesConfig struct {
+	Patterns []string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	Vibes    []string `json:"vibes,omitempty" yaml:"vibes,omitempty"`
 }
 
 // Issue validation method
@@ -418,9 +1015,10 @@ func (sr *ScanRequest) IsValid() bool {
 // ScanResult helper methods
 func (sr *ScanResult) CalculateSummary() ScanSummary {
 	summary := ScanSummary{
-		TotalIssues:  len(sr.Issues),
-		IssuesByType: make(map[VibeType]int),
-		FilesScanned: 0,
+		TotalIssues:      len(sr.Issues),
+		IssuesByType:     make(map[VibeType]int),
+		IssuesBySeverity: make(map[SeverityLevel]int),
+		FilesScanned:     0,
 	}
 
 	filesMap := make(map[string]bool)
@@ -438,6 +1036,8 @@ func (sr *ScanResult) CalculateSummary() ScanSummary {
 			summary.InfoIssues++
 		}
 
+		summary.IssuesBySeverity[issue.Severity]++
+
 		// Count by type
 		summary.IssuesByType[issue.Type]++
 
@@ -497,6 +1097,121 @@ func (c *Configuration) IsValid() bool {
 	return true
 }
 
+// DefaultGradeThresholds returns the grade scale used when none is configured
+func DefaultGradeThresholds() []GradeThreshold {
+	return []GradeThreshold{
+		{Grade: "A+", MinScore: 95},
+		{Grade: "A", MinScore: 90},
+		{Grade: "A-", MinScore: 85},
+		{Grade: "B+", MinScore: 80},
+		{Grade: "B", MinScore: 75},
+		{Grade: "B-", MinScore: 70},
+		{Grade: "C+", MinScore: 65},
+		{Grade: "C", MinScore: 60},
+		{Grade: "C-", MinScore: 55},
+		{Grade: "D+", MinScore: 50},
+		{Grade: "D", MinScore: 45},
+		{Grade: "D-", MinScore: 40},
+		{Grade: "F", MinScore: 0},
+	}
+}
+
+// ComputeReproducibilityHash hashes what a scan found: the number of files
+// scanned and each issue's rule, location, severity and message, in a fixed
+// order. Scan IDs, issue IDs, timestamps and durations are left out, so
+// scanning the same code with the same configuration always yields the same hash.
+func (r *ScanResult) ComputeReproducibilityHash() string {
+	type hashedIssue struct {
+		Type     VibeType      `json:"type"`
+		Rule     string        `json:"rule"`
+		Severity SeverityLevel `json:"severity"`
+		Category string        `json:"category"`
+		File     string        `json:"file"`
+		Line     int           `json:"line"`
+		Column   int           `json:"column"`
+		Message  string        `json:"message"`
+	}
+
+	issues := make([]hashedIssue, len(r.Issues))
+	for i, issue := range r.Issues {
+		issues[i] = hashedIssue{
+			Type:     issue.Type,
+			Rule:     issue.Rule,
+			Severity: issue.Severity,
+			Category: issue.Category,
+			File:     issue.File,
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Message:  issue.Message,
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		switch {
+		case a.File != b.File:
+			return a.File < b.File
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		case a.Column != b.Column:
+			return a.Column < b.Column
+		case a.Type != b.Type:
+			return a.Type < b.Type
+		case a.Rule != b.Rule:
+			return a.Rule < b.Rule
+		case a.Message != b.Message:
+			return a.Message < b.Message
+		default:
+			return a.Severity < b.Severity
+		}
+	})
+
+	// Encoding plain structs and slices cannot fail
+	data, _ := json.Marshal(struct {
+		FilesScanned int           `json:"files_scanned"`
+		Issues       []hashedIssue `json:"issues"`
+	}{r.FilesScanned, issues})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// GradeForScore returns the grade of the highest threshold the score reaches.
+// An empty threshold list falls back to DefaultGradeThresholds.
+func GradeForScore(score float64, thresholds []GradeThreshold) string {
+	if len(thresholds) == 0 {
+		thresholds = DefaultGradeThresholds()
+	}
+
+	sorted := make([]GradeThreshold, len(thresholds))
+	copy(sorted, thresholds)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinScore > sorted[j].MinScore
+	})
+
+	for _, threshold := range sorted {
+		if score >= threshold.MinScore {
+			return threshold.Grade
+		}
+	}
+
+	return "F"
+}
+
+// EscalateSeverity returns the next more severe level; critical and unknown
+// levels are returned unchanged
+func EscalateSeverity(severity SeverityLevel) SeverityLevel {
+	switch severity {
+	case SeverityInfo:
+		return SeverityWarning
+	case SeverityWarning:
+		return SeverityError
+	case SeverityError:
+		return SeverityCritical
+	default:
+		return severity
+	}
+}
+
 // VibeConfig helper method
 func (vc *VibeConfig) IsEnabled() bool {
 	return vc.Enabled
@@ -517,6 +1232,17 @@ type ScanSummary struct {
 	IssuesByType     map[VibeType]int      `json:"issues_by_type" yaml:"issues_by_type"`
 	IssuesBySeverity map[SeverityLevel]int `json:"issues_by_severity" yaml:"issues_by_severity"`
 	TopIssues        []string              `json:"top_issues" yaml:"top_issues"`
-	Score            float64               `json:"score" yaml:"score"`
-	Grade            string                `json:"grade" yaml:"grade"`
+	// IssuesByOwner counts issues by the CODEOWNERS owner of their file, with
+	// files nobody owns under "unowned"; it is set when any issue has an owner
+	IssuesByOwner  map[string]int `json:"issues_by_owner,omitempty" yaml:"issues_by_owner,omitempty"`
+	Score          float64        `json:"score" yaml:"score"`
+	Grade          string         `json:"grade" yaml:"grade"`
+	EscalatedRules map[string]int `json:"escalated_rules,omitempty" yaml:"escalated_rules,omitempty"`
+	// InlineSuppressed counts the issues dropped by kodevibe:ignore comments,
+	// in total and by rule, so heavy use of them stays visible
+	InlineSuppressed       int            `json:"inline_suppressed,omitempty" yaml:"inline_suppressed,omitempty"`
+	InlineSuppressedByRule map[string]int `json:"inline_suppressed_by_rule,omitempty" yaml:"inline_suppressed_by_rule,omitempty"`
+	// Sample is set when only a sample of the files was scanned; Score is
+	// then extrapolated to all of them
+	Sample *SampleSummary `json:"sample,omitempty" yaml:"sample,omitempty"`
 }
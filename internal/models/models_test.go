@@ -294,6 +294,9 @@ func TestScanResult_CalculateSummary(t *testing.T) {
 	// Test issue breakdown by type
 	assert.Equal(t, 2, summary.IssuesByType[VibeTypeSecurity])
 	assert.Equal(t, 2, summary.IssuesByType[VibeTypeCode])
+
+	// Test issue breakdown by severity
+	assert.Equal(t, map[SeverityLevel]int{SeverityCritical: 1, SeverityError: 1, SeverityWarning: 1, SeverityInfo: 1}, summary.IssuesBySeverity)
 }
 
 func TestScanResult_GetIssuesBySeverity(t *testing.T) {
@@ -396,6 +399,94 @@ func TestConfiguration_Validate(t *testing.T) {
 	assert.False(t, invalidConfig.IsValid())
 }
 
+func TestScannerConfig_ResolveConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   ScannerConfig
+		cpus     int
+		expected int
+		wantErr  bool
+	}{
+		{name: "unset is auto", config: ScannerConfig{}, cpus: 4, expected: 4},
+		{name: "legacy max_concurrency", config: ScannerConfig{MaxConcurrency: 20}, cpus: 4, expected: 20},
+		{name: "auto", config: ScannerConfig{Concurrency: "auto"}, cpus: 8, expected: 8},
+		{name: "auto per cpu", config: ScannerConfig{Concurrency: "Auto", ConcurrencyPerCPU: 2}, cpus: 4, expected: 8},
+		{name: "auto capped", config: ScannerConfig{Concurrency: "auto", ConcurrencyPerCPU: 2, MaxConcurrency: 6}, cpus: 4, expected: 6},
+		{name: "auto without cpus", config: ScannerConfig{Concurrency: "auto"}, cpus: 0, expected: 1},
+		{name: "fixed", config: ScannerConfig{Concurrency: "3", MaxConcurrency: 2}, cpus: 8, expected: 3},
+		{name: "zero", config: ScannerConfig{Concurrency: "0"}, cpus: 4, wantErr: true},
+		{name: "not a number", config: ScannerConfig{Concurrency: "lots"}, cpus: 4, wantErr: true},
+		{name: "negative per cpu", config: ScannerConfig{Concurrency: "auto", ConcurrencyPerCPU: -1}, cpus: 4, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			concurrency, err := test.config.ResolveConcurrency(test.cpus)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, concurrency)
+		})
+	}
+}
+
+func TestNewIssueAllowance_Exceeded(t *testing.T) {
+	allow := func(n int) *int { return &n }
+	issues := func(severities ...SeverityLevel) []Issue {
+		var out []Issue
+		for _, severity := range severities {
+			out = append(out, Issue{Severity: severity})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		allowance NewIssueAllowance
+		failing   []Issue
+		exceeded  []string
+	}{
+		{name: "no failing issues", failing: nil},
+		{name: "no allowance", failing: issues(SeverityError), exceeded: []string{"1 error issue(s), 0 allowed"}},
+		{
+			name:      "warnings within allowance",
+			allowance: NewIssueAllowance{Warning: allow(5)},
+			failing:   issues(SeverityWarning, SeverityWarning),
+		},
+		{
+			name:      "no new errors, some warnings",
+			allowance: NewIssueAllowance{Warning: allow(5)},
+			failing:   issues(SeverityCritical, SeverityWarning),
+			exceeded:  []string{"1 error issue(s), 0 allowed"},
+		},
+		{
+			name:      "total only",
+			allowance: NewIssueAllowance{Total: allow(2)},
+			failing:   issues(SeverityError, SeverityWarning),
+		},
+		{
+			name:      "total exceeded",
+			allowance: NewIssueAllowance{Total: allow(2)},
+			failing:   issues(SeverityError, SeverityWarning, SeverityInfo),
+			exceeded:  []string{"3 issue(s) in total, 2 allowed"},
+		},
+		{
+			name:      "severity limit under total",
+			allowance: NewIssueAllowance{Total: allow(10), Error: allow(0)},
+			failing:   issues(SeverityError, SeverityWarning),
+			exceeded:  []string{"1 error issue(s), 0 allowed"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.exceeded, test.allowance.Exceeded(test.failing))
+		})
+	}
+}
+
 func TestConfiguration_IsValid(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -467,6 +558,60 @@ func TestVibeConfig_IsEnabled(t *testing.T) {
 	assert.False(t, disabled.IsEnabled())
 }
 
+func TestEscalateSeverity(t *testing.T) {
+	assert.Equal(t, SeverityWarning, EscalateSeverity(SeverityInfo))
+	assert.Equal(t, SeverityError, EscalateSeverity(SeverityWarning))
+	assert.Equal(t, SeverityCritical, EscalateSeverity(SeverityError))
+	assert.Equal(t, SeverityCritical, EscalateSeverity(SeverityCritical))
+	assert.Equal(t, SeverityLevel("blocker"), EscalateSeverity("blocker"))
+}
+
+func TestGradeForScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		score      float64
+		thresholds []GradeThreshold
+		expected   string
+	}{
+		{"default top", 98, nil, "A+"},
+		{"default boundary", 90, nil, "A"},
+		{"default middle", 77, nil, "B"},
+		{"default failing", 30, nil, "F"},
+		{
+			name:  "strict custom scale",
+			score: 92,
+			thresholds: []GradeThreshold{
+				{Grade: "A", MinScore: 95},
+				{Grade: "B", MinScore: 85},
+				{Grade: "F", MinScore: 0},
+			},
+			expected: "B",
+		},
+		{
+			name:  "unordered custom scale",
+			score: 96,
+			thresholds: []GradeThreshold{
+				{Grade: "F", MinScore: 0},
+				{Grade: "A", MinScore: 95},
+				{Grade: "B", MinScore: 85},
+			},
+			expected: "A",
+		},
+		{
+			name:       "below every threshold",
+			score:      10,
+			thresholds: []GradeThreshold{{Grade: "PASS", MinScore: 50}},
+			expected:   "F",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GradeForScore(test.score, test.thresholds))
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkScanResult_CalculateSummary(b *testing.B) {
 	// Create a large set of issues
@@ -507,3 +652,66 @@ func BenchmarkScanResult_GetIssuesBySeverity(b *testing.B) {
 		result.GetIssuesBySeverity(SeverityError)
 	}
 }
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := map[string]string{
+		"Line length (137) exceeds maximum (120)":            "Line length ({n}) exceeds maximum ({n})",
+		"Function 'handleRequest' has 84 lines":              "Function '{s}' has {n} lines",
+		"Secret found (introduced in commit 3fa9c0d1b2e4)":   "Secret found (introduced in commit {hash})",
+		"Use sha256 instead of md5":                          "Use sha256 instead of md5",
+		"File is 2.5 MB, larger than the \"1 MB\" threshold": "File is {n} MB, larger than the \"{s}\" threshold",
+	}
+	for message, expected := range tests {
+		assert.Equal(t, expected, NormalizeMessage(message), message)
+	}
+}
+
+func TestIssue_Fingerprint(t *testing.T) {
+	issue := Issue{Type: VibeTypeCode, Rule: "line-length", File: "main.go", Line: 3, Message: "Line length (137) exceeds maximum (120)"}
+	moved := issue
+	moved.Line = 40
+	moved.Message = "Line length (141) exceeds maximum (120)"
+	assert.Equal(t, issue.Fingerprint(), moved.Fingerprint())
+
+	other := issue
+	other.File = "util.go"
+	assert.NotEqual(t, issue.Fingerprint(), other.Fingerprint())
+
+	explicit := issue
+	explicit.MessageTemplate = "Line too long"
+	assert.Equal(t, "Line too long", explicit.Template())
+}
+
+func TestSampleSummary_Extrapolate(t *testing.T) {
+	sample := SampleSummary{FilesDiscovered: 100, FilesSampled: 10}
+	assert.Equal(t, 100.0, sample.Extrapolate(100))
+	assert.Equal(t, 50.0, sample.Extrapolate(95))
+	assert.Equal(t, 0.0, sample.Extrapolate(80))
+	assert.Equal(t, 80.0, SampleSummary{FilesDiscovered: 10, FilesSampled: 10}.Extrapolate(80))
+}
+
+func TestScanResult_ComputeReproducibilityHash(t *testing.T) {
+	issues := []Issue{
+		{ID: "1", Type: VibeTypeCode, Rule: "no-console-log", File: "a.js", Line: 3, Message: "console.log", CreatedAt: time.Now()},
+		{ID: "2", Type: VibeTypeSecurity, Rule: "hardcoded-secret", File: "b.go", Line: 1, Message: "secret"},
+	}
+	result := &ScanResult{ScanID: "scan-1", FilesScanned: 2, StartTime: time.Now(), Issues: issues}
+
+	hash := result.ComputeReproducibilityHash()
+	assert.Len(t, hash, 64)
+
+	// IDs, timestamps and issue order do not change the hash
+	rescan := &ScanResult{
+		ScanID:       "scan-2",
+		FilesScanned: 2,
+		StartTime:    time.Now().Add(time.Hour),
+		Issues:       []Issue{{ID: "x", Type: VibeTypeSecurity, Rule: "hardcoded-secret", File: "b.go", Line: 1, Message: "secret"}, issues[0]},
+	}
+	rescan.Issues[1].ID = "y"
+	assert.Equal(t, hash, rescan.ComputeReproducibilityHash())
+
+	// Findings do
+	rescan.Issues[0].Line = 2
+	assert.NotEqual(t, hash, rescan.ComputeReproducibilityHash())
+	assert.NotEqual(t, hash, (&ScanResult{FilesScanned: 3, Issues: issues}).ComputeReproducibilityHash())
+}
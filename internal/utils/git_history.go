@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commitMarker starts each commit header in the log AddedLines reads
+const commitMarker = "\x00commit "
+
+// CommitLine is a line that a commit added to a file
+type CommitLine struct {
+	Commit string
+	Author string
+	Date   time.Time
+	// File is relative to the repository root, with forward slashes
+	File string
+	Line int
+	Text string
+}
+
+// TopLevel returns the root directory of the repository
+func (g *GitUtil) TopLevel() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = g.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository root: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// AddedLines calls fn with every line added by the commits reachable from
+// HEAD, oldest commit first. since limits the walk to commits after that ref
+// and maxCommits to the most recent ones (0 means no limit). Merge commits
+// are skipped, as their changes are reported on the branches they merge.
+func (g *GitUtil) AddedLines(ctx context.Context, since string, maxCommits int, fn func(CommitLine)) error {
+	args := []string{
+		"-c", "core.quotePath=false",
+		"log", "--reverse", "--no-merges", "--no-color", "--no-renames", "--no-ext-diff",
+		// The header starts with NUL so it can't be mistaken for an added line
+		"--unified=0", "--patch", "--format=%x00commit %H %ct %ae",
+	}
+	if maxCommits > 0 {
+		args = append(args, "--max-count", strconv.Itoa(maxCommits))
+	}
+	revision := "HEAD"
+	if since != "" {
+		revision = since + "..HEAD"
+	}
+	args = append(args, revision, "--")
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.repoPath
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to read git log: %w", err)
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run git log: %w", err)
+	}
+
+	parseErr := parseAddedLines(stdout, fn)
+	if parseErr != nil {
+		// Stop git rather than leave it blocked writing to a pipe no one reads
+		_ = cmd.Process.Kill()
+	}
+	waitErr := cmd.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if waitErr != nil {
+		return fmt.Errorf("git log failed: %w: %s", waitErr, strings.TrimSpace(stderr.String()))
+	}
+	return parseErr
+}
+
+// parseAddedLines reads a "git log --patch --unified=0" stream in the format
+// AddedLines requests
+func parseAddedLines(r io.Reader, fn func(CommitLine)) error {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	var (
+		current CommitLine
+		inHunk  bool
+	)
+	for lines.Scan() {
+		line := lines.Text()
+		switch {
+		case strings.HasPrefix(line, commitMarker):
+			fields := strings.Fields(strings.TrimPrefix(line, commitMarker))
+			current = CommitLine{}
+			inHunk = false
+			if len(fields) > 0 {
+				current.Commit = fields[0]
+			}
+			if len(fields) > 1 {
+				if seconds, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+					current.Date = time.Unix(seconds, 0).UTC()
+				}
+			}
+			if len(fields) > 2 {
+				current.Author = fields[2]
+			}
+		case strings.HasPrefix(line, "diff --git "):
+			current.File = ""
+			inHunk = false
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			file := strings.TrimPrefix(line, "+++ ")
+			if file == "/dev/null" {
+				// A deletion adds nothing
+				current.File = ""
+			} else {
+				current.File = strings.TrimPrefix(file, "b/")
+			}
+		case strings.HasPrefix(line, "@@ "):
+			inHunk = true
+			current.Line = hunkStart(line)
+		case inHunk && strings.HasPrefix(line, "+"):
+			if current.File != "" {
+				added := current
+				added.Text = strings.TrimSuffix(line[1:], "\r")
+				fn(added)
+			}
+			current.Line++
+		}
+	}
+	return lines.Err()
+}
+
+// hunkStart returns the first new-file line of a hunk header such as
+// "@@ -10,2 +12,3 @@"
+func hunkStart(header string) int {
+	for _, field := range strings.Fields(header) {
+		if strings.HasPrefix(field, "+") {
+			start, _, _ := strings.Cut(field[1:], ",")
+			if line, err := strconv.Atoi(start); err == nil {
+				return line
+			}
+		}
+	}
+	return 0
+}
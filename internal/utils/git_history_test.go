@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddedLines(t *testing.T) {
+	log := "\x00commit aaa 1700000000 alice@example.com\n" +
+		"\n" +
+		"diff --git a/app.go b/app.go\n" +
+		"new file mode 100644\n" +
+		"--- /dev/null\n" +
+		"+++ b/app.go\n" +
+		"@@ -0,0 +1,2 @@\n" +
+		"+package app\n" +
+		"+++ not a header\n" +
+		"\x00commit bbb 1700000100 bob@example.com\n" +
+		"\n" +
+		"diff --git a/app.go b/app.go\n" +
+		"--- a/app.go\n" +
+		"+++ b/app.go\n" +
+		"@@ -2 +2,2 @@\n" +
+		"-++ not a header\n" +
+		"+var key = \"x\"\r\n" +
+		"+// done\n" +
+		"diff --git a/old.go b/old.go\n" +
+		"deleted file mode 100644\n" +
+		"--- a/old.go\n" +
+		"+++ /dev/null\n" +
+		"@@ -1 +0,0 @@\n" +
+		"-package old\n"
+
+	var added []CommitLine
+	require.NoError(t, parseAddedLines(strings.NewReader(log), func(line CommitLine) {
+		added = append(added, line)
+	}))
+
+	require.Len(t, added, 4)
+	assert.Equal(t, CommitLine{Commit: "aaa", Author: "alice@example.com", Date: time.Unix(1700000000, 0).UTC(), File: "app.go", Line: 1, Text: "package app"}, added[0])
+	assert.Equal(t, "++ not a header", added[1].Text)
+	assert.Equal(t, 2, added[1].Line)
+	assert.Equal(t, "bbb", added[2].Commit)
+	assert.Equal(t, 2, added[2].Line)
+	assert.Equal(t, `var key = "x"`, added[2].Text)
+	assert.Equal(t, 3, added[3].Line)
+}
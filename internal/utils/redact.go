@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"kodevibe/internal/models"
+)
+
+const (
+	// RedactedValue replaces secret material in logs
+	RedactedValue = "[REDACTED]"
+
+	// DefaultMaxLogFieldLength bounds any single logged string
+	DefaultMaxLogFieldLength = 512
+)
+
+// sensitiveLogFields are log field and metadata keys whose values are never logged
+var sensitiveLogFields = map[string]bool{
+	"context":       true,
+	"match":         true,
+	"snippet":       true,
+	"content":       true,
+	"secret":        true,
+	"token":         true,
+	"password":      true,
+	"api_key":       true,
+	"authorization": true,
+}
+
+// secretValuePatterns match common credential formats wherever they appear in a log line
+var secretValuePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`(?i)\b((?:bearer|basic)\s+)[A-Za-z0-9._~+/=-]{8,}`),
+	regexp.MustCompile(`(?i)((?:password|passwd|secret|token|api[_-]?key)\s*[:=]\s*)["']?[^\s"',;]{4,}["']?`),
+}
+
+// RedactString removes credential-looking values from s
+func RedactString(s string) string {
+	for _, pattern := range secretValuePatterns {
+		if pattern.NumSubexp() > 0 {
+			s = pattern.ReplaceAllString(s, "${1}"+RedactedValue)
+		} else {
+			s = pattern.ReplaceAllString(s, RedactedValue)
+		}
+	}
+	return s
+}
+
+// RedactionHook scrubs secrets from log entries and bounds their size. It
+// redacts sensitive fields by name, credential-looking values anywhere in the
+// message or fields, and the context and matched value of logged issues.
+type RedactionHook struct {
+	MaxFieldLength int
+}
+
+// NewRedactionHook creates a hook with the default field length limit
+func NewRedactionHook() *RedactionHook {
+	return &RedactionHook{MaxFieldLength: DefaultMaxLogFieldLength}
+}
+
+// InstallRedaction adds a redaction hook to logger
+func InstallRedaction(logger *logrus.Logger) {
+	logger.AddHook(NewRedactionHook())
+}
+
+// Levels applies the hook to every level, including debug and trace
+func (h *RedactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire redacts the entry in place; logrus passes hooks a copy of the entry data
+func (h *RedactionHook) Fire(entry *logrus.Entry) error {
+	entry.Message = h.scrub(entry.Message)
+
+	for key, value := range entry.Data {
+		if sensitiveLogFields[strings.ToLower(key)] {
+			entry.Data[key] = RedactedValue
+			continue
+		}
+		entry.Data[key] = h.scrubValue(value)
+	}
+
+	return nil
+}
+
+func (h *RedactionHook) scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return h.scrub(v)
+	case error:
+		return h.scrub(v.Error())
+	case models.Issue:
+		return h.scrubIssue(v)
+	case *models.Issue:
+		if v == nil {
+			return v
+		}
+		return h.scrubIssue(*v)
+	case []models.Issue:
+		scrubbed := make([]models.Issue, len(v))
+		for i, issue := range v {
+			scrubbed[i] = h.scrubIssue(issue)
+		}
+		return scrubbed
+	default:
+		return value
+	}
+}
+
+// scrubIssue returns a copy of issue that is safe to log
+func (h *RedactionHook) scrubIssue(issue models.Issue) models.Issue {
+	if match, ok := issue.Metadata["match"].(string); ok && match != "" {
+		issue.Context = strings.ReplaceAll(issue.Context, match, RedactedValue)
+	}
+	issue.Context = h.scrub(issue.Context)
+	issue.Message = h.scrub(issue.Message)
+
+	if issue.Metadata != nil {
+		metadata := make(map[string]interface{}, len(issue.Metadata))
+		for key, value := range issue.Metadata {
+			if sensitiveLogFields[strings.ToLower(key)] {
+				metadata[key] = RedactedValue
+				continue
+			}
+			metadata[key] = value
+		}
+		issue.Metadata = metadata
+	}
+
+	return issue
+}
+
+// scrub redacts and truncates a single string
+func (h *RedactionHook) scrub(s string) string {
+	s = RedactString(s)
+	if h.MaxFieldLength > 0 && len(s) > h.MaxFieldLength {
+		s = TruncateString(s, h.MaxFieldLength) + "...(truncated)"
+	}
+	return s
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -16,7 +18,12 @@ import (
 	"github.com/patrickmn/go-cache"
 )
 
-// Cache wraps go-cache for consistent interface
+// Cache wraps go-cache for consistent interface.
+//
+// Cache is safe for concurrent use: go-cache guards its items with an
+// internal RWMutex, so Get, Set and Clear may be called from many goroutines.
+// Stored values are shared, not copied; callers must not mutate a value after
+// Set or one obtained from Get (store and return copies instead).
 type Cache struct {
 	cache *cache.Cache
 }
@@ -88,6 +95,17 @@ func (g *GitUtil) GetDiffFiles(target string) ([]string, error) {
 	return []string{}, nil
 }
 
+// HeadCommit returns the SHA of the commit checked out in the repository
+func (g *GitUtil) HeadCommit() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = g.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // Utility functions
 func TruncateString(s string, maxLen int) string {
 	if maxLen <= 0 {
@@ -297,6 +315,33 @@ func NormalizePattern(pattern string) string {
 	return pattern
 }
 
+// ToSlashPath converts Windows path separators to forward slashes so paths
+// and patterns can be compared the same way on every platform
+func ToSlashPath(p string) string {
+	return strings.ReplaceAll(p, "\\", "/")
+}
+
+// MatchPathPattern reports whether a path matches a glob pattern, ignoring
+// differences between Windows and Unix path separators
+func MatchPathPattern(pattern, p string) bool {
+	matched, err := path.Match(ToSlashPath(pattern), ToSlashPath(p))
+	return err == nil && matched
+}
+
+// TrimLineEnding removes a trailing carriage return left by CRLF line endings
+func TrimLineEnding(line string) string {
+	return strings.TrimRight(line, "\r")
+}
+
+// SplitLines splits content into lines, accepting both LF and CRLF endings
+func SplitLines(content string) []string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = TrimLineEnding(line)
+	}
+	return lines
+}
+
 // CalculateScore calculates a quality score based on issue counts
 func CalculateScore(total, critical, errors, warnings int) float64 {
 	if total == 0 {
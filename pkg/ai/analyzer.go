@@ -0,0 +1,214 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kodevibe/internal/models"
+)
+
+const (
+	// ProviderNone is the no-op provider used when AI detection is disabled
+	ProviderNone = "none"
+	// ProviderOpenAI sends snippets to an OpenAI-compatible chat completions API
+	ProviderOpenAI = "openai"
+	// ProviderOllama sends snippets to a local Ollama server
+	ProviderOllama = "ollama"
+
+	defaultTimeout     = 30 * time.Second
+	defaultMaxSnippets = 20
+)
+
+// Kind identifies the question asked about a snippet
+type Kind string
+
+const (
+	// KindSecret asks whether a flagged string is a real secret
+	KindSecret Kind = "secret"
+	// KindExplain asks for an explanation of complex code
+	KindExplain Kind = "explain"
+)
+
+// Request is a single snippet sent for a second opinion
+type Request struct {
+	Kind    Kind   `json:"kind"`
+	Rule    string `json:"rule"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+	Snippet string `json:"snippet"`
+}
+
+// Verdict is a provider's classification of a snippet
+type Verdict struct {
+	Label       string  `json:"label"`
+	Confidence  float64 `json:"confidence"`
+	Explanation string  `json:"explanation"`
+	Provider    string  `json:"provider"`
+	Model       string  `json:"model,omitempty"`
+}
+
+// Analyzer gives a second-opinion classification of suspicious snippets
+type Analyzer interface {
+	// Name returns the provider name
+	Name() string
+
+	// Enabled reports whether the analyzer sends anything at all
+	Enabled() bool
+
+	// Analyze classifies a snippet; it must honour ctx cancellation
+	Analyze(ctx context.Context, request Request) (*Verdict, error)
+}
+
+// Config holds the provider settings taken from AdvancedConfig
+type Config struct {
+	Provider    string
+	Model       string
+	Endpoint    string
+	APIKey      string
+	Timeout     time.Duration
+	MaxSnippets int
+}
+
+// ConfigFromAdvanced builds analyzer settings from the advanced configuration
+func ConfigFromAdvanced(advanced models.AdvancedConfig) Config {
+	config := Config{
+		Provider:    strings.ToLower(advanced.AIProvider),
+		Model:       advanced.AIModel,
+		Endpoint:    advanced.AIEndpoint,
+		APIKey:      advanced.AIAPIKey,
+		Timeout:     advanced.AITimeout,
+		MaxSnippets: advanced.AIMaxSnippets,
+	}
+
+	if !advanced.AIDetection || config.Provider == "" {
+		config.Provider = ProviderNone
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxSnippets <= 0 {
+		config.MaxSnippets = defaultMaxSnippets
+	}
+
+	return config
+}
+
+// Factory creates an analyzer for a provider
+type Factory func(config Config) (Analyzer, error)
+
+// Registry maps provider names to analyzer factories
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry creates a registry with the built-in providers
+func NewRegistry() *Registry {
+	registry := &Registry{
+		factories: make(map[string]Factory),
+	}
+
+	registry.factories[ProviderNone] = func(Config) (Analyzer, error) { return NoopAnalyzer{}, nil }
+	registry.factories[ProviderOpenAI] = NewOpenAIAnalyzer
+	registry.factories[ProviderOllama] = NewOllamaAnalyzer
+
+	return registry
+}
+
+// Register adds a provider factory
+func (r *Registry) Register(name string, factory Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name = strings.ToLower(name)
+	if name == "" || factory == nil {
+		return fmt.Errorf("provider name and factory are required")
+	}
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("AI provider %s already registered", name)
+	}
+
+	r.factories[name] = factory
+	return nil
+}
+
+// Providers returns the registered provider names
+func (r *Registry) Providers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the analyzer selected by config
+func (r *Registry) New(config Config) (Analyzer, error) {
+	r.mu.RLock()
+	factory, exists := r.factories[config.Provider]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unknown AI provider %q (available: %s)", config.Provider, strings.Join(r.Providers(), ", "))
+	}
+
+	analyzer, err := factory(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AI provider %s: %w", config.Provider, err)
+	}
+
+	return analyzer, nil
+}
+
+// NoopAnalyzer never sends content and returns no verdicts
+type NoopAnalyzer struct{}
+
+// Name returns the provider name
+func (NoopAnalyzer) Name() string { return ProviderNone }
+
+// Enabled always returns false
+func (NoopAnalyzer) Enabled() bool { return false }
+
+// Analyze returns no verdict
+func (NoopAnalyzer) Analyze(context.Context, Request) (*Verdict, error) { return nil, nil }
+
+// systemPrompt instructs providers to answer with a JSON verdict
+const systemPrompt = `You review findings from a static code quality scanner.
+Answer with a single JSON object: {"label": string, "confidence": number between 0 and 1, "explanation": string}.
+For kind "secret", label is "real_secret" or "false_positive".
+For kind "explain", label is "complex" or "acceptable" and explanation summarises what the code does and how to simplify it.`
+
+// userPrompt renders a request for a provider
+func userPrompt(request Request) string {
+	return fmt.Sprintf("Kind: %s\nRule: %s\nFile: %s:%d\nFinding: %s\nSnippet:\n%s",
+		request.Kind, request.Rule, request.File, request.Line, request.Message, request.Snippet)
+}
+
+// parseVerdict decodes the JSON verdict a provider returned as text
+func parseVerdict(content, provider, model string) (*Verdict, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	var verdict Verdict
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &verdict); err != nil {
+		return nil, fmt.Errorf("invalid verdict from %s: %w", provider, err)
+	}
+	if verdict.Label == "" {
+		return nil, fmt.Errorf("verdict from %s has no label", provider)
+	}
+
+	verdict.Provider = provider
+	verdict.Model = model
+	return &verdict, nil
+}
@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestConfigFromAdvanced(t *testing.T) {
+	config := ConfigFromAdvanced(models.AdvancedConfig{AIProvider: "OpenAI"})
+	assert.Equal(t, ProviderNone, config.Provider, "disabled detection must use the no-op provider")
+	assert.Equal(t, defaultTimeout, config.Timeout)
+	assert.Equal(t, defaultMaxSnippets, config.MaxSnippets)
+
+	config = ConfigFromAdvanced(models.AdvancedConfig{AIDetection: true, AIProvider: "OpenAI", AITimeout: time.Second})
+	assert.Equal(t, ProviderOpenAI, config.Provider)
+	assert.Equal(t, time.Second, config.Timeout)
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	assert.Equal(t, []string{ProviderNone, ProviderOllama, ProviderOpenAI}, registry.Providers())
+
+	analyzer, err := registry.New(Config{Provider: ProviderNone})
+	require.NoError(t, err)
+	assert.False(t, analyzer.Enabled())
+
+	_, err = registry.New(Config{Provider: "unknown"})
+	assert.Error(t, err)
+
+	require.NoError(t, registry.Register("custom", func(Config) (Analyzer, error) { return NoopAnalyzer{}, nil }))
+	assert.Error(t, registry.Register("custom", func(Config) (Analyzer, error) { return NoopAnalyzer{}, nil }))
+
+	t.Setenv("OPENAI_API_KEY", "")
+	_, err = registry.New(Config{Provider: ProviderOpenAI})
+	assert.Error(t, err, "openai requires an API key")
+}
+
+func TestOpenAIAnalyzer_Analyze(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "test-model", payload["model"])
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"content": `{"label":"false_positive","confidence":0.9,"explanation":"test fixture"}`}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	analyzer, err := NewOpenAIAnalyzer(Config{Endpoint: server.URL, Model: "test-model", APIKey: "test-key", Timeout: time.Second})
+	require.NoError(t, err)
+
+	verdict, err := analyzer.Analyze(context.Background(), Request{Kind: KindSecret, Snippet: `key = "abc"`})
+	require.NoError(t, err)
+	assert.Equal(t, "false_positive", verdict.Label)
+	assert.Equal(t, 0.9, verdict.Confidence)
+	assert.Equal(t, ProviderOpenAI, verdict.Provider)
+	assert.Equal(t, "test-model", verdict.Model)
+}
+
+func TestOllamaAnalyzer_Analyze(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/generate", r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]string{
+			"response": "```json\n{\"label\":\"complex\",\"confidence\":0.6,\"explanation\":\"deep nesting\"}\n```",
+		})
+	}))
+	defer server.Close()
+
+	analyzer, err := NewOllamaAnalyzer(Config{Endpoint: server.URL, Timeout: time.Second})
+	require.NoError(t, err)
+
+	verdict, err := analyzer.Analyze(context.Background(), Request{Kind: KindExplain, Snippet: "func f() {}"})
+	require.NoError(t, err)
+	assert.Equal(t, "complex", verdict.Label)
+	assert.Equal(t, defaultOllamaModel, verdict.Model)
+}
+
+func TestOllamaAnalyzer_RespectsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	analyzer, err := NewOllamaAnalyzer(Config{Endpoint: server.URL, Timeout: time.Minute})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = analyzer.Analyze(ctx, Request{Kind: KindSecret, Snippet: "x"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,164 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	defaultOpenAIEndpoint = "https://api.openai.com/v1"
+	defaultOpenAIModel    = "gpt-4o-mini"
+	defaultOllamaEndpoint = "http://localhost:11434"
+	defaultOllamaModel    = "llama3"
+)
+
+// OpenAIAnalyzer uses an OpenAI-compatible chat completions API
+type OpenAIAnalyzer struct {
+	endpoint   string
+	model      string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewOpenAIAnalyzer creates an OpenAI analyzer; the key falls back to OPENAI_API_KEY
+func NewOpenAIAnalyzer(config Config) (Analyzer, error) {
+	apiKey := config.APIKey
+	if apiKey == "" {
+		apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("an API key is required (advanced.ai_api_key or OPENAI_API_KEY)")
+	}
+
+	return &OpenAIAnalyzer{
+		endpoint:   strings.TrimRight(valueOr(config.Endpoint, defaultOpenAIEndpoint), "/"),
+		model:      valueOr(config.Model, defaultOpenAIModel),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// Name returns the provider name
+func (a *OpenAIAnalyzer) Name() string { return ProviderOpenAI }
+
+// Enabled always returns true
+func (a *OpenAIAnalyzer) Enabled() bool { return true }
+
+// Analyze asks the chat completions API to classify a snippet
+func (a *OpenAIAnalyzer) Analyze(ctx context.Context, request Request) (*Verdict, error) {
+	payload := map[string]interface{}{
+		"model": a.model,
+		"messages": []map[string]string{
+			{"role": "system", "content": systemPrompt},
+			{"role": "user", "content": userPrompt(request)},
+		},
+		"response_format": map[string]string{"type": "json_object"},
+		"temperature":     0,
+	}
+
+	var response struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + a.apiKey}
+	if err := postJSON(ctx, a.httpClient, a.endpoint+"/chat/completions", headers, payload, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("openai returned no choices")
+	}
+
+	return parseVerdict(response.Choices[0].Message.Content, ProviderOpenAI, a.model)
+}
+
+// OllamaAnalyzer uses a local Ollama server
+type OllamaAnalyzer struct {
+	endpoint   string
+	model      string
+	httpClient *http.Client
+}
+
+// NewOllamaAnalyzer creates an analyzer for a local Ollama server
+func NewOllamaAnalyzer(config Config) (Analyzer, error) {
+	return &OllamaAnalyzer{
+		endpoint:   strings.TrimRight(valueOr(config.Endpoint, defaultOllamaEndpoint), "/"),
+		model:      valueOr(config.Model, defaultOllamaModel),
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// Name returns the provider name
+func (a *OllamaAnalyzer) Name() string { return ProviderOllama }
+
+// Enabled always returns true
+func (a *OllamaAnalyzer) Enabled() bool { return true }
+
+// Analyze asks the Ollama generate API to classify a snippet
+func (a *OllamaAnalyzer) Analyze(ctx context.Context, request Request) (*Verdict, error) {
+	payload := map[string]interface{}{
+		"model":  a.model,
+		"system": systemPrompt,
+		"prompt": userPrompt(request),
+		"format": "json",
+		"stream": false,
+	}
+
+	var response struct {
+		Response string `json:"response"`
+	}
+
+	if err := postJSON(ctx, a.httpClient, a.endpoint+"/api/generate", nil, payload, &response); err != nil {
+		return nil, err
+	}
+
+	return parseVerdict(response.Response, ProviderOllama, a.model)
+}
+
+// postJSON sends a JSON request and decodes the JSON response
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("provider returned %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
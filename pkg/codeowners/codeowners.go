@@ -0,0 +1,244 @@
+// Package codeowners reads CODEOWNERS files and finds the owners of a path
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/gitpattern"
+	"kodevibe/internal/models"
+)
+
+// Issue metadata the scanner sets from the CODEOWNERS file
+const (
+	// OwnersKey lists the owners of the issue's file, such as @org/team
+	OwnersKey = "owners"
+	// OwnersRuleKey is the CODEOWNERS pattern that assigned them
+	OwnersRuleKey = "owners_rule"
+)
+
+// Unowned groups and selects the issues of files no rule gives an owner
+const Unowned = "unowned"
+
+// Locations are where a CODEOWNERS file is looked for in a repository, in order
+var Locations = []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule is one line of a CODEOWNERS file: a pattern and the owners of the
+// paths it matches. A rule without owners leaves its paths unowned.
+type Rule struct {
+	Pattern string
+	Owners  []string
+	Line    int
+	regex   *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	// Path is where the file was read from, if it was read from disk
+	Path string
+	// Root is the directory its patterns are relative to
+	Root  string
+	Rules []Rule
+}
+
+// Parse reads CODEOWNERS rules. As on GitHub, lines that aren't valid (such
+// as negated patterns) are skipped, and so are GitLab section headers.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if comment := strings.Index(line, " #"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern := fields[0]
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		file.Rules = append(file.Rules, Rule{
+			Pattern: pattern,
+			Owners:  fields[1:],
+			Line:    number,
+			regex:   gitpattern.Compile(pattern, gitpattern.Options{Contents: true, ShallowStar: true}),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+	return file, nil
+}
+
+// Load parses the CODEOWNERS file at path, whose patterns are relative to root
+func Load(path, root string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CODEOWNERS: %w", err)
+	}
+	defer f.Close()
+
+	file, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	file.Path, file.Root = path, root
+	return file, nil
+}
+
+// Find looks for a CODEOWNERS file in the Locations of the git repository
+// holding path, and returns nil when there is none
+func Find(path string) (*File, error) {
+	root := RepoRoot(path)
+	if root == "" {
+		return nil, nil
+	}
+	for _, location := range Locations {
+		candidate := filepath.Join(root, filepath.FromSlash(location))
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return Load(candidate, root)
+		}
+	}
+	return nil, nil
+}
+
+// RepoRoot returns the closest directory at or above path that holds a .git
+// entry, or ""
+func RepoRoot(path string) string {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Match returns the rule that owns a slash-separated path relative to the
+// root: the last matching one, as on GitHub. It returns nil when none match.
+func (f *File) Match(path string) *Rule {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].regex.MatchString(path) {
+			return &f.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Annotate records the owners of each issue's file in its metadata. Files
+// outside the root, and files no rule matches, are left unowned.
+func (f *File) Annotate(issues []models.Issue) {
+	root, err := filepath.Abs(f.Root)
+	if err != nil {
+		return
+	}
+	for i := range issues {
+		if issues[i].File == "" {
+			continue
+		}
+		absolute, err := filepath.Abs(issues[i].File)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(root, absolute)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rule := f.Match(rel)
+		if rule == nil || len(rule.Owners) == 0 {
+			continue
+		}
+		if issues[i].Metadata == nil {
+			issues[i].Metadata = make(map[string]interface{})
+		}
+		issues[i].Metadata[OwnersKey] = rule.Owners
+		issues[i].Metadata[OwnersRuleKey] = rule.Pattern
+	}
+}
+
+// IssueOwners returns the owners recorded in an issue's metadata, including
+// after a round trip through JSON
+func IssueOwners(issue models.Issue) []string {
+	switch owners := issue.Metadata[OwnersKey].(type) {
+	case []string:
+		return owners
+	case []interface{}:
+		names := make([]string, 0, len(owners))
+		for _, owner := range owners {
+			if name, ok := owner.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	case string:
+		return strings.Fields(owners)
+	}
+	return nil
+}
+
+// Filter keeps the issues owned by any of owners, compared case-insensitively;
+// Unowned selects the issues nobody owns. No owners keeps every issue.
+func Filter(issues []models.Issue, owners []string) []models.Issue {
+	if len(owners) == 0 {
+		return issues
+	}
+	kept := []models.Issue{}
+	for _, issue := range issues {
+		if ownedByAny(issue, owners) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// GroupByOwner lists the issues of each owner, with an issue that has several
+// owners under each of them and unowned issues under Unowned
+func GroupByOwner(issues []models.Issue) map[string][]models.Issue {
+	groups := make(map[string][]models.Issue)
+	for _, issue := range issues {
+		owners := IssueOwners(issue)
+		if len(owners) == 0 {
+			groups[Unowned] = append(groups[Unowned], issue)
+			continue
+		}
+		for _, owner := range owners {
+			groups[owner] = append(groups[owner], issue)
+		}
+	}
+	return groups
+}
+
+// ownedByAny reports whether any of owners owns the issue
+func ownedByAny(issue models.Issue, owners []string) bool {
+	issueOwners := IssueOwners(issue)
+	for _, owner := range owners {
+		if strings.EqualFold(owner, Unowned) && len(issueOwners) == 0 {
+			return true
+		}
+		for _, issueOwner := range issueOwners {
+			if strings.EqualFold(owner, issueOwner) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package codeowners
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+const sample = `# Default owners
+*                   @org/platform
+
+*.js                @org/frontend   # inline comment
+/apps/api/          @org/api @alice
+docs/*              @org/docs
+**/migrations       @org/dba
+/api/v[12]/         @org/api-legacy
+/vendor/
+!/apps/api/legacy   @org/legacy
+[Section]
+`
+
+func TestParseAndMatch(t *testing.T) {
+	file, err := Parse(strings.NewReader(sample))
+	require.NoError(t, err)
+	require.Len(t, file.Rules, 7, "comments, negations and section headers are skipped")
+	assert.Equal(t, []string{"@org/frontend"}, file.Rules[1].Owners)
+	assert.Equal(t, 4, file.Rules[1].Line)
+
+	owners := func(path string) []string {
+		rule := file.Match(path)
+		require.NotNil(t, rule, path)
+		return rule.Owners
+	}
+	assert.Equal(t, []string{"@org/platform"}, owners("main.go"))
+	assert.Equal(t, []string{"@org/frontend"}, owners("web/src/app.js"), "patterns without a slash match at any depth")
+	assert.Equal(t, []string{"@org/api", "@alice"}, owners("apps/api/handlers/user.go"))
+	assert.Equal(t, []string{"@org/api", "@alice"}, owners("apps/api/static/app.js"), "the last matching rule wins")
+	assert.Equal(t, []string{"@org/platform"}, owners("other/apps/api/user.go"), "a leading slash anchors to the root")
+	assert.Equal(t, []string{"@org/docs"}, owners("docs/guide.md"))
+	assert.Equal(t, []string{"@org/platform"}, owners("docs/build/setup.md"), "docs/* doesn't own subdirectories")
+	assert.Equal(t, []string{"@org/dba"}, owners("services/billing/migrations/001.sql"))
+	assert.Equal(t, []string{"@org/api-legacy"}, owners("api/v2/routes.go"), "character classes work as in gitignore")
+	assert.Equal(t, []string{"@org/platform"}, owners("api/v3/routes.go"))
+	assert.Empty(t, owners("vendor/lib/lib.go"), "a rule without owners leaves files unowned")
+}
+
+func TestFindAndAnnotate(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "apps", "api"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte(sample), 0644))
+
+	file, err := Find(filepath.Join(root, "apps", "api"))
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.Equal(t, root, file.Root)
+	assert.Equal(t, filepath.Join(root, ".github", "CODEOWNERS"), file.Path)
+
+	issues := []models.Issue{
+		{Rule: "sql-injection-risk", File: filepath.Join(root, "apps", "api", "db.go")},
+		{Rule: "vendored", File: filepath.Join(root, "vendor", "lib.go")},
+		{Rule: "outside", File: filepath.Join(t.TempDir(), "main.go")},
+	}
+	file.Annotate(issues)
+	assert.Equal(t, []string{"@org/api", "@alice"}, issues[0].Metadata[OwnersKey])
+	assert.Equal(t, "/apps/api/", issues[0].Metadata[OwnersRuleKey])
+	assert.Nil(t, issues[1].Metadata)
+	assert.Nil(t, issues[2].Metadata, "files outside the repository have no owner")
+
+	none, err := Find(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, none, "no repository, no CODEOWNERS")
+}
+
+func TestFilterAndGroup(t *testing.T) {
+	api := models.Issue{Rule: "a", Metadata: map[string]interface{}{OwnersKey: []string{"@org/api", "@alice"}}}
+	web := models.Issue{Rule: "b", Metadata: map[string]interface{}{OwnersKey: []string{"@org/frontend"}}}
+	unowned := models.Issue{Rule: "c"}
+
+	// Owners survive a JSON round trip, as when reports are made from saved results
+	data, err := json.Marshal(web)
+	require.NoError(t, err)
+	var decoded models.Issue
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []string{"@org/frontend"}, IssueOwners(decoded))
+
+	issues := []models.Issue{api, decoded, unowned}
+	assert.Len(t, Filter(issues, nil), 3)
+	assert.Equal(t, []models.Issue{api}, Filter(issues, []string{"@ORG/API"}))
+	assert.Equal(t, []models.Issue{unowned}, Filter(issues, []string{Unowned}))
+	assert.Empty(t, Filter(issues, []string{"@org/nobody"}))
+
+	groups := GroupByOwner(issues)
+	assert.Len(t, groups, 4)
+	assert.Len(t, groups["@alice"], 1)
+	assert.Len(t, groups[Unowned], 1)
+}
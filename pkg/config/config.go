@@ -8,7 +8,11 @@ import (
 	"time"
 
 	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	"kodevibe/pkg/hooks"
+	"kodevibe/pkg/vibes"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 )
@@ -31,8 +35,19 @@ func NewManager() *Manager {
 	}
 }
 
-// LoadConfig loads configuration from file and environment variables
-func (m *Manager) LoadConfig(configPath string) error {
+// LoadConfig loads configuration from file and environment variables. When
+// it fails before a configuration was read, GetConfig returns the built-in
+// defaults.
+func (m *Manager) LoadConfig(configPath string) (err error) {
+	defer func() {
+		if err != nil && m.config == nil {
+			m.config = m.getDefaultConfig()
+			if defaults, defaultsErr := builtInDefaults(); defaultsErr == nil {
+				m.config = defaults
+			}
+		}
+	}()
+
 	m.viper.SetConfigType("yaml")
 
 	// Set default values
@@ -51,11 +66,21 @@ func (m *Manager) LoadConfig(configPath string) error {
 		}
 	}
 
+	// Reject unknown keys and mistyped values, which would otherwise be
+	// silently ignored or replaced by defaults
+	if configFile := m.viper.ConfigFileUsed(); configFile != "" {
+		if err := validateConfigFileSchema(configFile); err != nil {
+			return fmt.Errorf("invalid configuration:\n%w", err)
+		}
+	}
+
 	// Load from environment variables
 	m.loadFromEnv()
 
-	// Unmarshal into config struct
-	if err := m.viper.Unmarshal(&m.config); err != nil {
+	// Unmarshal into config struct, matching keys by their yaml tags
+	if err := m.viper.Unmarshal(&m.config, func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "yaml"
+	}); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
@@ -72,6 +97,38 @@ func (m *Manager) GetConfig() *models.Configuration {
 	return m.config
 }
 
+// ConfigFileUsed returns the path of the config file that was loaded, if any
+func (m *Manager) ConfigFileUsed() string {
+	return m.viper.ConfigFileUsed()
+}
+
+// FindConfigFile looks for .kodevibe/config.yaml or the legacy DefaultConfigFile in
+// the directory of path and its parents. It returns the nearest match, or an empty
+// string if none exists.
+func FindConfigFile(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	dir := absPath
+	if info, err := os.Stat(absPath); err != nil || !info.IsDir() {
+		dir = filepath.Dir(absPath)
+	}
+
+	for {
+		if candidate := NewProjectLayout(dir).FindConfig(); candidate != "" {
+			return candidate, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
 // SaveConfig saves the current configuration to file
 func (m *Manager) SaveConfig(configPath string) error {
 	data, err := yaml.Marshal(m.config)
@@ -92,6 +149,11 @@ func (m *Manager) setDefaults() {
 	m.viper.SetDefault("project.type", "auto-detect")
 	m.viper.SetDefault("project.language", "auto-detect")
 
+	// Scanner settings
+	m.viper.SetDefault("scanner.concurrency", models.ConcurrencyAuto)
+	m.viper.SetDefault("scanner.discovery_budget", models.DefaultDiscoveryBudget)
+	m.viper.SetDefault("scanner.codeowners.enabled", true)
+
 	// Vibes settings
 	m.viper.SetDefault("vibes.security.enabled", true)
 	m.viper.SetDefault("vibes.security.level", "strict")
@@ -137,6 +199,7 @@ func (m *Manager) setDefaults() {
 	m.viper.SetDefault("server.host", "localhost")
 	m.viper.SetDefault("server.port", 8080)
 	m.viper.SetDefault("server.tls", false)
+	m.viper.SetDefault("server.shutdown_timeout", "30s")
 	m.viper.SetDefault("server.auth.enabled", false)
 	m.viper.SetDefault("server.rate_limit.enabled", true)
 	m.viper.SetDefault("server.rate_limit.rps", 100)
@@ -147,6 +210,13 @@ func (m *Manager) setDefaults() {
 	m.viper.SetDefault("server.monitoring.prometheus", true)
 	m.viper.SetDefault("server.monitoring.health_check", true)
 	m.viper.SetDefault("server.monitoring.metrics_path", "/metrics")
+	m.viper.SetDefault("server.monitoring.websocket.ping_interval", "54s")
+	m.viper.SetDefault("server.monitoring.websocket.read_timeout", "60s")
+	m.viper.SetDefault("server.monitoring.websocket.read_limit", 512)
+
+	// Integration settings, registered so they can be supplied through the environment
+	m.viper.SetDefault("integrations.github.token", "")
+	m.viper.SetDefault("integrations.github.webhook_secret", "")
 
 	// Reporting settings
 	m.viper.SetDefault("reporting.generate_reports", true)
@@ -155,6 +225,10 @@ func (m *Manager) setDefaults() {
 	m.viper.SetDefault("reporting.logging.enabled", true)
 	m.viper.SetDefault("reporting.logging.level", "info")
 	m.viper.SetDefault("reporting.logging.format", "json")
+
+	// Watch settings
+	m.viper.SetDefault("watch.state", true)
+	m.viper.SetDefault("watch.debounce", "300ms")
 }
 
 // loadFromFile loads configuration from a specific file
@@ -169,9 +243,9 @@ func (m *Manager) loadFromFile(configPath string) error {
 
 // loadFromDefaultLocations tries to load config from default locations
 func (m *Manager) loadFromDefaultLocations() error {
-	// Try current directory
-	if _, err := os.Stat(DefaultConfigFile); err == nil {
-		m.viper.SetConfigFile(DefaultConfigFile)
+	// Try current directory: .kodevibe/config.yaml, then the legacy .kodevibe.yaml
+	if projectConfig := NewProjectLayout(".").FindConfig(); projectConfig != "" {
+		m.viper.SetConfigFile(projectConfig)
 		return m.viper.ReadInConfig()
 	}
 
@@ -231,6 +305,18 @@ func (m *Manager) validateConfig() error {
 		}
 	}
 
+	for vibeType, vibeConfig := range m.config.Vibes {
+		for rule, severity := range vibeConfig.SeverityOverrides {
+			if severity != models.SeverityIgnore && !severityLevel(severity) {
+				return fmt.Errorf("vibes.%s.severity_overrides.%s: unknown severity %q (use critical, error, warning, info or ignore)", vibeType, rule, severity)
+			}
+		}
+	}
+
+	if err := vibes.ValidateSecretPatterns(m.config.Vibes[models.VibeTypeSecurity].Settings); err != nil {
+		return fmt.Errorf("vibes.security.settings: %w", err)
+	}
+
 	// Validate advanced settings
 	if m.config.Advanced.MaxConcurrency <= 0 {
 		m.config.Advanced.MaxConcurrency = 10
@@ -244,59 +330,114 @@ func (m *Manager) validateConfig() error {
 		m.config.Advanced.EntropyThreshold = 4.5
 	}
 
+	// Validate reporting settings
+	for _, threshold := range m.config.Reporting.GradeThresholds {
+		if threshold.Grade == "" {
+			return fmt.Errorf("grade threshold with min_score %.1f has no grade", threshold.MinScore)
+		}
+		if threshold.MinScore < 0 || threshold.MinScore > 100 {
+			return fmt.Errorf("grade threshold %s must have min_score between 0 and 100", threshold.Grade)
+		}
+	}
+
+	if _, err := m.config.Scanner.ResolveConcurrency(1); err != nil {
+		return err
+	}
+
+	if m.config.Scanner.MaxIssuesPerFile < 0 {
+		return fmt.Errorf("scanner.max_issues_per_file must not be negative")
+	}
+
+	if m.config.Reporting.MaxReportBytes < 0 {
+		return fmt.Errorf("reporting.max_report_bytes must not be negative")
+	}
+	if m.config.Reporting.ReportConcurrency < 0 {
+		return fmt.Errorf("reporting.report_concurrency must not be negative")
+	}
+	switch m.config.Reporting.GroupBy {
+	case "", models.GroupByType, models.GroupByOwner:
+	default:
+		return fmt.Errorf("reporting.group_by must be %s or %s, got %q", models.GroupByType, models.GroupByOwner, m.config.Reporting.GroupBy)
+	}
+	if m.config.Watch.MaxStateBytes < 0 {
+		return fmt.Errorf("watch.max_state_bytes must not be negative")
+	}
+	if m.config.Watch.Debounce < 0 {
+		return fmt.Errorf("watch.debounce must not be negative")
+	}
+
+	for rule, cwe := range m.config.Scanner.CWEMapping {
+		if cwe == "" {
+			continue
+		}
+		if err := vibes.ValidateCWE(cwe); err != nil {
+			return fmt.Errorf("scanner.cwe_mapping.%s: %w", rule, err)
+		}
+	}
+
+	if budget := m.config.Scanner.DiscoveryBudget; budget < 0 || budget > 1 {
+		return fmt.Errorf("scanner.discovery_budget must be between 0 and 1, got %g", budget)
+	}
+
+	// Validate git hook settings
+	if err := hooks.Validate(m.config.CICD.GitHooks); err != nil {
+		return err
+	}
+
+	allowNew := m.config.CICD.AllowNew
+	for name, allowed := range map[string]*int{"total": allowNew.Total, "error": allowNew.Error, "warning": allowNew.Warning, "info": allowNew.Info} {
+		if allowed != nil && *allowed < 0 {
+			return fmt.Errorf("ci_cd.allow_new.%s must not be negative", name)
+		}
+	}
+
+	for key := range m.config.Linters.Mapping {
+		if !strings.HasPrefix(key, "eslint:") && !strings.HasPrefix(key, "golangci:") {
+			return fmt.Errorf(`linters.mapping key %q must start with "eslint:" or "golangci:"`, key)
+		}
+	}
+
+	if dedupe := m.config.Server.Monitoring.HistoryDedupe; dedupe != "" && !utils.ContainsString(models.HistoryDedupeModes, dedupe) {
+		return fmt.Errorf("server.monitoring.history_dedupe must be one of %s, got %q", strings.Join(models.HistoryDedupeModes, ", "), dedupe)
+	}
+
+	if m.config.Server.ShutdownTimeout < 0 {
+		return fmt.Errorf("server.shutdown_timeout must not be negative")
+	}
+
+	// Validate dashboard WebSocket settings
+	ws := m.config.Server.Monitoring.WebSocket.WithDefaults()
+	if ws.PingInterval >= ws.ReadTimeout {
+		return fmt.Errorf("server.monitoring.websocket.ping_interval (%s) must be shorter than read_timeout (%s)",
+			ws.PingInterval, ws.ReadTimeout)
+	}
+
 	return nil
 }
 
+// severityLevel reports whether severity is one an issue can have
+func severityLevel(severity models.SeverityLevel) bool {
+	for _, level := range models.SeverityLevels {
+		if severity == level {
+			return true
+		}
+	}
+	return false
+}
+
 // getDefaultConfig returns a default configuration
 func (m *Manager) getDefaultConfig() *models.Configuration {
 	return &models.Configuration{
-		Vibes: map[models.VibeType]models.VibeConfig{
-			models.VibeTypeSecurity: {
-				Enabled: true,
-				Level:   "strict",
-			},
-			models.VibeTypeCode: {
-				Enabled: true,
-				Level:   "moderate",
-				Settings: map[string]interface{}{
-					"max_function_length": 50,
-					"max_nesting_depth":   4,
-				},
-			},
-			models.VibeTypePerformance: {
-				Enabled: true,
-				Level:   "moderate",
-				Settings: map[string]interface{}{
-					"max_bundle_size": "2MB",
-				},
-			},
-			models.VibeTypeFile: {
-				Enabled: true,
-				Level:   "strict",
-			},
-			models.VibeTypeGit: {
-				Enabled: true,
-				Level:   "moderate",
-				Settings: map[string]interface{}{
-					"min_commit_message_length": 10,
-				},
-			},
-			models.VibeTypeDependency: {
-				Enabled: true,
-				Level:   "moderate",
-				Settings: map[string]interface{}{
-					"check_vulnerabilities": true,
-				},
-			},
-			models.VibeTypeDocumentation: {
-				Enabled: false,
-				Level:   "moderate",
-			},
-		},
+		Vibes: vibes.DefaultVibeConfigs(),
 		Project: models.ProjectConfig{
 			Type:     "auto-detect",
 			Language: "auto-detect",
 		},
+		Scanner: models.ScannerConfig{
+			Concurrency:     models.ConcurrencyAuto,
+			DiscoveryBudget: models.DefaultDiscoveryBudget,
+			CodeOwners:      models.CodeOwnersConfig{Enabled: true},
+		},
 		Exclude: models.ExcludeConfig{
 			Files: []string{
 				"node_modules/**/*",
@@ -327,17 +468,33 @@ func (m *Manager) getDefaultConfig() *models.Configuration {
 			GenerateReports: true,
 			ReportFormat:    "text",
 			ReportPath:      "./kodevibe-reports",
+			Store: models.ReportStoreConfig{
+				Dir: filepath.Join(ProjectDir, ReportsDir),
+			},
 			Logging: models.LoggingConfig{
 				Enabled: true,
 				Level:   "info",
 				Format:  "json",
 			},
 		},
+		Watch: models.WatchConfig{
+			State: true,
+		},
 	}
 }
 
-// CreateDefaultConfig creates a default configuration file
+// CreateDefaultConfig creates a default configuration file. A path inside a
+// .kodevibe directory also initializes the project layout.
 func CreateDefaultConfig(path string) error {
+	dir := filepath.Dir(path)
+	if filepath.Base(dir) == ProjectDir {
+		if err := NewProjectLayout(filepath.Dir(dir)).Init(); err != nil {
+			return err
+		}
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
 	manager := NewManager()
 	manager.config = manager.getDefaultConfig()
 	return manager.SaveConfig(path)
@@ -393,7 +550,9 @@ func MergeConfigs(configs ...*models.Configuration) *models.Configuration {
 	return base
 }
 
-// ValidateConfigFile validates a configuration file
+// ValidateConfigFile validates a configuration file, or the one LoadConfig
+// would find when path is empty. Schema problems are returned together, as
+// SchemaErrors.
 func ValidateConfigFile(path string) error {
 	manager := NewManager()
 	return manager.LoadConfig(path)
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestFindConfigFile(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "service", "pkg")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	found, err := FindConfigFile(nested)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	rootConfig := filepath.Join(root, DefaultConfigFile)
+	require.NoError(t, os.WriteFile(rootConfig, []byte("project:\n  type: go\n"), 0644))
+
+	found, err = FindConfigFile(filepath.Join(nested, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, rootConfig, found)
+
+	serviceConfig := filepath.Join(root, "service", DefaultConfigFile)
+	require.NoError(t, os.WriteFile(serviceConfig, []byte("project:\n  type: go\n"), 0644))
+
+	found, err = FindConfigFile(nested)
+	require.NoError(t, err)
+	assert.Equal(t, serviceConfig, found)
+}
+
+func TestLoadConfig_SnakeCaseKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	content := `reporting:
+  generate_reports: false
+  report_path: ./out
+  grade_thresholds:
+    - grade: A
+      min_score: 95
+    - grade: F
+      min_score: 0
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadConfig(path))
+
+	cfg := manager.GetConfig()
+	assert.False(t, cfg.Reporting.GenerateReports)
+	assert.Equal(t, "./out", cfg.Reporting.ReportPath)
+	require.Len(t, cfg.Reporting.GradeThresholds, 2)
+	assert.Equal(t, 95.0, cfg.Reporting.GradeThresholds[0].MinScore)
+	assert.Equal(t, path, manager.ConfigFileUsed())
+}
+
+func TestLoadConfig_WebSocketSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	content := `server:
+  monitoring:
+    websocket:
+      ping_interval: 30s
+      read_timeout: 2m
+      read_limit: 4096
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadConfig(path))
+
+	ws := manager.GetConfig().Server.Monitoring.WebSocket
+	assert.Equal(t, 30*time.Second, ws.PingInterval)
+	assert.Equal(t, 2*time.Minute, ws.ReadTimeout)
+	assert.Equal(t, int64(4096), ws.ReadLimit)
+
+	invalid := `server:
+  monitoring:
+    websocket:
+      ping_interval: 90s
+      read_timeout: 60s
+`
+	require.NoError(t, os.WriteFile(path, []byte(invalid), 0644))
+	assert.ErrorContains(t, NewManager().LoadConfig(path), "ping_interval")
+}
+
+func TestLoadConfig_CWEMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte("scanner:\n  cwe_mapping:\n    my-rule: CWE-89\n    eval-usage: \"\"\n"), 0644))
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadConfig(path))
+	assert.Equal(t, map[string]string{"my-rule": "CWE-89", "eval-usage": ""}, manager.GetConfig().Scanner.CWEMapping)
+
+	require.NoError(t, os.WriteFile(path, []byte("scanner:\n  cwe_mapping:\n    my-rule: SQLi\n"), 0644))
+	assert.ErrorContains(t, NewManager().LoadConfig(path), "scanner.cwe_mapping.my-rule")
+}
+
+func TestLoadConfig_CodeOwners(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte("reporting:\n  group_by: owner\n"), 0644))
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadConfig(path))
+	assert.True(t, manager.GetConfig().Scanner.CodeOwners.Enabled, "findings are annotated with owners by default")
+	assert.Equal(t, "owner", manager.GetConfig().Reporting.GroupBy)
+
+	require.NoError(t, os.WriteFile(path, []byte("scanner:\n  codeowners:\n    enabled: false\nreporting:\n  group_by: team\n"), 0644))
+	assert.ErrorContains(t, NewManager().LoadConfig(path), "reporting.group_by")
+}
+
+func TestLoadConfig_SeverityOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	config := "vibes:\n  code:\n    enabled: true\n    severity_overrides:\n      no-console-log: error\n      magic-number: ignore\n"
+	require.NoError(t, os.WriteFile(path, []byte(config), 0644))
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadConfig(path))
+	assert.Equal(t, map[string]models.SeverityLevel{"no-console-log": models.SeverityError, "magic-number": models.SeverityIgnore},
+		manager.GetConfig().Vibes[models.VibeTypeCode].SeverityOverrides)
+
+	require.NoError(t, os.WriteFile(path, []byte("vibes:\n  code:\n    severity_overrides:\n      no-console-log: fatal\n"), 0644))
+	assert.ErrorContains(t, NewManager().LoadConfig(path), path+`:4: vibes.code.severity_overrides.no-console-log: "fatal" is not one of critical, error, warning, info, ignore`)
+}
+
+func TestLoadConfig_SecretPatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	config := "vibes:\n  security:\n    settings:\n      secret_patterns:\n        - name: Acme Live Token\n          regex: 'acme_live_(0-9a-f'\n"
+	require.NoError(t, os.WriteFile(path, []byte(config), 0644))
+	assert.ErrorContains(t, NewManager().LoadConfig(path), `vibes.security.settings: secret_patterns[0]: pattern "Acme Live Token": invalid regex`)
+
+	config = "vibes:\n  security:\n    settings:\n      secret_patterns:\n        - name: Acme Live Token\n          regex: 'acme_live_[0-9a-f]{40}'\n          confidence: 0.9\n      disabled_secret_patterns: [JWT Token]\n"
+	require.NoError(t, os.WriteFile(path, []byte(config), 0644))
+	require.NoError(t, NewManager().LoadConfig(path))
+}
+
+func TestFindConfigFile_ProjectDir(t *testing.T) {
+	root := t.TempDir()
+	layout := NewProjectLayout(root)
+
+	legacyConfig := layout.LegacyConfigPath()
+	require.NoError(t, os.WriteFile(legacyConfig, []byte("project:\n  type: go\n"), 0644))
+
+	found, err := FindConfigFile(root)
+	require.NoError(t, err)
+	assert.Equal(t, legacyConfig, found)
+
+	require.NoError(t, layout.Init())
+	require.NoError(t, os.WriteFile(layout.ConfigPath(), []byte("project:\n  type: go\n"), 0644))
+
+	found, err = FindConfigFile(filepath.Join(root, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, layout.ConfigPath(), found, ".kodevibe/config.yaml takes precedence")
+}
+
+func TestCreateDefaultConfig_ProjectLayout(t *testing.T) {
+	root := t.TempDir()
+	layout := NewProjectLayout(root)
+
+	require.NoError(t, CreateDefaultConfig(layout.ConfigPath()))
+
+	assert.FileExists(t, layout.ConfigPath())
+	gitignore, err := os.ReadFile(filepath.Join(layout.Dir(), ".gitignore"))
+	require.NoError(t, err)
+	assert.Contains(t, string(gitignore), CacheDir+"/")
+
+	require.NoError(t, ValidateConfigFile(layout.ConfigPath()))
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Project directory layout. Sidecar artifacts live under .kodevibe/ at the
+// project root; the legacy .kodevibe.yaml at the root is still read.
+const (
+	ProjectDir        = ".kodevibe"
+	ProjectConfigFile = "config.yaml"
+	BaselineFile      = "baseline.json"
+	SuppressionsFile  = "suppressions.yaml"
+	CacheDir          = "cache"
+	HistoryFile       = "history.json"
+	WatchStateFile    = "watch-state.json"
+	ReportsDir        = "reports"
+)
+
+// projectGitignore keeps machine-local artifacts out of version control
+const projectGitignore = "# Generated by kodevibe; config, baseline and suppressions are meant to be committed\n" +
+	CacheDir + "/\n" +
+	ReportsDir + "/\n" +
+	WatchStateFile + "\n"
+
+// ProjectLayout resolves the paths of kodevibe artifacts for a project root
+type ProjectLayout struct {
+	Root string
+}
+
+// NewProjectLayout creates a layout rooted at root
+func NewProjectLayout(root string) *ProjectLayout {
+	return &ProjectLayout{Root: root}
+}
+
+// Dir returns the .kodevibe directory
+func (l *ProjectLayout) Dir() string {
+	return filepath.Join(l.Root, ProjectDir)
+}
+
+// ConfigPath returns the path of .kodevibe/config.yaml
+func (l *ProjectLayout) ConfigPath() string {
+	return filepath.Join(l.Dir(), ProjectConfigFile)
+}
+
+// LegacyConfigPath returns the path of the root-level .kodevibe.yaml
+func (l *ProjectLayout) LegacyConfigPath() string {
+	return filepath.Join(l.Root, DefaultConfigFile)
+}
+
+// BaselinePath returns the path of the issue baseline
+func (l *ProjectLayout) BaselinePath() string {
+	return filepath.Join(l.Dir(), BaselineFile)
+}
+
+// SuppressionsPath returns the path of the suppressions file
+func (l *ProjectLayout) SuppressionsPath() string {
+	return filepath.Join(l.Dir(), SuppressionsFile)
+}
+
+// CachePath returns the cache directory
+func (l *ProjectLayout) CachePath() string {
+	return filepath.Join(l.Dir(), CacheDir)
+}
+
+// HistoryPath returns the path of the scan history
+func (l *ProjectLayout) HistoryPath() string {
+	return filepath.Join(l.Dir(), HistoryFile)
+}
+
+// WatchStatePath returns the path of the state kodevibe watch resumes from
+func (l *ProjectLayout) WatchStatePath() string {
+	return filepath.Join(l.Dir(), WatchStateFile)
+}
+
+// ReportsPath returns the directory of the content-addressed report store
+func (l *ProjectLayout) ReportsPath() string {
+	return filepath.Join(l.Dir(), ReportsDir)
+}
+
+// FindConfig returns the project's config file, preferring .kodevibe/config.yaml
+// over the legacy .kodevibe.yaml, or an empty string if neither exists
+func (l *ProjectLayout) FindConfig() string {
+	for _, candidate := range []string{l.ConfigPath(), l.LegacyConfigPath()} {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Init creates the .kodevibe directory with a .gitignore for the cache
+func (l *ProjectLayout) Init() error {
+	if err := os.MkdirAll(l.Dir(), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", l.Dir(), err)
+	}
+
+	gitignore := filepath.Join(l.Dir(), ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		if err := os.WriteFile(gitignore, []byte(projectGitignore), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", gitignore, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/ai"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect Schema produces
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a JSON Schema document or subschema
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+}
+
+// durationPattern matches the Go durations accepted for time.Duration settings
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+
+	// typeEnums are the values allowed for every field of a type
+	typeEnums = map[reflect.Type][]string{
+		reflect.TypeOf(models.SeverityLevel("")): {
+			string(models.SeverityCritical), string(models.SeverityError), string(models.SeverityWarning), string(models.SeverityInfo),
+		},
+		reflect.TypeOf(models.VibeType("")): vibeTypeNames(),
+	}
+
+	// schemaEnums are the values allowed for a setting, by path; "*" stands
+	// for a map key and "[]" for a list item
+	schemaEnums = map[string][]string{
+		"reporting.report_format":             {"text", "json", "ndjson", "sarif", "html", "xml", "junit", "csv", "defectdojo"},
+		"reporting.group_by":                  {models.GroupByType, models.GroupByOwner},
+		"reporting.logging.level":             {"debug", "info", "warn", "error"},
+		"reporting.logging.format":            {"json", "text"},
+		"advanced.external_scanners[].format": {"sarif", "ndjson"},
+		"advanced.ai_provider":                ai.NewRegistry().Providers(),
+		"server.monitoring.history_dedupe":    models.HistoryDedupeModes,
+		"scanner.generated_files.vibes[]":     append([]string{"all"}, vibeTypeNames()...),
+		"ci_cd.git_hooks.pre_commit.vibes[]":  vibeTypeNames(),
+		"ci_cd.git_hooks.pre_push.vibes[]":    vibeTypeNames(),
+		"scanner.enabled_vibes[]":             vibeTypeNames(),
+		"scanner.routes[].vibes[]":            append([]string{"all", "default"}, vibeTypeNames()...),
+		"vibes.*.severity_overrides.*":        {"critical", "error", "warning", "info", string(models.SeverityIgnore)},
+		"vibes.*.level":                       models.VibeLevels,
+	}
+
+	// schemaPatterns constrain string settings, by path
+	schemaPatterns = map[string]string{
+		"scanner.concurrency": `^(auto|[1-9][0-9]*)$`,
+	}
+
+	// schemaDescriptions document settings, by path
+	schemaDescriptions = map[string]string{
+		"scanner":                                "How files are discovered and checked",
+		"scanner.concurrency":                    `Vibes checked at once: "auto" sizes it to the CPUs, or a fixed number`,
+		"scanner.concurrency_per_cpu":            "Checks per CPU when concurrency is auto (0 means 1)",
+		"scanner.max_concurrency":                "Fixed concurrency when concurrency is unset, and the cap for auto (0 = no cap)",
+		"scanner.timeout":                        "Scan timeout in seconds",
+		"scanner.enabled_vibes":                  "Vibes run when none are chosen on the command line",
+		"scanner.exclude_patterns":               "File name patterns skipped during discovery",
+		"scanner.max_depth":                      "Maximum directory depth below each scanned path (0 = unlimited)",
+		"scanner.max_issues_per_file":            "Issues reported for one file at most, keeping the most severe (0 = no limit)",
+		"scanner.fail_on_no_files":               "Fail a scan whose paths and filters match no files",
+		"scanner.cwe_mapping":                    `CWE of each rule's findings (e.g. my-rule: CWE-89), on top of the built-in mapping; "" maps a rule to none`,
+		"scanner.codeowners":                     "Record the owners of each finding's file, from a CODEOWNERS file, in its metadata",
+		"scanner.codeowners.enabled":             "Annotate findings with their owners",
+		"scanner.codeowners.file":                "CODEOWNERS file to read (default: CODEOWNERS, .github/CODEOWNERS, .gitlab/CODEOWNERS or docs/CODEOWNERS in the repository)",
+		"scanner.routes":                         "Vibes for files matching patterns, overriding the vibe selection per file; the first matching route wins",
+		"scanner.routes[].files":                 "File patterns, matched like test file patterns (e.g. *.sql, docs/**)",
+		"scanner.routes[].vibes":                 `Vibes that check the matching files; "default" adds the scan's usual vibes`,
+		"scanner.discovery_budget":               "Share of the scan timeout file discovery may use, from 0 to 1 (0 = all of it)",
+		"scanner.generated_files":                "Which vibes scan generated API stubs",
+		"scanner.generated_files.patterns":       "Patterns added to the built-in generated-file patterns",
+		"scanner.generated_files.vibes":          `Vibes that still scan generated files; "all" scans them like any other file`,
+		"server":                                 "The kodevibe server and dashboard",
+		"server.monitoring.websocket":            "Dashboard WebSocket keepalive",
+		"server.monitoring.history_dedupe":       `Which dashboard history points a new analysis replaces: "commit" (latest per commit), "hash" (latest per identical findings) or "none"`,
+		"vibes":                                  "Per-vibe settings, keyed by vibe",
+		"vibes.*.enabled":                        "Run this vibe",
+		"vibes.*.level":                          "How strictly the vibe checks",
+		"vibes.*.rules":                          "Rules to run; empty runs all of the vibe's rules",
+		"vibes.*.settings":                       "Checker-specific settings; see docs/rules.md",
+		"vibes.*.escalate_after":                 "Raise the severity of a rule's findings once it fires more than this many times, by rule",
+		"vibes.*.severity_overrides":             `Replace the severity of a rule's findings, by rule; "ignore" drops them`,
+		"vibes.*.exclude_tests":                  "Skip test files",
+		"vibes.*.test_patterns":                  "Patterns that identify test files",
+		"project":                                "Project metadata; type selects the default vibes",
+		"project.type":                           `Project type, or "auto-detect" to detect it from marker files`,
+		"exclude":                                "Files left out of every scan",
+		"exclude.files":                          "Path globs excluded from scans",
+		"exclude.patterns":                       "File name patterns excluded from scans",
+		"exclude.paths":                          "Directories excluded from scans",
+		"custom_rules":                           "Regular-expression rules added to the built-in checkers",
+		"integrations":                           "Notification and issue-tracker integrations",
+		"advanced":                               "Entropy analysis, AI review, caching and external scanners",
+		"advanced.ai_provider":                   "AI review provider",
+		"advanced.ai_api_key":                    "AI provider API key; OpenAI falls back to OPENAI_API_KEY",
+		"advanced.external_scanners":             "External tools whose SARIF or NDJSON findings are merged into the scan",
+		"advanced.external_concurrency":          "External scanners run at once",
+		"languages":                              "Per-language settings, keyed by language",
+		"ci_cd":                                  "CI and git hook settings",
+		"ci_cd.git_hooks":                        "Hooks written by 'kodevibe hooks install'",
+		"ci_cd.allow_new":                        "Failing issues a CI scan lets through, e.g. in PR scans of the changed files",
+		"ci_cd.allow_new.total":                  "Failing issues allowed across all severities",
+		"ci_cd.allow_new.error":                  "Failing errors (and critical issues) allowed",
+		"ci_cd.allow_new.warning":                "Failing warnings allowed",
+		"ci_cd.allow_new.info":                   "Failing info issues allowed",
+		"reporting":                              "Report output",
+		"reporting.report_format":                "Default report format",
+		"reporting.grade_thresholds":             "Minimum scores for each grade, highest first",
+		"reporting.rule_help_url":                "URL template for rule documentation links",
+		"reporting.store":                        "Where generated reports are kept",
+		"reporting.csv_columns":                  "Columns of CSV reports, in order",
+		"reporting.group_by":                     "Group text report issues by vibe type or by CODEOWNERS owner",
+		"reporting.path_base":                    `Directory report paths are relative to, or "absolute"`,
+		"reporting.standalone":                   "Write HTML reports as single offline files with the scan data embedded",
+		"reporting.max_report_bytes":             "Split report files over this size into numbered pages with an index (0 = no limit)",
+		"reporting.report_concurrency":           "How many formats --output-dir generates at once (0 = all at once)",
+		"reporting.defectdojo":                   "DefectDojo Generic Findings Import reports (--format defectdojo)",
+		"reporting.defectdojo.split_occurrences": "One finding per occurrence instead of one per rule, message and file listing every line",
+		"linters":                                "Linters the project already runs, whose rules KodeVibe leaves to them",
+		"linters.enabled":                        "Read the eslint and golangci-lint configs and turn off the KodeVibe rules they enforce",
+		"linters.eslint_config":                  "ESLint config file; empty looks for .eslintrc.* and package.json in the scanned directories",
+		"linters.golangci_config":                "golangci-lint config file; empty looks for .golangci.* in the scanned directories",
+		"linters.mapping":                        `KodeVibe rules each linter rule covers, keyed by "eslint:<rule>" or "golangci:<linter>"`,
+		"watch":                                  "kodevibe watch settings",
+		"watch.state":                            "Remember findings between watch runs and rescan only files changed since",
+		"watch.state_file":                       "Where the watch state is kept (default .kodevibe/watch-state.json)",
+		"watch.max_state_bytes":                  "Bound the watch state file, forgetting the files scanned longest ago (0 = 4 MiB)",
+		"watch.debounce":                         "How long file changes must settle before the changed files are scanned together",
+	}
+)
+
+// Schema returns a JSON Schema for the configuration file, generated from the
+// yaml tags of models.Configuration with the built-in defaults
+func Schema() (*JSONSchema, error) {
+	defaults, err := builtInDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	schema := schemaFor(reflect.TypeOf(models.Configuration{}), reflect.ValueOf(*defaults), "")
+	schema.Schema = JSONSchemaDraft
+	schema.Title = "KodeVibe configuration"
+	schema.Description = "Configuration for .kodevibe/config.yaml and .kodevibe.yaml"
+	return schema, nil
+}
+
+// builtInDefaults is the configuration used when no config file is found
+func builtInDefaults() (*models.Configuration, error) {
+	m := NewManager()
+	m.setDefaults()
+	m.config = m.getDefaultConfig()
+	if err := m.viper.Unmarshal(&m.config, func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "yaml"
+	}); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal default config: %w", err)
+	}
+	if err := m.validateConfig(); err != nil {
+		return nil, fmt.Errorf("invalid default configuration: %w", err)
+	}
+	return m.config, nil
+}
+
+// schemaFor describes a value of type t at path, taking its default from
+// value when value is valid
+func schemaFor(t reflect.Type, value reflect.Value, path string) *JSONSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if value.IsValid() {
+			value = value.Elem()
+		}
+	}
+
+	schema := &JSONSchema{
+		Description: schemaDescriptions[path],
+		Pattern:     schemaPatterns[path],
+	}
+	if values, ok := schemaEnums[path]; ok {
+		schema.Enum = enumValues(values)
+	} else if values, ok := typeEnums[t]; ok {
+		schema.Enum = enumValues(values)
+	}
+
+	switch {
+	case t == durationType:
+		schema.Type = []string{"string", "integer"}
+		schema.Pattern = durationPattern
+		if schema.Description == "" {
+			schema.Description = "Duration such as 30s, 5m or 1h"
+		}
+		if value.IsValid() && value.Int() != 0 {
+			schema.Default = time.Duration(value.Int()).String()
+		}
+		return schema
+	case t == timeType:
+		schema.Type = "string"
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		schema.Type = "object"
+		schema.AdditionalProperties = false
+		schema.Properties = make(map[string]*JSONSchema)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := yamlFieldName(field)
+			if name == "" {
+				continue
+			}
+			var fieldValue reflect.Value
+			if value.IsValid() {
+				fieldValue = value.Field(i)
+			}
+			schema.Properties[name] = schemaFor(field.Type, fieldValue, joinSchemaPath(path, name))
+		}
+	case reflect.Map:
+		schema.Type = "object"
+		if values, ok := typeEnums[t.Key()]; ok {
+			// Maps keyed by an enum, like vibes, list each key
+			schema.Enum = nil
+			schema.AdditionalProperties = false
+			schema.Properties = make(map[string]*JSONSchema)
+			for _, key := range values {
+				var entry reflect.Value
+				if value.IsValid() && !value.IsNil() {
+					entry = value.MapIndex(reflect.ValueOf(key).Convert(t.Key()))
+				}
+				schema.Properties[key] = schemaFor(t.Elem(), entry, path+".*")
+			}
+			break
+		}
+		if t.Elem().Kind() != reflect.Interface {
+			schema.AdditionalProperties = schemaFor(t.Elem(), reflect.Value{}, path+".*")
+		}
+	case reflect.Slice, reflect.Array:
+		schema.Type = "array"
+		schema.Items = schemaFor(t.Elem(), reflect.Value{}, path+"[]")
+		if value.IsValid() && value.Len() > 0 {
+			schema.Default = value.Interface()
+		}
+	case reflect.Bool:
+		schema.Type = "boolean"
+		if value.IsValid() && value.Bool() {
+			schema.Default = true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema.Type = "integer"
+		if value.IsValid() && !value.IsZero() {
+			schema.Default = value.Interface()
+		}
+	case reflect.Float32, reflect.Float64:
+		schema.Type = "number"
+		if value.IsValid() && !value.IsZero() {
+			schema.Default = value.Interface()
+		}
+	case reflect.String:
+		schema.Type = "string"
+		if value.IsValid() && value.String() != "" {
+			schema.Default = value.String()
+		}
+	}
+	return schema
+}
+
+// yamlFieldName is the config key of a struct field, or "" if it has none
+func yamlFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func joinSchemaPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func enumValues(values []string) []interface{} {
+	enum := make([]interface{}, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return enum
+}
+
+func vibeTypeNames() []string {
+	return []string{
+		string(models.VibeTypeSecurity),
+		string(models.VibeTypeCode),
+		string(models.VibeTypePerformance),
+		string(models.VibeTypeFile),
+		string(models.VibeTypeGit),
+		string(models.VibeTypeDependency),
+		string(models.VibeTypeDocumentation),
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema(t *testing.T) {
+	schema, err := Schema()
+	require.NoError(t, err)
+
+	assert.Equal(t, JSONSchemaDraft, schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, false, schema.AdditionalProperties)
+
+	scanner := schema.Properties["scanner"]
+	require.NotNil(t, scanner)
+	concurrency := scanner.Properties["concurrency"]
+	require.NotNil(t, concurrency)
+	assert.Equal(t, "string", concurrency.Type)
+	assert.Equal(t, "auto", concurrency.Default)
+	assert.NotEmpty(t, concurrency.Description)
+
+	security := schema.Properties["vibes"].Properties["security"]
+	require.NotNil(t, security)
+	assert.Equal(t, true, security.Properties["enabled"].Default)
+	assert.Equal(t, "strict", security.Properties["level"].Default)
+
+	severity := schema.Properties["custom_rules"].Items.Properties["severity"]
+	assert.Contains(t, severity.Enum, "warning")
+
+	cacheTTL := schema.Properties["advanced"].Properties["cache_ttl"]
+	assert.Equal(t, []string{"string", "integer"}, cacheTTL.Type)
+	assert.Equal(t, "1h0m0s", cacheTTL.Default)
+
+	_, err = json.Marshal(schema)
+	require.NoError(t, err)
+}
+
+func TestSchema_CoversDefaultConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	require.NoError(t, CreateDefaultConfig(path))
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadConfig(path))
+	data, err := yaml.Marshal(manager.GetConfig())
+	require.NoError(t, err)
+	var document map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(data, &document))
+
+	schema, err := Schema()
+	require.NoError(t, err)
+	assertKeysInSchema(t, schema, document, "")
+}
+
+// assertKeysInSchema checks every key of a decoded config document is a property of schema
+func assertKeysInSchema(t *testing.T, schema *JSONSchema, value interface{}, path string) {
+	t.Helper()
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if schema.Properties == nil && schema.AdditionalProperties == nil {
+			// A free-form object such as a vibe's settings
+			return
+		}
+		for key, child := range value {
+			property, ok := schema.Properties[key]
+			if !ok {
+				additional, isSchema := schema.AdditionalProperties.(*JSONSchema)
+				if !isSchema {
+					assert.Failf(t, "key missing from schema", "%s%s", path, key)
+					continue
+				}
+				property = additional
+			}
+			assertKeysInSchema(t, property, child, path+key+".")
+		}
+	case []interface{}:
+		for _, item := range value {
+			if schema.Items != nil {
+				assertKeysInSchema(t, schema.Items, item, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaError is a problem with one setting of a config file
+type SchemaError struct {
+	// File is the config file, when the problem was found in one
+	File string
+	// Path is the setting's YAML path, such as vibes.security.level or custom_rules[2].severity
+	Path   string
+	Line   int
+	Column int
+	// Message says what is wrong, and what was likely meant when there is a close match
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	location := fmt.Sprintf("line %d", e.Line)
+	if e.File != "" {
+		location = fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %s", location, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, e.Path, e.Message)
+}
+
+// SchemaErrors lists every problem found in a config file
+type SchemaErrors []*SchemaError
+
+func (errs SchemaErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// ValidateConfigSchema checks a YAML config document against Schema: unknown
+// keys, values of the wrong type, and values outside a setting's allowed set
+// are all reported, in document order. The error is only for YAML that
+// doesn't parse.
+func ValidateConfigSchema(data []byte) (SchemaErrors, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	schema, err := Schema()
+	if err != nil {
+		return nil, err
+	}
+
+	var errs SchemaErrors
+	validateNode(document.Content[0], schema, "", &errs)
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+	return errs, nil
+}
+
+// validateConfigFileSchema checks the config file at path against Schema
+func validateConfigFileSchema(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	errs, err := ValidateConfigSchema(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if len(errs) > 0 {
+		for _, err := range errs {
+			err.File = path
+		}
+		return errs
+	}
+	return nil
+}
+
+// validateNode checks node against schema, adding what it finds to errs
+func validateNode(node *yaml.Node, schema *JSONSchema, path string, errs *SchemaErrors) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		// An empty value leaves the setting unset
+		return
+	}
+	report := func(format string, args ...interface{}) {
+		*errs = append(*errs, &SchemaError{Path: path, Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch schema.Type {
+	case nil:
+		// Free-form, such as a vibe's settings
+		return
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			report("expected a map, got %s", nodeKind(node))
+			return
+		}
+		validateMapping(node, schema, path, errs)
+		return
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			report("expected a list, got %s", nodeKind(node))
+			return
+		}
+		for i, item := range node.Content {
+			validateNode(item, schema.Items, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+		return
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		report("expected %s, got %s", schemaKind(schema.Type), nodeKind(node))
+		return
+	}
+
+	value := node.Value
+	switch schema.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 0, 64); err != nil {
+			report("expected an integer, got %q", value)
+			return
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			report("expected a number, got %q", value)
+			return
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			report("expected true or false, got %q", value)
+			return
+		}
+	default:
+		if schema.Pattern == durationPattern {
+			if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return
+			}
+			if _, err := time.ParseDuration(value); err != nil {
+				report("expected a duration such as 30s, 5m or 1h, got %q", value)
+			}
+			return
+		}
+	}
+
+	if value == "" {
+		// An empty string leaves the setting at its default
+		return
+	}
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		report("%q is not one of %s", value, enumList(schema.Enum))
+		return
+	}
+	if schema.Pattern != "" {
+		if pattern, err := regexp.Compile(schema.Pattern); err == nil && !pattern.MatchString(value) {
+			report("%q does not match %s", value, schema.Pattern)
+		}
+	}
+}
+
+// validateMapping checks the keys of a map against schema's properties
+func validateMapping(node *yaml.Node, schema *JSONSchema, path string, errs *SchemaErrors) {
+	additional, _ := schema.AdditionalProperties.(*JSONSchema)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		childPath := joinSchemaPath(path, key.Value)
+		if key.Tag == "!!merge" {
+			validateNode(value, schema, path, errs)
+			continue
+		}
+
+		property, ok := schema.Properties[key.Value]
+		switch {
+		case ok:
+		case additional != nil:
+			property = additional
+		case schema.Properties == nil && schema.AdditionalProperties == nil:
+			// A free-form map
+			continue
+		default:
+			message := "unknown key"
+			if suggestion := closestKey(key.Value, schema.Properties); suggestion != "" {
+				message = fmt.Sprintf("unknown key (did you mean %s?)", suggestion)
+			}
+			*errs = append(*errs, &SchemaError{Path: childPath, Line: key.Line, Column: key.Column, Message: message})
+			continue
+		}
+		validateNode(value, property, childPath, errs)
+	}
+}
+
+// closestKey is the property most like key, if one is within a couple of
+// edits of it
+func closestKey(key string, properties map[string]*JSONSchema) string {
+	best, bestDistance := "", 3
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if distance := editDistance(strings.ToLower(key), name); distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func nodeKind(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
+
+func schemaKind(schemaType interface{}) string {
+	switch schemaType {
+	case "integer":
+		return "an integer"
+	case "number":
+		return "a number"
+	case "boolean":
+		return "true or false"
+	default:
+		return "a single value"
+	}
+}
+
+func enumContains(enum []interface{}, value string) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func enumList(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, value := range enum {
+		values[i] = fmt.Sprint(value)
+	}
+	return strings.Join(values, ", ")
+}
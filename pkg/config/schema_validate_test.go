@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigSchema(t *testing.T) {
+	errs, err := ValidateConfigSchema([]byte(`scaner:
+  timeout: 30
+scanner:
+  max_depth: deep
+  concurrency: 4
+vibes:
+  securty:
+    enabled: true
+  code:
+    enabled: "yes please"
+    level: high
+    settings:
+      anything_goes: [1, 2]
+custom_rules:
+  - name: no-eval
+    pattern: eval
+    severty: high
+    severity: warning
+advanced:
+  cache_ttl: forever
+  ai_provider: ""
+`))
+	require.NoError(t, err)
+
+	var problems []string
+	for _, problem := range errs {
+		problems = append(problems, problem.Error())
+	}
+	assert.Equal(t, []string{
+		"line 1: scaner: unknown key (did you mean scanner?)",
+		`line 4: scanner.max_depth: expected an integer, got "deep"`,
+		"line 7: vibes.securty: unknown key (did you mean security?)",
+		`line 10: vibes.code.enabled: expected true or false, got "yes please"`,
+		`line 11: vibes.code.level: "high" is not one of strict, moderate, relaxed`,
+		"line 17: custom_rules[0].severty: unknown key (did you mean severity?)",
+		`line 20: advanced.cache_ttl: expected a duration such as 30s, 5m or 1h, got "forever"`,
+	}, problems)
+	assert.Equal(t, 3, errs[0].Column+2, "columns are 1-based")
+}
+
+func TestValidateConfigSchema_Types(t *testing.T) {
+	errs, err := ValidateConfigSchema([]byte(`exclude: [a, b]
+custom_rules:
+  name: not-a-list
+reporting:
+  report_format: pdf
+`))
+	require.NoError(t, err)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "exclude", errs[0].Path)
+	assert.Contains(t, errs[0].Message, "expected a map, got a list")
+	assert.Contains(t, errs[1].Message, "expected a list, got a map")
+	assert.Equal(t, "reporting.report_format", errs[2].Path)
+	assert.Contains(t, errs[2].Message, `"pdf" is not one of`)
+
+	_, err = ValidateConfigSchema([]byte("scanner: [unclosed\n"))
+	assert.ErrorContains(t, err, "invalid YAML")
+}
+
+func TestValidateConfigFile_ListsEveryProblem(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte("scanner:\n  max_depht: 3\nserver:\n  port: http\n"), 0644))
+
+	err := ValidateConfigFile(path)
+	var problems SchemaErrors
+	require.True(t, errors.As(err, &problems), "unexpected error: %v", err)
+	require.Len(t, problems, 2)
+	assert.Equal(t, path+":2: scanner.max_depht: unknown key (did you mean max_depth?)", problems[0].Error())
+	assert.Equal(t, path+`:4: server.port: expected an integer, got "http"`, problems[1].Error())
+
+	manager := NewManager()
+	require.Error(t, manager.LoadConfig(path))
+	require.NotNil(t, manager.GetConfig(), "a config that fails validation falls back to the defaults")
+	assert.Equal(t, 8080, manager.GetConfig().Server.Port)
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package dashboard
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime is the user and system CPU time the process has used
+func processCPUTime() (time.Duration, bool) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, false
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), true
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package dashboard
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime is the user and kernel CPU time the process has used
+func processCPUTime() (time.Duration, bool) {
+	var creation, exit, kernel, user syscall.Filetime
+	process, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0, false
+	}
+	if err := syscall.GetProcessTimes(process, &creation, &exit, &kernel, &user); err != nil {
+		return 0, false
+	}
+	// Kernel and user times count 100-nanosecond intervals
+	ticks := func(ft syscall.Filetime) int64 {
+		return int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)
+	}
+	return time.Duration((ticks(kernel) + ticks(user)) * 100), true
+}
@@ -0,0 +1,79 @@
+package dashboard
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/scoring"
+)
+
+// maxPushBytes bounds the scan result a client can push
+const maxPushBytes = 32 << 20
+
+// PushPath is where clients POST scan results to a dashboard with push enabled
+const PushPath = "/api/push"
+
+// EnablePush lets clients holding token POST scan results to PushPath, as
+// "Authorization: Bearer <token>". Each pushed result is scored and shown
+// as if it had been analyzed here. An empty token is refused, so results
+// can never be pushed anonymously.
+func (d *RealtimeDashboard) EnablePush(token string) error {
+	if token == "" {
+		return fmt.Errorf("pushing results to the dashboard needs an auth token")
+	}
+	d.mux.HandleFunc(PushPath, func(w http.ResponseWriter, r *http.Request) {
+		d.handlePush(w, r, token)
+	})
+	return nil
+}
+
+// handlePush accepts a pushed models.ScanResult
+func (d *RealtimeDashboard) handlePush(w http.ResponseWriter, r *http.Request, token string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSONError(w, http.StatusMethodNotAllowed, "use POST to push a scan result")
+		return
+	}
+	if !bearerTokenMatches(r, token) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="kodevibe"`)
+		writeJSONError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+		return
+	}
+
+	var result models.ScanResult
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPushBytes))
+	if err := decoder.Decode(&result); err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid scan result: %v", err))
+		return
+	}
+
+	analysis := scoring.AnalysisResultFromScan(&result)
+	d.UpdateAnalysis(analysis)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "accepted",
+		"commit": result.Commit,
+		"issues": len(result.Issues),
+	})
+}
+
+// bearerTokenMatches compares the request's bearer token in constant time
+func bearerTokenMatches(r *http.Request, token string) bool {
+	scheme, credentials, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(credentials)), []byte(token)) == 1
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,50 @@
+package dashboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestRealtimeDashboard_Push(t *testing.T) {
+	dashboard := NewRealtimeDashboard(0)
+	assert.Error(t, dashboard.EnablePush(""), "push without a token is refused")
+	require.NoError(t, dashboard.EnablePush("s3cret"))
+
+	body, err := json.Marshal(&models.ScanResult{
+		Commit:       "abc123",
+		FilesScanned: 4,
+		Issues:       []models.Issue{{Type: models.VibeTypeCode, Severity: models.SeverityWarning, Rule: "no-var"}},
+	})
+	require.NoError(t, err)
+
+	push := func(method, authorization string, payload []byte) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, PushPath, bytes.NewReader(payload))
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		dashboard.mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, push(http.MethodPost, "", body).Code)
+	assert.Equal(t, http.StatusUnauthorized, push(http.MethodPost, "Bearer wrong", body).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, push(http.MethodGet, "Bearer s3cret", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, push(http.MethodPost, "Bearer s3cret", []byte("{")).Code)
+	assert.Empty(t, dashboard.analysisHistory.Items(), "rejected pushes leave the history alone")
+
+	recorder := push(http.MethodPost, "Bearer s3cret", body)
+	require.Equal(t, http.StatusAccepted, recorder.Code, recorder.Body.String())
+	history := dashboard.analysisHistory.Items()
+	require.Len(t, history, 1)
+	assert.Equal(t, "abc123", history[0].Commit)
+	assert.Equal(t, 4, history[0].FilesAnalyzed)
+}
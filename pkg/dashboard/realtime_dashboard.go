@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,13 +19,16 @@ import (
 // RealtimeDashboard provides live analysis monitoring and visualization
 type RealtimeDashboard struct {
 	server          *http.Server
+	mux             *http.ServeMux
 	upgrader        websocket.Upgrader
 	clients         map[*websocket.Conn]*Client
 	clientsMutex    sync.RWMutex
-	analysisHistory []AnalysisSnapshot
+	analysisHistory *ringBuffer[AnalysisSnapshot]
 	historyMutex    sync.RWMutex
 	metricsEngine   *MetricsEngine
 	alertEngine     *AlertEngine
+	wsConfig        models.WebSocketConfig
+	historyDedupe   string
 	isRunning       bool
 }
 
@@ -38,6 +43,8 @@ type Client struct {
 // AnalysisSnapshot captures a moment in time analysis state
 type AnalysisSnapshot struct {
 	Timestamp        time.Time          `json:"timestamp"`
+	Commit           string             `json:"commit,omitempty"`
+	ResultHash       string             `json:"resultHash,omitempty"`
 	OverallScore     float64            `json:"overallScore"`
 	VibeScores       map[string]float64 `json:"vibeScores"`
 	IssueCount       int                `json:"issueCount"`
@@ -64,14 +71,21 @@ type Alert struct {
 
 // PerformanceMetrics tracks real-time performance data
 type PerformanceMetrics struct {
-	CPUUsage        float64 `json:"cpuUsage"`
-	MemoryUsage     int64   `json:"memoryUsage"`
-	FilesPerSecond  float64 `json:"filesPerSecond"`
-	LinesPerSecond  float64 `json:"linesPerSecond"`
-	ActiveAnalysers int     `json:"activeAnalysers"`
-	QueueDepth      int     `json:"queueDepth"`
-	ResponseTime    float64 `json:"responseTime"`
-	ThroughputMBps  float64 `json:"throughputMBps"`
+	// CPUUsage is the share of all CPUs the dashboard process used over the last sample, in percent
+	CPUUsage float64 `json:"cpuUsage"`
+	// MemoryUsage is the memory the Go runtime holds from the OS, in bytes
+	MemoryUsage int64 `json:"memoryUsage"`
+	// FilesPerSecond and LinesPerSecond are the analysis rates of recent analyses
+	FilesPerSecond float64 `json:"filesPerSecond"`
+	LinesPerSecond float64 `json:"linesPerSecond"`
+	// ActiveAnalysers and QueueDepth count analyses run by the dashboard, which
+	// only receives results, so they stay 0
+	ActiveAnalysers int `json:"activeAnalysers"`
+	QueueDepth      int `json:"queueDepth"`
+	// ResponseTime is the mean duration of recent analyses, in milliseconds
+	ResponseTime float64 `json:"responseTime"`
+	// ThroughputMBps stays 0: analysis results don't record how many bytes were read
+	ThroughputMBps float64 `json:"throughputMBps"`
 }
 
 // TrendData contains trending analysis for visualization
@@ -106,13 +120,15 @@ type VelocityMetrics struct {
 // MetricsEngine handles real-time metrics calculation
 type MetricsEngine struct {
 	scoringEngine *scoring.AdvancedScoringEngine
-	datapoints    []DataPoint
+	datapoints    *ringBuffer[DataPoint]
+	analyses      *ringBuffer[analysisThroughput]
+	cpu           *cpuSampler
 	mutex         sync.RWMutex
 }
 
 // AlertEngine manages real-time alerts and notifications
 type AlertEngine struct {
-	alerts     []Alert
+	alerts     *ringBuffer[Alert]
 	thresholds map[string]float64
 	mutex      sync.RWMutex
 }
@@ -129,9 +145,11 @@ type DataPoint struct {
 func NewRealtimeDashboard(port int) *RealtimeDashboard {
 	dashboard := &RealtimeDashboard{
 		clients:         make(map[*websocket.Conn]*Client),
-		analysisHistory: make([]AnalysisSnapshot, 0),
+		analysisHistory: newRingBuffer[AnalysisSnapshot](maxAnalysisHistory),
 		metricsEngine:   NewMetricsEngine(),
 		alertEngine:     NewAlertEngine(),
+		wsConfig:        models.DefaultWebSocketConfig(),
+		historyDedupe:   models.HistoryDedupeCommit,
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true // Allow all origins for development
@@ -147,6 +165,7 @@ func NewRealtimeDashboard(port int) *RealtimeDashboard {
 	mux.HandleFunc("/api/alerts", dashboard.handleAlertsAPI)
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./web/static/"))))
 
+	dashboard.mux = mux
 	dashboard.server = &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
 		Handler: mux,
@@ -155,6 +174,30 @@ func NewRealtimeDashboard(port int) *RealtimeDashboard {
 	return dashboard
 }
 
+// SetWebSocketConfig overrides the ping interval, read timeout and read limit
+// used for new client connections; unset fields keep their defaults
+func (d *RealtimeDashboard) SetWebSocketConfig(config models.WebSocketConfig) {
+	d.wsConfig = config.WithDefaults()
+}
+
+// SetHistoryDedupe chooses which earlier history points a new analysis
+// replaces: those of the same commit, those with the same findings, or none.
+// An empty mode keeps the default, commit.
+func (d *RealtimeDashboard) SetHistoryDedupe(mode string) error {
+	switch mode {
+	case "":
+		mode = models.HistoryDedupeCommit
+	case models.HistoryDedupeCommit, models.HistoryDedupeHash, models.HistoryDedupeNone:
+	default:
+		return fmt.Errorf("unknown history dedupe mode %q (supported: %s)", mode, strings.Join(models.HistoryDedupeModes, ", "))
+	}
+
+	d.historyMutex.Lock()
+	d.historyDedupe = mode
+	d.historyMutex.Unlock()
+	return nil
+}
+
 // Start starts the real-time dashboard server
 func (d *RealtimeDashboard) Start() error {
 	d.isRunning = true
@@ -168,6 +211,24 @@ func (d *RealtimeDashboard) Start() error {
 	return d.server.ListenAndServe()
 }
 
+// StartTLS starts the real-time dashboard server with TLS
+func (d *RealtimeDashboard) StartTLS(certFile, keyFile string) error {
+	d.isRunning = true
+
+	go d.metricsCollectionLoop()
+	go d.alertMonitoringLoop()
+	go d.clientCleanupLoop()
+
+	log.Printf("Starting real-time dashboard on %s (TLS)", d.server.Addr)
+	return d.server.ListenAndServeTLS(certFile, keyFile)
+}
+
+// SetAddr sets the host:port the dashboard listens on; the default is the
+// port given to NewRealtimeDashboard on all interfaces
+func (d *RealtimeDashboard) SetAddr(addr string) {
+	d.server.Addr = addr
+}
+
 // Stop stops the dashboard server
 func (d *RealtimeDashboard) Stop() error {
 	d.isRunning = false
@@ -179,12 +240,15 @@ func (d *RealtimeDashboard) UpdateAnalysis(result *models.AnalysisResult) {
 	snapshot := d.createSnapshot(result)
 
 	d.historyMutex.Lock()
-	d.analysisHistory = append(d.analysisHistory, snapshot)
-
-	// Keep only last 1000 snapshots
-	if len(d.analysisHistory) > 1000 {
-		d.analysisHistory = d.analysisHistory[1:]
+	// Re-runs of the same commit would otherwise count as progress in the
+	// velocity metrics, so only the latest analysis of each is kept
+	if key := d.historyKey(snapshot); key != "" {
+		d.analysisHistory.RemoveFunc(func(earlier AnalysisSnapshot) bool {
+			return d.historyKey(earlier) == key
+		})
 	}
+	// The ring buffer keeps only the last maxAnalysisHistory snapshots
+	d.analysisHistory.Push(snapshot)
 	d.historyMutex.Unlock()
 
 	// Update metrics
@@ -253,6 +317,19 @@ func (d *RealtimeDashboard) handleAlertsAPI(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(alerts)
 }
 
+// historyKey identifies the snapshots a new one replaces under the dedupe
+// mode; "" means it replaces none. Callers hold historyMutex.
+func (d *RealtimeDashboard) historyKey(snapshot AnalysisSnapshot) string {
+	switch d.historyDedupe {
+	case models.HistoryDedupeCommit:
+		return snapshot.Commit
+	case models.HistoryDedupeHash:
+		return snapshot.ResultHash
+	default:
+		return ""
+	}
+}
+
 // createSnapshot creates an analysis snapshot from results
 func (d *RealtimeDashboard) createSnapshot(result *models.AnalysisResult) AnalysisSnapshot {
 	vibeScores := make(map[string]float64)
@@ -274,6 +351,8 @@ func (d *RealtimeDashboard) createSnapshot(result *models.AnalysisResult) Analys
 
 	return AnalysisSnapshot{
 		Timestamp:        time.Now(),
+		Commit:           result.Commit,
+		ResultHash:       result.ResultHash,
 		OverallScore:     result.OverallScore,
 		VibeScores:       vibeScores,
 		IssueCount:       len(result.Issues),
@@ -323,11 +402,8 @@ func (d *RealtimeDashboard) broadcastAlert(alert Alert) {
 func (d *RealtimeDashboard) sendInitialData(client *Client) {
 	// Send recent analysis history
 	d.historyMutex.RLock()
-	if len(d.analysisHistory) > 0 {
-		recentHistory := d.analysisHistory
-		if len(recentHistory) > 50 {
-			recentHistory = recentHistory[len(recentHistory)-50:]
-		}
+	if d.analysisHistory.Len() > 0 {
+		recentHistory := d.analysisHistory.Last(50)
 
 		initialData := map[string]interface{}{
 			"type": "initial_data",
@@ -359,7 +435,7 @@ func (d *RealtimeDashboard) handleClientWrites(client *Client) {
 			if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
 				return
 			}
-		case <-time.After(54 * time.Second):
+		case <-time.After(d.wsConfig.PingInterval):
 			// Send ping to keep connection alive
 			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
@@ -377,11 +453,11 @@ func (d *RealtimeDashboard) handleClientReads(client *Client) {
 		client.conn.Close()
 	}()
 
-	client.conn.SetReadLimit(512)
-	client.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	client.conn.SetReadLimit(d.wsConfig.ReadLimit)
+	client.conn.SetReadDeadline(time.Now().Add(d.wsConfig.ReadTimeout))
 	client.conn.SetPongHandler(func(string) error {
 		client.lastSeen = time.Now()
-		client.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		client.conn.SetReadDeadline(time.Now().Add(d.wsConfig.ReadTimeout))
 		return nil
 	})
 
@@ -392,6 +468,7 @@ func (d *RealtimeDashboard) handleClientReads(client *Client) {
 		}
 
 		client.lastSeen = time.Now()
+		client.conn.SetReadDeadline(time.Now().Add(d.wsConfig.ReadTimeout))
 
 		// Handle client messages (subscription changes, etc.)
 		d.handleClientMessage(client, message)
@@ -476,15 +553,12 @@ func (d *RealtimeDashboard) calculateTrendData() TrendData {
 	d.historyMutex.RLock()
 	defer d.historyMutex.RUnlock()
 
-	if len(d.analysisHistory) < 2 {
+	if d.analysisHistory.Len() < 2 {
 		return TrendData{}
 	}
 
 	// Calculate trends from recent history
-	recentHistory := d.analysisHistory
-	if len(recentHistory) > 20 {
-		recentHistory = recentHistory[len(recentHistory)-20:]
-	}
+	recentHistory := d.analysisHistory.Last(20)
 
 	scoreHistory := make([]ScorePoint, 0)
 	issueHistory := make([]IssuePoint, 0)
@@ -561,13 +635,15 @@ func (d *RealtimeDashboard) cleanupStaleClients() {
 func NewMetricsEngine() *MetricsEngine {
 	return &MetricsEngine{
 		scoringEngine: scoring.NewAdvancedScoringEngine(),
-		datapoints:    make([]DataPoint, 0),
+		datapoints:    newRingBuffer[DataPoint](maxDatapoints),
+		analyses:      newRingBuffer[analysisThroughput](throughputWindow),
+		cpu:           newCPUSampler(),
 	}
 }
 
 func NewAlertEngine() *AlertEngine {
 	return &AlertEngine{
-		alerts: make([]Alert, 0),
+		alerts: newRingBuffer[Alert](maxAlerts),
 		thresholds: map[string]float64{
 			"score_critical":  30.0,
 			"score_warning":   60.0,
@@ -588,7 +664,7 @@ func (me *MetricsEngine) AddAnalysisResult(result *models.AnalysisResult) {
 	timestamp := time.Now()
 
 	// Add score datapoints
-	me.datapoints = append(me.datapoints, DataPoint{
+	me.datapoints.Push(DataPoint{
 		Timestamp: timestamp,
 		Metric:    "overall_score",
 		Value:     result.OverallScore,
@@ -596,24 +672,26 @@ func (me *MetricsEngine) AddAnalysisResult(result *models.AnalysisResult) {
 	})
 
 	// Add issue count datapoint
-	me.datapoints = append(me.datapoints, DataPoint{
+	me.datapoints.Push(DataPoint{
 		Timestamp: timestamp,
 		Metric:    "issue_count",
 		Value:     float64(len(result.Issues)),
 		Metadata:  map[string]interface{}{"duration": result.Duration},
 	})
 
-	// Keep only recent datapoints (last 1000)
-	if len(me.datapoints) > 1000 {
-		me.datapoints = me.datapoints[len(me.datapoints)-1000:]
-	}
+	me.analyses.Push(analysisThroughput{
+		files:    result.FilesAnalyzed,
+		lines:    result.LinesAnalyzed,
+		duration: result.Duration,
+	})
 }
 
 func (me *MetricsEngine) GetCurrentMetrics() map[string]interface{} {
 	me.mutex.RLock()
 	defer me.mutex.RUnlock()
 
-	if len(me.datapoints) == 0 {
+	recent, ok := me.datapoints.Newest()
+	if !ok {
 		return map[string]interface{}{
 			"score":  0.0,
 			"issues": 0,
@@ -621,7 +699,6 @@ func (me *MetricsEngine) GetCurrentMetrics() map[string]interface{} {
 		}
 	}
 
-	recent := me.datapoints[len(me.datapoints)-1]
 	return map[string]interface{}{
 		"score":     recent.Value,
 		"issues":    int(recent.Value),
@@ -630,20 +707,30 @@ func (me *MetricsEngine) GetCurrentMetrics() map[string]interface{} {
 	}
 }
 
+// GetPerformanceMetrics reports the process's current memory, the CPU usage
+// of the last sample and the throughput of recent analyses
 func (me *MetricsEngine) GetPerformanceMetrics() PerformanceMetrics {
-	return PerformanceMetrics{
-		CPUUsage:        45.2,
-		MemoryUsage:     1024 * 1024 * 256, // 256MB
-		FilesPerSecond:  15.5,
-		LinesPerSecond:  450.0,
-		ActiveAnalysers: 4,
-		QueueDepth:      0,
-		ResponseTime:    125.0,
-		ThroughputMBps:  2.3,
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	me.mutex.RLock()
+	defer me.mutex.RUnlock()
+
+	metrics := PerformanceMetrics{
+		CPUUsage:    me.cpu.usage,
+		MemoryUsage: int64(memStats.Sys),
 	}
+	throughputMetrics(&metrics, me.analyses.Items())
+	return metrics
 }
 
+// CollectSystemMetrics samples the process's CPU usage since the previous
+// collection and reports the resulting metrics
 func (me *MetricsEngine) CollectSystemMetrics() PerformanceMetrics {
+	me.mutex.Lock()
+	me.cpu.sample(time.Now())
+	me.mutex.Unlock()
+
 	return me.GetPerformanceMetrics()
 }
 
@@ -686,8 +773,8 @@ func (ae *AlertEngine) CheckAlerts(result *models.AnalysisResult) []Alert {
 		})
 	}
 
-	// Add new alerts to the list
-	ae.alerts = append(ae.alerts, newAlerts...)
+	// Add new alerts to the list; the oldest are dropped past maxAlerts
+	ae.alerts.Push(newAlerts...)
 
 	return newAlerts
 }
@@ -700,7 +787,7 @@ func (ae *AlertEngine) GetActiveAlerts() []Alert {
 	cutoff := time.Now().Add(-1 * time.Hour)
 	var activeAlerts []Alert
 
-	for _, alert := range ae.alerts {
+	for _, alert := range ae.alerts.Items() {
 		if alert.Timestamp.After(cutoff) {
 			activeAlerts = append(activeAlerts, alert)
 		}
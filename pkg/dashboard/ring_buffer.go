@@ -0,0 +1,102 @@
+package dashboard
+
+// Capacities of the dashboard's in-memory histories
+const (
+	maxAnalysisHistory = 1000
+	maxDatapoints      = 1000
+	maxAlerts          = 500
+)
+
+// ringBuffer keeps the most recent items in a fixed-size backing array, so
+// long-running servers overwrite old entries instead of growing or retaining
+// slices that were resliced away. It is not safe for concurrent use; callers
+// guard it with their own mutex.
+type ringBuffer[T any] struct {
+	items []T
+	start int
+	size  int
+}
+
+// newRingBuffer creates a ring buffer holding at most capacity items
+func newRingBuffer[T any](capacity int) *ringBuffer[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ringBuffer[T]{items: make([]T, capacity)}
+}
+
+// Push appends items, overwriting the oldest ones once the buffer is full
+func (r *ringBuffer[T]) Push(items ...T) {
+	for _, item := range items {
+		end := (r.start + r.size) % len(r.items)
+		r.items[end] = item
+		if r.size < len(r.items) {
+			r.size++
+		} else {
+			r.start = (r.start + 1) % len(r.items)
+		}
+	}
+}
+
+// Len returns the number of items held
+func (r *ringBuffer[T]) Len() int {
+	return r.size
+}
+
+// Cap returns the maximum number of items held
+func (r *ringBuffer[T]) Cap() int {
+	return len(r.items)
+}
+
+// Last returns a copy of the n most recent items, oldest first
+func (r *ringBuffer[T]) Last(n int) []T {
+	if n > r.size {
+		n = r.size
+	}
+	if n <= 0 {
+		return []T{}
+	}
+
+	out := make([]T, n)
+	first := r.start + r.size - n
+	for i := range out {
+		out[i] = r.items[(first+i)%len(r.items)]
+	}
+	return out
+}
+
+// Items returns a copy of every item, oldest first
+func (r *ringBuffer[T]) Items() []T {
+	return r.Last(r.size)
+}
+
+// Newest returns the most recently pushed item
+func (r *ringBuffer[T]) Newest() (T, bool) {
+	var zero T
+	if r.size == 0 {
+		return zero, false
+	}
+	return r.items[(r.start+r.size-1)%len(r.items)], true
+}
+
+// RemoveFunc drops every item for which drop returns true, keeping the order
+// of the rest, and returns the number dropped
+func (r *ringBuffer[T]) RemoveFunc(drop func(T) bool) int {
+	kept := 0
+	for i := 0; i < r.size; i++ {
+		item := r.items[(r.start+i)%len(r.items)]
+		if drop(item) {
+			continue
+		}
+		r.items[(r.start+kept)%len(r.items)] = item
+		kept++
+	}
+
+	var zero T
+	for i := kept; i < r.size; i++ {
+		r.items[(r.start+i)%len(r.items)] = zero
+	}
+	removed := r.size - kept
+	r.size = kept
+	return removed
+}
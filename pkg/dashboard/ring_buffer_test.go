@@ -0,0 +1,129 @@
+package dashboard
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestRingBuffer(t *testing.T) {
+	ring := newRingBuffer[int](3)
+	assert.Equal(t, 0, ring.Len())
+	assert.Equal(t, []int{}, ring.Items())
+	_, ok := ring.Newest()
+	assert.False(t, ok)
+
+	ring.Push(1, 2)
+	assert.Equal(t, []int{1, 2}, ring.Items())
+
+	ring.Push(3, 4, 5)
+	assert.Equal(t, 3, ring.Len())
+	assert.Equal(t, 3, ring.Cap())
+	assert.Equal(t, []int{3, 4, 5}, ring.Items())
+	assert.Equal(t, []int{4, 5}, ring.Last(2))
+	assert.Equal(t, []int{3, 4, 5}, ring.Last(10))
+	newest, ok := ring.Newest()
+	require.True(t, ok)
+	assert.Equal(t, 5, newest)
+
+	// Returned slices are copies
+	items := ring.Items()
+	items[0] = 99
+	assert.Equal(t, []int{3, 4, 5}, ring.Items())
+}
+
+func TestRingBuffer_RemoveFunc(t *testing.T) {
+	ring := newRingBuffer[int](4)
+	ring.Push(1, 2, 3, 4, 5, 6)
+	require.Equal(t, []int{3, 4, 5, 6}, ring.Items())
+
+	removed := ring.RemoveFunc(func(n int) bool { return n%2 == 0 })
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, []int{3, 5}, ring.Items())
+
+	ring.Push(7, 8, 9)
+	assert.Equal(t, []int{5, 7, 8, 9}, ring.Items())
+	newest, ok := ring.Newest()
+	require.True(t, ok)
+	assert.Equal(t, 9, newest)
+}
+
+func TestRealtimeDashboard_HistoryDedupe(t *testing.T) {
+	analyses := []*models.AnalysisResult{
+		{OverallScore: 70, LinesAnalyzed: 100, Commit: "aaa", ResultHash: "h1"},
+		{OverallScore: 72, LinesAnalyzed: 100, Commit: "aaa", ResultHash: "h2"},
+		{OverallScore: 80, LinesAnalyzed: 150, Commit: "bbb", ResultHash: "h2"},
+		{OverallScore: 80, LinesAnalyzed: 150, Commit: "bbb", ResultHash: "h2"},
+	}
+	history := func(mode string) []AnalysisSnapshot {
+		dashboard := NewRealtimeDashboard(0)
+		require.NoError(t, dashboard.SetHistoryDedupe(mode))
+		for _, analysis := range analyses {
+			dashboard.UpdateAnalysis(analysis)
+		}
+		return dashboard.analysisHistory.Items()
+	}
+
+	byCommit := history("")
+	require.Len(t, byCommit, 2)
+	assert.Equal(t, "aaa", byCommit[0].Commit)
+	assert.Equal(t, 72.0, byCommit[0].OverallScore, "the latest analysis of a commit is kept")
+	assert.Equal(t, "bbb", byCommit[1].Commit)
+
+	byHash := history(models.HistoryDedupeHash)
+	require.Len(t, byHash, 2)
+	assert.Equal(t, "h1", byHash[0].ResultHash)
+	assert.Equal(t, "bbb", byHash[1].Commit)
+
+	assert.Len(t, history(models.HistoryDedupeNone), 4)
+
+	assert.Error(t, NewRealtimeDashboard(0).SetHistoryDedupe("sha"))
+}
+
+func TestRealtimeDashboard_HistoryIsBounded(t *testing.T) {
+	dashboard := NewRealtimeDashboard(0)
+	result := &models.AnalysisResult{OverallScore: 90, FilesAnalyzed: 10}
+
+	update := func(n int) {
+		for i := 0; i < n; i++ {
+			dashboard.UpdateAnalysis(result)
+		}
+	}
+	heapAlloc := func() uint64 {
+		runtime.GC()
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		return stats.HeapAlloc
+	}
+
+	// Fill every buffer, then check that further updates neither grow them nor the heap
+	update(2 * maxAnalysisHistory)
+	before := heapAlloc()
+	update(5 * maxAnalysisHistory)
+	after := heapAlloc()
+
+	assert.Equal(t, maxAnalysisHistory, dashboard.analysisHistory.Len())
+	assert.Equal(t, maxAnalysisHistory, cap(dashboard.analysisHistory.items))
+	assert.Equal(t, maxDatapoints, dashboard.metricsEngine.datapoints.Len())
+	assert.Equal(t, maxDatapoints, cap(dashboard.metricsEngine.datapoints.items))
+	if after > before {
+		assert.Less(t, after-before, uint64(1<<20), "heap grew by %d bytes after %d updates", after-before, 5*maxAnalysisHistory)
+	}
+}
+
+func TestAlertEngine_AlertsAreBounded(t *testing.T) {
+	engine := NewAlertEngine()
+	result := &models.AnalysisResult{OverallScore: 10}
+
+	for i := 0; i < 3*maxAlerts; i++ {
+		require.Len(t, engine.CheckAlerts(result), 1)
+	}
+
+	assert.Equal(t, maxAlerts, engine.alerts.Len())
+	assert.Equal(t, maxAlerts, cap(engine.alerts.items))
+	assert.Len(t, engine.GetActiveAlerts(), maxAlerts)
+}
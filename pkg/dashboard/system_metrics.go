@@ -0,0 +1,62 @@
+package dashboard
+
+import (
+	"runtime"
+	"time"
+)
+
+// throughputWindow is how many recent analyses the throughput metrics average over
+const throughputWindow = 10
+
+// analysisThroughput is how much one analysis covered, and how long it took
+type analysisThroughput struct {
+	files    int
+	lines    int
+	duration time.Duration
+}
+
+// cpuSampler measures the process's CPU usage between samples
+type cpuSampler struct {
+	wall  time.Time
+	cpu   time.Duration
+	usage float64
+}
+
+// newCPUSampler starts measuring from now
+func newCPUSampler() *cpuSampler {
+	sampler := &cpuSampler{wall: time.Now()}
+	sampler.cpu, _ = processCPUTime()
+	return sampler
+}
+
+// sample updates usage to the share of all CPUs the process used since the
+// previous sample, as a percentage
+func (s *cpuSampler) sample(now time.Time) {
+	cpu, ok := processCPUTime()
+	if !ok {
+		return
+	}
+	if elapsed := now.Sub(s.wall); elapsed > 0 {
+		usage := float64(cpu-s.cpu) / float64(elapsed) / float64(runtime.NumCPU()) * 100
+		s.usage = min(max(usage, 0), 100)
+	}
+	s.wall, s.cpu = now, cpu
+}
+
+// throughputMetrics fills the analysis rates of metrics from recent analyses
+func throughputMetrics(metrics *PerformanceMetrics, analyses []analysisThroughput) {
+	var files, lines int
+	var duration time.Duration
+	for _, analysis := range analyses {
+		files += analysis.files
+		lines += analysis.lines
+		duration += analysis.duration
+	}
+	if duration <= 0 {
+		return
+	}
+	seconds := duration.Seconds()
+	metrics.FilesPerSecond = float64(files) / seconds
+	metrics.LinesPerSecond = float64(lines) / seconds
+	metrics.ResponseTime = seconds * 1000 / float64(len(analyses))
+}
@@ -0,0 +1,53 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"kodevibe/internal/models"
+)
+
+func TestMetricsEngine_Throughput(t *testing.T) {
+	engine := NewMetricsEngine()
+
+	metrics := engine.GetPerformanceMetrics()
+	assert.Zero(t, metrics.FilesPerSecond, "no analyses, no throughput")
+	assert.Positive(t, metrics.MemoryUsage)
+
+	engine.AddAnalysisResult(&models.AnalysisResult{FilesAnalyzed: 10, LinesAnalyzed: 1000, Duration: time.Second})
+	engine.AddAnalysisResult(&models.AnalysisResult{FilesAnalyzed: 30, LinesAnalyzed: 2000, Duration: 3 * time.Second})
+
+	metrics = engine.GetPerformanceMetrics()
+	assert.InDelta(t, 10, metrics.FilesPerSecond, 0.001)
+	assert.InDelta(t, 750, metrics.LinesPerSecond, 0.001)
+	assert.InDelta(t, 2000, metrics.ResponseTime, 0.001)
+
+	// Only the most recent analyses count
+	for i := 0; i < throughputWindow; i++ {
+		engine.AddAnalysisResult(&models.AnalysisResult{FilesAnalyzed: 5, LinesAnalyzed: 50, Duration: time.Second})
+	}
+	assert.InDelta(t, 5, engine.GetPerformanceMetrics().FilesPerSecond, 0.001)
+}
+
+func TestMetricsEngine_CollectSystemMetrics(t *testing.T) {
+	engine := NewMetricsEngine()
+
+	// Burn some CPU so the sample has something to measure
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for x := 0; time.Now().Before(deadline); x++ {
+		_ = x * x
+	}
+
+	metrics := engine.CollectSystemMetrics()
+	assert.Positive(t, metrics.CPUUsage)
+	assert.LessOrEqual(t, metrics.CPUUsage, 100.0)
+	assert.Equal(t, metrics.CPUUsage, engine.GetPerformanceMetrics().CPUUsage, "reading the metrics doesn't take a new sample")
+}
+
+func TestCPUSampler_Clamps(t *testing.T) {
+	sampler := &cpuSampler{wall: time.Now().Add(-time.Second), cpu: time.Hour * 1000}
+	sampler.sample(time.Now())
+	assert.Zero(t, sampler.usage, "a CPU time below the previous sample can't make usage negative")
+}
@@ -0,0 +1,327 @@
+// Package doctor diagnoses environment and setup problems that make scans
+// fail or silently do nothing.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"kodevibe/pkg/config"
+)
+
+// Status is the outcome of a single check
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// commandTimeout bounds each external command a check runs
+const commandTimeout = 10 * time.Second
+
+// Result is the outcome of one diagnostic check
+type Result struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// Options controls what the doctor inspects
+type Options struct {
+	// Dir is the project directory; it defaults to the working directory
+	Dir string
+	// ConfigFile is an explicit config file; otherwise one is searched for from Dir
+	ConfigFile string
+	// Paths are scan targets that must exist and be readable
+	Paths []string
+}
+
+// Run performs all checks and returns their results in a stable order
+func Run(ctx context.Context, opts Options) []Result {
+	if opts.Dir == "" {
+		opts.Dir = "."
+	}
+
+	var results []Result
+	results = append(results, checkGit(ctx, opts.Dir)...)
+	results = append(results, checkConfig(opts)...)
+	results = append(results, checkGoToolchain(ctx, opts.Dir))
+	results = append(results, checkWritable("Hooks directory", gitHooksDir(ctx, opts.Dir),
+		"Fix the permissions of the directory so 'kodevibe install --hooks' can write hooks"))
+	results = append(results, checkWritable("Cache directory", config.NewProjectLayout(opts.Dir).CachePath(),
+		"Fix the permissions of .kodevibe/ or run with --cache=false"))
+	for _, path := range opts.Paths {
+		results = append(results, checkReadable(path))
+	}
+
+	return filterEmpty(results)
+}
+
+// Failed reports whether any result failed
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+func checkGit(ctx context.Context, dir string) []Result {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return []Result{{
+			Name:    "Git",
+			Status:  StatusWarn,
+			Message: "git was not found on PATH; the git vibe, --staged, --diff and hooks will not work",
+			Hint:    "Install git and make sure it is on PATH",
+		}}
+	}
+
+	version, err := runCommand(ctx, dir, "git", "--version")
+	if err != nil {
+		return []Result{{
+			Name:    "Git",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%s is not runnable: %v", gitPath, err),
+			Hint:    "Reinstall git or fix the PATH entry",
+		}}
+	}
+
+	results := []Result{{Name: "Git", Status: StatusPass, Message: version}}
+
+	if _, err := runCommand(ctx, dir, "git", "rev-parse", "--show-toplevel"); err != nil {
+		results = append(results, Result{
+			Name:    "Git repository",
+			Status:  StatusWarn,
+			Message: "not inside a git repository; git-based checks and hooks are unavailable",
+			Hint:    "Run kodevibe from the root of a git checkout, or 'git init' first",
+		})
+	} else {
+		results = append(results, Result{Name: "Git repository", Status: StatusPass, Message: "inside a git repository"})
+	}
+
+	return results
+}
+
+func checkConfig(opts Options) []Result {
+	configPath := opts.ConfigFile
+	if configPath == "" {
+		found, err := config.FindConfigFile(opts.Dir)
+		if err != nil {
+			return []Result{{Name: "Config", Status: StatusFail, Message: err.Error()}}
+		}
+		configPath = found
+	}
+
+	if configPath == "" {
+		return []Result{{
+			Name:    "Config",
+			Status:  StatusWarn,
+			Message: "no config file found; built-in defaults will be used",
+			Hint:    "Run 'kodevibe config init' to create .kodevibe/config.yaml",
+		}}
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadConfig(configPath); err != nil {
+		return []Result{{
+			Name:    "Config",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%s is invalid: %v", configPath, err),
+			Hint:    "Fix the reported key, or regenerate the file with 'kodevibe config init'",
+		}}
+	}
+
+	results := []Result{{Name: "Config", Status: StatusPass, Message: fmt.Sprintf("%s is valid", configPath)}}
+
+	enabled := 0
+	for _, vibeConfig := range manager.GetConfig().Vibes {
+		if vibeConfig.Enabled {
+			enabled++
+		}
+	}
+	if enabled == 0 {
+		results = append(results, Result{
+			Name:    "Enabled vibes",
+			Status:  StatusWarn,
+			Message: "no vibes are enabled, so 'kodevibe scan' without --vibes does nothing",
+			Hint:    "Set enabled: true for at least one vibe, or pass --vibes all",
+		})
+	} else {
+		results = append(results, Result{Name: "Enabled vibes", Status: StatusPass, Message: fmt.Sprintf("%d vibes enabled", enabled)})
+	}
+
+	return results
+}
+
+var (
+	goDirectivePattern = regexp.MustCompile(`(?m)^go\s+(\d+(?:\.\d+)*)`)
+	goVersionPattern   = regexp.MustCompile(`go(\d+(?:\.\d+)*)`)
+)
+
+// checkGoToolchain makes sure Go projects can be resolved with --package
+func checkGoToolchain(ctx context.Context, dir string) Result {
+	goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return Result{}
+	}
+
+	if _, err := exec.LookPath("go"); err != nil {
+		return Result{
+			Name:    "Go toolchain",
+			Status:  StatusWarn,
+			Message: "go.mod found but go is not on PATH; --package cannot resolve packages",
+			Hint:    "Install Go or pass files and directories to scan instead of --package",
+		}
+	}
+
+	output, err := runCommand(ctx, dir, "go", "env", "GOVERSION")
+	if err != nil {
+		return Result{Name: "Go toolchain", Status: StatusFail, Message: fmt.Sprintf("go is not runnable: %v", err)}
+	}
+
+	installed := goVersionPattern.FindStringSubmatch(output)
+	required := goDirectivePattern.FindSubmatch(goMod)
+	if installed == nil || required == nil {
+		return Result{Name: "Go toolchain", Status: StatusPass, Message: output}
+	}
+
+	if compareVersions(installed[1], string(required[1])) < 0 {
+		return Result{
+			Name:    "Go toolchain",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("go.mod requires go %s but %s is installed", required[1], output),
+			Hint:    "Upgrade Go, or set GOTOOLCHAIN=auto so go can download the required version",
+		}
+	}
+
+	return Result{Name: "Go toolchain", Status: StatusPass, Message: output}
+}
+
+// gitHooksDir returns the hooks directory of the repository containing dir
+func gitHooksDir(ctx context.Context, dir string) string {
+	hooksDir, err := runCommand(ctx, dir, "git", "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return ""
+	}
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+	return hooksDir
+}
+
+// checkWritable verifies a directory, or its nearest existing parent, accepts new files
+func checkWritable(name, dir, hint string) Result {
+	if dir == "" {
+		return Result{}
+	}
+
+	target := dir
+	for {
+		if info, err := os.Stat(target); err == nil {
+			if !info.IsDir() {
+				return Result{Name: name, Status: StatusFail, Message: fmt.Sprintf("%s is not a directory", target), Hint: hint}
+			}
+			break
+		}
+		parent := filepath.Dir(target)
+		if parent == target {
+			break
+		}
+		target = parent
+	}
+
+	probe, err := os.CreateTemp(target, ".kodevibe-doctor-*")
+	if err != nil {
+		return Result{Name: name, Status: StatusFail, Message: fmt.Sprintf("%s is not writable: %v", target, err), Hint: hint}
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return Result{Name: name, Status: StatusPass, Message: fmt.Sprintf("%s is writable", dir)}
+}
+
+// checkReadable verifies a scan target exists and can be read
+func checkReadable(path string) Result {
+	name := fmt.Sprintf("Path %s", path)
+
+	file, err := os.Open(path)
+	if err != nil {
+		hint := "Check the path and its permissions"
+		if os.IsNotExist(err) {
+			hint = "Check for typos; paths are relative to the current directory"
+		}
+		return Result{Name: name, Status: StatusFail, Message: err.Error(), Hint: hint}
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return Result{Name: name, Status: StatusFail, Message: err.Error()}
+	}
+	if info.IsDir() {
+		if _, err := file.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+			return Result{Name: name, Status: StatusFail, Message: fmt.Sprintf("directory is not readable: %v", err), Hint: "Check the directory permissions"}
+		}
+	}
+
+	return Result{Name: name, Status: StatusPass, Message: "readable"}
+}
+
+func runCommand(ctx context.Context, dir, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// compareVersions compares dotted numeric versions such as 1.21 and 1.21.3
+func compareVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func filterEmpty(results []Result) []Result {
+	filtered := results[:0]
+	for _, result := range results {
+		if result.Name != "" {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
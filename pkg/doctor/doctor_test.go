@@ -0,0 +1,69 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resultNamed(t *testing.T, results []Result, name string) Result {
+	t.Helper()
+	for _, result := range results {
+		if result.Name == name {
+			return result
+		}
+	}
+	t.Fatalf("no %q result in %+v", name, results)
+	return Result{}
+}
+
+func TestRun_ConfigChecks(t *testing.T) {
+	dir := t.TempDir()
+
+	results := Run(context.Background(), Options{Dir: dir})
+	assert.Equal(t, StatusWarn, resultNamed(t, results, "Config").Status)
+
+	configPath := filepath.Join(dir, ".kodevibe.yaml")
+	disabled := "vibes:\n"
+	for _, vibe := range []string{"security", "code", "performance", "file", "git", "dependency", "documentation"} {
+		disabled += "  " + vibe + ":\n    enabled: false\n"
+	}
+	require.NoError(t, os.WriteFile(configPath, []byte(disabled), 0644))
+
+	results = Run(context.Background(), Options{Dir: dir})
+	assert.Equal(t, StatusPass, resultNamed(t, results, "Config").Status)
+	assert.Equal(t, StatusWarn, resultNamed(t, results, "Enabled vibes").Status)
+
+	require.NoError(t, os.WriteFile(configPath, []byte("reporting:\n  grade_thresholds:\n    - grade: A\n      min_score: 200\n"), 0644))
+
+	results = Run(context.Background(), Options{Dir: dir})
+	config := resultNamed(t, results, "Config")
+	assert.Equal(t, StatusFail, config.Status)
+	assert.NotEmpty(t, config.Hint)
+	assert.True(t, Failed(results))
+}
+
+func TestRun_PathsAndWriteAccess(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing")
+
+	results := Run(context.Background(), Options{Dir: dir, Paths: []string{dir, missing}})
+
+	assert.Equal(t, StatusPass, resultNamed(t, results, "Path "+dir).Status)
+	assert.Equal(t, StatusFail, resultNamed(t, results, "Path "+missing).Status)
+	assert.Equal(t, StatusPass, resultNamed(t, results, "Cache directory").Status)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "write probes must be cleaned up")
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("1.21", "1.21.0"))
+	assert.Equal(t, -1, compareVersions("1.20.5", "1.21"))
+	assert.Equal(t, 1, compareVersions("1.23.1", "1.23"))
+}
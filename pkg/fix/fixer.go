@@ -2,14 +2,19 @@ package fix
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	"kodevibe/pkg/vibes"
 
 	"github.com/sirupsen/logrus"
 )
@@ -19,6 +24,11 @@ type Fixer struct {
 	config *models.Configuration
 	logger *logrus.Logger
 	fixers map[string]FixRule
+	// checker re-scans fixed files to confirm the fixes
+	checker *vibes.CodeChecker
+	// dryRun prints the fixes as a diff to out instead of writing them
+	dryRun bool
+	out    io.Writer
 }
 
 // FixRule defines how to fix a specific rule violation
@@ -26,26 +36,56 @@ type FixRule struct {
 	Name        string
 	Pattern     *regexp.Regexp
 	Replacement string
-	FileTypes   []string
-	Confidence  float64
-	Validator   func(original, fixed string) bool
+	// Transform, when set, fixes a file's content in place of Pattern, for
+	// fixes that must tell code from strings and comments
+	Transform  func(content, ext string) string
+	FileTypes  []string
+	Confidence float64
+	Validator  func(original, fixed string) bool
+}
+
+// ruleAliases are former names of fix rules, still accepted by --rules
+var ruleAliases = map[string]string{
+	"var-to-let": "no-var",
+}
+
+// apply returns content with the rule's fix applied
+func (r FixRule) apply(content, ext string) string {
+	if r.Transform != nil {
+		return r.Transform(content, ext)
+	}
+	return r.Pattern.ReplaceAllString(content, r.Replacement)
 }
 
 // NewFixer creates a new fixer instance
 func NewFixer(config *models.Configuration, logger *logrus.Logger) *Fixer {
 	fixer := &Fixer{
-		config: config,
-		logger: logger,
-		fixers: make(map[string]FixRule),
+		config:  config,
+		logger:  logger,
+		fixers:  make(map[string]FixRule),
+		checker: vibes.NewCodeChecker(),
+		out:     os.Stdout,
+	}
+	if vibeConfig, ok := config.Vibes[models.VibeTypeCode]; ok {
+		if err := fixer.checker.Configure(vibeConfig); err != nil {
+			logger.Warnf("Re-scanning fixed files with the default code settings: %v", err)
+		}
 	}
 
 	fixer.initializeFixRules()
 	return fixer
 }
 
+// SetDryRun makes Fix print each file's fixes as a unified diff instead of
+// writing them
+func (f *Fixer) SetDryRun(dryRun bool) {
+	f.dryRun = dryRun
+}
+
 // Fix attempts to automatically fix issues in the specified paths
 func (f *Fixer) Fix(paths []string, autoFix bool, createBackup bool, rules []string) error {
 	f.logger.Info("Starting auto-fix operation")
+	rules = resolveRuleAliases(rules)
 
 	var totalFixed int
 	var totalErrors int
@@ -76,10 +116,26 @@ func (f *Fixer) Fix(paths []string, autoFix bool, createBackup bool, rules []str
 		}
 	}
 
+	if f.dryRun {
+		f.logger.Infof("Dry run completed: %d fixes would be applied, %d errors", totalFixed, totalErrors)
+		return nil
+	}
 	f.logger.Infof("Auto-fix completed: %d fixes applied, %d errors", totalFixed, totalErrors)
 	return nil
 }
 
+// resolveRuleAliases replaces former rule names with their current ones
+func resolveRuleAliases(rules []string) []string {
+	resolved := make([]string, len(rules))
+	for i, rule := range rules {
+		if name, ok := ruleAliases[rule]; ok {
+			rule = name
+		}
+		resolved[i] = rule
+	}
+	return resolved
+}
+
 // fixFile fixes issues in a single file
 func (f *Fixer) fixFile(filePath string, autoFix bool, createBackup bool, rules []string) (int, int) {
 	var fixedCount int
@@ -94,11 +150,13 @@ func (f *Fixer) fixFile(filePath string, autoFix bool, createBackup bool, rules
 	originalContent := string(content)
 	modifiedContent := originalContent
 	fileModified := false
+	var applied []string
 
 	ext := strings.ToLower(filepath.Ext(filePath))
 
-	// Apply fix rules
-	for ruleName, fixRule := range f.fixers {
+	// Apply fix rules in a stable order, so the same file always gets the same fixes
+	for _, ruleName := range f.GetAvailableFixRules() {
+		fixRule := f.fixers[ruleName]
 		// Skip if specific rules were requested and this isn't one of them
 		if len(rules) > 0 && !contains(rules, ruleName) {
 			continue
@@ -110,9 +168,9 @@ func (f *Fixer) fixFile(filePath string, autoFix bool, createBackup bool, rules
 		}
 
 		// Apply the fix
-		newContent := fixRule.Pattern.ReplaceAllString(modifiedContent, fixRule.Replacement)
+		newContent := fixRule.apply(modifiedContent, ext)
 		if newContent != modifiedContent {
-			if autoFix || f.confirmFix(filePath, ruleName, modifiedContent, newContent) {
+			if autoFix || f.dryRun || f.confirmFix(filePath, ruleName, modifiedContent, newContent) {
 				// Validate the fix if validator exists
 				if fixRule.Validator != nil && !fixRule.Validator(modifiedContent, newContent) {
 					f.logger.Warnf("Fix validation failed for rule %s in file %s", ruleName, filePath)
@@ -123,39 +181,120 @@ func (f *Fixer) fixFile(filePath string, autoFix bool, createBackup bool, rules
 				modifiedContent = newContent
 				fileModified = true
 				fixedCount++
-				f.logger.Infof("Applied fix %s to %s", ruleName, filePath)
+				applied = append(applied, ruleName)
+				if !f.dryRun {
+					f.logger.Infof("Applied fix %s to %s", ruleName, filePath)
+				}
 			}
 		}
 	}
 
-	// Write the file if it was modified
-	if fileModified {
-		// Create backup if requested
-		if createBackup {
-			backupPath := filePath + ".backup." + time.Now().Format("20060102-150405")
-			if err := os.WriteFile(backupPath, []byte(originalContent), 0644); err != nil {
-				f.logger.Errorf("Failed to create backup %s: %v", backupPath, err)
-				errorCount++
-			} else {
-				f.logger.Infof("Created backup: %s", backupPath)
-			}
-		}
+	if !fileModified {
+		return fixedCount, errorCount
+	}
+	if f.dryRun {
+		fmt.Fprint(f.out, unifiedDiff(filePath, originalContent, modifiedContent))
+		return fixedCount, errorCount
+	}
 
-		// Write the modified content
-		if err := os.WriteFile(filePath, []byte(modifiedContent), 0644); err != nil {
-			f.logger.Errorf("Failed to write fixed file %s: %v", filePath, err)
+	// Scan before writing, so the re-scan can tell which issues the fixes introduced
+	before, err := f.checker.CheckFile(context.Background(), filePath)
+	if err != nil {
+		f.logger.Warnf("Failed to scan %s before fixing: %v", filePath, err)
+	}
+
+	// Create backup if requested
+	var backupPath string
+	if createBackup {
+		backupPath = filePath + ".backup." + time.Now().Format("20060102-150405")
+		if err := os.WriteFile(backupPath, []byte(originalContent), 0644); err != nil {
+			f.logger.Errorf("Failed to create backup %s: %v", backupPath, err)
 			errorCount++
+			backupPath = ""
+		} else {
+			f.logger.Infof("Created backup: %s", backupPath)
 		}
 	}
 
+	// Write the modified content
+	if err := os.WriteFile(filePath, []byte(modifiedContent), 0644); err != nil {
+		f.logger.Errorf("Failed to write fixed file %s: %v", filePath, err)
+		return 0, errorCount + 1
+	}
+
+	if err := f.verifyFix(filePath, originalContent, modifiedContent, before, applied); err != nil {
+		f.logger.Errorf("Rolling back fixes to %s: %v", filePath, err)
+		f.rollback(filePath, backupPath, originalContent)
+		return 0, errorCount + 1
+	}
+
 	return fixedCount, errorCount
 }
 
+// verifyFix re-scans a fixed file, warning about issues of the applied rules
+// that remain, and fails if the fixes broke the file's syntax or introduced
+// errors the file didn't have before
+func (f *Fixer) verifyFix(filePath, original, fixed string, before []models.Issue, applied []string) error {
+	if err := f.ValidateFix(original, fixed, filePath); err != nil && f.ValidateFix(original, original, filePath) == nil {
+		return err
+	}
+
+	after, err := f.checker.CheckFile(context.Background(), filePath)
+	if err != nil {
+		return fmt.Errorf("failed to re-scan: %w", err)
+	}
+
+	remaining := make(map[string]int)
+	for _, issue := range after {
+		remaining[issue.Rule]++
+	}
+	for _, rule := range applied {
+		if remaining[rule] > 0 {
+			f.logger.Warnf("%d %s issue(s) remain in %s after fixing", remaining[rule], rule, filePath)
+		}
+	}
+
+	errorsBefore := errorCounts(before)
+	for rule, count := range errorCounts(after) {
+		if count > errorsBefore[rule] {
+			return fmt.Errorf("the fixes introduced %d new %s error(s)", count-errorsBefore[rule], rule)
+		}
+	}
+	return nil
+}
+
+// errorCounts counts the error and critical issues of each rule
+func errorCounts(issues []models.Issue) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		if issue.Severity == models.SeverityError || issue.Severity == models.SeverityCritical {
+			counts[issue.Rule]++
+		}
+	}
+	return counts
+}
+
+// rollback restores a file from its backup, or from its original content
+// when no backup was made
+func (f *Fixer) rollback(filePath, backupPath, original string) {
+	content := []byte(original)
+	if backupPath != "" {
+		if backup, err := os.ReadFile(backupPath); err == nil {
+			content = backup
+		} else {
+			f.logger.Warnf("Failed to read backup %s, restoring from memory: %v", backupPath, err)
+		}
+	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		f.logger.Errorf("Failed to roll back %s: %v", filePath, err)
+	}
+}
+
 // shouldSkipFile determines if a file should be skipped
 func (f *Fixer) shouldSkipFile(filePath string) bool {
 	// Check exclude patterns
 	for _, pattern := range f.config.Exclude.Files {
-		if matched, err := filepath.Match(pattern, filePath); err == nil && matched {
+		if utils.MatchPathPattern(pattern, filePath) {
 			return true
 		}
 	}
@@ -235,20 +374,26 @@ func (f *Fixer) initializeFixRules() {
 		Confidence:  0.9,
 	}
 
-	f.fixers["strict-equality"] = FixRule{
-		Name:        "Use strict equality",
-		Pattern:     regexp.MustCompile(`([^!=])==[^=]`),
-		Replacement: "${1}===",
+	f.fixers["no-debugger"] = FixRule{
+		Name:        "Remove debugger statements",
+		Pattern:     regexp.MustCompile(`(?m)^[ \t]*debugger[ \t]*;?[ \t]*\r?\n`),
+		Replacement: "",
 		FileTypes:   []string{".js", ".jsx", ".ts", ".tsx"},
-		Confidence:  0.95,
+		Confidence:  0.9,
 	}
 
-	f.fixers["var-to-let"] = FixRule{
-		Name:        "Replace var with let",
-		Pattern:     regexp.MustCompile(`\bvar\b`),
-		Replacement: "let",
-		FileTypes:   []string{".js", ".jsx", ".ts", ".tsx"},
-		Confidence:  0.8,
+	f.fixers["strict-equality"] = FixRule{
+		Name:       "Use strict equality",
+		Transform:  replaceLooseEquality,
+		FileTypes:  jsExtensions,
+		Confidence: 0.95,
+	}
+
+	f.fixers["no-var"] = FixRule{
+		Name:       "Replace var with let",
+		Transform:  replaceVar,
+		FileTypes:  jsExtensions,
+		Confidence: 0.8,
 	}
 
 	// Python fixes
@@ -271,10 +416,16 @@ func (f *Fixer) initializeFixRules() {
 
 	// Generic fixes
 	f.fixers["trailing-whitespace"] = FixRule{
-		Name:        "Remove trailing whitespace",
-		Pattern:     regexp.MustCompile(`[ \t]+$`),
-		Replacement: "",
-		Confidence:  1.0,
+		Name:       "Remove trailing whitespace",
+		Transform:  stripTrailingWhitespace,
+		Confidence: 1.0,
+	}
+
+	f.fixers["commented-code"] = FixRule{
+		Name:       "Remove commented-out code",
+		Transform:  removeCommentedCode,
+		FileTypes:  commentedCodeExtensions(),
+		Confidence: 0.8,
 	}
 
 	f.fixers["multiple-blank-lines"] = FixRule{
@@ -304,12 +455,13 @@ func (f *Fixer) initializeFixRules() {
 	}
 }
 
-// GetAvailableFixRules returns a list of available fix rules
+// GetAvailableFixRules returns a sorted list of available fix rules
 func (f *Fixer) GetAvailableFixRules() []string {
 	var rules []string
 	for ruleName := range f.fixers {
 		rules = append(rules, ruleName)
 	}
+	sort.Strings(rules)
 	return rules
 }
 
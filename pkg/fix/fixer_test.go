@@ -0,0 +1,138 @@
+package fix
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func newTestFixer() *Fixer {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewFixer(&models.Configuration{}, logger)
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestReplaceVar_LeavesStringsAndProperties(t *testing.T) {
+	src := "var a = 1;\nfor (var i = 0; i < 3; i++) {}\nconst s = \"var x\"; // var y\nobj.var = 2;\n"
+	assert.Equal(t,
+		"let a = 1;\nfor (let i = 0; i < 3; i++) {}\nconst s = \"var x\"; // var y\nobj.var = 2;\n",
+		replaceVar(src, ".js"))
+}
+
+func TestReplaceLooseEquality(t *testing.T) {
+	src := "if (a == b && c === d && e != f && g !== h) {}\nconst s = 'x == y';\nif (x==1) {}\n"
+	assert.Equal(t,
+		"if (a === b && c === d && e != f && g !== h) {}\nconst s = 'x == y';\nif (x===1) {}\n",
+		replaceLooseEquality(src, ".js"))
+}
+
+func TestStripTrailingWhitespace_EveryLine(t *testing.T) {
+	assert.Equal(t, "a\nb\r\nc\n\nd", stripTrailingWhitespace("a  \nb\t\r\nc \n \nd ", ".txt"))
+}
+
+func TestRemoveCommentedCode(t *testing.T) {
+	src := strings.Join([]string{
+		"const a = 1;",
+		"// const b = compute(a);",
+		"// return b;",
+		"a += 1; // a = a + 1",
+		"// Keep this note about why foo(x) is slow",
+		"// because it scans every row",
+		"// render(c)",
+		"function render(value) {}",
+		"//go:generate stringer -type=Kind",
+		"",
+	}, "\n")
+	assert.Equal(t, strings.Join([]string{
+		"const a = 1;",
+		"a += 1; // a = a + 1",
+		"// Keep this note about why foo(x) is slow",
+		"// because it scans every row",
+		"// render(c)",
+		"function render(value) {}",
+		"//go:generate stringer -type=Kind",
+		"",
+	}, "\n"), removeCommentedCode(src, ".js"))
+
+	// Languages whose line comments can be code, like C's #if, are left alone
+	assert.Equal(t, "#if defined(X)\n", removeCommentedCode("#if defined(X)\n", ".c"))
+}
+
+func TestFixer_FixesAndConfirmsByRescan(t *testing.T) {
+	path := writeTestFile(t, "app.js", "var a = 1;  \nif (a == 2) { a = 3 }\n// var b = load(a);\n")
+
+	fixer := newTestFixer()
+	require.NoError(t, fixer.Fix([]string{path}, true, false, []string{"var-to-let", "strict-equality", "trailing-whitespace", "commented-code"}))
+
+	assert.Equal(t, "let a = 1;\nif (a === 2) { a = 3 }\n", readTestFile(t, path))
+}
+
+func TestFixer_RollsBackFixesThatIntroduceErrors(t *testing.T) {
+	original := "let a = 1;\n"
+	path := writeTestFile(t, "app.js", original)
+
+	fixer := newTestFixer()
+	fixer.fixers["add-debugger"] = FixRule{
+		Name:      "Introduces a no-debugger error",
+		Transform: func(content, ext string) string { return content + "debugger;\n" },
+	}
+	require.NoError(t, fixer.Fix([]string{path}, true, true, []string{"add-debugger"}))
+
+	assert.Equal(t, original, readTestFile(t, path))
+	backups, err := filepath.Glob(path + ".backup.*")
+	require.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestFixer_DryRunPrintsDiffWithoutWriting(t *testing.T) {
+	original := "let a = 1;\nlet b = 2;\nlet c = 3;\nlet d = 4;\nlet e = 5;\nvar f = a == b;\n"
+	path := writeTestFile(t, "app.js", original)
+
+	var out strings.Builder
+	fixer := newTestFixer()
+	fixer.out = &out
+	fixer.SetDryRun(true)
+	require.NoError(t, fixer.Fix([]string{path}, false, true, []string{"no-var", "strict-equality"}))
+
+	assert.Equal(t, original, readTestFile(t, path))
+	assert.Equal(t, "--- "+path+"\n+++ "+path+"\n"+
+		"@@ -3,4 +3,4 @@\n"+
+		" let c = 3;\n let d = 4;\n let e = 5;\n"+
+		"-var f = a == b;\n+let f = a === b;\n", out.String())
+
+	backups, err := filepath.Glob(path + ".backup.*")
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+}
+
+func TestUnifiedDiff_SeparateHunksAndMissingNewline(t *testing.T) {
+	original := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk"
+	fixed := "A\nb\nc\nd\ne\nf\ng\nh\ni\nj\nK"
+	assert.Equal(t, "--- f.txt\n+++ f.txt\n"+
+		"@@ -1,4 +1,4 @@\n-a\n+A\n b\n c\n d\n"+
+		"@@ -8,4 +8,4 @@\n h\n i\n j\n-k\n\\ No newline at end of file\n+K\n\\ No newline at end of file\n",
+		unifiedDiff("f.txt", original, fixed))
+	assert.Empty(t, unifiedDiff("f.txt", original, original))
+}
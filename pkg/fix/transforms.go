@@ -0,0 +1,133 @@
+package fix
+
+import (
+	"regexp"
+	"strings"
+
+	"kodevibe/pkg/vibes"
+)
+
+// jsExtensions are the files the JavaScript fixes apply to
+var jsExtensions = []string{".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx"}
+
+var (
+	// varKeyword matches the var of a declaration, not properties or
+	// identifiers that merely end in var
+	varKeyword = regexp.MustCompile(`(?m)(?:^|[^\w$.])(var)\s`)
+	// looseEquality matches == that isn't part of ===, !==, <== or >==
+	looseEquality = regexp.MustCompile(`(?m)(?:^|[^=!<>])(==)(?:[^=]|$)`)
+	// trailingWhitespace matches spaces and tabs at the end of each line,
+	// keeping a CRLF line ending's carriage return
+	trailingWhitespace = regexp.MustCompile(`(?m)[ \t]+(\r?)$`)
+	// commentDirective matches comments that are instructions to tools, such
+	// as //go:generate, // +build, # type: ignore and #!, which stay put
+	commentDirective = regexp.MustCompile(`^\s*(?://|#)\s?(?:[\w-]+:|\+build|!)`)
+	// declaration matches a line that declares a function, type or variable,
+	// whose preceding comments document it
+	declaration = regexp.MustCompile(`^\s*(?:(?:export|public|private|protected|static|async|pub)\s+)*(?:func|function|def|class|type|interface|struct|enum|fn|const|let|var|val)\b`)
+)
+
+// lineComments are the line comment markers commented-out code is removed
+// after, by file extension. Only languages whose line comments can't be
+// mistaken for code are listed, so C's #if and #include lines are safe.
+var lineComments = map[string]string{
+	".js":    "//",
+	".jsx":   "//",
+	".mjs":   "//",
+	".cjs":   "//",
+	".ts":    "//",
+	".tsx":   "//",
+	".go":    "//",
+	".java":  "//",
+	".kt":    "//",
+	".rs":    "//",
+	".swift": "//",
+	".dart":  "//",
+	".scala": "//",
+	".cs":    "//",
+	".py":    "#",
+	".rb":    "#",
+	".sh":    "#",
+	".bash":  "#",
+	".zsh":   "#",
+}
+
+// commentedCodeExtensions are the files the commented-code fix applies to
+func commentedCodeExtensions() []string {
+	extensions := make([]string, 0, len(lineComments))
+	for ext := range lineComments {
+		extensions = append(extensions, ext)
+	}
+	return extensions
+}
+
+// replaceVar replaces var declarations with let
+func replaceVar(content, ext string) string {
+	return replaceCode(content, varKeyword, "let")
+}
+
+// replaceLooseEquality replaces == with ===
+func replaceLooseEquality(content, ext string) string {
+	return replaceCode(content, looseEquality, "===")
+}
+
+// stripTrailingWhitespace removes the spaces and tabs that end each line
+func stripTrailingWhitespace(content, ext string) string {
+	return trailingWhitespace.ReplaceAllString(content, "$1")
+}
+
+// removeCommentedCode drops runs of whole-line comments that the
+// commented-code rule flags line by line. A run with any prose in it, or that
+// sits directly above a declaration, is documentation that mentions code and
+// is kept; so are comments after code and block comments.
+func removeCommentedCode(content, ext string) string {
+	marker, ok := lineComments[ext]
+	if !ok {
+		return content
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	isComment := func(line string) bool {
+		return strings.HasPrefix(strings.TrimSpace(line), marker)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		if !isComment(lines[i]) {
+			b.WriteString(lines[i])
+			i++
+			continue
+		}
+
+		end := i
+		allCode := true
+		for ; end < len(lines) && isComment(lines[end]); end++ {
+			allCode = allCode && !commentDirective.MatchString(lines[end]) && vibes.IsCommentedOutCode(lines[end])
+		}
+		documents := end < len(lines) && declaration.MatchString(lines[end])
+		if !allCode || documents {
+			for _, line := range lines[i:end] {
+				b.WriteString(line)
+			}
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// replaceCode replaces the first group of each match of pattern with
+// replacement. Matching runs on the code with strings and comments masked,
+// so text that merely mentions the pattern is left as it is.
+func replaceCode(content string, pattern *regexp.Regexp, replacement string) string {
+	masked := vibes.MaskCode(content)
+
+	var b strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(masked, -1) {
+		b.WriteString(content[last:match[2]])
+		b.WriteString(replacement)
+		last = match[3]
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
@@ -0,0 +1,158 @@
+// Package hooks writes the git hooks that run KodeVibe before commits and pushes.
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// Hook names, in install order
+const (
+	PreCommit = "pre-commit"
+	PrePush   = "pre-push"
+)
+
+// Names are the hooks KodeVibe manages
+var Names = []string{PreCommit, PrePush}
+
+// generatedMarker identifies hook scripts written by Install
+const generatedMarker = "# Generated by 'kodevibe hooks install' from ci_cd.git_hooks."
+
+// safeShellWord matches arguments that need no quoting in a shell script
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_./,:=@+-]+$`)
+
+// Hook returns the configuration of the named hook with defaults applied
+func Hook(cfg models.GitHooksConfig, name string) (models.GitHookConfig, error) {
+	cfg = cfg.WithDefaults()
+	switch name {
+	case PreCommit:
+		return cfg.PreCommit, nil
+	case PrePush:
+		return cfg.PrePush, nil
+	default:
+		return models.GitHookConfig{}, fmt.Errorf("unknown hook %q (supported: %s)", name, strings.Join(Names, ", "))
+	}
+}
+
+// Command returns the kodevibe arguments a hook runs
+func Command(hook models.GitHookConfig) []string {
+	args := []string{"scan", "--ci"}
+	if len(hook.Vibes) > 0 {
+		args = append(args, "--vibes", strings.Join(hook.Vibes, ","))
+	}
+	if len(hook.FailOn) > 0 {
+		severities := make([]string, len(hook.FailOn))
+		for i, severity := range hook.FailOn {
+			severities[i] = string(severity)
+		}
+		args = append(args, "--fail-on", strings.Join(severities, ","))
+	}
+	if hook.Staged != nil && *hook.Staged {
+		args = append(args, "--staged")
+	}
+	return append(args, hook.Args...)
+}
+
+// Script returns the shell script of the named hook
+func Script(name string, hook models.GitHookConfig) string {
+	words := append([]string{"kodevibe"}, Command(hook)...)
+	for i, word := range words {
+		words[i] = shellQuote(word)
+	}
+
+	title := strings.ToUpper(name[:1]) + name[1:]
+	return fmt.Sprintf(`#!/bin/bash
+%s
+# Change the configuration and rerun it rather than editing this file.
+echo "🌊 KodeVibe - %s scan..."
+%s
+exit $?
+`, generatedMarker, title, strings.Join(words, " "))
+}
+
+// Validate checks that every hook fails on known severities
+func Validate(cfg models.GitHooksConfig) error {
+	for _, name := range Names {
+		hook, _ := Hook(cfg, name)
+		for _, severity := range hook.FailOn {
+			switch severity {
+			case models.SeverityCritical, models.SeverityError, models.SeverityWarning, models.SeverityInfo:
+			default:
+				return fmt.Errorf("ci_cd.git_hooks %s: unknown fail_on severity %q", name, severity)
+			}
+		}
+	}
+	return nil
+}
+
+// Install writes the enabled hooks into the repository at root and removes
+// hooks it generated earlier that are now disabled. It returns the installed hooks.
+func Install(root string, cfg models.GitHooksConfig) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(root, ".git")); os.IsNotExist(err) {
+		return nil, fmt.Errorf("not a git repository")
+	}
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+
+	hooksDir := filepath.Join(root, ".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	var installed []string
+	for _, name := range Names {
+		hook, _ := Hook(cfg, name)
+		path := filepath.Join(hooksDir, name)
+		if !*hook.Enabled {
+			if Generated(path) {
+				if err := os.Remove(path); err != nil {
+					return installed, fmt.Errorf("failed to remove disabled %s hook: %w", name, err)
+				}
+			}
+			continue
+		}
+
+		if err := os.WriteFile(path, []byte(Script(name, hook)), 0755); err != nil {
+			return installed, fmt.Errorf("failed to write %s hook: %w", name, err)
+		}
+		installed = append(installed, name)
+	}
+	return installed, nil
+}
+
+// Uninstall removes the KodeVibe hooks from the repository at root
+func Uninstall(root string) error {
+	for _, name := range Names {
+		path := filepath.Join(root, ".git", "hooks", name)
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Generated reports whether the hook script at path was written by Install
+func Generated(path string) bool {
+	content, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(content), generatedMarker)
+}
+
+// UpToDate reports whether the installed hook at path matches the script the configuration generates
+func UpToDate(path, name string, hook models.GitHookConfig) bool {
+	content, err := os.ReadFile(path)
+	return err == nil && string(content) == Script(name, hook)
+}
+
+func shellQuote(word string) string {
+	if safeShellWord.MatchString(word) {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
@@ -0,0 +1,97 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestCommand_Defaults(t *testing.T) {
+	preCommit, err := Hook(models.GitHooksConfig{}, PreCommit)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"scan", "--ci", "--vibes", "security,code,file", "--fail-on", "error", "--staged"}, Command(preCommit))
+
+	prePush, err := Hook(models.GitHooksConfig{}, PrePush)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"scan", "--ci", "--fail-on", "error,warning,info"}, Command(prePush))
+
+	_, err = Hook(models.GitHooksConfig{}, "post-merge")
+	assert.Error(t, err)
+}
+
+func TestCommand_Configured(t *testing.T) {
+	staged := false
+	cfg := models.GitHooksConfig{
+		PreCommit: models.GitHookConfig{
+			Vibes:  []string{"security"},
+			FailOn: []models.SeverityLevel{models.SeverityError},
+			Staged: &staged,
+			Args:   []string{"--exclude", "docs/my notes.md"},
+		},
+	}
+
+	hook, err := Hook(cfg, PreCommit)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"scan", "--ci", "--vibes", "security", "--fail-on", "error", "--exclude", "docs/my notes.md"}, Command(hook))
+	assert.Contains(t, Script(PreCommit, hook), "kodevibe scan --ci --vibes security --fail-on error --exclude 'docs/my notes.md'\n")
+}
+
+func TestInstall(t *testing.T) {
+	root := t.TempDir()
+	_, err := Install(root, models.GitHooksConfig{})
+	assert.Error(t, err, "hooks need a git repository")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	installed, err := Install(root, models.GitHooksConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, Names, installed)
+
+	preCommit := filepath.Join(root, ".git", "hooks", PreCommit)
+	info, err := os.Stat(preCommit)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "hooks are executable")
+	assert.True(t, Generated(preCommit))
+
+	hook, _ := Hook(models.GitHooksConfig{}, PreCommit)
+	assert.True(t, UpToDate(preCommit, PreCommit, hook))
+	hook.FailOn = []models.SeverityLevel{models.SeverityWarning}
+	assert.False(t, UpToDate(preCommit, PreCommit, hook))
+
+	// Disabling a hook removes the one Install wrote
+	disabled := false
+	installed, err = Install(root, models.GitHooksConfig{PreCommit: models.GitHookConfig{Enabled: &disabled}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{PrePush}, installed)
+	assert.NoFileExists(t, preCommit)
+
+	require.NoError(t, Uninstall(root))
+	assert.NoFileExists(t, filepath.Join(root, ".git", "hooks", PrePush))
+}
+
+func TestInstall_KeepsOtherDisabledHooks(t *testing.T) {
+	root := t.TempDir()
+	hooksDir := filepath.Join(root, ".git", "hooks")
+	require.NoError(t, os.MkdirAll(hooksDir, 0755))
+	custom := filepath.Join(hooksDir, PrePush)
+	require.NoError(t, os.WriteFile(custom, []byte("#!/bin/sh\nmake test\n"), 0755))
+
+	disabled := false
+	_, err := Install(root, models.GitHooksConfig{PrePush: models.GitHookConfig{Enabled: &disabled}})
+	require.NoError(t, err)
+	assert.FileExists(t, custom, "a hook KodeVibe did not write is left alone")
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(models.GitHooksConfig{}))
+	assert.NoError(t, Validate(models.GitHooksConfig{
+		PrePush: models.GitHookConfig{FailOn: []models.SeverityLevel{models.SeverityCritical}},
+	}))
+	assert.Error(t, Validate(models.GitHooksConfig{
+		PrePush: models.GitHookConfig{FailOn: []models.SeverityLevel{"blocker"}},
+	}))
+}
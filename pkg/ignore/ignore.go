@@ -0,0 +1,262 @@
+// Package ignore reads .kodevibeignore files, which leave paths out of scans
+// with the syntax and semantics of .gitignore
+package ignore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"kodevibe/internal/gitpattern"
+)
+
+// FileName is the name of the ignore file, honored in a repository's root
+// and in any directory below it
+const FileName = ".kodevibeignore"
+
+// Rule is one pattern of an ignore file
+type Rule struct {
+	Pattern string
+	// Negate re-includes the paths the pattern matches (a leading "!")
+	Negate bool
+	// DirOnly matches directories only (a trailing "/")
+	DirOnly bool
+	// Source is the ignore file the rule was read from, if it was read from disk
+	Source string
+	Line   int
+	regex  *regexp.Regexp
+}
+
+// Ignores reports whether the rule leaves the paths it matches out
+func (r *Rule) Ignores() bool {
+	return r != nil && !r.Negate
+}
+
+// String describes the rule by its file, line and pattern
+func (r *Rule) String() string {
+	pattern := r.Pattern
+	if r.Negate {
+		pattern = "!" + pattern
+	}
+	if r.DirOnly {
+		pattern += "/"
+	}
+	if r.Source == "" {
+		return pattern
+	}
+	return fmt.Sprintf("%s:%d: %s", r.Source, r.Line, pattern)
+}
+
+// File is a parsed ignore file, whose patterns are relative to its directory
+type File struct {
+	Path  string
+	Rules []Rule
+}
+
+// Parse reads ignore rules. Blank lines and "#" comments are skipped, and a
+// backslash escapes a leading "#" or "!" and trailing spaces, as in git.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := trimTrailingSpaces(strings.TrimSuffix(scanner.Text(), "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := Rule{Line: number}
+		if strings.HasPrefix(line, "!") {
+			rule.Negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.DirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.Pattern = line
+		rule.regex = gitpattern.Compile(line, gitpattern.Options{})
+		file.Rules = append(file.Rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+	return file, nil
+}
+
+// Load parses the ignore file at path
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", FileName, err)
+	}
+	defer f.Close()
+
+	file, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	file.Path = path
+	for i := range file.Rules {
+		file.Rules[i].Source = path
+	}
+	return file, nil
+}
+
+// Match returns the last rule matching a slash-separated path relative to
+// the file's directory, or nil. It does not look at the path's parents.
+func (f *File) Match(path string, isDir bool) *Rule {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		rule := &f.Rules[i]
+		if rule.DirOnly && !isDir {
+			continue
+		}
+		if rule.regex.MatchString(path) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Matcher decides whether paths are ignored by the ignore files of their
+// repository: the one in its root and those in the directories between the
+// root and the path, where deeper files take precedence. A repository's root
+// is the closest directory holding .git; outside one, every parent directory
+// is searched. Matcher caches the files it reads and is safe for concurrent
+// use.
+type Matcher struct {
+	mu   sync.Mutex
+	dirs map[string]*directory
+	// onError is told about ignore files that exist but can't be read
+	onError func(path string, err error)
+}
+
+// directory is what a Matcher knows about one directory
+type directory struct {
+	file *File
+	// root is set for a repository root, above which no files apply
+	root bool
+}
+
+// NewMatcher creates a Matcher. onError, if not nil, is called once for
+// each ignore file that can't be read; such files are treated as empty.
+func NewMatcher(onError func(path string, err error)) *Matcher {
+	return &Matcher{dirs: make(map[string]*directory), onError: onError}
+}
+
+// Reset forgets the ignore files read so far, so edits to them are seen
+func (m *Matcher) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dirs = make(map[string]*directory)
+}
+
+// Match returns the rule deciding whether path is ignored, or nil when no
+// rule matches. As in git, a path inside an ignored directory is ignored
+// whatever the rules for the path itself say.
+func (m *Matcher) Match(path string, isDir bool) *Rule {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+
+	// The directories whose ignore files apply, from the root down
+	dirs := m.chain(filepath.Dir(absolute))
+	for i := 1; i < len(dirs); i++ {
+		if rule := m.decide(dirs[:i], dirs[i], true); rule.Ignores() {
+			return rule
+		}
+	}
+	return m.decide(dirs, absolute, isDir)
+}
+
+// decide returns the last rule of the ignore files of dirs matching path
+func (m *Matcher) decide(dirs []string, path string, isDir bool) *Rule {
+	var decision *Rule
+	for _, dir := range dirs {
+		file := m.directory(dir).file
+		if file == nil {
+			continue
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			continue
+		}
+		if rule := file.Match(rel, isDir); rule != nil {
+			decision = rule
+		}
+	}
+	return decision
+}
+
+// chain lists dir and its parents up to the repository root, root first
+func (m *Matcher) chain(dir string) []string {
+	var dirs []string
+	for {
+		dirs = append(dirs, dir)
+		parent := filepath.Dir(dir)
+		if m.directory(dir).root || parent == dir {
+			break
+		}
+		dir = parent
+	}
+	for i, j := 0, len(dirs)-1; i < j; i, j = i+1, j-1 {
+		dirs[i], dirs[j] = dirs[j], dirs[i]
+	}
+	return dirs
+}
+
+// directory reads dir's ignore file and whether it is a repository root,
+// the first time it is asked about
+func (m *Matcher) directory(dir string) *directory {
+	m.mu.Lock()
+	known, ok := m.dirs[dir]
+	m.mu.Unlock()
+	if ok {
+		return known
+	}
+
+	found := &directory{}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		found.root = true
+	}
+	path := filepath.Join(dir, FileName)
+	file, err := Load(path)
+	switch {
+	case err == nil:
+		found.file = file
+	case !errors.Is(err, fs.ErrNotExist) && m.onError != nil:
+		m.onError(path, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if known, ok := m.dirs[dir]; ok {
+		// Another goroutine read it first; keep one answer per directory
+		return known
+	}
+	m.dirs[dir] = found
+	return found
+}
+
+// trimTrailingSpaces removes the spaces ending a line unless a backslash
+// escapes them
+func trimTrailingSpaces(line string) string {
+	trimmed := strings.TrimRight(line, " ")
+	if len(trimmed) < len(line) && strings.HasSuffix(trimmed, `\`) {
+		return trimmed[:len(trimmed)-1] + " "
+	}
+	return trimmed
+}
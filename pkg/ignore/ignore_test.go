@@ -0,0 +1,119 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	file, err := Parse(strings.NewReader("# comment\n\n*.log\n!keep.log\nbuild/\n\\#hash\n\\!bang\ntrailing\\ \n/\n"))
+	require.NoError(t, err)
+	require.Len(t, file.Rules, 6)
+
+	assert.Equal(t, Rule{Pattern: "*.log", Line: 3}, withoutRegex(file.Rules[0]))
+	assert.Equal(t, Rule{Pattern: "keep.log", Negate: true, Line: 4}, withoutRegex(file.Rules[1]))
+	assert.Equal(t, Rule{Pattern: "build", DirOnly: true, Line: 5}, withoutRegex(file.Rules[2]))
+	assert.Equal(t, "#hash", file.Rules[3].Pattern)
+	assert.Equal(t, "!bang", file.Rules[4].Pattern)
+	assert.False(t, file.Rules[4].Negate)
+	assert.Equal(t, "trailing ", file.Rules[5].Pattern, "an escaped trailing space is kept")
+	assert.Equal(t, "!keep.log", file.Rules[1].String())
+}
+
+func withoutRegex(rule Rule) Rule {
+	rule.regex = nil
+	return rule
+}
+
+func TestFile_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		match   bool
+	}{
+		{"*.log", "debug.log", false, true},
+		{"*.log", "logs/debug.log", false, true},
+		{"*.log", "debug.log.txt", false, false},
+		{"/root.txt", "root.txt", false, true},
+		{"/root.txt", "sub/root.txt", false, false},
+		{"docs/*.md", "docs/a.md", false, true},
+		{"docs/*.md", "docs/sub/a.md", false, false},
+		{"docs/*.md", "other/docs/a.md", false, false},
+		{"**/fixtures", "a/b/fixtures", true, true},
+		{"**/fixtures", "fixtures", true, true},
+		{"gen/**", "gen/a/b.go", false, true},
+		{"gen/**", "gen", true, false},
+		{"a/**/z.go", "a/z.go", false, true},
+		{"a/**/z.go", "a/b/c/z.go", false, true},
+		{"file?.go", "file1.go", false, true},
+		{"file?.go", "file10.go", false, false},
+		{"[abc].go", "b.go", false, true},
+		{"[!abc].go", "b.go", false, false},
+		{"[!abc].go", "d.go", false, true},
+		{"[a-c]x", "bx", false, true},
+		{"[oops", "[oops", false, true},
+		{`\*.go`, "*.go", false, true},
+		{`\*.go`, "a.go", false, false},
+		{"vendor/", "vendor", true, true},
+		{"vendor/", "vendor", false, false},
+	}
+	for _, tt := range tests {
+		file, err := Parse(strings.NewReader(tt.pattern))
+		require.NoError(t, err)
+		assert.Equal(t, tt.match, file.Match(tt.path, tt.isDir) != nil, "%s against %s", tt.pattern, tt.path)
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	write(FileName, "*.gen.go\n!keep.gen.go\ngenerated/\nlegacy/*\n!legacy/current.go\n")
+	write("pkg/"+FileName, "!pkg.gen.go\nlocal.txt\n")
+	// Ignore files above the repository root don't apply
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(root), FileName), []byte("*.go\n"), 0644))
+	t.Cleanup(func() { os.Remove(filepath.Join(filepath.Dir(root), FileName)) })
+
+	matcher := NewMatcher(nil)
+	ignored := func(rel string) bool {
+		return matcher.Match(filepath.Join(root, filepath.FromSlash(rel)), false).Ignores()
+	}
+
+	assert.False(t, ignored("main.go"))
+	assert.True(t, ignored("api.gen.go"))
+	assert.False(t, ignored("keep.gen.go"), "negations re-include files")
+	assert.True(t, ignored("generated/types.go"), "files inside ignored directories are ignored")
+	assert.True(t, ignored("sub/generated/types.go"))
+	assert.True(t, ignored("legacy/old.go"))
+	assert.False(t, ignored("legacy/current.go"))
+	assert.False(t, ignored("pkg/pkg.gen.go"), "deeper ignore files take precedence")
+	assert.True(t, ignored("pkg/other.gen.go"))
+	assert.True(t, ignored("pkg/local.txt"))
+	assert.False(t, ignored("local.txt"), "a directory's rules apply below it only")
+
+	rule := matcher.Match(filepath.Join(root, "keep.gen.go"), false)
+	require.NotNil(t, rule)
+	assert.True(t, rule.Negate)
+	assert.Equal(t, filepath.Join(root, FileName)+":2: !keep.gen.go", rule.String())
+
+	// Negating a file in an ignored directory doesn't re-include it, as in git
+	write("generated/"+FileName, "!types.go\n")
+	matcher.Reset()
+	assert.True(t, ignored("generated/types.go"))
+
+	// Edits are seen after a reset
+	write(FileName, "")
+	assert.True(t, ignored("api.gen.go"), "cached until reset")
+	matcher.Reset()
+	assert.False(t, ignored("api.gen.go"))
+}
@@ -0,0 +1,199 @@
+// Package integrations sends scan results to the services configured under
+// integrations in the config file
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"kodevibe/internal/models"
+)
+
+// slackTopIssues is how many issues a Slack message lists
+const slackTopIssues = 5
+
+// slackTimeout bounds posting one message, so a slow webhook can't hold up a scan
+const slackTimeout = 10 * time.Second
+
+// slackClient posts Slack messages
+var slackClient = &http.Client{Timeout: slackTimeout}
+
+// slackMessage is an incoming webhook payload. Text is the fallback shown in
+// notifications; Blocks are the message itself.
+type slackMessage struct {
+	Channel   string       `json:"channel,omitempty"`
+	Username  string       `json:"username,omitempty"`
+	IconEmoji string       `json:"icon_emoji,omitempty"`
+	Text      string       `json:"text"`
+	Blocks    []slackBlock `json:"blocks"`
+}
+
+// slackBlock is a Block Kit layout block
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+// slackText is a Block Kit text object
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// PostScanResultToSlack posts a summary of result to the incoming webhook of
+// cfg: the grade, the issue counts by severity and the most severe issues.
+// Environment variables in the webhook URL, such as ${SLACK_WEBHOOK}, are
+// expanded. Network errors and non-2xx responses are returned.
+func PostScanResultToSlack(cfg models.SlackConfig, result *models.ScanResult) error {
+	webhookURL := os.ExpandEnv(cfg.WebhookURL)
+	if webhookURL == "" {
+		return fmt.Errorf("integrations.slack.webhook_url is not set")
+	}
+
+	payload, err := json.Marshal(newSlackMessage(cfg, result))
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), slackTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := slackClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Slack explains rejected payloads in the body, e.g. invalid_blocks
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if reason := strings.TrimSpace(string(body)); reason != "" {
+			return fmt.Errorf("Slack returned %s: %s", resp.Status, reason)
+		}
+		return fmt.Errorf("Slack returned %s", resp.Status)
+	}
+	return nil
+}
+
+// newSlackMessage lays out the summary of result
+func newSlackMessage(cfg models.SlackConfig, result *models.ScanResult) slackMessage {
+	summary := result.Summary
+	project := result.ProjectPath
+	if project == "" {
+		project = "project"
+	}
+
+	message := slackMessage{
+		Channel:   cfg.Channel,
+		Username:  cfg.Username,
+		IconEmoji: cfg.IconEmoji,
+		Text: fmt.Sprintf("KodeVibe scan of %s: grade %s, %d issues (%d critical, %d errors)",
+			project, summary.Grade, summary.TotalIssues, summary.CriticalIssues, summary.ErrorIssues),
+	}
+
+	message.Blocks = append(message.Blocks,
+		slackBlock{
+			Type: "header",
+			Text: &slackText{Type: "plain_text", Text: fmt.Sprintf("KodeVibe: grade %s (%.1f)", summary.Grade, summary.Score)},
+		},
+		slackBlock{
+			Type: "section",
+			Fields: []slackText{
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Critical*\n%d", summary.CriticalIssues)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Errors*\n%d", summary.ErrorIssues)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Warnings*\n%d", summary.WarningIssues)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Info*\n%d", summary.InfoIssues)},
+			},
+		},
+	)
+
+	if top := topIssues(result.Issues, slackTopIssues); len(top) > 0 {
+		lines := make([]string, len(top))
+		for i, issue := range top {
+			lines[i] = fmt.Sprintf("%s *%s* `%s:%d` (%s)",
+				slackSeverityEmoji(issue.Severity), slackEscape(issue.Title), slackEscape(issue.File), issue.Line, slackEscape(issue.Rule))
+		}
+		heading := fmt.Sprintf("*Top %d of %d issues*", len(top), len(result.Issues))
+		message.Blocks = append(message.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: heading + "\n" + strings.Join(lines, "\n")},
+		})
+	}
+
+	footer := fmt.Sprintf("%s · %d files scanned", slackEscape(project), result.FilesScanned)
+	if result.Commit != "" {
+		footer += fmt.Sprintf(" · commit `%.12s`", result.Commit)
+	}
+	message.Blocks = append(message.Blocks, slackBlock{
+		Type:     "context",
+		Elements: []slackText{{Type: "mrkdwn", Text: footer}},
+	})
+
+	return message
+}
+
+// topIssues returns the n most severe issues, in file and line order within a severity
+func topIssues(issues []models.Issue, n int) []models.Issue {
+	sorted := make([]models.Issue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if slackSeverityRank(a.Severity) != slackSeverityRank(b.Severity) {
+			return slackSeverityRank(a.Severity) < slackSeverityRank(b.Severity)
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+func slackSeverityRank(severity models.SeverityLevel) int {
+	switch severity {
+	case models.SeverityCritical:
+		return 0
+	case models.SeverityError:
+		return 1
+	case models.SeverityWarning:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func slackSeverityEmoji(severity models.SeverityLevel) string {
+	switch severity {
+	case models.SeverityCritical:
+		return ":rotating_light:"
+	case models.SeverityError:
+		return ":x:"
+	case models.SeverityWarning:
+		return ":warning:"
+	default:
+		return ":information_source:"
+	}
+}
+
+// slackEscape escapes the characters Slack's mrkdwn treats as markup
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
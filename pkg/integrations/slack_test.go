@@ -0,0 +1,102 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func slackTestResult() *models.ScanResult {
+	var issues []models.Issue
+	for i := 1; i <= 4; i++ {
+		issues = append(issues, models.Issue{Severity: models.SeverityWarning, Title: "Use let/const instead of var", File: "app.js", Line: i, Rule: "no-var"})
+	}
+	issues = append(issues,
+		models.Issue{Severity: models.SeverityError, Title: "Debugger statement found", File: "app.js", Line: 9, Rule: "no-debugger"},
+		models.Issue{Severity: models.SeverityCritical, Title: "AWS key <redacted>", File: "config.js", Line: 2, Rule: "aws-access-key"},
+	)
+	return &models.ScanResult{
+		ProjectPath:  "/src/shop",
+		FilesScanned: 12,
+		Issues:       issues,
+		Summary: models.ScanSummary{
+			TotalIssues:    len(issues),
+			CriticalIssues: 1,
+			ErrorIssues:    1,
+			WarningIssues:  4,
+			Score:          61.5,
+			Grade:          "D",
+		},
+	}
+}
+
+func TestPostScanResultToSlack_PostsBlockKitSummary(t *testing.T) {
+	var received slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	t.Setenv("KODEVIBE_TEST_SLACK_WEBHOOK", server.URL)
+	cfg := models.SlackConfig{Enabled: true, WebhookURL: "${KODEVIBE_TEST_SLACK_WEBHOOK}", Channel: "#builds"}
+	require.NoError(t, PostScanResultToSlack(cfg, slackTestResult()))
+
+	assert.Equal(t, "#builds", received.Channel)
+	assert.Equal(t, "KodeVibe scan of /src/shop: grade D, 6 issues (1 critical, 1 errors)", received.Text)
+	require.Len(t, received.Blocks, 4)
+	assert.Equal(t, "KodeVibe: grade D (61.5)", received.Blocks[0].Text.Text)
+	assert.Equal(t, []slackText{
+		{Type: "mrkdwn", Text: "*Critical*\n1"},
+		{Type: "mrkdwn", Text: "*Errors*\n1"},
+		{Type: "mrkdwn", Text: "*Warnings*\n4"},
+		{Type: "mrkdwn", Text: "*Info*\n0"},
+	}, received.Blocks[1].Fields)
+
+	// The five most severe issues, with mrkdwn markup escaped
+	top := strings.Split(received.Blocks[2].Text.Text, "\n")
+	require.Len(t, top, 6)
+	assert.Equal(t, "*Top 5 of 6 issues*", top[0])
+	assert.Equal(t, ":rotating_light: *AWS key &lt;redacted&gt;* `config.js:2` (aws-access-key)", top[1])
+	assert.Equal(t, ":x: *Debugger statement found* `app.js:9` (no-debugger)", top[2])
+	assert.Equal(t, ":warning: *Use let/const instead of var* `app.js:3` (no-var)", top[5])
+
+	assert.Equal(t, "/src/shop · 12 files scanned", received.Blocks[3].Elements[0].Text)
+}
+
+func TestPostScanResultToSlack_ReportsRejectedPayloads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid_blocks")
+	}))
+	defer server.Close()
+
+	err := PostScanResultToSlack(models.SlackConfig{Enabled: true, WebhookURL: server.URL}, slackTestResult())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400 Bad Request: invalid_blocks")
+}
+
+func TestPostScanResultToSlack_NetworkAndConfigErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	err := PostScanResultToSlack(models.SlackConfig{Enabled: true, WebhookURL: url}, slackTestResult())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to post to Slack")
+
+	err = PostScanResultToSlack(models.SlackConfig{Enabled: true}, slackTestResult())
+	assert.EqualError(t, err, "integrations.slack.webhook_url is not set")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"kodevibe/internal/models"
@@ -344,6 +345,10 @@ func getNextGrade(current string) string {
 	if next, exists := gradeMap[current]; exists {
 		return next
 	}
+	// Grades with +/- modifiers progress like their base letter
+	if next, exists := gradeMap[strings.TrimRight(current, "+-")]; exists {
+		return next
+	}
 	return "A"
 }
 
@@ -198,6 +198,8 @@ func TestGetNextGrade(t *testing.T) {
 		{"B", "A"},
 		{"A", "A+"},
 		{"A+", "A+"},
+		{"B+", "A"},
+		{"C-", "B"},
 		{"Unknown", "A"},
 	}
 
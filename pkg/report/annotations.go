@@ -0,0 +1,84 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// AnnotateGitHub selects GitHub Actions workflow command annotations
+const AnnotateGitHub = "github"
+
+// WriteGitHubAnnotations prints one GitHub Actions workflow command per issue
+// so findings show up inline on the pull request diff. Absolute file paths
+// are made relative to workspace (normally $GITHUB_WORKSPACE) when possible.
+func WriteGitHubAnnotations(w io.Writer, issues []models.Issue, workspace string) error {
+	for _, issue := range issues {
+		properties := []string{"file=" + escapeAnnotationProperty(annotationPath(issue.File, workspace))}
+		if issue.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", issue.Line))
+		}
+		if issue.Column > 0 {
+			properties = append(properties, fmt.Sprintf("col=%d", issue.Column))
+		}
+
+		title := issue.Title
+		if issue.Rule != "" {
+			title = fmt.Sprintf("%s (%s)", title, issue.Rule)
+		}
+		if title = strings.TrimSpace(title); title != "" {
+			properties = append(properties, "title="+escapeAnnotationProperty(title))
+		}
+
+		message := issue.Message
+		if message == "" {
+			message = issue.Title
+		}
+
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n",
+			annotationLevel(issue.Severity), strings.Join(properties, ","), escapeAnnotationData(message)); err != nil {
+			return fmt.Errorf("failed to write annotation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// annotationLevel maps issue severity to a workflow command
+func annotationLevel(severity models.SeverityLevel) string {
+	switch severity {
+	case models.SeverityCritical, models.SeverityError:
+		return "error"
+	case models.SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// annotationPath returns file relative to workspace with forward slashes
+func annotationPath(file, workspace string) string {
+	if workspace != "" && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(workspace, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+// escapeAnnotationData escapes a workflow command message
+func escapeAnnotationData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeAnnotationProperty escapes a workflow command property value
+func escapeAnnotationProperty(s string) string {
+	s = escapeAnnotationData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
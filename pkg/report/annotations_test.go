@@ -0,0 +1,28 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	issues := []models.Issue{
+		{Severity: models.SeverityCritical, Title: "Hardcoded secret", Rule: "secret-aws", Message: "AWS key found", File: "/work/repo/cmd/main.go", Line: 12, Column: 5},
+		{Severity: models.SeverityWarning, Title: "Line too long", Message: "100% too long,\nreally", File: "web/app.js", Line: 3},
+		{Severity: models.SeverityInfo, Title: "Missing docs: README", File: "docs/a,b.md"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteGitHubAnnotations(&buf, issues, "/work/repo"))
+
+	assert.Equal(t,
+		"::error file=cmd/main.go,line=12,col=5,title=Hardcoded secret (secret-aws)::AWS key found\n"+
+			"::warning file=web/app.js,line=3,title=Line too long::100%25 too long,%0Areally\n"+
+			"::notice file=docs/a%2Cb.md,title=Missing docs%3A README::Missing docs: README\n",
+		buf.String())
+}
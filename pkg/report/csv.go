@@ -0,0 +1,106 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/codeowners"
+)
+
+// csvColumn is one column a CSV report can contain
+type csvColumn struct {
+	header string
+	value  func(issue models.Issue) string
+}
+
+// csvColumns are the columns selectable through reporting.csv_columns and --csv-columns
+var csvColumns = map[string]csvColumn{
+	"id":             {"ID", func(issue models.Issue) string { return issue.ID }},
+	"type":           {"Type", func(issue models.Issue) string { return string(issue.Type) }},
+	"vibe":           {"Vibe", func(issue models.Issue) string { return string(issue.Type) }},
+	"category":       {"Category", func(issue models.Issue) string { return string(issue.Category) }},
+	"severity":       {"Severity", func(issue models.Issue) string { return string(issue.Severity) }},
+	"rule":           {"Rule", func(issue models.Issue) string { return issue.Rule }},
+	"file":           {"File", func(issue models.Issue) string { return issue.File }},
+	"line":           {"Line", func(issue models.Issue) string { return strconv.Itoa(issue.Line) }},
+	"column":         {"Column", func(issue models.Issue) string { return strconv.Itoa(issue.Column) }},
+	"title":          {"Title", func(issue models.Issue) string { return issue.Title }},
+	"message":        {"Message", func(issue models.Issue) string { return issue.Message }},
+	"context":        {"Context", func(issue models.Issue) string { return issue.Context }},
+	"confidence":     {"Confidence", func(issue models.Issue) string { return strconv.FormatFloat(issue.Confidence, 'f', -1, 64) }},
+	"fixable":        {"Fixable", func(issue models.Issue) string { return strconv.FormatBool(issue.Fixable) }},
+	"fix_suggestion": {"Fix Suggestion", func(issue models.Issue) string { return issue.FixSuggestion }},
+	"cwe":            {"CWE", func(issue models.Issue) string { return issue.CWE }},
+	"owners":         {"Owners", func(issue models.Issue) string { return strings.Join(codeowners.IssueOwners(issue), " ") }},
+}
+
+// DefaultCSVColumns are the columns of a CSV report when none are configured
+var DefaultCSVColumns = []string{"type", "category", "severity", "rule", "file", "line", "title", "message", "fix_suggestion"}
+
+// CSVColumnNames returns every selectable CSV column, sorted
+func CSVColumnNames() []string {
+	names := make([]string, 0, len(csvColumns))
+	for name := range csvColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateCSVColumns checks that every column is known
+func ValidateCSVColumns(columns []string) error {
+	for _, column := range columns {
+		if _, ok := csvColumns[strings.ToLower(strings.TrimSpace(column))]; !ok {
+			return fmt.Errorf("unknown CSV column %q (supported: %s)", column, strings.Join(CSVColumnNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// generateCSVReport generates an RFC 4180 CSV report with a header row and one
+// row per issue. Fields containing commas, quotes or line breaks are quoted.
+func (r *Reporter) generateCSVReport(result *models.ScanResult) (string, error) {
+	columns := DefaultCSVColumns
+	if r.config != nil && len(r.config.Reporting.CSVColumns) > 0 {
+		columns = r.config.Reporting.CSVColumns
+	}
+	if err := ValidateCSVColumns(columns); err != nil {
+		return "", err
+	}
+
+	selected := make([]csvColumn, len(columns))
+	header := make([]string, len(columns))
+	for i, name := range columns {
+		selected[i] = csvColumns[strings.ToLower(strings.TrimSpace(name))]
+		header[i] = selected[i].header
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.UseCRLF = true
+
+	if err := writer.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	row := make([]string, len(selected))
+	for _, issue := range result.Issues {
+		for i, column := range selected {
+			row[i] = column.value(issue)
+		}
+		if err := writer.Write(row); err != nil {
+			return "", fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.String(), nil
+}
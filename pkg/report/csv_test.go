@@ -0,0 +1,65 @@
+package report
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReporter_GenerateCSV(t *testing.T) {
+	result := &models.ScanResult{
+		Issues: []models.Issue{
+			{
+				Type:          models.VibeTypeSecurity,
+				Category:      models.CategorySecurity,
+				Severity:      models.SeverityError,
+				Rule:          "hardcoded-secret",
+				File:          "config/app,prod.go",
+				Line:          12,
+				Title:         `Secret "found"`,
+				Message:       "Hardcoded secret\nrotate it, then remove it",
+				FixSuggestion: "Use an environment variable",
+				Confidence:    0.95,
+			},
+		},
+	}
+
+	output, err := NewReporter(&models.Configuration{}).Generate(result, "csv")
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(output, "\r\n"))
+	assert.Contains(t, output, `"config/app,prod.go"`)
+	assert.Contains(t, output, `"Secret ""found"""`)
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"Type", "Category", "Severity", "Rule", "File", "Line", "Title", "Message", "Fix Suggestion"}, records[0])
+	assert.Equal(t, "config/app,prod.go", records[1][4])
+	assert.Equal(t, "Hardcoded secret\nrotate it, then remove it", records[1][7])
+
+	config := &models.Configuration{}
+	config.Reporting.CSVColumns = []string{"file", "line", "Rule", "vibe", "confidence", "fix_suggestion"}
+	output, err = NewReporter(config).Generate(result, "csv")
+	require.NoError(t, err)
+	records, err = csv.NewReader(strings.NewReader(output)).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"File", "Line", "Rule", "Vibe", "Confidence", "Fix Suggestion"},
+		{"config/app,prod.go", "12", "hardcoded-secret", "security", "0.95", "Use an environment variable"},
+	}, records)
+
+	config.Reporting.CSVColumns = []string{"file", "owner"}
+	_, err = NewReporter(config).Generate(result, "csv")
+	assert.ErrorContains(t, err, `unknown CSV column "owner"`)
+}
+
+func TestReporter_GenerateCSVWithoutIssues(t *testing.T) {
+	output, err := NewReporter(&models.Configuration{}).Generate(&models.ScanResult{}, "csv")
+	require.NoError(t, err)
+	assert.Equal(t, "Type,Category,Severity,Rule,File,Line,Title,Message,Fix Suggestion\r\n", output)
+}
@@ -0,0 +1,177 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// defectDojoReport is DefectDojo's Generic Findings Import format
+type defectDojoReport struct {
+	Findings []defectDojoFinding `json:"findings"`
+}
+
+type defectDojoFinding struct {
+	Title       string `json:"title"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Mitigation  string `json:"mitigation,omitempty"`
+	References  string `json:"references,omitempty"`
+	FilePath    string `json:"file_path,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	CWE         int    `json:"cwe,omitempty"`
+	Date        string `json:"date,omitempty"`
+	// UniqueIDFromTool is what DefectDojo deduplicates findings by across imports
+	UniqueIDFromTool string `json:"unique_id_from_tool"`
+	VulnIDFromTool   string `json:"vuln_id_from_tool,omitempty"`
+	// NbOccurences is spelled as DefectDojo spells it
+	NbOccurences   int  `json:"nb_occurences,omitempty"`
+	StaticFinding  bool `json:"static_finding"`
+	DynamicFinding bool `json:"dynamic_finding"`
+}
+
+// generateDefectDojoReport maps issues to DefectDojo findings. Issues sharing
+// a fingerprint (the same rule and message in the same file) become one
+// finding listing every line, since DefectDojo would merge them by their
+// unique ID anyway; reporting.defectdojo.split_occurrences keeps them apart.
+func (r *Reporter) generateDefectDojoReport(result *models.ScanResult) (string, error) {
+	catalog := ruleCatalog()
+	split := r.config != nil && r.config.Reporting.DefectDojo.SplitOccurrences
+
+	var date string
+	if !result.StartTime.IsZero() {
+		date = result.StartTime.Format("2006-01-02")
+	}
+
+	findings := []defectDojoFinding{}
+	occurrences := make(map[string][]models.Issue)
+	for _, issue := range result.Issues {
+		fingerprinted := issue
+		fingerprinted.File = sarifURI(issue.File, result.ProjectPath)
+		id := fingerprinted.Fingerprint()
+		if split {
+			id = fmt.Sprintf("%s:%d", id, issue.Line)
+		}
+
+		if _, seen := occurrences[id]; !seen {
+			findings = append(findings, defectDojoFinding{
+				Title:            defectDojoTitle(issue),
+				Severity:         defectDojoSeverity(issue.Severity),
+				Mitigation:       issue.FixSuggestion,
+				References:       learnMoreURL(r.ruleHelpTemplate(), catalog, issue),
+				FilePath:         fingerprinted.File,
+				Line:             issue.Line,
+				CWE:              issueCWE(issue),
+				Date:             date,
+				UniqueIDFromTool: id,
+				VulnIDFromTool:   issue.Rule,
+				StaticFinding:    true,
+			})
+		}
+		occurrences[id] = append(occurrences[id], issue)
+	}
+
+	for i := range findings {
+		issues := occurrences[findings[i].UniqueIDFromTool]
+		findings[i].Description = defectDojoDescription(issues)
+		if len(issues) > 1 {
+			findings[i].NbOccurences = len(issues)
+		}
+	}
+
+	data, err := json.MarshalIndent(defectDojoReport{Findings: findings}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal DefectDojo findings: %w", err)
+	}
+	return string(data), nil
+}
+
+// defectDojoTitle names a finding after its rule's title, falling back to the rule
+func defectDojoTitle(issue models.Issue) string {
+	switch {
+	case issue.Title != "":
+		return issue.Title
+	case issue.Rule != "":
+		return issue.Rule
+	default:
+		return string(issue.Type)
+	}
+}
+
+// defectDojoSeverity maps severity to DefectDojo's Critical/High/Medium/Low/Info
+func defectDojoSeverity(severity models.SeverityLevel) string {
+	switch severity {
+	case models.SeverityCritical:
+		return "Critical"
+	case models.SeverityError:
+		return "High"
+	case models.SeverityWarning:
+		return "Medium"
+	case models.SeverityInfo:
+		return "Info"
+	default:
+		return "Low"
+	}
+}
+
+// defectDojoDescription describes a finding in markdown: the message, where
+// it occurs and the code it was found in
+func defectDojoDescription(issues []models.Issue) string {
+	first := issues[0]
+	var description strings.Builder
+	message := first.Message
+	if message == "" {
+		message = first.Title
+	}
+	description.WriteString(message)
+	description.WriteString("\n\n")
+
+	fmt.Fprintf(&description, "**Rule:** %s (%s vibe)\n", first.Rule, first.Type)
+	if first.Confidence > 0 {
+		fmt.Fprintf(&description, "**Confidence:** %.0f%%\n", first.Confidence*100)
+	}
+
+	lines := make([]int, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Line > 0 {
+			lines = append(lines, issue.Line)
+		}
+	}
+	sort.Ints(lines)
+	if len(lines) > 1 {
+		numbers := make([]string, len(lines))
+		for i, line := range lines {
+			numbers[i] = strconv.Itoa(line)
+		}
+		fmt.Fprintf(&description, "**Lines:** %s\n", strings.Join(numbers, ", "))
+	}
+
+	if first.Context != "" {
+		fmt.Fprintf(&description, "\n```\n%s\n```\n", first.Context)
+	}
+	return strings.TrimRight(description.String(), "\n")
+}
+
+// issueCWE returns the number of an issue's CWE, falling back to one carried
+// in its metadata (as external scanners' findings may) as 89 or "CWE-89"
+func issueCWE(issue models.Issue) int {
+	if number, err := strconv.Atoi(strings.TrimPrefix(issue.CWE, "CWE-")); err == nil {
+		return number
+	}
+	switch cwe := issue.Metadata["cwe"].(type) {
+	case int:
+		return cwe
+	case float64:
+		return int(cwe)
+	case string:
+		number, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(cwe)), "CWE-"))
+		if err == nil {
+			return number
+		}
+	}
+	return 0
+}
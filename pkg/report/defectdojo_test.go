@@ -0,0 +1,78 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReporter_GenerateDefectDojo(t *testing.T) {
+	sqlInjection := models.Issue{
+		Type:          models.VibeTypeSecurity,
+		Severity:      models.SeverityError,
+		Title:         "Potential SQL Injection vulnerability",
+		Message:       "Query built from unescaped input",
+		File:          "/work/repo/api/users.go",
+		Line:          12,
+		Rule:          "sql-injection-risk",
+		Context:       `db.Query("SELECT * FROM users WHERE id = " + id)`,
+		FixSuggestion: "Use parameterized queries",
+		Confidence:    0.9,
+		CWE:           "CWE-89",
+	}
+	again := sqlInjection
+	again.Line = 40
+	console := models.Issue{Type: models.VibeTypeCode, Severity: models.SeverityInfo, Title: "Console statement", Message: "console.log found", File: "/work/repo/app.js", Line: 3, Rule: "custom-console", Metadata: map[string]interface{}{"cwe": "cwe-489"}}
+	result := &models.ScanResult{
+		StartTime:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		ProjectPath: "/work/repo",
+		Issues:      []models.Issue{sqlInjection, console, again},
+	}
+	config := &models.Configuration{Reporting: models.ReportingConfig{PathBase: "/work/repo"}}
+
+	output, err := NewReporter(config).Generate(result, "defectdojo")
+	require.NoError(t, err)
+	var report defectDojoReport
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	require.Len(t, report.Findings, 2, "occurrences of one finding are aggregated")
+
+	finding := report.Findings[0]
+	assert.Equal(t, "Potential SQL Injection vulnerability", finding.Title)
+	assert.Equal(t, "High", finding.Severity)
+	assert.Equal(t, "api/users.go", finding.FilePath)
+	assert.Equal(t, 12, finding.Line)
+	assert.Equal(t, 89, finding.CWE)
+	assert.Equal(t, "2024-05-01", finding.Date)
+	assert.Equal(t, "Use parameterized queries", finding.Mitigation)
+	assert.Equal(t, "sql-injection-risk", finding.VulnIDFromTool)
+	assert.Len(t, finding.UniqueIDFromTool, 64)
+	assert.Equal(t, 2, finding.NbOccurences)
+	assert.Contains(t, finding.Description, "**Lines:** 12, 40")
+	assert.Contains(t, finding.Description, "SELECT * FROM users")
+	assert.Contains(t, finding.References, "docs/rules.md#sql-injection-risk")
+	assert.True(t, finding.StaticFinding)
+
+	custom := report.Findings[1]
+	assert.Equal(t, "Info", custom.Severity)
+	assert.Empty(t, custom.References, "rules outside the catalog have no docs")
+	assert.Equal(t, 489, custom.CWE, "a CWE in the metadata is used when the issue has none")
+	assert.Zero(t, custom.NbOccurences)
+
+	// The fingerprint is stable across runs, so re-imports deduplicate
+	rerun, err := NewReporter(config).Generate(result, "defectdojo")
+	require.NoError(t, err)
+	assert.Equal(t, output, rerun)
+
+	config.Reporting.DefectDojo.SplitOccurrences = true
+	output, err = NewReporter(config).Generate(result, "defectdojo")
+	require.NoError(t, err)
+	report = defectDojoReport{}
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	require.Len(t, report.Findings, 3)
+	assert.NotEqual(t, report.Findings[0].UniqueIDFromTool, report.Findings[2].UniqueIDFromTool)
+}
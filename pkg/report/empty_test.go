@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReporter_CleanScan(t *testing.T) {
+	// A clean scan as the scanner leaves it: nil issues and files
+	result := &models.ScanResult{
+		ID:           "scan-1",
+		StartTime:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		ProjectPath:  "/work/repo",
+		FilesScanned: 3,
+		Summary:      models.ScanSummary{Score: 100, Grade: "A+"},
+	}
+	reporter := NewReporter(&models.Configuration{})
+	generate := func(format string) string {
+		output, err := reporter.Generate(result, format)
+		require.NoError(t, err, format)
+		return output
+	}
+
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(generate("json")), &report))
+	assert.Equal(t, []interface{}{}, report["issues"])
+	assert.Equal(t, []interface{}{}, report["files"])
+	summary := report["summary"].(map[string]interface{})
+	assert.Equal(t, float64(0), summary["total_issues"])
+	assert.Equal(t, map[string]interface{}{}, summary["issues_by_type"])
+	assert.Equal(t, map[string]interface{}{}, summary["issues_by_severity"])
+	assert.Equal(t, []interface{}{}, summary["top_issues"])
+	assert.Nil(t, result.Issues, "the caller's result is left as it was")
+
+	var sarif map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(generate("sarif")), &sarif))
+	run := sarif["runs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, run["results"])
+	driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, driver["rules"])
+
+	var document struct {
+		XMLName      xml.Name `xml:"scan_result"`
+		FilesScanned int      `xml:"files_scanned"`
+		Summary      struct {
+			TotalIssues int `xml:"total_issues"`
+		} `xml:"summary"`
+		Issues *struct {
+			Issue []struct{} `xml:"issue"`
+		} `xml:"issues"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(strings.TrimPrefix(generate("xml"), xml.Header)), &document))
+	assert.Equal(t, 3, document.FilesScanned)
+	require.NotNil(t, document.Issues, "<issues> is written even when empty")
+	assert.Empty(t, document.Issues.Issue)
+
+	var suite struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(strings.TrimPrefix(generate("junit"), xml.Header)), &suite))
+	assert.Equal(t, 0, suite.Failures)
+
+	assert.JSONEq(t, `{"findings": []}`, generate("defectdojo"))
+	assert.Empty(t, generate("ndjson"))
+	assert.Equal(t, "Type,Category,Severity,Rule,File,Line,Title,Message,Fix Suggestion\r\n", generate("csv"))
+	assert.Contains(t, generate("text"), "Total Issues: 0")
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(generate("html")), "</html>"))
+}
+
+func TestReporter_GenerateXML(t *testing.T) {
+	result := &models.ScanResult{
+		ID: "scan-2",
+		Issues: []models.Issue{
+			{Type: models.VibeTypeCode, Severity: models.SeverityWarning, Rule: "no-var", Message: "Use let", File: "app.js", Line: 3},
+		},
+		Summary: models.ScanSummary{
+			TotalIssues:      1,
+			WarningIssues:    1,
+			IssuesByType:     map[models.VibeType]int{models.VibeTypeCode: 1},
+			IssuesBySeverity: map[models.SeverityLevel]int{models.SeverityWarning: 1},
+		},
+	}
+
+	output, err := NewReporter(&models.Configuration{}).Generate(result, "xml")
+	require.NoError(t, err)
+	assert.Contains(t, output, `<count key="code">1</count>`)
+	assert.Contains(t, output, "<rule>no-var</rule>")
+	assert.Contains(t, output, "<line>3</line>")
+}
@@ -0,0 +1,85 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"kodevibe/internal/models"
+)
+
+// ReportFileName is the name, before the extension, of the reports WriteFormats writes
+const ReportFileName = "kodevibe-report"
+
+// reportExtensions are the file extensions of each format's report
+var reportExtensions = map[string]string{
+	"text":       ".txt",
+	"json":       ".json",
+	"html":       ".html",
+	"xml":        ".xml",
+	"junit":      ".junit.xml",
+	"csv":        ".csv",
+	"ndjson":     ".ndjson",
+	"jsonl":      ".jsonl",
+	"sarif":      ".sarif",
+	"defectdojo": ".defectdojo.json",
+}
+
+// FormatReport is the outcome of writing one format's report
+type FormatReport struct {
+	Format string
+	Path   string
+	// Index is set when the report was split into pages
+	Index *ReportIndex
+	Err   error
+}
+
+// FormatPath returns where WriteFormats writes the report of format in dir
+func FormatPath(dir, format string) string {
+	format = strings.ToLower(format)
+	ext, known := reportExtensions[format]
+	if !known {
+		ext = "." + format
+	}
+	return filepath.Join(dir, ReportFileName+ext)
+}
+
+// WriteFormats writes the result's report in each format to dir, generating
+// up to concurrency of them at once; 0 or less generates them all at once.
+// Formats are independent: one failing still leaves the others written, and
+// the returned error joins every failure. maxBytes pages reports as WritePaged.
+func (r *Reporter) WriteFormats(result *models.ScanResult, formats []string, dir string, maxBytes, concurrency int) ([]FormatReport, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if concurrency <= 0 || concurrency > len(formats) {
+		concurrency = len(formats)
+	}
+
+	reports := make([]FormatReport, len(formats))
+	semaphore := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, format := range formats {
+		reports[i] = FormatReport{Format: format, Path: FormatPath(dir, format)}
+		wg.Add(1)
+		go func(written *FormatReport) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			written.Index, written.Err = r.WritePaged(result, written.Format, written.Path, maxBytes)
+		}(&reports[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, written := range reports {
+		if written.Err != nil {
+			errs = append(errs, fmt.Errorf("%s report: %w", written.Format, written.Err))
+		}
+	}
+	return reports, errors.Join(errs...)
+}
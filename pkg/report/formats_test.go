@@ -0,0 +1,65 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReporter_WriteFormats(t *testing.T) {
+	result := &models.ScanResult{
+		ID: "scan-1",
+		Issues: []models.Issue{
+			{Type: models.VibeTypeCode, Severity: models.SeverityWarning, Rule: "no-var", Message: "Use let", File: "app.js", Line: 1},
+		},
+		Summary: models.ScanSummary{TotalIssues: 1, WarningIssues: 1},
+	}
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	for _, concurrency := range []int{0, 1} {
+		written, err := NewReporter(&models.Configuration{}).WriteFormats(result, []string{"json", "pdf", "html", "junit"}, dir, 0, concurrency)
+
+		// The unknown format fails alone; the others are still written
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pdf report: unsupported format: pdf")
+		require.Len(t, written, 4)
+		assert.Error(t, written[1].Err)
+		for _, w := range []FormatReport{written[0], written[2], written[3]} {
+			assert.NoError(t, w.Err, w.Format)
+			assert.FileExists(t, w.Path)
+		}
+		assert.NoFileExists(t, written[1].Path)
+		assert.Equal(t, filepath.Join(dir, "kodevibe-report.junit.xml"), written[3].Path)
+
+		data, err := os.ReadFile(written[0].Path)
+		require.NoError(t, err)
+		var decoded models.ScanResult
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, "scan-1", decoded.ID)
+		html, err := os.ReadFile(written[2].Path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(html), "<!DOCTYPE html>"))
+	}
+}
+
+func TestReporter_WriteFormatsPaged(t *testing.T) {
+	result := &models.ScanResult{ID: "scan-2"}
+	for i := 0; i < 20; i++ {
+		result.Issues = append(result.Issues, models.Issue{Type: models.VibeTypeCode, Severity: models.SeverityInfo, Rule: "todo-comments", Message: strings.Repeat("x", 100), File: "a.go", Line: i + 1})
+	}
+	dir := t.TempDir()
+
+	written, err := NewReporter(&models.Configuration{}).WriteFormats(result, []string{"ndjson", "csv"}, dir, 1000, 0)
+	require.NoError(t, err)
+	for _, w := range written {
+		require.NotNil(t, w.Index, w.Format)
+		assert.FileExists(t, IndexPath(w.Path))
+	}
+}
@@ -213,6 +213,7 @@ function populateSecurityIssues() {
             <div class="issue-remediation">
                 <strong>Remediation:</strong> ' + issue.remediation + '
             </div>
+            ' + (issue.learnMore ? '<a class="learn-more" href="' + issue.learnMore + '" target="_blank" rel="noopener">Learn more</a>' : '') + '
         </div>
     ').join('');
 }
@@ -433,18 +434,24 @@ function exportJSON() {
     URL.revokeObjectURL(url);
 }
 
+function csvField(value) {
+    const text = value === undefined || value === null ? '' : String(value);
+    return /[",\r\n]/.test(text) ? '"' + text.replace(/"/g, '""') + '"' : text;
+}
+
 function exportCSV() {
     const issues = window.reportData.issues;
-    const csvHeader = 'File,Line,Severity,Category,Message\\n';
-    const csvData = issues.map(issue => 
-        '"' + issue.file + '",' + 
-        issue.line + ',' + 
-        issue.severity + ',' + 
-        (issue.category || 'general') + ',' + 
-        '"' + issue.message.replace(/"/g, '""') + '"'
-    ).join('\\n');
-    
-    const dataBlob = new Blob([csvHeader + csvData], {type: 'text/csv'});
+    const rows = [['File', 'Line', 'Severity', 'Category', 'Rule', 'Message']].concat(issues.map(issue => [
+        issue.file,
+        issue.line,
+        issue.severity,
+        issue.category || 'general',
+        issue.rule,
+        issue.message
+    ]));
+    const csv = rows.map(row => row.map(csvField).join(',')).join('\r\n') + '\r\n';
+    
+    const dataBlob = new Blob([csv], {type: 'text/csv'});
     const url = URL.createObjectURL(dataBlob);
     
     const link = document.createElement('a');
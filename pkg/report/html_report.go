@@ -6,15 +6,23 @@ import (
 	"html/template"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+	"unicode"
 
 	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
 )
 
 // HTMLReportGenerator creates interactive HTML reports
 type HTMLReportGenerator struct {
 	templateDir string
 	outputDir   string
+
+	// RuleHelpURL is the template of issues' learn-more links, as
+	// reporting.rule_help_url; empty uses DefaultRuleHelpURL
+	RuleHelpURL string
+	catalog     map[string]vibes.RuleInfo
 }
 
 // NewHTMLReportGenerator creates a new HTML report generator
@@ -22,6 +30,7 @@ func NewHTMLReportGenerator(outputDir string) *HTMLReportGenerator {
 	return &HTMLReportGenerator{
 		templateDir: "templates",
 		outputDir:   outputDir,
+		catalog:     ruleCatalog(),
 	}
 }
 
@@ -99,6 +108,7 @@ type SecurityIssue struct {
 	Description string `json:"description"`
 	Remediation string `json:"remediation"`
 	CWE         string `json:"cwe,omitempty"`
+	LearnMore   string `json:"learnMore,omitempty"`
 }
 
 type PerformanceMetrics struct {
@@ -131,7 +141,12 @@ func (h *HTMLReportGenerator) prepareReportData(result *models.AnalysisResult, p
 
 // generateMainHTML creates the main HTML report file
 func (h *HTMLReportGenerator) generateMainHTML(data *ReportData) error {
-	tmpl := template.Must(template.New("report").Parse(htmlTemplate))
+	tmpl := template.Must(template.New("report").Funcs(template.FuncMap{
+		"upper":     func(value interface{}) string { return strings.ToUpper(fmt.Sprint(value)) },
+		"title":     toTitle,
+		"div":       func(a, b int64) float64 { return float64(a) / float64(b) },
+		"learnMore": h.learnMoreURL,
+	}).Parse(htmlTemplate))
 
 	// Convert data to JSON for JavaScript
 	jsonData, err := json.Marshal(data)
@@ -230,7 +245,7 @@ func (h *HTMLReportGenerator) extractSecurityIssues(issues []models.Issue) []Sec
 	var securityIssues []SecurityIssue
 
 	for _, issue := range issues {
-		if issue.Category == "security" {
+		if issue.Category == models.CategorySecurity || (issue.Category == "" && issue.Type == models.VibeTypeSecurity) {
 			securityIssues = append(securityIssues, SecurityIssue{
 				Severity:    string(issue.Severity),
 				Category:    "Security",
@@ -238,7 +253,8 @@ func (h *HTMLReportGenerator) extractSecurityIssues(issues []models.Issue) []Sec
 				Line:        issue.Line,
 				Description: issue.Message,
 				Remediation: generateRemediation(issue.Message),
-				CWE:         extractCWE(issue.Message),
+				CWE:         issue.CWE,
+				LearnMore:   h.learnMoreURL(issue),
 			})
 		}
 	}
@@ -246,13 +262,32 @@ func (h *HTMLReportGenerator) extractSecurityIssues(issues []models.Issue) []Sec
 	return securityIssues
 }
 
+// toTitle upper-cases the first letter of s
+func toTitle(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// learnMoreURL links an issue to its rule's documentation, or returns "" when it has none
+func (h *HTMLReportGenerator) learnMoreURL(issue models.Issue) string {
+	return learnMoreURL(h.RuleHelpURL, h.catalog, issue)
+}
+
 func (h *HTMLReportGenerator) generatePerformanceMetrics(result *models.AnalysisResult) PerformanceMetrics {
-	return PerformanceMetrics{
-		MemoryUsage:    1024 * 1024 * 50, // 50MB
-		ExecutionTime:  result.Duration.Seconds(),
-		FilesPerSecond: float64(result.FilesAnalyzed) / result.Duration.Seconds(),
-		Bottlenecks:    []string{"Large file parsing", "Regex complexity", "Memory allocation"},
+	metrics := PerformanceMetrics{
+		MemoryUsage:   1024 * 1024 * 50, // 50MB
+		ExecutionTime: result.Duration.Seconds(),
+		Bottlenecks:   []string{"Large file parsing", "Regex complexity", "Memory allocation"},
+	}
+	// JSON has no NaN for an instant analysis
+	if result.Duration > 0 {
+		metrics.FilesPerSecond = float64(result.FilesAnalyzed) / result.Duration.Seconds()
 	}
+	return metrics
 }
 
 // Helper functions
@@ -270,20 +305,6 @@ func generateRemediation(message string) string {
 	}
 }
 
-func extractCWE(message string) string {
-	// Extract CWE identifiers from messages
-	if contains(message, "injection") {
-		return "CWE-89"
-	}
-	if contains(message, "xss") {
-		return "CWE-79"
-	}
-	if contains(message, "authentication") {
-		return "CWE-287"
-	}
-	return ""
-}
-
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr ||
 		(len(s) > len(substr) &&
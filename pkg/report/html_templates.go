@@ -33,7 +33,7 @@ const htmlTemplate = `
                         <span class="score-max">/100</span>
                     </div>
                 </div>
-                <div class="score-grade">{{if ge .OverallScore 90}}⭐⭐⭐⭐⭐ Excellent{{else if ge .OverallScore 80}}⭐⭐⭐⭐ Very Good{{else if ge .OverallScore 70}}⭐⭐⭐ Good{{else if ge .OverallScore 60}}⭐⭐ Fair{{else}}⭐ Needs Improvement{{end}}</div>
+                <div class="score-grade">{{if ge .OverallScore 90.0}}⭐⭐⭐⭐⭐ Excellent{{else if ge .OverallScore 80.0}}⭐⭐⭐⭐ Very Good{{else if ge .OverallScore 70.0}}⭐⭐⭐ Good{{else if ge .OverallScore 60.0}}⭐⭐ Fair{{else}}⭐ Needs Improvement{{end}}</div>
             </div>
 
             <div class="card stats-card">
@@ -113,7 +113,7 @@ const htmlTemplate = `
                     <div class="vibe-card">
                         <div class="vibe-header">
                             <h4>{{title .Name}}</h4>
-                            <div class="vibe-score {{if ge .Score 90}}excellent{{else if ge .Score 70}}good{{else}}poor{{end}}">
+                            <div class="vibe-score {{if ge .Score 90.0}}excellent{{else if ge .Score 70.0}}good{{else}}poor{{end}}">
                                 {{printf "%.1f" .Score}}/100
                             </div>
                         </div>
@@ -144,6 +144,7 @@ const htmlTemplate = `
                             <div class="issue-header">
                                 <span class="severity-badge {{.Severity}}">{{upper .Severity}}</span>
                                 <span class="issue-file">{{.File}}:{{.Line}}</span>
+                                {{with learnMore .}}<a class="learn-more" href="{{.}}" target="_blank" rel="noopener">Learn more</a>{{end}}
                             </div>
                             <div class="issue-message">{{.Message}}</div>
                             {{if .Fix}}
@@ -654,6 +655,12 @@ body {
     font-size: 0.9rem;
 }
 
+.learn-more {
+    margin-left: auto;
+    font-size: 0.85rem;
+    color: #1976d2;
+}
+
 .security-overview {
     margin-bottom: 2rem;
 }
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// ruleCatalog maps the IDs of the built-in rules to their descriptions
+func ruleCatalog() map[string]vibes.RuleInfo {
+	catalog := make(map[string]vibes.RuleInfo)
+	for _, checker := range vibes.BuiltinCheckers() {
+		for _, rule := range checker.Rules() {
+			rule.Vibe = checker.Type()
+			if rule.CWE == "" {
+				rule.CWE = vibes.RuleCWE(rule.ID)
+			}
+			catalog[rule.ID] = rule
+		}
+	}
+	return catalog
+}
+
+// expandRuleHelpURL fills in a help URL template for a rule. An empty template
+// uses DefaultRuleHelpURL, and one without {rule} gets the rule appended.
+func expandRuleHelpURL(template string, vibeType models.VibeType, ruleID string) string {
+	if template == "" {
+		template = DefaultRuleHelpURL
+	}
+	if !strings.Contains(template, "{rule}") {
+		template = strings.TrimRight(template, "/") + "/{rule}"
+	}
+
+	return strings.NewReplacer("{rule}", ruleID, "{vibe}", string(vibeType)).Replace(template)
+}
+
+// learnMoreURL links an issue to its rule's documentation. Only rules in the
+// catalog are documented, so other issues get no link.
+func learnMoreURL(template string, catalog map[string]vibes.RuleInfo, issue models.Issue) string {
+	info, known := catalog[issue.Rule]
+	if !known {
+		return ""
+	}
+	return expandRuleHelpURL(template, info.Vibe, issue.Rule)
+}
+
+// ruleHelpTemplate returns the configured help URL template, or "" for the default
+func (r *Reporter) ruleHelpTemplate() string {
+	if r.config == nil {
+		return ""
+	}
+	return r.config.Reporting.RuleHelpURL
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestLearnMoreURL(t *testing.T) {
+	catalog := ruleCatalog()
+
+	assert.Equal(t, "https://github.com/KooshaPari/KodeVibe-Go/blob/main/docs/rules.md#no-var",
+		learnMoreURL("", catalog, models.Issue{Type: models.VibeTypeCode, Rule: "no-var"}))
+	assert.Equal(t, "https://docs.example.com/security/xss-risk",
+		learnMoreURL("https://docs.example.com/{vibe}/{rule}", catalog, models.Issue{Rule: "xss-risk"}),
+		"the vibe comes from the catalog")
+	assert.Empty(t, learnMoreURL("", catalog, models.Issue{Rule: "team-custom-rule"}))
+	assert.Empty(t, learnMoreURL("", catalog, models.Issue{}))
+}
+
+func TestReporter_HTMLLearnMore(t *testing.T) {
+	result := &models.ScanResult{
+		Issues: []models.Issue{
+			{Type: models.VibeTypeCode, Severity: models.SeverityWarning, Rule: "no-var", Title: "Use let", File: "app.js", Line: 1},
+			{Type: models.VibeTypeCode, Severity: models.SeverityInfo, Rule: "team-custom-rule", Title: "Custom", File: "app.js", Line: 2},
+		},
+	}
+	config := &models.Configuration{Reporting: models.ReportingConfig{RuleHelpURL: "https://wiki.example.com/kodevibe/{vibe}#{rule}"}}
+
+	output, err := NewReporter(config).Generate(result, "html")
+	require.NoError(t, err)
+	assert.Contains(t, output, `<a class="learn-more" href="https://wiki.example.com/kodevibe/code#no-var"`)
+	assert.Equal(t, 1, strings.Count(output, `class="learn-more" href=`), "custom rules have no docs to link")
+}
+
+func TestHTMLReportGenerator_LearnMore(t *testing.T) {
+	dir := t.TempDir()
+	generator := NewHTMLReportGenerator(dir)
+	generator.RuleHelpURL = "https://docs.example.com/rules/"
+
+	err := generator.GenerateReport(&models.AnalysisResult{
+		Issues: []models.Issue{
+			{Type: models.VibeTypeSecurity, Category: models.CategorySecurity, Severity: models.SeverityError, Rule: "xss-risk", Message: "XSS", File: "app.js", Line: 3},
+		},
+	}, "/work/app")
+	require.NoError(t, err)
+
+	page, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	// The issues tab links the rule, and the security tab gets the link with its data
+	assert.Contains(t, string(page), `<a class="learn-more" href="https://docs.example.com/rules/xss-risk"`)
+	assert.Contains(t, string(page), `"learnMore":"https://docs.example.com/rules/xss-risk"`)
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// GenerateMultiRepo renders a combined multi-repo scan in text, json or html
+func (r *Reporter) GenerateMultiRepo(result *models.MultiRepoResult, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "text":
+		return r.generateMultiRepoText(result), nil
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return string(data), nil
+	case "html":
+		return r.generateMultiRepoHTML(result)
+	default:
+		return "", fmt.Errorf("unsupported format for multi-repo scans: %s (supported: text, json, html)", format)
+	}
+}
+
+// generateMultiRepoText generates a per-repo table followed by the org rollup
+func (r *Reporter) generateMultiRepoText(result *models.MultiRepoResult) string {
+	var buf bytes.Buffer
+
+	buf.WriteString("🌊 KodeVibe Multi-Repo Report\n")
+	buf.WriteString(strings.Repeat("=", 50) + "\n")
+	for _, repo := range result.Repos {
+		if repo.Result == nil {
+			buf.WriteString(fmt.Sprintf("❌ %-30s failed: %s\n", repo.Name, repo.Error))
+			continue
+		}
+		summary := repo.Result.Summary
+		buf.WriteString(fmt.Sprintf("%-33s %5.1f (%s)  %d issues in %d files\n",
+			repo.Name, summary.Score, summary.Grade, len(repo.Result.Issues), repo.Result.FilesScanned))
+	}
+
+	rollup := result.Rollup
+	buf.WriteString("\n📊 Org Rollup\n")
+	buf.WriteString(strings.Repeat("-", 20) + "\n")
+	buf.WriteString(fmt.Sprintf("Repositories: %d scanned, %d failed\n", rollup.ReposScanned, rollup.ReposFailed))
+	buf.WriteString(fmt.Sprintf("Files Scanned: %d\n", rollup.FilesScanned))
+	buf.WriteString(fmt.Sprintf("Total Issues: %d\n", rollup.TotalIssues))
+	if rollup.ReposScanned > 0 {
+		buf.WriteString(fmt.Sprintf("Average Score: %.1f (%s)\n", rollup.AverageScore, rollup.Grade))
+		buf.WriteString(fmt.Sprintf("Lowest Score: %.1f (%s)\n", rollup.LowestScore, rollup.LowestRepo))
+	}
+
+	return buf.String()
+}
+
+// generateMultiRepoHTML generates an HTML page with the org rollup and a row per repository
+func (r *Reporter) generateMultiRepoHTML(result *models.MultiRepoResult) (string, error) {
+	tmpl := `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>KodeVibe Multi-Repo Report</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, sans-serif; margin: 0; padding: 20px; background: #f5f5f5; }
+        .container { max-width: 1200px; margin: 0 auto; background: white; border-radius: 8px; padding: 20px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        .title { color: #0969da; font-size: 28px; margin: 0; }
+        .subtitle { color: #656d76; margin: 5px 0 0 0; }
+        .summary { display: grid; grid-template-columns: repeat(auto-fit, minmax(200px, 1fr)); gap: 15px; margin: 20px 0; }
+        .summary-card { background: #f6f8fa; border-radius: 6px; padding: 15px; }
+        .summary-title { font-weight: 600; color: #24292f; margin-bottom: 8px; }
+        .summary-value { font-size: 24px; font-weight: 700; }
+        table { width: 100%; border-collapse: collapse; }
+        th, td { text-align: left; padding: 10px; border-bottom: 1px solid #eaecef; }
+        th { background: #f6f8fa; }
+        .error { color: #d1242f; }
+        .warning { color: #fb8500; }
+        .grade-a { color: #1a7f37; }
+        .grade-b { color: #3fb950; }
+        .grade-c { color: #fb8500; }
+        .grade-d { color: #f85149; }
+        .grade-f { color: #d1242f; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1 class="title">🌊 KodeVibe Multi-Repo Report</h1>
+        <p class="subtitle">Generated: {{.StartTime.Format "2006-01-02 15:04:05 UTC"}} | Duration: {{.Duration}}</p>
+
+        <div class="summary">
+            <div class="summary-card">
+                <div class="summary-title">Repositories</div>
+                <div class="summary-value">{{.Rollup.ReposScanned}}{{if .Rollup.ReposFailed}} <span class="error">+{{.Rollup.ReposFailed}} failed</span>{{end}}</div>
+            </div>
+            <div class="summary-card">
+                <div class="summary-title">Total Issues</div>
+                <div class="summary-value">{{.Rollup.TotalIssues}}</div>
+            </div>
+            <div class="summary-card">
+                <div class="summary-title">Average Score</div>
+                <div class="summary-value grade-{{.Rollup.Grade | gradeClass}}">{{printf "%.1f" .Rollup.AverageScore}} ({{.Rollup.Grade}})</div>
+            </div>
+            {{if .Rollup.LowestRepo}}
+            <div class="summary-card">
+                <div class="summary-title">Lowest Score</div>
+                <div class="summary-value">{{printf "%.1f" .Rollup.LowestScore}} <small>{{.Rollup.LowestRepo}}</small></div>
+            </div>
+            {{end}}
+        </div>
+
+        <table>
+            <tr><th>Repository</th><th>Score</th><th>Issues</th><th>Errors</th><th>Warnings</th><th>Files</th></tr>
+            {{range .Repos}}
+            {{if .Result}}
+            <tr>
+                <td title="{{.Source}}">{{.Name}}</td>
+                <td class="grade-{{.Result.Summary.Grade | gradeClass}}">{{printf "%.1f" .Result.Summary.Score}} ({{.Result.Summary.Grade}})</td>
+                <td>{{len .Result.Issues}}</td>
+                <td class="error">{{.Result.Summary.ErrorIssues}}</td>
+                <td class="warning">{{.Result.Summary.WarningIssues}}</td>
+                <td>{{.Result.FilesScanned}}</td>
+            </tr>
+            {{else}}
+            <tr>
+                <td title="{{.Source}}">{{.Name}}</td>
+                <td colspan="5" class="error">Failed: {{.Error}}</td>
+            </tr>
+            {{end}}
+            {{end}}
+        </table>
+    </div>
+</body>
+</html>`
+
+	t, err := template.New("multi-repo").Funcs(template.FuncMap{"gradeClass": gradeClass}).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, result); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReporter_GenerateMultiRepo(t *testing.T) {
+	result := &models.MultiRepoResult{
+		StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Repos: []models.RepoScanResult{
+			{
+				Name:   "api",
+				Source: "https://github.com/acme/api.git",
+				Result: &models.ScanResult{
+					FilesScanned: 12,
+					Issues:       []models.Issue{{Rule: "no-console-log", Severity: models.SeverityWarning}},
+					Summary:      models.ScanSummary{Score: 95, Grade: "A", WarningIssues: 1},
+				},
+			},
+			{Name: "web", Source: "git@github.com:acme/web.git", Error: "git clone failed"},
+		},
+		Rollup: models.OrgRollup{Repos: 2, ReposScanned: 1, ReposFailed: 1, FilesScanned: 12, TotalIssues: 1, AverageScore: 95, Grade: "A", LowestScore: 95, LowestRepo: "api"},
+	}
+	reporter := NewReporter(&models.Configuration{})
+
+	text, err := reporter.GenerateMultiRepo(result, "text")
+	require.NoError(t, err)
+	assert.Contains(t, text, "api")
+	assert.Contains(t, text, "95.0 (A)")
+	assert.Contains(t, text, "web")
+	assert.Contains(t, text, "git clone failed")
+	assert.Contains(t, text, "1 scanned, 1 failed")
+
+	data, err := reporter.GenerateMultiRepo(result, "json")
+	require.NoError(t, err)
+	var decoded models.MultiRepoResult
+	require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+	assert.Equal(t, result.Rollup.AverageScore, decoded.Rollup.AverageScore)
+	assert.Len(t, decoded.Repos, 2)
+
+	html, err := reporter.GenerateMultiRepo(result, "html")
+	require.NoError(t, err)
+	assert.Contains(t, html, "KodeVibe Multi-Repo Report")
+	assert.Contains(t, html, `title="https://github.com/acme/api.git"`)
+	assert.Contains(t, html, "Failed: git clone failed")
+
+	_, err = reporter.GenerateMultiRepo(result, "sarif")
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// maxNDJSONLineSize bounds a single issue line when reading NDJSON input
+const maxNDJSONLineSize = 1024 * 1024
+
+// LineError describes a malformed line in NDJSON input
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// generateNDJSONReport writes one JSON-encoded issue per line
+func (r *Reporter) generateNDJSONReport(result *models.ScanResult) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+
+	for _, issue := range result.Issues {
+		if err := encoder.Encode(issue); err != nil {
+			return "", fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// ReadIssuesNDJSON reads a stream of issues, one JSON object per line.
+// Blank lines are ignored; malformed or invalid lines are skipped and
+// returned as LineErrors so callers can report them.
+func ReadIssuesNDJSON(r io.Reader) ([]models.Issue, []*LineError, error) {
+	var issues []models.Issue
+	var malformed []*LineError
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var issue models.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			malformed = append(malformed, &LineError{Line: lineNumber, Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+
+		if err := validateIssue(issue); err != nil {
+			malformed = append(malformed, &LineError{Line: lineNumber, Err: err})
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return issues, malformed, fmt.Errorf("error reading input: %w", err)
+	}
+
+	return issues, malformed, nil
+}
+
+// validateIssue checks that an ingested issue carries the fields reports rely on
+func validateIssue(issue models.Issue) error {
+	if issue.File == "" {
+		return fmt.Errorf("missing file")
+	}
+
+	switch issue.Severity {
+	case models.SeverityCritical, models.SeverityError, models.SeverityWarning, models.SeverityInfo:
+	default:
+		return fmt.Errorf("invalid severity %q", issue.Severity)
+	}
+
+	if issue.Message == "" && issue.Title == "" {
+		return fmt.Errorf("missing message")
+	}
+
+	return nil
+}
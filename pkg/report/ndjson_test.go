@@ -0,0 +1,55 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReadIssuesNDJSON(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"1","type":"security","severity":"error","message":"Hardcoded secret","file":"a.go","line":3,"rule":"secret"}`,
+		``,
+		`{"id":"2","severity":"warning","title":"Line too long","file":"b.js"}`,
+		`{not json}`,
+		`{"id":"3","severity":"urgent","message":"bad severity","file":"c.go"}`,
+		`{"id":"4","severity":"info","message":"no file"}`,
+	}, "\n")
+
+	issues, malformed, err := ReadIssuesNDJSON(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, "a.go", issues[0].File)
+	assert.Equal(t, models.SeverityError, issues[0].Severity)
+	assert.Equal(t, "b.js", issues[1].File)
+
+	require.Len(t, malformed, 3)
+	assert.Equal(t, 4, malformed[0].Line)
+	assert.Equal(t, 5, malformed[1].Line)
+	assert.Contains(t, malformed[1].Error(), "invalid severity")
+	assert.Equal(t, 6, malformed[2].Line)
+}
+
+func TestReporter_GenerateNDJSONRoundTrip(t *testing.T) {
+	result := &models.ScanResult{
+		Issues: []models.Issue{
+			{ID: "1", Severity: models.SeverityWarning, Message: "first", File: "a.go", Line: 1},
+			{ID: "2", Severity: models.SeverityInfo, Message: "second", File: "b.go", Line: 2},
+		},
+	}
+
+	output, err := NewReporter(&models.Configuration{}).Generate(result, "ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(output, "\n"))
+
+	issues, malformed, err := ReadIssuesNDJSON(strings.NewReader(output))
+	require.NoError(t, err)
+	assert.Empty(t, malformed)
+	assert.Equal(t, result.Issues[0].Message, issues[0].Message)
+	assert.Equal(t, result.Issues[1].File, issues[1].File)
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/scanner"
+)
+
+func TestReporter_Owners(t *testing.T) {
+	owned := func(rule string, owners ...string) models.Issue {
+		return models.Issue{
+			Type: models.VibeTypeCode, Severity: models.SeverityWarning, Title: rule, Rule: rule, File: rule + ".go", Line: 1,
+			Metadata: map[string]interface{}{"owners": owners},
+		}
+	}
+	issues := []models.Issue{
+		owned("api-one", "@org/api"),
+		owned("api-two", "@org/api"),
+		owned("shared", "@org/api", "@org/web"),
+		{Type: models.VibeTypeSecurity, Severity: models.SeverityError, Title: "orphan", Rule: "orphan", File: "x.go", Line: 2},
+	}
+	result := &models.ScanResult{Issues: issues, Summary: scanner.Summarize(issues, nil)}
+
+	config := &models.Configuration{}
+	output, err := NewReporter(config).Generate(result, "text")
+	require.NoError(t, err)
+	assert.Contains(t, output, "👥 Issues by Owner\n--------------------\n@org/api: 3\n@org/web: 1\nunowned: 1\n")
+	assert.Contains(t, output, "🔸 code (3 issues)")
+	assert.Contains(t, output, "Owners: @org/api @org/web")
+
+	config.Reporting.GroupBy = models.GroupByOwner
+	output, err = NewReporter(config).Generate(result, "text")
+	require.NoError(t, err)
+	assert.Contains(t, output, "🔸 @org/api (3 issues)")
+	assert.Contains(t, output, "🔸 unowned (1 issues)")
+	assert.Less(t, strings.Index(output, "🔸 @org/api"), strings.Index(output, "🔸 @org/web"), "owners with the most issues come first")
+
+	config.Reporting.CSVColumns = []string{"rule", "owners"}
+	output, err = NewReporter(config).Generate(result, "csv")
+	require.NoError(t, err)
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shared", "@org/api @org/web"}, records[3])
+	assert.Equal(t, []string{"orphan", ""}, records[4])
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// ReportIndex lists the pages of a report split by WritePaged. Each page is a
+// complete report in the index's format holding a consecutive run of the
+// issues; the summary and every other field describe the whole scan.
+type ReportIndex struct {
+	Format      string             `json:"format"`
+	ScanID      string             `json:"scan_id"`
+	MaxBytes    int                `json:"max_bytes"`
+	TotalIssues int                `json:"total_issues"`
+	Summary     models.ScanSummary `json:"summary"`
+	Pages       []ReportPage       `json:"pages"`
+}
+
+// ReportPage is one file of a paged report
+type ReportPage struct {
+	// File is relative to the index
+	File string `json:"file"`
+	// FirstIssue is the position of the page's first issue in the scan's issues
+	FirstIssue int    `json:"first_issue"`
+	Issues     int    `json:"issues"`
+	Bytes      int    `json:"bytes"`
+	SHA256     string `json:"sha256"`
+	// Oversize marks a page over max_bytes because one issue alone exceeds it
+	Oversize bool `json:"oversize,omitempty"`
+}
+
+// IndexPath returns where WritePaged writes the index of a report bound for path
+func IndexPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".index.json"
+}
+
+// WritePaged writes the report to path when it fits in maxBytes. A larger
+// report is split into pages, written next to path as <name>.page-001<ext>
+// and so on, with an index at IndexPath(path) in place of the report; the
+// index is returned. maxBytes of 0 or less writes one file of any size.
+func (r *Reporter) WritePaged(result *models.ScanResult, format, path string, maxBytes int) (*ReportIndex, error) {
+	output, err := r.Generate(result, format)
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes <= 0 || len(output) <= maxBytes {
+		if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write report: %w", err)
+		}
+		return nil, nil
+	}
+
+	index := &ReportIndex{
+		Format:      strings.ToLower(format),
+		ScanID:      result.ID,
+		MaxBytes:    maxBytes,
+		TotalIssues: len(result.Issues),
+		Summary:     result.Summary,
+	}
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+
+	// Start from the share of issues that fits, shrinking pages that don't
+	perPage := len(result.Issues) * maxBytes / len(output)
+	for start := 0; start < len(result.Issues) || len(index.Pages) == 0; {
+		count := min(max(perPage, 1), len(result.Issues)-start)
+		page := *result
+		var pageOutput string
+		for {
+			page.Issues = result.Issues[start : start+count]
+			if pageOutput, err = r.Generate(&page, format); err != nil {
+				return nil, err
+			}
+			if len(pageOutput) <= maxBytes || count <= 1 {
+				break
+			}
+			count = max(1, min(count-1, count*maxBytes/len(pageOutput)))
+		}
+
+		file := fmt.Sprintf("%s.page-%03d%s", stem, len(index.Pages)+1, ext)
+		if err := os.WriteFile(file, []byte(pageOutput), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write report page: %w", err)
+		}
+		sum := sha256.Sum256([]byte(pageOutput))
+		index.Pages = append(index.Pages, ReportPage{
+			File:       filepath.Base(file),
+			FirstIssue: start,
+			Issues:     count,
+			Bytes:      len(pageOutput),
+			SHA256:     hex.EncodeToString(sum[:]),
+			Oversize:   len(pageOutput) > maxBytes,
+		})
+		start += count
+		if count == 0 {
+			break
+		}
+	}
+
+	// Leave no stale single-file report where the index now stands in for it
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove old report: %w", err)
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report index: %w", err)
+	}
+	if err := os.WriteFile(IndexPath(path), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write report index: %w", err)
+	}
+	return index, nil
+}
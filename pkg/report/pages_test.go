@@ -0,0 +1,86 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func pagedResult(issues int) *models.ScanResult {
+	result := &models.ScanResult{ID: "scan-1", Summary: models.ScanSummary{TotalIssues: issues}}
+	for i := 0; i < issues; i++ {
+		result.Issues = append(result.Issues, models.Issue{
+			Type: models.VibeTypeCode, Severity: models.SeverityWarning, Rule: "no-var",
+			Message: fmt.Sprintf("Use let instead of var (%d)", i), File: "app.js", Line: i + 1,
+		})
+	}
+	return result
+}
+
+func TestReporter_WritePaged(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.json")
+	reporter := NewReporter(&models.Configuration{})
+	result := pagedResult(200)
+
+	// A report that fits is written as one file
+	index, err := reporter.WritePaged(result, "json", path, 10*1024*1024)
+	require.NoError(t, err)
+	assert.Nil(t, index)
+	assert.FileExists(t, path)
+
+	index, err = reporter.WritePaged(result, "json", path, 16*1024)
+	require.NoError(t, err)
+	require.NotNil(t, index)
+	assert.Greater(t, len(index.Pages), 1)
+	assert.Equal(t, 200, index.TotalIssues)
+	assert.NoFileExists(t, path, "the index replaces the single report")
+
+	var stored ReportIndex
+	data, err := os.ReadFile(IndexPath(path))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &stored))
+	assert.Equal(t, *index, stored)
+
+	// Reassembling the pages in order gives back every issue
+	var issues []models.Issue
+	for i, page := range index.Pages {
+		assert.Equal(t, fmt.Sprintf("report.page-%03d.json", i+1), page.File)
+		assert.Equal(t, len(issues), page.FirstIssue)
+		assert.LessOrEqual(t, page.Bytes, 16*1024)
+		assert.False(t, page.Oversize)
+
+		data, err := os.ReadFile(filepath.Join(dir, page.File))
+		require.NoError(t, err)
+		sum := sha256.Sum256(data)
+		assert.Equal(t, hex.EncodeToString(sum[:]), page.SHA256)
+
+		var pageResult models.ScanResult
+		require.NoError(t, json.Unmarshal(data, &pageResult))
+		assert.Len(t, pageResult.Issues, page.Issues)
+		assert.Equal(t, 200, pageResult.Summary.TotalIssues, "pages keep the scan's summary")
+		issues = append(issues, pageResult.Issues...)
+	}
+	require.Len(t, issues, 200)
+	assert.Equal(t, result.Issues[199].Message, issues[199].Message)
+}
+
+func TestReporter_WritePagedOversizeIssue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.csv")
+	index, err := NewReporter(&models.Configuration{}).WritePaged(pagedResult(3), "csv", path, 10)
+	require.NoError(t, err)
+	require.Len(t, index.Pages, 3)
+	for _, page := range index.Pages {
+		assert.Equal(t, 1, page.Issues)
+		assert.True(t, page.Oversize)
+	}
+}
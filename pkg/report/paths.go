@@ -0,0 +1,143 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// PathBaseAbsolute is the reporting.path_base value that reports absolute paths
+const PathBaseAbsolute = "absolute"
+
+// truncatedIssuesKey is the result metadata the scanner fills with the number
+// of issues dropped from each file over scanner.max_issues_per_file
+const truncatedIssuesKey = "truncated_issues"
+
+// exemptedIssuesKey is the result metadata the scanner fills with the issues
+// of functions exempt from the complexity rules
+const exemptedIssuesKey = "exempted_issues"
+
+// withReportPaths returns a copy of result whose issue paths are relative to
+// the report's path base, with forward slashes. Files outside the base keep
+// their path.
+func (r *Reporter) withReportPaths(result *models.ScanResult) *models.ScanResult {
+	if len(result.Issues) == 0 && result.Metadata[exemptedIssuesKey] == nil {
+		return result
+	}
+
+	base := ""
+	if r.config != nil {
+		base = r.config.Reporting.PathBase
+	}
+	if base == "" {
+		base = defaultPathBase(result.ProjectPath)
+	}
+
+	reported := *result
+	reported.Issues = make([]models.Issue, len(result.Issues))
+	for i, issue := range result.Issues {
+		issue.File = reportPath(issue.File, base)
+		reported.Issues[i] = issue
+	}
+
+	truncated, hasTruncated := result.Metadata[truncatedIssuesKey].(map[string]int)
+	exempted, hasExempted := result.Metadata[exemptedIssuesKey].([]models.Issue)
+	if hasTruncated || hasExempted {
+		reported.Metadata = make(map[string]interface{}, len(result.Metadata))
+		for key, value := range result.Metadata {
+			reported.Metadata[key] = value
+		}
+	}
+	if hasTruncated {
+		files := make(map[string]int, len(truncated))
+		for file, count := range truncated {
+			files[reportPath(file, base)] = count
+		}
+		reported.Metadata[truncatedIssuesKey] = files
+	}
+	if hasExempted {
+		issues := make([]models.Issue, len(exempted))
+		for i, issue := range exempted {
+			issue.File = reportPath(issue.File, base)
+			issues[i] = issue
+		}
+		reported.Metadata[exemptedIssuesKey] = issues
+	}
+	return &reported
+}
+
+// reportPath returns file relative to base with forward slashes, or absolute
+// when base is "absolute". Files outside base, and relative paths that don't
+// exist from the working directory (e.g. already repo-relative paths read back
+// from a report), are returned unchanged apart from their slashes.
+func reportPath(file, base string) string {
+	if file == "" || base == "" {
+		return filepath.ToSlash(file)
+	}
+	if !filepath.IsAbs(file) {
+		if _, err := os.Stat(file); err != nil {
+			return filepath.ToSlash(file)
+		}
+	}
+
+	absolute, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	if base == PathBaseAbsolute {
+		return filepath.ToSlash(absolute)
+	}
+
+	absoluteBase, err := filepath.Abs(base)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	rel, err := filepath.Rel(absoluteBase, absolute)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// defaultPathBase is the root of the git repository holding the first scanned
+// path, or of the working directory, falling back to the working directory
+func defaultPathBase(projectPath string) string {
+	start := "."
+	if first, _, _ := strings.Cut(projectPath, ","); first != "" {
+		start = first
+	}
+	if root := repoRoot(start); root != "" {
+		return root
+	}
+	if root := repoRoot("."); root != "" {
+		return root
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		return cwd
+	}
+	return ""
+}
+
+// repoRoot returns the closest directory at or above path that holds a .git
+// entry (a directory, or a file in worktrees and submodules), or ""
+func repoRoot(path string) string {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReportPath(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "pkg", "app.go")
+
+	assert.Equal(t, "pkg/app.go", reportPath(file, root))
+	assert.Equal(t, "app.go", reportPath(file, filepath.Join(root, "pkg")))
+	assert.Equal(t, filepath.ToSlash(file), reportPath(file, PathBaseAbsolute))
+	assert.Equal(t, filepath.ToSlash(file), reportPath(file, filepath.Join(root, "other")), "files outside the base keep their path")
+
+	// Relative paths are resolved from the working directory (this package's directory)
+	assert.Equal(t, "report/paths.go", reportPath("paths.go", ".."))
+	assert.Equal(t, "pkg/missing.go", reportPath("pkg/missing.go", ".."), "paths that don't exist are left as they are")
+}
+
+func TestRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "svc", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(nested, "main.go"), []byte("package main\n"), 0644))
+
+	assert.Empty(t, repoRoot(nested))
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	assert.Equal(t, root, repoRoot(nested))
+	assert.Equal(t, root, repoRoot(filepath.Join(nested, "main.go")))
+	assert.Equal(t, root, defaultPathBase(nested+",/elsewhere"))
+}
+
+func TestReporter_PathBase(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	file := filepath.Join(root, "cmd", "main.go")
+
+	result := &models.ScanResult{
+		ProjectPath: filepath.Join(root, "cmd"),
+		Issues:      []models.Issue{{Rule: "no-panic", File: file, Line: 3, Severity: models.SeverityError}},
+	}
+
+	// Repository root by default, in every format
+	for _, format := range []string{"json", "ndjson", "csv", "sarif", "junit", "text", "html"} {
+		output, err := NewReporter(&models.Configuration{}).Generate(result, format)
+		require.NoError(t, err, format)
+		assert.Contains(t, output, "cmd/main.go", format)
+		assert.NotContains(t, output, filepath.ToSlash(file), format)
+	}
+	assert.Equal(t, file, result.Issues[0].File, "the result itself is not modified")
+
+	config := &models.Configuration{}
+	config.Reporting.PathBase = filepath.Join(root, "cmd")
+	output, err := NewReporter(config).Generate(result, "csv")
+	require.NoError(t, err)
+	assert.Contains(t, output, ",main.go,")
+
+	config.Reporting.PathBase = PathBaseAbsolute
+	output, err = NewReporter(config).Generate(result, "csv")
+	require.NoError(t, err)
+	assert.Contains(t, output, filepath.ToSlash(file))
+}
+
+func TestReporter_TruncatedFiles(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	file := filepath.Join(root, "gen", "big.go")
+
+	result := &models.ScanResult{
+		ProjectPath: root,
+		Issues:      []models.Issue{{Rule: "long-line", File: file, Line: 1, Severity: models.SeverityInfo}},
+		Metadata:    map[string]interface{}{truncatedIssuesKey: map[string]int{file: 40}},
+	}
+
+	output, err := NewReporter(&models.Configuration{}).Generate(result, "text")
+	require.NoError(t, err)
+	assert.Contains(t, output, "gen/big.go: 40 more issues not reported (max_issues_per_file)")
+	assert.Equal(t, map[string]int{file: 40}, result.Metadata[truncatedIssuesKey], "the result itself is not modified")
+}
@@ -0,0 +1,203 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"time"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// ciSystem describes how to recognize a CI system from its environment
+type ciSystem struct {
+	name string
+	// detect is set in the CI system's jobs
+	detect string
+	// runURL builds a link to the job, or returns ""
+	runURL func(getenv func(string) string) string
+	// commitVar and operatorVar hold the commit and the user who started the job
+	commitVar, operatorVar string
+}
+
+var ciSystems = []ciSystem{
+	{
+		name: "github-actions", detect: "GITHUB_ACTIONS", commitVar: "GITHUB_SHA", operatorVar: "GITHUB_ACTOR",
+		runURL: func(getenv func(string) string) string {
+			if getenv("GITHUB_SERVER_URL") == "" || getenv("GITHUB_REPOSITORY") == "" || getenv("GITHUB_RUN_ID") == "" {
+				return ""
+			}
+			return getenv("GITHUB_SERVER_URL") + "/" + getenv("GITHUB_REPOSITORY") + "/actions/runs/" + getenv("GITHUB_RUN_ID")
+		},
+	},
+	{name: "gitlab-ci", detect: "GITLAB_CI", commitVar: "CI_COMMIT_SHA", operatorVar: "GITLAB_USER_LOGIN", runURL: envURL("CI_JOB_URL")},
+	{name: "circleci", detect: "CIRCLECI", commitVar: "CIRCLE_SHA1", operatorVar: "CIRCLE_USERNAME", runURL: envURL("CIRCLE_BUILD_URL")},
+	{name: "azure-pipelines", detect: "TF_BUILD", commitVar: "BUILD_SOURCEVERSION", operatorVar: "BUILD_REQUESTEDFOR"},
+	{name: "buildkite", detect: "BUILDKITE", commitVar: "BUILDKITE_COMMIT", operatorVar: "BUILDKITE_BUILD_CREATOR", runURL: envURL("BUILDKITE_BUILD_URL")},
+	{name: "jenkins", detect: "JENKINS_URL", commitVar: "GIT_COMMIT", operatorVar: "BUILD_USER_ID", runURL: envURL("BUILD_URL")},
+	{name: "travis-ci", detect: "TRAVIS", commitVar: "TRAVIS_COMMIT", runURL: envURL("TRAVIS_BUILD_WEB_URL")},
+}
+
+func envURL(name string) func(func(string) string) string {
+	return func(getenv func(string) string) string { return getenv(name) }
+}
+
+// ParseMetadata reads --metadata key=value pairs
+func ParseMetadata(pairs []string) (map[string]string, error) {
+	metadata := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid metadata %q: expected key=value", pair)
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
+// NewProvenance collects the provenance of a report from the configuration
+// and the environment: the CI system and its run, the operator and the host.
+// metadata fields named like a provenance field (operator, host, ci_system,
+// ci_run_url, commit) override it; the others are kept as custom fields.
+func NewProvenance(config *models.Configuration, toolVersion, commit string, metadata map[string]string) *models.Provenance {
+	return newProvenance(config, toolVersion, commit, metadata, os.Getenv)
+}
+
+func newProvenance(config *models.Configuration, toolVersion, commit string, metadata map[string]string, getenv func(string) string) *models.Provenance {
+	provenance := &models.Provenance{
+		Tool:           "kodevibe",
+		ToolVersion:    toolVersion,
+		RulesetVersion: rulesetVersion(config),
+		ConfigHash:     configHash(config),
+		GeneratedAt:    time.Now().UTC(),
+		Commit:         commit,
+	}
+
+	for _, system := range ciSystems {
+		if getenv(system.detect) == "" {
+			continue
+		}
+		provenance.CISystem = system.name
+		if system.runURL != nil {
+			provenance.CIRunURL = system.runURL(getenv)
+		}
+		if provenance.Commit == "" {
+			provenance.Commit = getenv(system.commitVar)
+		}
+		if system.operatorVar != "" {
+			provenance.Operator = getenv(system.operatorVar)
+		}
+		break
+	}
+	if provenance.CISystem == "" && getenv("CI") != "" {
+		provenance.CISystem = "ci"
+	}
+
+	if operator := getenv("KODEVIBE_OPERATOR"); operator != "" {
+		provenance.Operator = operator
+	}
+	if provenance.Operator == "" {
+		if current, err := user.Current(); err == nil {
+			provenance.Operator = current.Username
+		}
+	}
+	provenance.Host, _ = os.Hostname()
+
+	for key, value := range metadata {
+		switch key {
+		case "operator":
+			provenance.Operator = value
+		case "host":
+			provenance.Host = value
+		case "ci_system":
+			provenance.CISystem = value
+		case "ci_run_url":
+			provenance.CIRunURL = value
+		case "commit":
+			provenance.Commit = value
+		default:
+			if provenance.Custom == nil {
+				provenance.Custom = make(map[string]string)
+			}
+			provenance.Custom[key] = value
+		}
+	}
+	return provenance
+}
+
+// rulesetVersion hashes the ID and default severity of every built-in and
+// custom rule, so it changes whenever a rule is added, removed or re-graded
+func rulesetVersion(config *models.Configuration) string {
+	var rules []string
+	for _, checker := range vibes.BuiltinCheckers() {
+		for _, rule := range checker.Rules() {
+			rules = append(rules, fmt.Sprintf("%s/%s:%s", checker.Type(), rule.ID, rule.Severity))
+		}
+	}
+	if config != nil {
+		for _, rule := range config.CustomRules {
+			rules = append(rules, fmt.Sprintf("custom/%s:%s:%s", rule.Name, rule.Severity, rule.Pattern))
+		}
+	}
+	sort.Strings(rules)
+
+	sum := sha256.Sum256([]byte(strings.Join(rules, "\n")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// configHash hashes the effective configuration
+func configHash(config *models.Configuration) string {
+	if config == nil {
+		return ""
+	}
+	// Maps are encoded with sorted keys, so equal configurations hash alike
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// provenanceFields lists a provenance block as label/value pairs for the
+// text, HTML and JUnit reports, in a fixed order
+func provenanceFields(provenance *models.Provenance) [][2]string {
+	if provenance == nil {
+		return nil
+	}
+	fields := [][2]string{
+		{"tool", provenance.Tool + " " + provenance.ToolVersion},
+		{"ruleset_version", provenance.RulesetVersion},
+		{"config_hash", provenance.ConfigHash},
+		{"generated_at", provenance.GeneratedAt.Format(time.RFC3339)},
+	}
+	optional := [][2]string{
+		{"operator", provenance.Operator},
+		{"host", provenance.Host},
+		{"ci_system", provenance.CISystem},
+		{"ci_run_url", provenance.CIRunURL},
+		{"commit", provenance.Commit},
+	}
+	for _, field := range optional {
+		if field[1] != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	keys := make([]string, 0, len(provenance.Custom))
+	for key := range provenance.Custom {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fields = append(fields, [2]string{key, provenance.Custom[key]})
+	}
+	return fields
+}
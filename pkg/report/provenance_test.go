@@ -0,0 +1,114 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestParseMetadata(t *testing.T) {
+	metadata, err := ParseMetadata([]string{"ticket=SOX-42", "operator=release-bot", "note=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ticket": "SOX-42", "operator": "release-bot", "note": "a=b"}, metadata)
+
+	_, err = ParseMetadata([]string{"ticket"})
+	assert.Error(t, err)
+	_, err = ParseMetadata([]string{"=value"})
+	assert.Error(t, err)
+}
+
+func TestNewProvenance(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_SERVER_URL": "https://github.com",
+		"GITHUB_REPOSITORY": "acme/app",
+		"GITHUB_RUN_ID":     "123",
+		"GITHUB_SHA":        "abc123",
+		"GITHUB_ACTOR":      "octocat",
+	}
+	config := &models.Configuration{}
+
+	provenance := newProvenance(config, "1.0.0", "", map[string]string{"ticket": "SOX-42"}, func(name string) string { return env[name] })
+	assert.Equal(t, "kodevibe", provenance.Tool)
+	assert.Equal(t, "1.0.0", provenance.ToolVersion)
+	assert.Equal(t, "github-actions", provenance.CISystem)
+	assert.Equal(t, "https://github.com/acme/app/actions/runs/123", provenance.CIRunURL)
+	assert.Equal(t, "abc123", provenance.Commit)
+	assert.Equal(t, "octocat", provenance.Operator)
+	assert.Equal(t, map[string]string{"ticket": "SOX-42"}, provenance.Custom)
+	assert.Len(t, provenance.ConfigHash, 64)
+	assert.Len(t, provenance.RulesetVersion, 12)
+	assert.False(t, provenance.GeneratedAt.IsZero())
+
+	// The scanned commit wins over the CI's, and metadata over both
+	provenance = newProvenance(config, "1.0.0", "def456", map[string]string{"operator": "auditor"}, func(name string) string { return env[name] })
+	assert.Equal(t, "def456", provenance.Commit)
+	assert.Equal(t, "auditor", provenance.Operator)
+	assert.Empty(t, provenance.Custom)
+
+	other := newProvenance(&models.Configuration{Vibes: map[models.VibeType]models.VibeConfig{models.VibeTypeSecurity: {Enabled: true}}}, "1.0.0", "", nil, func(string) string { return "" })
+	assert.NotEqual(t, provenance.ConfigHash, other.ConfigHash)
+	assert.Equal(t, provenance.RulesetVersion, other.RulesetVersion)
+	assert.Empty(t, other.CISystem)
+}
+
+func TestReporter_Provenance(t *testing.T) {
+	result := &models.ScanResult{
+		ID:     "scan-1",
+		Issues: []models.Issue{{Type: models.VibeTypeCode, Severity: models.SeverityWarning, Rule: "no-var", Message: "Use let", File: "app.js", Line: 3}},
+		Provenance: &models.Provenance{
+			Tool: "kodevibe", ToolVersion: "1.0.0", RulesetVersion: "0123456789ab", ConfigHash: "feedface",
+			CISystem: "gitlab-ci", Commit: "abc123", Custom: map[string]string{"ticket": "SOX-42"},
+		},
+	}
+	reporter := NewReporter(&models.Configuration{})
+
+	text, err := reporter.Generate(result, "text")
+	require.NoError(t, err)
+	assert.Contains(t, text, "ruleset_version: 0123456789ab\n")
+	assert.Contains(t, text, "ticket: SOX-42\n")
+
+	html, err := reporter.Generate(result, "html")
+	require.NoError(t, err)
+	assert.Contains(t, html, "<dt>config_hash</dt><dd>feedface</dd>")
+
+	output, err := reporter.Generate(result, "json")
+	require.NoError(t, err)
+	var decoded models.ScanResult
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+	assert.Equal(t, result.Provenance.CISystem, decoded.Provenance.CISystem)
+
+	output, err = reporter.Generate(result, "sarif")
+	require.NoError(t, err)
+	var log sarifLog
+	require.NoError(t, json.Unmarshal([]byte(output), &log))
+	assert.Equal(t, "1.0.0", log.Runs[0].Tool.Driver.Version)
+	require.NotNil(t, log.Runs[0].Properties)
+	assert.Equal(t, "SOX-42", log.Runs[0].Properties.Provenance.Custom["ticket"])
+
+	output, err = reporter.Generate(result, "junit")
+	require.NoError(t, err)
+	var suite struct {
+		Properties []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:"value,attr"`
+		} `xml:"properties>property"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(strings.TrimPrefix(output, xml.Header)), &suite))
+	assert.Contains(t, suite.Properties, struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+	}{"commit", "abc123"})
+
+	// Without provenance the envelopes are unchanged
+	result.Provenance = nil
+	text, err = reporter.Generate(result, "text")
+	require.NoError(t, err)
+	assert.NotContains(t, text, "ruleset_version")
+}
@@ -6,10 +6,13 @@ import (
 	"encoding/xml"
 	"fmt"
 	"html/template"
+	"sort"
 	"strings"
 	"time"
 
 	"kodevibe/internal/models"
+	"kodevibe/pkg/codeowners"
+	"kodevibe/pkg/vibes"
 )
 
 // Reporter generates reports in various formats
@@ -26,6 +29,8 @@ func NewReporter(config *models.Configuration) *Reporter {
 
 // Generate generates a report in the specified format
 func (r *Reporter) Generate(result *models.ScanResult, format string) (string, error) {
+	result = withEmptyCollections(r.withReportPaths(result))
+
 	switch strings.ToLower(format) {
 	case "text":
 		return r.generateTextReport(result)
@@ -39,6 +44,12 @@ func (r *Reporter) Generate(result *models.ScanResult, format string) (string, e
 		return r.generateJUnitReport(result)
 	case "csv":
 		return r.generateCSVReport(result)
+	case "ndjson", "jsonl":
+		return r.generateNDJSONReport(result)
+	case "sarif":
+		return r.generateSARIFReport(result)
+	case string(models.ReportFormatDefectDojo):
+		return r.generateDefectDojoReport(result)
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
@@ -56,6 +67,9 @@ func (r *Reporter) generateTextReport(result *models.ScanResult) (string, error)
 	buf.WriteString(fmt.Sprintf("Duration: %v\n", result.Duration))
 	buf.WriteString(fmt.Sprintf("Files Scanned: %d\n", result.FilesScanned))
 	buf.WriteString(fmt.Sprintf("Files Skipped: %d\n", result.FilesSkipped))
+	for _, field := range provenanceFields(result.Provenance) {
+		buf.WriteString(fmt.Sprintf("%s: %s\n", field[0], field[1]))
+	}
 	buf.WriteString("\n")
 
 	// Summary
@@ -66,8 +80,41 @@ func (r *Reporter) generateTextReport(result *models.ScanResult) (string, error)
 	buf.WriteString(fmt.Sprintf("Warnings: %d\n", result.Summary.WarningIssues))
 	buf.WriteString(fmt.Sprintf("Info: %d\n", result.Summary.InfoIssues))
 	buf.WriteString(fmt.Sprintf("Score: %.1f (%s)\n", result.Summary.Score, result.Summary.Grade))
+	if sample := result.Summary.Sample; sample != nil {
+		buf.WriteString(fmt.Sprintf("Sampled: %g%% of files (%d of %d, seed %d); the score is an estimate with confidence %.2f\n",
+			sample.Percent, sample.FilesSampled, sample.FilesDiscovered, sample.Seed, sample.Confidence))
+	}
+	if result.Summary.InlineSuppressed > 0 {
+		buf.WriteString(fmt.Sprintf("Suppressed Inline: %d (%s)\n", result.Summary.InlineSuppressed, ruleCounts(result.Summary.InlineSuppressedByRule)))
+	}
 	buf.WriteString("\n")
 
+	// Files whose least severe issues were dropped by max_issues_per_file
+	if truncated, ok := result.Metadata[truncatedIssuesKey].(map[string]int); ok && len(truncated) > 0 {
+		files := make([]string, 0, len(truncated))
+		for file := range truncated {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+
+		buf.WriteString("✂️  Truncated Files\n")
+		buf.WriteString(strings.Repeat("-", 20) + "\n")
+		for _, file := range files {
+			buf.WriteString(fmt.Sprintf("%s: %d more issues not reported (max_issues_per_file)\n", file, truncated[file]))
+		}
+		buf.WriteString("\n")
+	}
+
+	// Issues left out because their function's complexity is accepted
+	if exempted, ok := result.Metadata[exemptedIssuesKey].([]models.Issue); ok && len(exempted) > 0 {
+		buf.WriteString("🙈 Exempted Issues\n")
+		buf.WriteString(strings.Repeat("-", 20) + "\n")
+		for _, issue := range exempted {
+			buf.WriteString(fmt.Sprintf("%s:%d %s (%s)\n", issue.File, issue.Line, issue.Rule, exemptionNote(issue)))
+		}
+		buf.WriteString("\n")
+	}
+
 	// Issues by type
 	if len(result.Summary.IssuesByType) > 0 {
 		buf.WriteString("🎯 Issues by Type\n")
@@ -78,19 +125,43 @@ func (r *Reporter) generateTextReport(result *models.ScanResult) (string, error)
 		buf.WriteString("\n")
 	}
 
+	// Issues by CODEOWNERS owner, most first
+	if owners := result.Summary.IssuesByOwner; len(owners) > 0 {
+		buf.WriteString("👥 Issues by Owner\n")
+		buf.WriteString(strings.Repeat("-", 20) + "\n")
+		for _, owner := range keysByCount(owners) {
+			buf.WriteString(fmt.Sprintf("%s: %d\n", owner, owners[owner]))
+		}
+		buf.WriteString("\n")
+	}
+
 	// Detailed issues
 	if len(result.Issues) > 0 {
 		buf.WriteString("🔍 Detailed Issues\n")
 		buf.WriteString(strings.Repeat("-", 20) + "\n")
 
-		// Group issues by type
-		issuesByType := make(map[models.VibeType][]models.Issue)
-		for _, issue := range result.Issues {
-			issuesByType[issue.Type] = append(issuesByType[issue.Type], issue)
+		// Group issues by type, or by owner
+		groups := make(map[string][]models.Issue)
+		var order []string
+		if r.config != nil && r.config.Reporting.GroupBy == models.GroupByOwner {
+			groups = codeowners.GroupByOwner(result.Issues)
+			counts := make(map[string]int, len(groups))
+			for owner, owned := range groups {
+				counts[owner] = len(owned)
+			}
+			order = keysByCount(counts)
+		} else {
+			for _, issue := range result.Issues {
+				if _, seen := groups[string(issue.Type)]; !seen {
+					order = append(order, string(issue.Type))
+				}
+				groups[string(issue.Type)] = append(groups[string(issue.Type)], issue)
+			}
 		}
 
-		for vibeType, issues := range issuesByType {
-			buf.WriteString(fmt.Sprintf("\n🔸 %s (%d issues)\n", vibeType, len(issues)))
+		for _, group := range order {
+			issues := groups[group]
+			buf.WriteString(fmt.Sprintf("\n🔸 %s (%d issues)\n", group, len(issues)))
 			for i, issue := range issues {
 				if i >= 10 { // Limit to first 10 issues per type
 					buf.WriteString(fmt.Sprintf("  ... and %d more issues\n", len(issues)-10))
@@ -100,6 +171,15 @@ func (r *Reporter) generateTextReport(result *models.ScanResult) (string, error)
 				buf.WriteString(fmt.Sprintf("  %s %s\n", severityIcon, issue.Title))
 				buf.WriteString(fmt.Sprintf("    File: %s:%d\n", issue.File, issue.Line))
 				buf.WriteString(fmt.Sprintf("    Rule: %s\n", issue.Rule))
+				if issue.CWE != "" {
+					buf.WriteString(fmt.Sprintf("    CWE: %s\n", issue.CWE))
+				}
+				if owners := codeowners.IssueOwners(issue); len(owners) > 0 {
+					buf.WriteString(fmt.Sprintf("    Owners: %s\n", strings.Join(owners, " ")))
+				}
+				if issue.Category != "" {
+					buf.WriteString(fmt.Sprintf("    Category: %s\n", issue.Category))
+				}
 				if issue.Message != "" {
 					buf.WriteString(fmt.Sprintf("    Message: %s\n", issue.Message))
 				}
@@ -130,13 +210,16 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>KodeVibe Scan Report</title>
+    {{if .Standalone}}<meta http-equiv="Content-Security-Policy" content="{{.Standalone.CSP}}">
+    {{end}}<title>KodeVibe Scan Report</title>
     <style>
         body { font-family: -apple-system, BlinkMacSystemFont, sans-serif; margin: 0; padding: 20px; background: #f5f5f5; }
         .container { max-width: 1200px; margin: 0 auto; background: white; border-radius: 8px; padding: 20px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
         .header { border-bottom: 2px solid #e1e5e9; padding-bottom: 20px; margin-bottom: 20px; }
         .title { color: #0969da; font-size: 28px; margin: 0; }
         .subtitle { color: #656d76; margin: 5px 0 0 0; }
+        .provenance { display: grid; grid-template-columns: max-content 1fr; gap: 2px 12px; margin: 10px 0 0 0; color: #656d76; font-size: 12px; }
+        .provenance dd { margin: 0; font-family: monospace; word-break: break-all; }
         .summary { display: grid; grid-template-columns: repeat(auto-fit, minmax(200px, 1fr)); gap: 15px; margin: 20px 0; }
         .summary-card { background: #f6f8fa; border-radius: 6px; padding: 15px; }
         .summary-title { font-weight: 600; color: #24292f; margin-bottom: 8px; }
@@ -154,6 +237,7 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
         .issue-meta { font-size: 14px; color: #656d76; }
         .issue-message { margin: 8px 0; }
         .issue-fix { background: #f6f8fa; padding: 8px; border-radius: 4px; margin-top: 8px; font-size: 14px; }
+        .learn-more { color: #0969da; }
         .severity-error { border-left: 4px solid #d1242f; }
         .severity-warning { border-left: 4px solid #fb8500; }
         .severity-info { border-left: 4px solid #0969da; }
@@ -162,13 +246,17 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
         .grade-c { color: #fb8500; }
         .grade-d { color: #f85149; }
         .grade-f { color: #d1242f; }
+        .charts { display: flex; flex-wrap: wrap; gap: 30px; margin: 20px 0; }
+        .filters { margin: 20px 0; }
+        .filters button { border: 1px solid #d1d9e0; background: #f6f8fa; border-radius: 6px; padding: 6px 12px; cursor: pointer; }
     </style>
 </head>
 <body>
     <div class="container">
         <div class="header">
             <h1 class="title">🌊 KodeVibe Scan Report</h1>
-            <p class="subtitle">Scan ID: {{.ID}} | Generated: {{.StartTime.Format "2006-01-02 15:04:05 UTC"}}</p>
+            <p class="subtitle">Scan ID: {{.ID}} | Generated: {{.StartTime.Format "2006-01-02 15:04:05 UTC"}}{{if .Standalone}}{{if .ReproducibilityHash}} | Hash: {{.ReproducibilityHash}}{{end}}{{end}}</p>
+            {{if .Provenance}}<dl class="provenance">{{range .Provenance}}<dt>{{index . 0}}</dt><dd>{{index . 1}}</dd>{{end}}</dl>{{end}}
         </div>
 
         <div class="summary">
@@ -186,7 +274,7 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
             </div>
             <div class="summary-card">
                 <div class="summary-title">Score</div>
-                <div class="summary-value grade-{{.Summary.Grade | lower}}">{{printf "%.1f" .Summary.Score}} ({{.Summary.Grade}})</div>
+                <div class="summary-value grade-{{.Summary.Grade | gradeClass}}">{{printf "%.1f" .Summary.Score}} ({{.Summary.Grade}})</div>
             </div>
         </div>
 
@@ -205,15 +293,26 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
             </div>
         </div>
 
+        {{if .Standalone}}
+        <div class="charts">{{.Standalone.SeverityChart}}{{.Standalone.TypeChart}}</div>
+        <div class="filters" id="filters" hidden>
+            <button data-filter="all">All</button>
+            <button data-filter="error">Errors</button>
+            <button data-filter="warning">Warnings</button>
+            <button data-filter="info">Info</button>
+            <button id="download-data">Download scan data (JSON)</button>
+        </div>
+        {{end}}
+
         {{if .Issues}}
         <div class="issues">
             {{range $vibeType, $issues := .IssuesByType}}
             <div class="vibe-section">
                 <div class="vibe-header">{{$vibeType}} ({{len $issues}} issues)</div>
                 {{range $issues}}
-                <div class="issue severity-{{.Severity}}">
+                <div class="issue severity-{{.Severity}}" data-severity="{{.Severity}}">
                     <div class="issue-title">{{.Title}}</div>
-                    <div class="issue-meta">{{.File}}:{{.Line}} | Rule: {{.Rule}} | Severity: {{.Severity}}</div>
+                    <div class="issue-meta">{{.File}}:{{.Line}} | Rule: {{.Rule}}{{if .CWE}} | {{.CWE}}{{end}}{{if .Category}} | Category: {{.Category}}{{end}} | Severity: {{.Severity}}{{with learnMore .}} | <a class="learn-more" href="{{.}}" target="_blank" rel="noopener">Learn more</a>{{end}}</div>
                     {{if .Message}}<div class="issue-message">{{.Message}}</div>{{end}}
                     {{if .FixSuggestion}}<div class="issue-fix"><strong>Fix:</strong> {{.FixSuggestion}}</div>{{end}}
                 </div>
@@ -223,6 +322,10 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
         </div>
         {{end}}
     </div>
+    {{if .Standalone}}
+    <script type="application/json" id="kodevibe-data">{{.Standalone.Data}}</script>
+    <script>{{.Standalone.Script}}</script>
+    {{end}}
 </body>
 </html>`
 
@@ -235,13 +338,28 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
 	data := struct {
 		*models.ScanResult
 		IssuesByType map[models.VibeType][]models.Issue
+		Standalone   *standaloneData
+		Provenance   [][2]string
 	}{
 		ScanResult:   result,
 		IssuesByType: issuesByType,
+		Provenance:   provenanceFields(result.Provenance),
+	}
+	if r.standalone() {
+		standalone, err := newStandaloneData(result)
+		if err != nil {
+			return "", err
+		}
+		data.Standalone = standalone
 	}
 
+	catalog := ruleCatalog()
 	funcMap := template.FuncMap{
-		"lower": strings.ToLower,
+		"lower":      strings.ToLower,
+		"gradeClass": gradeClass,
+		"learnMore": func(issue models.Issue) string {
+			return learnMoreURL(r.ruleHelpTemplate(), catalog, issue)
+		},
 	}
 
 	t, err := template.New("report").Funcs(funcMap).Parse(tmpl)
@@ -257,9 +375,131 @@ func (r *Reporter) generateHTMLReport(result *models.ScanResult) (string, error)
 	return buf.String(), nil
 }
 
+// withEmptyCollections returns result with empty rather than nil issues,
+// files and summary breakdowns, so a clean scan still gives complete
+// documents, e.g. "issues": [] rather than null in JSON
+func withEmptyCollections(result *models.ScanResult) *models.ScanResult {
+	if result.Issues != nil && result.Files != nil && result.Summary.IssuesByType != nil &&
+		result.Summary.IssuesBySeverity != nil && result.Summary.TopIssues != nil {
+		return result
+	}
+	complete := *result
+	if complete.Issues == nil {
+		complete.Issues = []models.Issue{}
+	}
+	if complete.Files == nil {
+		complete.Files = []string{}
+	}
+	if complete.Summary.IssuesByType == nil {
+		complete.Summary.IssuesByType = map[models.VibeType]int{}
+	}
+	if complete.Summary.IssuesBySeverity == nil {
+		complete.Summary.IssuesBySeverity = map[models.SeverityLevel]int{}
+	}
+	if complete.Summary.TopIssues == nil {
+		complete.Summary.TopIssues = []string{}
+	}
+	return &complete
+}
+
+// xmlReport is the document of XML reports
+type xmlReport struct {
+	XMLName      xml.Name   `xml:"scan_result"`
+	ScanID       string     `xml:"scan_id,attr"`
+	StartTime    string     `xml:"start_time"`
+	Duration     string     `xml:"duration"`
+	ProjectPath  string     `xml:"project_path"`
+	Commit       string     `xml:"commit,omitempty"`
+	FilesScanned int        `xml:"files_scanned"`
+	FilesSkipped int        `xml:"files_skipped"`
+	Summary      xmlSummary `xml:"summary"`
+	// Issues is always written, as <issues/> when the scan found nothing
+	Issues struct {
+		Issue []xmlIssue `xml:"issue"`
+	} `xml:"issues"`
+}
+
+type xmlSummary struct {
+	TotalIssues      int        `xml:"total_issues"`
+	ErrorIssues      int        `xml:"error_issues"`
+	WarningIssues    int        `xml:"warning_issues"`
+	InfoIssues       int        `xml:"info_issues"`
+	Score            float64    `xml:"score"`
+	Grade            string     `xml:"grade"`
+	IssuesByType     []xmlCount `xml:"issues_by_type>count"`
+	IssuesBySeverity []xmlCount `xml:"issues_by_severity>count"`
+}
+
+type xmlCount struct {
+	Key   string `xml:"key,attr"`
+	Value int    `xml:",chardata"`
+}
+
+type xmlIssue struct {
+	ID            string  `xml:"id,attr,omitempty"`
+	Type          string  `xml:"type"`
+	Severity      string  `xml:"severity"`
+	Category      string  `xml:"category,omitempty"`
+	Rule          string  `xml:"rule"`
+	Title         string  `xml:"title"`
+	Message       string  `xml:"message"`
+	File          string  `xml:"file"`
+	Line          int     `xml:"line"`
+	Column        int     `xml:"column"`
+	FixSuggestion string  `xml:"fix_suggestion,omitempty"`
+	Confidence    float64 `xml:"confidence"`
+}
+
+// xmlCounts lists counts sorted by key, as XML has no maps
+func xmlCounts[K ~string](counts map[K]int) []xmlCount {
+	list := make([]xmlCount, 0, len(counts))
+	for key, value := range counts {
+		list = append(list, xmlCount{Key: string(key), Value: value})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
+
 // generateXMLReport generates an XML report
 func (r *Reporter) generateXMLReport(result *models.ScanResult) (string, error) {
-	data, err := xml.MarshalIndent(result, "", "  ")
+	report := xmlReport{
+		ScanID:       result.ID,
+		StartTime:    result.StartTime.Format(time.RFC3339),
+		Duration:     result.Duration.String(),
+		ProjectPath:  result.ProjectPath,
+		Commit:       result.Commit,
+		FilesScanned: result.FilesScanned,
+		FilesSkipped: result.FilesSkipped,
+		Summary: xmlSummary{
+			TotalIssues:      result.Summary.TotalIssues,
+			ErrorIssues:      result.Summary.ErrorIssues,
+			WarningIssues:    result.Summary.WarningIssues,
+			InfoIssues:       result.Summary.InfoIssues,
+			Score:            result.Summary.Score,
+			Grade:            result.Summary.Grade,
+			IssuesByType:     xmlCounts(result.Summary.IssuesByType),
+			IssuesBySeverity: xmlCounts(result.Summary.IssuesBySeverity),
+		},
+	}
+	report.Issues.Issue = make([]xmlIssue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		report.Issues.Issue = append(report.Issues.Issue, xmlIssue{
+			ID:            issue.ID,
+			Type:          string(issue.Type),
+			Severity:      string(issue.Severity),
+			Category:      issue.Category,
+			Rule:          issue.Rule,
+			Title:         issue.Title,
+			Message:       issue.Message,
+			File:          issue.File,
+			Line:          issue.Line,
+			Column:        issue.Column,
+			FixSuggestion: issue.FixSuggestion,
+			Confidence:    issue.Confidence,
+		})
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal XML: %w", err)
 	}
@@ -279,13 +519,19 @@ func (r *Reporter) generateJUnitReport(result *models.ScanResult) (string, error
 		} `xml:"failure,omitempty"`
 	}
 
+	type JUnitProperty struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+	}
+
 	type JUnitTestSuite struct {
-		XMLName  xml.Name        `xml:"testsuite"`
-		Name     string          `xml:"name,attr"`
-		Tests    int             `xml:"tests,attr"`
-		Failures int             `xml:"failures,attr"`
-		Time     string          `xml:"time,attr"`
-		TestCase []JUnitTestCase `xml:"testcase"`
+		XMLName    xml.Name        `xml:"testsuite"`
+		Name       string          `xml:"name,attr"`
+		Tests      int             `xml:"tests,attr"`
+		Failures   int             `xml:"failures,attr"`
+		Time       string          `xml:"time,attr"`
+		Properties []JUnitProperty `xml:"properties>property,omitempty"`
+		TestCase   []JUnitTestCase `xml:"testcase"`
 	}
 
 	var testCases []JUnitTestCase
@@ -319,6 +565,9 @@ func (r *Reporter) generateJUnitReport(result *models.ScanResult) (string, error
 		Time:     fmt.Sprintf("%.3f", result.Duration.Seconds()),
 		TestCase: testCases,
 	}
+	for _, field := range provenanceFields(result.Provenance) {
+		suite.Properties = append(suite.Properties, JUnitProperty{Name: field[0], Value: field[1]})
+	}
 
 	data, err := xml.MarshalIndent(suite, "", "  ")
 	if err != nil {
@@ -328,30 +577,6 @@ func (r *Reporter) generateJUnitReport(result *models.ScanResult) (string, error
 	return xml.Header + string(data), nil
 }
 
-// generateCSVReport generates a CSV report
-func (r *Reporter) generateCSVReport(result *models.ScanResult) (string, error) {
-	var buf bytes.Buffer
-
-	// Header
-	buf.WriteString("Type,Severity,Rule,File,Line,Title,Message,Fix Suggestion\n")
-
-	// Issues
-	for _, issue := range result.Issues {
-		buf.WriteString(fmt.Sprintf("%s,%s,%s,%s,%d,\"%s\",\"%s\",\"%s\"\n",
-			issue.Type,
-			issue.Severity,
-			issue.Rule,
-			issue.File,
-			issue.Line,
-			strings.ReplaceAll(issue.Title, "\"", "\"\""),
-			strings.ReplaceAll(issue.Message, "\"", "\"\""),
-			strings.ReplaceAll(issue.FixSuggestion, "\"", "\"\""),
-		))
-	}
-
-	return buf.String(), nil
-}
-
 // getSeverityIcon returns an icon for the severity level
 func (r *Reporter) getSeverityIcon(severity models.SeverityLevel) string {
 	switch severity {
@@ -365,3 +590,52 @@ func (r *Reporter) getSeverityIcon(severity models.SeverityLevel) string {
 		return "🔍"
 	}
 }
+
+// gradeClass returns the CSS class suffix for a grade, ignoring +/- modifiers
+func gradeClass(grade string) string {
+	grade = strings.TrimRight(grade, "+-")
+	if grade == "" {
+		return "f"
+	}
+	return strings.ToLower(grade[:1])
+}
+
+// keysByCount orders the keys of counts (owners, rules) by count, most first, then by name
+func keysByCount(counts map[string]int) []string {
+	owners := make([]string, 0, len(counts))
+	for owner := range counts {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if counts[owners[i]] != counts[owners[j]] {
+			return counts[owners[i]] > counts[owners[j]]
+		}
+		return owners[i] < owners[j]
+	})
+	return owners
+}
+
+// ruleCounts lists counts by rule, most first, as "rule: n, rule: n"
+func ruleCounts(counts map[string]int) string {
+	var parts []string
+	for _, rule := range keysByCount(counts) {
+		parts = append(parts, fmt.Sprintf("%s: %d", rule, counts[rule]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// exemptionNote says how an exempted issue's function was exempted
+func exemptionNote(issue models.Issue) string {
+	exemption, ok := issue.Metadata[vibes.ExemptionKey].(vibes.Exemption)
+	if !ok {
+		return "exempted"
+	}
+	switch {
+	case exemption.Reason != "":
+		return fmt.Sprintf("%s in %s: %s", exemption.By, exemption.Function, exemption.Reason)
+	case exemption.Pattern != "":
+		return fmt.Sprintf("%s %q matches %s", exemption.By, exemption.Pattern, exemption.Function)
+	default:
+		return fmt.Sprintf("%s in %s", exemption.By, exemption.Function)
+	}
+}
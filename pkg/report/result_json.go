@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"kodevibe/internal/models"
+)
+
+// ErrIncompatibleResult is wrapped by the errors ReadScanResultJSON returns
+// for results whose layout this build can't load, so callers can tell them
+// apart from unreadable input
+var ErrIncompatibleResult = errors.New("incompatible scan result")
+
+// ReadScanResultJSON reads a scan result saved with --format json. Results
+// from a newer schema version, or whose fields no longer have the types this
+// build expects, are rejected with an error wrapping ErrIncompatibleResult.
+func ReadScanResultJSON(r io.Reader) (*models.ScanResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return nil, fmt.Errorf("expected a scan result object, not a list: save issue lists as .ndjson")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, ok := fields["issues"]; !ok {
+		return nil, fmt.Errorf("%w: no issues field, so this is not a KodeVibe scan result", ErrIncompatibleResult)
+	}
+
+	var version int
+	if raw, ok := fields["schema_version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("%w: schema_version must be a number", ErrIncompatibleResult)
+		}
+	}
+	if version > models.ScanResultSchemaVersion {
+		return nil, fmt.Errorf("%w: schema version %d is newer than the %d this KodeVibe reads; upgrade KodeVibe", ErrIncompatibleResult, version, models.ScanResultSchemaVersion)
+	}
+
+	var result models.ScanResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("%w: field %s is a JSON %s where schema version %d expects %s; re-run kodevibe scan to regenerate it",
+				ErrIncompatibleResult, typeErr.Field, typeErr.Value, models.ScanResultSchemaVersion, jsonKind(typeErr.Type))
+		}
+		return nil, fmt.Errorf("invalid scan result: %w", err)
+	}
+
+	for i, issue := range result.Issues {
+		switch issue.Severity {
+		case models.SeverityCritical, models.SeverityError, models.SeverityWarning, models.SeverityInfo:
+		default:
+			return nil, fmt.Errorf("%w: issue %d has severity %q; re-run kodevibe scan to regenerate it", ErrIncompatibleResult, i+1, issue.Severity)
+		}
+	}
+	result.SchemaVersion = models.ScanResultSchemaVersion
+
+	return &result, nil
+}
+
+// jsonKind names the JSON value a Go type decodes from
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	default:
+		return t.String()
+	}
+}
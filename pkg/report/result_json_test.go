@@ -0,0 +1,70 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReadScanResultJSON_RoundTrip(t *testing.T) {
+	result := &models.ScanResult{
+		SchemaVersion: models.ScanResultSchemaVersion,
+		ScanID:        "scan-1",
+		StartTime:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Duration:      2 * time.Second,
+		FilesScanned:  3,
+		Issues: []models.Issue{
+			{ID: "1", Type: models.VibeTypeSecurity, Severity: models.SeverityError, Message: "Hardcoded secret", File: "a.go", Line: 3, Rule: "secret"},
+		},
+		Summary: models.ScanSummary{TotalIssues: 1, ErrorIssues: 1, InlineSuppressed: 2},
+	}
+	data, err := NewReporter(&models.Configuration{}).Generate(result, "json")
+	require.NoError(t, err)
+
+	loaded, err := ReadScanResultJSON(strings.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "scan-1", loaded.ScanID)
+	assert.Equal(t, 2*time.Second, loaded.Duration)
+	assert.Equal(t, 3, loaded.FilesScanned)
+	require.Len(t, loaded.Issues, 1)
+	assert.Equal(t, "a.go", loaded.Issues[0].File)
+	assert.Equal(t, 2, loaded.Summary.InlineSuppressed)
+}
+
+func TestReadScanResultJSON_Unversioned(t *testing.T) {
+	loaded, err := ReadScanResultJSON(strings.NewReader(`{"scan_id":"old","issues":[{"severity":"warning","message":"m","file":"b.js"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, models.ScanResultSchemaVersion, loaded.SchemaVersion)
+	assert.Len(t, loaded.Issues, 1)
+}
+
+func TestReadScanResultJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		incompatible bool
+		contains     string
+	}{
+		{name: "not JSON", input: `{"issues": [`, contains: "invalid JSON"},
+		{name: "issue list", input: `[{"file":"a.go"}]`, contains: ".ndjson"},
+		{name: "not a scan result", input: `{"name":"x"}`, incompatible: true, contains: "no issues field"},
+		{name: "newer schema", input: `{"schema_version":99,"issues":[]}`, incompatible: true, contains: "upgrade KodeVibe"},
+		{name: "changed field type", input: `{"issues":{"a.go":[]}}`, incompatible: true, contains: "field issues is a JSON object where schema version 1 expects an array"},
+		{name: "old severity", input: `{"issues":[{"severity":"high","file":"a.go"}]}`, incompatible: true, contains: `severity "high"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadScanResultJSON(strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.contains)
+			assert.Equal(t, tt.incompatible, errors.Is(err, ErrIncompatibleResult))
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// DefaultRuleHelpURL documents every built-in rule; {rule} and {vibe} are replaced
+	DefaultRuleHelpURL = "https://github.com/KooshaPari/KodeVibe-Go/blob/main/docs/rules.md#{rule}"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool           `json:"tool"`
+	Results    []sarifResult       `json:"results"`
+	Properties *sarifRunProperties `json:"properties,omitempty"`
+}
+
+// sarifRunProperties carries the scan's provenance in the run's property bag
+type sarifRunProperties struct {
+	Provenance *models.Provenance `json:"provenance"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifProperties    `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifProperties struct {
+	Tags             []string `json:"tags,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+}
+
+// sarifFingerprintKey names the fingerprint in partialFingerprints; bump its
+// version if Issue.Fingerprint changes
+const sarifFingerprintKey = "kodevibeFingerprint/v1"
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	// PartialFingerprints lets code scanning track a finding across commits
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// generateSARIFReport generates a SARIF 2.1.0 log for code scanning tools such as
+// GitHub code scanning. Rules carry a help URI, tags derived from the vibe and
+// category, and a default level so results can be filtered and categorised.
+func (r *Reporter) generateSARIFReport(result *models.ScanResult) (string, error) {
+	catalog := ruleCatalog()
+
+	rules := []sarifRule{}
+	ruleIndex := make(map[string]int)
+	results := make([]sarifResult, 0, len(result.Issues))
+
+	for _, issue := range result.Issues {
+		ruleID := issue.Rule
+		if ruleID == "" {
+			ruleID = string(issue.Type)
+		}
+
+		index, exists := ruleIndex[ruleID]
+		if !exists {
+			index = len(rules)
+			ruleIndex[ruleID] = index
+			rules = append(rules, r.sarifRuleFor(ruleID, issue, catalog))
+		}
+
+		message := issue.Message
+		if message == "" {
+			message = issue.Title
+		}
+
+		uri := sarifURI(issue.File, result.ProjectPath)
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: uri},
+		}
+		// Fingerprint the repository-relative path so checkouts in different
+		// directories agree
+		fingerprinted := issue
+		fingerprinted.File = uri
+		if issue.Line > 0 {
+			location.Region = &sarifRegion{StartLine: issue.Line, StartColumn: issue.Column}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: index,
+			Level:     sarifLevel(issue.Severity),
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+			PartialFingerprints: map[string]string{
+				sarifFingerprintKey: fingerprinted.Fingerprint(),
+			},
+		})
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "KodeVibe",
+			InformationURI: "https://github.com/KooshaPari/KodeVibe-Go",
+			Rules:          rules,
+		}},
+		Results: results,
+	}
+	if result.Provenance != nil {
+		run.Tool.Driver.Version = result.Provenance.ToolVersion
+		run.Properties = &sarifRunProperties{Provenance: result.Provenance}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal SARIF: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// sarifRuleFor describes a rule from the built-in catalog, falling back to the
+// issue itself for custom rules
+func (r *Reporter) sarifRuleFor(ruleID string, issue models.Issue, catalog map[string]vibes.RuleInfo) sarifRule {
+	info, known := catalog[ruleID]
+	if !known {
+		info = vibes.RuleInfo{ID: ruleID, Vibe: issue.Type, Title: issue.Title, Severity: issue.Severity}
+	}
+	if info.Vibe == "" {
+		info.Vibe = issue.Type
+	}
+
+	title := info.Title
+	if title == "" {
+		title = ruleID
+	}
+
+	rule := sarifRule{
+		ID:                   ruleID,
+		Name:                 sarifRuleName(ruleID),
+		ShortDescription:     sarifMessage{Text: title},
+		HelpURI:              r.ruleHelpURL(info.Vibe, ruleID),
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(info.Severity)},
+		Properties:           sarifProperties{Tags: sarifTags(info.Vibe, issue.Category)},
+	}
+	// GitHub code scanning shows CWE tags in this form
+	cwe := issue.CWE
+	if cwe == "" {
+		cwe = info.CWE
+	}
+	if cwe != "" {
+		rule.Properties.Tags = append(rule.Properties.Tags, "external/cwe/"+strings.ToLower(cwe))
+	}
+	if info.Description != "" {
+		rule.FullDescription = &sarifMessage{Text: info.Description}
+	}
+	if info.Vibe == models.VibeTypeSecurity || issue.Category == models.CategorySecurity {
+		rule.Properties.SecuritySeverity = securitySeverity(info.Severity)
+	}
+
+	return rule
+}
+
+// ruleHelpURL expands the configured help URL template for a rule
+func (r *Reporter) ruleHelpURL(vibeType models.VibeType, ruleID string) string {
+	return expandRuleHelpURL(r.ruleHelpTemplate(), vibeType, ruleID)
+}
+
+// sarifTags derives code scanning tags from the vibe and category
+func sarifTags(vibeType models.VibeType, category string) []string {
+	var tags []string
+	for _, tag := range []string{string(vibeType), category} {
+		if tag != "" && (len(tags) == 0 || tags[0] != tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if vibeType != models.VibeTypeSecurity && category != models.CategorySecurity {
+		tags = append(tags, "quality")
+	}
+	return tags
+}
+
+// sarifLevel maps severity to a SARIF result level
+func sarifLevel(severity models.SeverityLevel) string {
+	switch severity {
+	case models.SeverityCritical, models.SeverityError:
+		return "error"
+	case models.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// securitySeverity is the CVSS-style score GitHub uses to rank security alerts
+func securitySeverity(severity models.SeverityLevel) string {
+	switch severity {
+	case models.SeverityCritical:
+		return "9.0"
+	case models.SeverityError:
+		return "7.0"
+	case models.SeverityWarning:
+		return "5.0"
+	default:
+		return "2.0"
+	}
+}
+
+// sarifRuleName turns a rule ID like no-console-log into NoConsoleLog
+func sarifRuleName(ruleID string) string {
+	var name strings.Builder
+	for _, word := range strings.FieldsFunc(ruleID, func(r rune) bool { return r == '-' || r == '_' }) {
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return name.String()
+}
+
+// sarifURI returns a forward-slash path, relative to the project when possible
+func sarifURI(file, projectPath string) string {
+	if projectPath != "" && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(projectPath, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+func TestReporter_GenerateSARIF(t *testing.T) {
+	result := &models.ScanResult{
+		ProjectPath: "/work/repo",
+		Issues: []models.Issue{
+			{Type: models.VibeTypeSecurity, Category: models.CategorySecurity, Severity: models.SeverityError, Rule: "hardcoded-credentials", Message: "Hardcoded password", File: "/work/repo/config/db.go", Line: 4, Column: 2},
+			{Type: models.VibeTypeCode, Category: models.CategoryBestPractices, Severity: models.SeverityWarning, Rule: "no-var", Message: "Use let", File: "web/app.js", Line: 9},
+			{Type: models.VibeTypeCode, Category: models.CategoryBestPractices, Severity: models.SeverityWarning, Rule: "no-var", Message: "Use const", File: "web/app.js", Line: 12},
+			{Type: models.VibeTypeCode, Severity: models.SeverityInfo, Rule: "team-custom-rule", Title: "Custom check", File: "a.go", CWE: "CWE-20"},
+		},
+	}
+
+	output, err := NewReporter(&models.Configuration{}).Generate(result, "sarif")
+	require.NoError(t, err)
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal([]byte(output), &log))
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	require.Len(t, run.Tool.Driver.Rules, 3, "rules are deduplicated")
+	credentials := run.Tool.Driver.Rules[0]
+	assert.Equal(t, "hardcoded-credentials", credentials.ID)
+	assert.Equal(t, "error", credentials.DefaultConfiguration.Level)
+	assert.Equal(t, []string{"security", "external/cwe/cwe-798"}, credentials.Properties.Tags)
+	assert.Equal(t, "7.0", credentials.Properties.SecuritySeverity)
+	assert.Equal(t, "https://github.com/KooshaPari/KodeVibe-Go/blob/main/docs/rules.md#hardcoded-credentials", credentials.HelpURI)
+
+	noVar := run.Tool.Driver.Rules[1]
+	assert.Equal(t, []string{"code", "best-practices", "quality"}, noVar.Properties.Tags)
+	assert.Empty(t, noVar.Properties.SecuritySeverity)
+	assert.Equal(t, "Custom check", run.Tool.Driver.Rules[2].ShortDescription.Text)
+	assert.Contains(t, run.Tool.Driver.Rules[2].Properties.Tags, "external/cwe/cwe-20", "an issue's own CWE is tagged")
+
+	require.Len(t, run.Results, 4)
+	assert.Equal(t, "config/db.go", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 1, run.Results[2].RuleIndex)
+	assert.Equal(t, "note", run.Results[3].Level)
+	assert.Nil(t, run.Results[3].Locations[0].PhysicalLocation.Region)
+
+	fingerprint := run.Results[0].PartialFingerprints[sarifFingerprintKey]
+	assert.Len(t, fingerprint, 64)
+	assert.NotEqual(t, fingerprint, run.Results[1].PartialFingerprints[sarifFingerprintKey])
+}
+
+func TestReporter_ruleHelpURL(t *testing.T) {
+	reporter := NewReporter(&models.Configuration{Reporting: models.ReportingConfig{RuleHelpURL: "https://docs.example.com/{vibe}/{rule}.html"}})
+	assert.Equal(t, "https://docs.example.com/code/no-var.html", reporter.ruleHelpURL(models.VibeTypeCode, "no-var"))
+
+	reporter = NewReporter(&models.Configuration{Reporting: models.ReportingConfig{RuleHelpURL: "https://docs.example.com/rules/"}})
+	assert.Equal(t, "https://docs.example.com/rules/no-var", reporter.ruleHelpURL(models.VibeTypeCode, "no-var"))
+}
+
+// The default SARIF help URI points into docs/rules.md, so every built-in rule needs a section there
+func TestRulesDocCoversCatalog(t *testing.T) {
+	doc, err := os.ReadFile("../../docs/rules.md")
+	require.NoError(t, err)
+
+	for _, checker := range vibes.BuiltinCheckers() {
+		for _, rule := range checker.Rules() {
+			assert.True(t, strings.Contains(string(doc), "\n### "+rule.ID+"\n"), "docs/rules.md has no section for %s", rule.ID)
+			if cwe := vibes.RuleCWE(rule.ID); cwe != "" {
+				assert.Contains(t, string(doc), "\n### "+rule.ID+"\n\n**"+rule.Title+"** (default severity: "+string(rule.Severity)+", "+cwe+")", "docs/rules.md does not list the CWE of %s", rule.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"sort"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// standaloneCSP blocks every external request a standalone report could make
+const standaloneCSP = "default-src 'none'; style-src 'unsafe-inline'; script-src 'unsafe-inline'; img-src data:"
+
+// standaloneScript enables severity filtering and downloading the embedded
+// scan data; without JavaScript the report still renders in full
+const standaloneScript = `(function () {
+  var filters = document.getElementById('filters');
+  filters.hidden = false;
+  filters.addEventListener('click', function (event) {
+    var severity = event.target.getAttribute('data-filter');
+    if (severity === null) return;
+    document.querySelectorAll('.issue').forEach(function (issue) {
+      issue.hidden = severity !== 'all' && issue.getAttribute('data-severity') !== severity;
+    });
+  });
+  document.getElementById('download-data').addEventListener('click', function () {
+    var data = document.getElementById('kodevibe-data').textContent;
+    var link = document.createElement('a');
+    link.href = URL.createObjectURL(new Blob([data], {type: 'application/json'}));
+    link.download = 'kodevibe-result.json';
+    link.click();
+  });
+})();`
+
+// standaloneData is what a standalone HTML report adds to the regular one
+type standaloneData struct {
+	CSP           string
+	Data          template.JS
+	Script        template.JS
+	SeverityChart template.HTML
+	TypeChart     template.HTML
+}
+
+// standalone reports whether HTML reports are single files with the scan
+// data embedded and charts pre-rendered
+func (r *Reporter) standalone() bool {
+	return r.config != nil && r.config.Reporting.Standalone
+}
+
+// newStandaloneData embeds the result as JSON and pre-renders its charts as inline SVG
+func newStandaloneData(result *models.ScanResult) (*standaloneData, error) {
+	// json.Marshal escapes <, > and &, so the data cannot close its script element
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed scan data: %w", err)
+	}
+
+	bySeverity := result.Summary.IssuesBySeverity
+	var severityBars []chartBar
+	if bySeverity[models.SeverityCritical] > 0 {
+		severityBars = append(severityBars, chartBar{Label: "Critical", Value: bySeverity[models.SeverityCritical], Color: "#8b0000"})
+	}
+	severityBars = append(severityBars,
+		chartBar{Label: "Errors", Value: bySeverity[models.SeverityError], Color: "#d1242f"},
+		chartBar{Label: "Warnings", Value: bySeverity[models.SeverityWarning], Color: "#fb8500"},
+		chartBar{Label: "Info", Value: bySeverity[models.SeverityInfo], Color: "#0969da"},
+	)
+
+	counts := make(map[string]int)
+	for _, issue := range result.Issues {
+		counts[string(issue.Type)]++
+	}
+	typeBars := make([]chartBar, 0, len(counts))
+	for vibeType, count := range counts {
+		typeBars = append(typeBars, chartBar{Label: vibeType, Value: count, Color: "#8250df"})
+	}
+	sort.Slice(typeBars, func(i, j int) bool { return typeBars[i].Label < typeBars[j].Label })
+
+	return &standaloneData{
+		CSP:           standaloneCSP,
+		Data:          template.JS(data),
+		Script:        template.JS(standaloneScript),
+		SeverityChart: barChartSVG("Issues by severity", severityBars),
+		TypeChart:     barChartSVG("Issues by vibe", typeBars),
+	}, nil
+}
+
+// chartBar is one bar of a pre-rendered chart
+type chartBar struct {
+	Label string
+	Value int
+	Color string
+}
+
+// barChartSVG renders a horizontal bar chart as inline SVG, so it shows
+// without JavaScript or any external resource
+func barChartSVG(title string, bars []chartBar) template.HTML {
+	const (
+		width      = 420
+		labelWidth = 110
+		valueWidth = 50
+		rowHeight  = 26
+		top        = 30
+	)
+
+	max := 0
+	for _, bar := range bars {
+		if bar.Value > max {
+			max = bar.Value
+		}
+	}
+	height := top + rowHeight*len(bars) + 10
+	if len(bars) == 0 {
+		height = top + rowHeight
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg class="chart" xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s">`,
+		width, height, width, height, html.EscapeString(title))
+	fmt.Fprintf(&svg, `<text x="0" y="18" font-size="14" font-weight="600" fill="#24292f">%s</text>`, html.EscapeString(title))
+	if len(bars) == 0 {
+		fmt.Fprintf(&svg, `<text x="0" y="%d" font-size="13" fill="#656d76">No issues</text>`, top+16)
+	}
+	for i, bar := range bars {
+		y := top + i*rowHeight
+		length := 0
+		if max > 0 {
+			length = bar.Value * (width - labelWidth - valueWidth) / max
+		}
+		fmt.Fprintf(&svg, `<text x="0" y="%d" font-size="13" fill="#24292f">%s</text>`, y+15, html.EscapeString(bar.Label))
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="18" rx="3" fill="%s"/>`, labelWidth, y+2, length, bar.Color)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" font-size="13" fill="#24292f">%d</text>`, labelWidth+length+6, y+15, bar.Value)
+	}
+	svg.WriteString(`</svg>`)
+
+	// The SVG is built only from escaped labels and numbers
+	return template.HTML(svg.String())
+}
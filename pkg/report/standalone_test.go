@@ -0,0 +1,66 @@
+package report
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestReporter_StandaloneHTML(t *testing.T) {
+	result := &models.ScanResult{
+		ID: "scan-1",
+		Issues: []models.Issue{
+			{Type: models.VibeTypeSecurity, Rule: "xss", File: "app.js", Line: 2, Severity: models.SeverityError,
+				Title: "XSS", Message: `innerHTML = "</script><script>alert(1)</script>"`},
+			{Type: models.VibeTypeCode, Rule: "todo-comments", File: "app.js", Line: 5, Severity: models.SeverityInfo, Title: "TODO"},
+		},
+		Summary: models.ScanSummary{TotalIssues: 2, ErrorIssues: 1, InfoIssues: 1,
+			IssuesBySeverity: map[models.SeverityLevel]int{models.SeverityError: 1, models.SeverityInfo: 1}},
+		ReproducibilityHash: "abc123",
+	}
+
+	plain, err := NewReporter(&models.Configuration{}).Generate(result, "html")
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "kodevibe-data")
+	assert.NotContains(t, plain, "<svg")
+
+	config := &models.Configuration{}
+	config.Reporting.Standalone = true
+	output, err := NewReporter(config).Generate(result, "html")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, `Content-Security-Policy`)
+	assert.Contains(t, output, "Hash: abc123")
+	assert.NotRegexp(t, `src="(https?:)?//`, output, "nothing is loaded from elsewhere")
+	assert.NotRegexp(t, `<(link|img)\b`, output, "no sidecar files")
+	assert.Contains(t, output, `aria-label="Issues by severity"`)
+	assert.Contains(t, output, `aria-label="Issues by vibe"`)
+	assert.Contains(t, output, `data-severity="error"`)
+	assert.NotContains(t, output, ">Critical<", "no critical bar without critical issues")
+
+	// The embedded data is the full result, and cannot end its script element early
+	data := regexp.MustCompile(`(?s)<script type="application/json" id="kodevibe-data">(.*?)</script>`).FindStringSubmatch(output)
+	require.Len(t, data, 2)
+	var embedded models.ScanResult
+	require.NoError(t, json.Unmarshal([]byte(data[1]), &embedded))
+	assert.Equal(t, result.Issues[0].Message, embedded.Issues[0].Message)
+	assert.Len(t, embedded.Issues, 2)
+}
+
+func TestBarChartSVG(t *testing.T) {
+	svg := string(barChartSVG("Issues <by> vibe", []chartBar{
+		{Label: "a&b", Value: 4, Color: "#000"},
+		{Label: "c", Value: 2, Color: "#000"},
+	}))
+	assert.Contains(t, svg, "Issues &lt;by&gt; vibe")
+	assert.Contains(t, svg, ">a&amp;b<")
+	assert.Contains(t, svg, `width="260"`, "the largest bar fills the chart")
+	assert.Contains(t, svg, `width="130"`)
+
+	assert.Contains(t, string(barChartSVG("Empty", nil)), "No issues")
+}
@@ -0,0 +1,235 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kodevibe/internal/models"
+)
+
+// ErrReportNotFound is returned when the store holds no report for a hash and format
+var ErrReportNotFound = errors.New("report not found")
+
+// validReportHash matches the SHA-256 hex digests used as store keys
+var validReportHash = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// storedFormats maps every format the store accepts to its file name and content type
+var storedFormats = map[string]struct {
+	file        string
+	contentType string
+}{
+	"text":       {"report.txt", "text/plain; charset=utf-8"},
+	"json":       {"report.json", "application/json"},
+	"ndjson":     {"report.ndjson", "application/x-ndjson"},
+	"html":       {"report.html", "text/html; charset=utf-8"},
+	"xml":        {"report.xml", "application/xml"},
+	"junit":      {"report.junit.xml", "application/xml"},
+	"csv":        {"report.csv", "text/csv; charset=utf-8"},
+	"sarif":      {"report.sarif", "application/sarif+json"},
+	"defectdojo": {"report.defectdojo.json", "application/json"},
+}
+
+// StoredReport describes the reports kept for one scan result
+type StoredReport struct {
+	Hash      string    `json:"hash"`
+	Formats   []string  `json:"formats"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store keeps generated reports on disk keyed by the result's reproducibility
+// hash, one directory per hash. Stored reports are immutable: saving a hash
+// and format that already exist keeps the original. Entries older than maxAge
+// or beyond the newest maxEntries are evicted on every save; zero disables a limit.
+type Store struct {
+	dir        string
+	maxAge     time.Duration
+	maxEntries int
+	mutex      sync.Mutex
+}
+
+// NewStore creates a store rooted at dir. The directory is created on the first save.
+func NewStore(dir string, maxAge time.Duration, maxEntries int) *Store {
+	return &Store{
+		dir:        dir,
+		maxAge:     maxAge,
+		maxEntries: maxEntries,
+	}
+}
+
+// ContentType returns the HTTP content type of a stored format
+func ContentType(format string) string {
+	if stored, ok := storedFormats[strings.ToLower(format)]; ok {
+		return stored.contentType
+	}
+	return "application/octet-stream"
+}
+
+// Save stores a report generated from result and returns the hash it is kept under
+func (s *Store) Save(result *models.ScanResult, format string, content []byte) (string, error) {
+	hash := result.ReproducibilityHash
+	if hash == "" {
+		hash = result.ComputeReproducibilityHash()
+	}
+
+	path, err := s.path(hash, format)
+	if err != nil {
+		return "", err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	// Write through a temporary file so readers never see a partial report
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".report-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create report file: %w", err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to store report: %w", err)
+	}
+
+	if _, err := s.evict(time.Now()); err != nil {
+		return hash, err
+	}
+
+	return hash, nil
+}
+
+// Get returns a stored report
+func (s *Store) Get(hash, format string) ([]byte, error) {
+	path, err := s.path(hash, format)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrReportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	return content, nil
+}
+
+// List returns the stored reports, newest first
+func (s *Store) List() ([]StoredReport, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.list()
+}
+
+// Evict removes reports past the store's age and count limits and returns how many were removed
+func (s *Store) Evict() (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.evict(time.Now())
+}
+
+func (s *Store) evict(now time.Time) (int, error) {
+	if s.maxAge <= 0 && s.maxEntries <= 0 {
+		return 0, nil
+	}
+
+	reports, err := s.list()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for i, stored := range reports {
+		expired := s.maxAge > 0 && now.Sub(stored.CreatedAt) > s.maxAge
+		overflow := s.maxEntries > 0 && i >= s.maxEntries
+		if !expired && !overflow {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(s.dir, stored.Hash)); err != nil {
+			return removed, fmt.Errorf("failed to evict report %s: %w", stored.Hash, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+func (s *Store) list() ([]StoredReport, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []StoredReport{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report store: %w", err)
+	}
+
+	reports := []StoredReport{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !validReportHash.MatchString(entry.Name()) {
+			continue
+		}
+
+		stored := StoredReport{Hash: entry.Name(), Formats: []string{}}
+		for format, file := range storedFormats {
+			info, err := os.Stat(filepath.Join(s.dir, entry.Name(), file.file))
+			if err != nil {
+				continue
+			}
+			stored.Formats = append(stored.Formats, format)
+			if stored.CreatedAt.IsZero() || info.ModTime().Before(stored.CreatedAt) {
+				stored.CreatedAt = info.ModTime()
+			}
+		}
+		if len(stored.Formats) == 0 {
+			continue
+		}
+		sort.Strings(stored.Formats)
+		reports = append(reports, stored)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if !reports[i].CreatedAt.Equal(reports[j].CreatedAt) {
+			return reports[i].CreatedAt.After(reports[j].CreatedAt)
+		}
+		return reports[i].Hash < reports[j].Hash
+	})
+
+	return reports, nil
+}
+
+// path validates a hash and format and returns where the report is kept
+func (s *Store) path(hash, format string) (string, error) {
+	if !validReportHash.MatchString(hash) {
+		return "", fmt.Errorf("invalid report hash %q", hash)
+	}
+	stored, ok := storedFormats[strings.ToLower(format)]
+	if !ok {
+		return "", fmt.Errorf("unsupported report format: %s", format)
+	}
+	return filepath.Join(s.dir, hash, stored.file), nil
+}
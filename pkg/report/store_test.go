@@ -0,0 +1,93 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func storeTestResult(line int) *models.ScanResult {
+	return &models.ScanResult{
+		FilesScanned: 1,
+		Issues:       []models.Issue{{Type: models.VibeTypeCode, Rule: "no-console-log", File: "app.js", Line: line, Message: "console.log"}},
+	}
+}
+
+func TestStore_SaveAndGet(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "reports"), 0, 0)
+	result := storeTestResult(1)
+
+	hash, err := store.Save(result, "html", []byte("<html>first</html>"))
+	require.NoError(t, err)
+	assert.Equal(t, result.ComputeReproducibilityHash(), hash)
+
+	content, err := store.Get(hash, "html")
+	require.NoError(t, err)
+	assert.Equal(t, "<html>first</html>", string(content))
+
+	// Reports are immutable once stored
+	_, err = store.Save(result, "html", []byte("<html>second</html>"))
+	require.NoError(t, err)
+	content, err = store.Get(hash, "html")
+	require.NoError(t, err)
+	assert.Equal(t, "<html>first</html>", string(content))
+
+	_, err = store.Save(result, "json", []byte(`{}`))
+	require.NoError(t, err)
+	reports, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, hash, reports[0].Hash)
+	assert.Equal(t, []string{"html", "json"}, reports[0].Formats)
+
+	_, err = store.Get(hash, "sarif")
+	assert.ErrorIs(t, err, ErrReportNotFound)
+	_, err = store.Get("../../etc/passwd", "html")
+	assert.ErrorContains(t, err, "invalid report hash")
+	_, err = store.Get(hash, "pdf")
+	assert.ErrorContains(t, err, "unsupported report format")
+
+	assert.Equal(t, "text/html; charset=utf-8", ContentType("html"))
+}
+
+func TestStore_Evict(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, 0, 2)
+
+	var hashes []string
+	for line := 1; line <= 3; line++ {
+		hash, err := store.Save(storeTestResult(line), "json", []byte(`{}`))
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+
+		// Give each report a distinct age, oldest first
+		when := time.Now().Add(time.Duration(line-10) * time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, hash, "report.json"), when, when))
+	}
+
+	// The third save evicted the oldest beyond max_entries
+	reports, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, hashes[2], reports[0].Hash)
+	assert.Equal(t, hashes[1], reports[1].Hash)
+
+	aged := NewStore(dir, 7*time.Hour+30*time.Minute, 0)
+	removed, err := aged.Evict()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, err = aged.Get(hashes[1], "json")
+	assert.ErrorIs(t, err, ErrReportNotFound)
+	_, err = aged.Get(hashes[2], "json")
+	assert.NoError(t, err)
+
+	empty, err := NewStore(filepath.Join(dir, "missing"), 0, 0).List()
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,113 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/ai"
+)
+
+// aiExplainLines is how many lines from the start of a flagged function are sent for explanation
+const aiExplainLines = 80
+
+// aiRequestKind decides whether an issue is worth a second opinion and what to ask
+func aiRequestKind(issue models.Issue) (ai.Kind, bool) {
+	switch {
+	case strings.HasPrefix(issue.Rule, "secret-"),
+		issue.Rule == "hardcoded-credentials",
+		issue.Rule == "high-entropy-string":
+		return ai.KindSecret, true
+	case issue.Rule == "cyclomatic-complexity",
+		issue.Rule == "function-length",
+		issue.Rule == "nesting-depth":
+		return ai.KindExplain, true
+	default:
+		return "", false
+	}
+}
+
+// reviewWithAI asks the configured AI provider about suspicious issues and
+// records its verdicts in issue metadata. Nothing is sent unless AI detection
+// is enabled, and at most ai_max_snippets requests are made per scan.
+func (s *Scanner) reviewWithAI(ctx context.Context, issues []models.Issue) {
+	if s.aiAnalyzer == nil || !s.aiAnalyzer.Enabled() {
+		return
+	}
+
+	sent := 0
+	for i := range issues {
+		if sent >= s.aiConfig.MaxSnippets || ctx.Err() != nil {
+			return
+		}
+
+		issue := &issues[i]
+		kind, ok := aiRequestKind(*issue)
+		if !ok {
+			continue
+		}
+
+		request := ai.Request{
+			Kind:    kind,
+			Rule:    issue.Rule,
+			File:    issue.File,
+			Line:    issue.Line,
+			Message: issue.Message,
+			Snippet: issue.Context,
+		}
+		if kind == ai.KindExplain {
+			if snippet, err := readLines(issue.File, issue.Line, aiExplainLines); err == nil {
+				request.Snippet = snippet
+			}
+		}
+		if request.Snippet == "" {
+			continue
+		}
+		sent++
+
+		requestCtx, cancel := context.WithTimeout(ctx, s.aiConfig.Timeout)
+		verdict, err := s.aiAnalyzer.Analyze(requestCtx, request)
+		cancel()
+
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"provider": s.aiAnalyzer.Name(),
+				"rule":     issue.Rule,
+				"file":     issue.File,
+				"error":    err.Error(),
+			}).Warn("AI review failed")
+			continue
+		}
+		if verdict == nil {
+			continue
+		}
+
+		if issue.Metadata == nil {
+			issue.Metadata = make(map[string]interface{})
+		}
+		issue.Metadata["ai_verdict"] = verdict
+	}
+}
+
+// readLines returns up to count lines of a file starting at the 1-based line start
+func readLines(path string, start, count int) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan() && len(lines) < count; lineNumber++ {
+		if lineNumber >= start {
+			lines = append(lines, scanner.Text())
+		}
+	}
+
+	return strings.Join(lines, "\n"), scanner.Err()
+}
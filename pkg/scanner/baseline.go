@@ -0,0 +1,166 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"kodevibe/internal/models"
+)
+
+// BaselineVersion is the format version of baseline files
+const BaselineVersion = 1
+
+// BaselineEntry is one fingerprint of a baseline and how many of the
+// baselined issues share it
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	// Rule and File make the baseline reviewable; matching uses the fingerprint
+	Rule  string `json:"rule"`
+	File  string `json:"file"`
+	Count int    `json:"count"`
+}
+
+// Baseline records the issues a project accepted when it adopted KodeVibe,
+// so later scans only report the issues introduced since
+type Baseline struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	Entries   []BaselineEntry `json:"entries"`
+}
+
+// BaselineFingerprint identifies an issue by its rule, its file relative to
+// root and the code it was found in with whitespace collapsed, falling back
+// to its message template. It leaves out the line number, so an issue keeps
+// its fingerprint when edits above it move it.
+func BaselineFingerprint(issue models.Issue, root string) string {
+	context := strings.Join(strings.Fields(issue.Context), " ")
+	if context == "" {
+		context = issue.Template()
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{issue.Rule, baselinePath(issue.File, root), context}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewBaseline records every issue, with files relative to root
+func NewBaseline(issues []models.Issue, root string) *Baseline {
+	entries := make(map[string]*BaselineEntry)
+	for _, issue := range issues {
+		fingerprint := BaselineFingerprint(issue, root)
+		if entry, exists := entries[fingerprint]; exists {
+			entry.Count++
+			continue
+		}
+		entries[fingerprint] = &BaselineEntry{
+			Fingerprint: fingerprint,
+			Rule:        issue.Rule,
+			File:        baselinePath(issue.File, root),
+			Count:       1,
+		}
+	}
+
+	baseline := &Baseline{Version: BaselineVersion, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	for _, entry := range entries {
+		baseline.Entries = append(baseline.Entries, *entry)
+	}
+	// Sort so regenerating an unchanged baseline gives a small diff
+	sort.Slice(baseline.Entries, func(i, j int) bool {
+		a, b := baseline.Entries[i], baseline.Entries[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return baseline
+}
+
+// LoadBaseline reads a baseline file
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("baseline %s not found; create it with --write-baseline: %w", path, err)
+		}
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version > BaselineVersion {
+		return nil, fmt.Errorf("baseline %s has version %d; this kodevibe reads up to version %d", path, baseline.Version, BaselineVersion)
+	}
+	return &baseline, nil
+}
+
+// Save writes the baseline file, creating its directory if needed
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Filter drops the baselined issues and returns the rest with the number
+// dropped. A fingerprint covers as many issues as were baselined with it, so
+// a copy of a baselined issue is still reported.
+func (b *Baseline) Filter(issues []models.Issue, root string) ([]models.Issue, int) {
+	if len(b.Entries) == 0 {
+		return issues, 0
+	}
+	remaining := make(map[string]int, len(b.Entries))
+	for _, entry := range b.Entries {
+		remaining[entry.Fingerprint] += entry.Count
+	}
+
+	kept := make([]models.Issue, 0, len(issues))
+	for _, issue := range issues {
+		fingerprint := BaselineFingerprint(issue, root)
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept, len(issues) - len(kept)
+}
+
+// baselinePath is a file relative to root with forward slashes, so baselines
+// match wherever the repository is checked out. Files outside root keep
+// their path.
+func baselinePath(file, root string) string {
+	if file == "" {
+		return ""
+	}
+	absolute, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	absoluteRoot, err := filepath.Abs(root)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	rel, err := filepath.Rel(absoluteRoot, absolute)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
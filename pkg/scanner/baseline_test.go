@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestBaseline_IssueMovedToNewLine(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "app", "main.js")
+	legacy := []models.Issue{
+		{Rule: "no-console-log", File: file, Line: 10, Context: "  console.log(user)", Message: "Remove console.log"},
+		{Rule: "no-var", File: file, Line: 12, Context: "var x = 1;", Message: "Use let or const"},
+	}
+	baseline := NewBaseline(legacy, root)
+	require.Len(t, baseline.Entries, 2)
+	assert.Equal(t, "app/main.js", baseline.Entries[0].File, "files are relative to the root")
+
+	// Lines were added above both issues, and the indentation changed
+	moved := []models.Issue{
+		{Rule: "no-console-log", File: file, Line: 25, Context: "\tconsole.log(user)", Message: "Remove console.log"},
+		{Rule: "no-var", File: file, Line: 27, Context: "var x = 1;", Message: "Use let or const"},
+		{Rule: "no-var", File: file, Line: 40, Context: "var y = 2;", Message: "Use let or const"},
+	}
+	kept, dropped := baseline.Filter(moved, root)
+	assert.Equal(t, 2, dropped)
+	require.Len(t, kept, 1, "only the issue introduced after baselining is reported")
+	assert.Equal(t, 40, kept[0].Line)
+}
+
+func TestBaseline_CountsDuplicates(t *testing.T) {
+	issue := models.Issue{Rule: "magic-number", File: "calc.go", Context: "return x * 42", Message: "Magic number 42"}
+	baseline := NewBaseline([]models.Issue{issue, issue}, ".")
+	require.Len(t, baseline.Entries, 1)
+	assert.Equal(t, 2, baseline.Entries[0].Count)
+
+	kept, dropped := baseline.Filter([]models.Issue{issue, issue, issue}, ".")
+	assert.Equal(t, 2, dropped)
+	assert.Len(t, kept, 1, "a third copy is new")
+}
+
+func TestBaselineFingerprint(t *testing.T) {
+	root := t.TempDir()
+	issue := models.Issue{Rule: "eval-usage", File: filepath.Join(root, "a.js"), Line: 3, Context: "eval(input)", Message: "Avoid eval"}
+
+	moved := issue
+	moved.Line = 30
+	assert.Equal(t, BaselineFingerprint(issue, root), BaselineFingerprint(moved, root))
+
+	checkedOutElsewhere := issue
+	other := t.TempDir()
+	checkedOutElsewhere.File = filepath.Join(other, "a.js")
+	assert.Equal(t, BaselineFingerprint(issue, root), BaselineFingerprint(checkedOutElsewhere, other))
+
+	for _, changed := range []models.Issue{
+		{Rule: "no-eval", File: issue.File, Context: issue.Context},
+		{Rule: issue.Rule, File: filepath.Join(root, "b.js"), Context: issue.Context},
+		{Rule: issue.Rule, File: issue.File, Context: "eval(other)"},
+	} {
+		assert.NotEqual(t, BaselineFingerprint(issue, root), BaselineFingerprint(changed, root))
+	}
+
+	noContext := models.Issue{Rule: "file-size", File: "big.bin", Message: "File is 12 MB"}
+	assert.Equal(t, BaselineFingerprint(noContext, "."), BaselineFingerprint(models.Issue{Rule: "file-size", File: "big.bin", Message: "File is 14 MB"}, "."),
+		"without context the message template is used")
+}
+
+func TestBaseline_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".kodevibe", "baseline.json")
+	baseline := NewBaseline([]models.Issue{{Rule: "no-var", File: "a.js", Context: "var a"}}, ".")
+	require.NoError(t, baseline.Save(path))
+
+	loaded, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, baseline.Entries, loaded.Entries)
+	assert.Equal(t, BaselineVersion, loaded.Version)
+
+	_, err = LoadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "--write-baseline")
+}
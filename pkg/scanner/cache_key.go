@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	"kodevibe/pkg/vibes"
+)
+
+// racyWindow is how long after its modification time a file's size and mtime
+// are trusted to identify its content. Filesystems with coarse timestamps
+// (FAT keeps 2 seconds) can change a file without changing its mtime, so a
+// hash taken within the window is checked again on the next scan.
+const racyWindow = 2 * time.Second
+
+// fileHashes remembers the content hash of each file with the size and mtime
+// it had when hashed, so unchanged files are only stat'ed on later scans
+type fileHashes struct {
+	mu    sync.Mutex
+	files map[string]fileHash
+}
+
+// fileHash is the hash of a file's bytes and the stat it was taken at
+type fileHash struct {
+	size     int64
+	modTime  time.Time
+	hashedAt time.Time
+	hash     string
+}
+
+func newFileHashes() *fileHashes {
+	return &fileHashes{files: make(map[string]fileHash)}
+}
+
+// hash returns the hex SHA-256 of a file's bytes. It rehashes the file only
+// when its size or mtime changed since the last time, or when that mtime was
+// too close to the hash to tell a later write apart. The scan's read-once
+// buffer on ctx is used when there is one.
+func (h *fileHashes) hash(ctx context.Context, path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	h.mu.Lock()
+	known, ok := h.files[path]
+	h.mu.Unlock()
+	if ok && known.size == info.Size() && known.modTime.Equal(info.ModTime()) &&
+		known.modTime.Before(known.hashedAt.Add(-racyWindow)) {
+		return known.hash, nil
+	}
+
+	hashedAt := time.Now()
+	var hash string
+	if contents := vibes.FileContentsFromContext(ctx); contents != nil {
+		hash, err = contents.Hash(path)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		hash = hex.EncodeToString(sum[:])
+	}
+
+	h.mu.Lock()
+	h.files[path] = fileHash{size: info.Size(), modTime: info.ModTime(), hashedAt: hashedAt, hash: hash}
+	h.mu.Unlock()
+	return hash, nil
+}
+
+// generateCacheKey derives the cache key of a vibe's results from the vibe,
+// its configured settings and the content of every file, so a checkout that
+// only touches files still reuses them and an edit never does
+func (s *Scanner) generateCacheKey(ctx context.Context, files []string, vibeType models.VibeType) string {
+	keyParts := []string{string(vibeType)}
+
+	// Settings change the findings as much as content does. Maps marshal
+	// with sorted keys, so equal settings give equal keys.
+	if settings, err := json.Marshal(s.config.Vibes[vibeType]); err == nil {
+		keyParts = append(keyParts, string(settings))
+	}
+
+	for _, file := range files {
+		hash, err := s.fileHashes.hash(ctx, file)
+		if err != nil {
+			// The checker reports unreadable files; keep them in the key
+			keyParts = append(keyParts, file+":unreadable")
+			continue
+		}
+		keyParts = append(keyParts, file+":"+hash)
+	}
+
+	return utils.HashStrings(keyParts)
+}
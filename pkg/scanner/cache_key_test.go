@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// countingChecker reports one issue per file and counts its runs
+type countingChecker struct {
+	vibes.Checker
+	runs atomic.Int32
+}
+
+func (c *countingChecker) Type() models.VibeType { return "counting" }
+func (c *countingChecker) Name() string          { return "counting" }
+func (c *countingChecker) Supports(string) bool  { return true }
+
+func (c *countingChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
+	c.runs.Add(1)
+	var issues []models.Issue
+	for _, file := range files {
+		issues = append(issues, models.Issue{File: file, Rule: "counting", Severity: models.SeverityInfo, Message: "counted"})
+	}
+	return issues, nil
+}
+
+func TestScanner_CacheKeyedByContent(t *testing.T) {
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "main.go")
+	require.NoError(t, os.WriteFile(file, []byte("package main\n"), 0644))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(file, past, past))
+
+	config := &models.Configuration{
+		Scanner:  models.ScannerConfig{MaxConcurrency: 2},
+		Advanced: models.AdvancedConfig{CacheEnabled: true, CacheTTL: time.Minute},
+	}
+	scanner, err := NewScanner(config, logrus.New())
+	require.NoError(t, err)
+	checker := &countingChecker{}
+	require.NoError(t, scanner.vibeRegistry.RegisterChecker(checker))
+
+	scan := func() {
+		_, err := scanner.Scan(context.Background(), &models.ScanRequest{Paths: []string{tempDir}, Vibes: []string{"counting"}})
+		require.NoError(t, err)
+	}
+
+	scan()
+	require.Equal(t, int32(1), checker.runs.Load())
+
+	// A checkout rewrites the file with the same bytes and a new mtime
+	touched := time.Now().Add(-30 * time.Minute)
+	require.NoError(t, os.WriteFile(file, []byte("package main\n"), 0644))
+	require.NoError(t, os.Chtimes(file, touched, touched))
+	scan()
+	assert.Equal(t, int32(1), checker.runs.Load(), "a touched but unchanged file hits the cache")
+
+	require.NoError(t, os.WriteFile(file, []byte("package app\n"), 0644))
+	scan()
+	assert.Equal(t, int32(2), checker.runs.Load(), "changed content misses the cache")
+
+	config.Vibes = map[models.VibeType]models.VibeConfig{"counting": {Enabled: true, Settings: map[string]interface{}{"strict": true}}}
+	scan()
+	assert.Equal(t, int32(3), checker.runs.Load(), "changed settings miss the cache")
+}
+
+func TestCopyIssues_CopiesMetadata(t *testing.T) {
+	cached := []models.Issue{{Rule: "counting", Metadata: map[string]interface{}{"cwe": "CWE-798"}}, {Rule: "bare"}}
+	copied := copyIssues(cached)
+	copied[0].Metadata["escalated_from"] = "error"
+	copied[1].Rule = "changed"
+
+	assert.Equal(t, map[string]interface{}{"cwe": "CWE-798"}, cached[0].Metadata)
+	assert.Equal(t, "bare", cached[1].Rule)
+	assert.Nil(t, copied[1].Metadata)
+}
+
+func TestFileHashes(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "data.txt")
+	write := func(content string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+	hashes := newFileHashes()
+
+	// An old mtime with the same size is trusted without reading the file
+	past := time.Now().Add(-time.Hour)
+	write("aaa", past)
+	first, err := hashes.hash(context.Background(), file)
+	require.NoError(t, err)
+	write("bbb", past)
+	cached, err := hashes.hash(context.Background(), file)
+	require.NoError(t, err)
+	assert.Equal(t, first, cached, "the stat fast path skips rehashing")
+
+	// An mtime as recent as the hash may hide a later write of the same size
+	recent := time.Now()
+	write("ccc", recent)
+	racy, err := hashes.hash(context.Background(), file)
+	require.NoError(t, err)
+	write("ddd", recent)
+	rehashed, err := hashes.hash(context.Background(), file)
+	require.NoError(t, err)
+	assert.NotEqual(t, racy, rehashed, "racily clean files are rehashed")
+
+	// The scan's read-once buffer gives the same hash
+	contents := vibes.NewFileContents()
+	fromBuffer, err := newFileHashes().hash(vibes.WithFileContents(context.Background(), contents), file)
+	require.NoError(t, err)
+	assert.Equal(t, rehashed, fromBuffer)
+	assert.Equal(t, 1, contents.Len())
+
+	_, err = hashes.hash(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,16 @@
+package scanner
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the ID of the request that started a scan
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,22 @@
+package scanner
+
+import (
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// assignCWEs sets the CWE of every issue that has none from its rule:
+// scanner.cwe_mapping first, where an empty value maps the rule to nothing,
+// then the built-in mapping
+func (s *Scanner) assignCWEs(issues []models.Issue) {
+	for i := range issues {
+		if issues[i].CWE != "" {
+			continue
+		}
+		if cwe, exists := s.config.Scanner.CWEMapping[issues[i].Rule]; exists {
+			issues[i].CWE = cwe
+			continue
+		}
+		issues[i].CWE = vibes.RuleCWE(issues[i].Rule)
+	}
+}
@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// DefaultDetectSecretsBaseline is where detect-secrets keeps its baseline
+const DefaultDetectSecretsBaseline = ".secrets.baseline"
+
+// DetectSecretsBaseline is the part of a detect-secrets .secrets.baseline
+// that is imported: the known secrets in each file
+type DetectSecretsBaseline struct {
+	Version string                                   `json:"version"`
+	Results map[string][]DetectSecretsBaselineSecret `json:"results"`
+}
+
+// DetectSecretsBaselineSecret is one secret recorded in a detect-secrets baseline
+type DetectSecretsBaselineSecret struct {
+	Type     string `json:"type"`
+	Filename string `json:"filename"`
+	// HashedSecret is the hex SHA-1 of the secret value
+	HashedSecret string `json:"hashed_secret"`
+	LineNumber   int    `json:"line_number"`
+	// IsSecret is set once the secret is audited: false for a false positive, true for a real secret
+	IsSecret   *bool `json:"is_secret,omitempty"`
+	IsVerified bool  `json:"is_verified"`
+}
+
+// DetectSecretsImportOptions choose which baseline secrets become suppressions
+type DetectSecretsImportOptions struct {
+	// AuditedOnly imports only secrets audited as false positives. By default
+	// every secret not audited as real is imported, since detect-secrets
+	// treats the whole baseline as accepted.
+	AuditedOnly bool
+	// PinLines also limits each suppression to the recorded line number, so
+	// it stops matching once the secret moves
+	PinLines bool
+}
+
+// LoadDetectSecretsBaseline reads a detect-secrets baseline file
+func LoadDetectSecretsBaseline(path string) (*DetectSecretsBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read detect-secrets baseline: %w", err)
+	}
+
+	var baseline DetectSecretsBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse detect-secrets baseline %s: %w", path, err)
+	}
+	return &baseline, nil
+}
+
+// ImportDetectSecrets adds a secret-hash suppression for each secret in a
+// detect-secrets baseline. It returns how many were added and how many were
+// skipped, either because they are already suppressed or because options
+// exclude them. Real secrets (is_secret: true) are never imported.
+func (s *Suppressions) ImportDetectSecrets(baseline *DetectSecretsBaseline, options DetectSecretsImportOptions) (added, skipped int) {
+	files := make([]string, 0, len(baseline.Results))
+	for file := range baseline.Results {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	addedAt := time.Now().UTC().Truncate(time.Second)
+	for _, file := range files {
+		for _, secret := range baseline.Results[file] {
+			if secret.HashedSecret == "" || (secret.IsSecret != nil && *secret.IsSecret) ||
+				(options.AuditedOnly && secret.IsSecret == nil) {
+				skipped++
+				continue
+			}
+
+			filename := secret.Filename
+			if filename == "" {
+				filename = file
+			}
+			suppression := Suppression{
+				File:       suppressionPath(filename),
+				SecretHash: secret.HashedSecret,
+				Reason:     "detect-secrets: " + secret.Type,
+				AddedAt:    addedAt,
+			}
+			if options.PinLines {
+				suppression.Line = secret.LineNumber
+			}
+			if s.hasSecretSuppression(suppression) {
+				skipped++
+				continue
+			}
+			s.Suppressions = append(s.Suppressions, suppression)
+			added++
+		}
+	}
+	return added, skipped
+}
+
+// hasSecretSuppression reports whether the same secret is already suppressed in the same file
+func (s *Suppressions) hasSecretSuppression(candidate Suppression) bool {
+	for _, suppression := range s.Suppressions {
+		if suppression.SecretHash == candidate.SecretHash && suppression.Rule == candidate.Rule &&
+			suppressionPath(suppression.File) == candidate.File &&
+			(suppression.Line == 0 || suppression.Line == candidate.Line) {
+			return true
+		}
+	}
+	return false
+}
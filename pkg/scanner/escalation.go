@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"kodevibe/internal/models"
+)
+
+// escalateIssues raises the severity of every issue whose rule fired more often
+// than its vibe's escalate_after threshold. It returns the occurrence count of
+// each escalated rule, keyed by vibe/rule like the counts themselves.
+func (s *Scanner) escalateIssues(issues []models.Issue) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[escalationKey(issue)]++
+	}
+
+	escalated := make(map[string]int)
+	for i := range issues {
+		issue := &issues[i]
+
+		vibeConfig, exists := s.config.Vibes[issue.Type]
+		if !exists {
+			continue
+		}
+		threshold, exists := vibeConfig.EscalateAfter[issue.Rule]
+		if !exists || threshold <= 0 {
+			continue
+		}
+
+		count := counts[escalationKey(*issue)]
+		if count <= threshold {
+			continue
+		}
+
+		if issue.Metadata == nil {
+			issue.Metadata = make(map[string]interface{})
+		}
+		issue.Metadata["escalated_from"] = string(issue.Severity)
+		issue.Metadata["occurrences"] = count
+		issue.Severity = models.EscalateSeverity(issue.Severity)
+		escalated[escalationKey(*issue)] = count
+	}
+
+	return escalated
+}
+
+func escalationKey(issue models.Issue) string {
+	return string(issue.Type) + "/" + issue.Rule
+}
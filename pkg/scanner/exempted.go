@@ -0,0 +1,26 @@
+package scanner
+
+import (
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// ExemptedIssuesKey is the result metadata key listing the issues left out
+// of the results because their function is exempt from the complexity rules
+// (see vibes.ExemptionKey), so accepted complexity stays auditable
+const ExemptedIssuesKey = "exempted_issues"
+
+// separateExempted splits off the issues a checker exempted. The rest stay
+// in their original order.
+func separateExempted(issues []models.Issue) ([]models.Issue, []models.Issue) {
+	var exempted []models.Issue
+	kept := issues[:0]
+	for _, issue := range issues {
+		if vibes.IsExempted(issue) {
+			exempted = append(exempted, issue)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept, exempted
+}
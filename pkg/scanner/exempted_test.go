@@ -0,0 +1,27 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+func TestSeparateExempted(t *testing.T) {
+	exemption := vibes.Exemption{By: vibes.ExemptedByAnnotation, Function: "parse"}
+	issues := []models.Issue{
+		{File: "a.go", Line: 1, Rule: "magic-number"},
+		{File: "a.go", Line: 3, Rule: "cyclomatic-complexity", Metadata: map[string]interface{}{vibes.ExemptionKey: exemption}},
+		{File: "b.go", Line: 2, Rule: "function-length"},
+	}
+
+	kept, exempted := separateExempted(append([]models.Issue(nil), issues...))
+	assert.Equal(t, []models.Issue{issues[0], issues[2]}, kept)
+	assert.Equal(t, []models.Issue{issues[1]}, exempted)
+
+	kept, exempted = separateExempted(append([]models.Issue(nil), issues[0]))
+	assert.Len(t, kept, 1)
+	assert.Nil(t, exempted)
+}
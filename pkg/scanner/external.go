@@ -0,0 +1,303 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// External scanner defaults
+const (
+	DefaultExternalTimeout     = 2 * time.Minute
+	DefaultExternalConcurrency = 2
+
+	// externalWaitDelay is how long to wait for output pipes after a scanner is killed
+	externalWaitDelay = 5 * time.Second
+	// maxExternalStderr caps the stderr kept on a failure issue
+	maxExternalStderr = 4096
+)
+
+// External scanner output formats
+const (
+	ExternalFormatSARIF  = "sarif"
+	ExternalFormatNDJSON = "ndjson"
+)
+
+// externalPathsArg is replaced by the scanned paths in an external scanner's args
+const externalPathsArg = "{paths}"
+
+// externalFailureRule is the rule of the issue reported when an external scanner fails
+const externalFailureRule = "external-scanner-failed"
+
+// runExternalScanners runs the enabled external scanners, a bounded number at
+// a time, and returns their findings. A scanner that fails, times out or
+// produces unreadable output is reported as an issue carrying its stderr
+// rather than failing the scan.
+func (s *Scanner) runExternalScanners(ctx context.Context, paths []string) []models.Issue {
+	var enabled []models.ExternalScanner
+	for _, external := range s.config.Advanced.ExternalScanners {
+		if external.Enabled && external.Command != "" {
+			enabled = append(enabled, external)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	concurrency := s.config.Advanced.ExternalConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultExternalConcurrency
+	}
+	sem := semaphore.NewWeighted(int64(concurrency))
+
+	var (
+		allIssues []models.Issue
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+	for _, external := range enabled {
+		wg.Add(1)
+		go func(external models.ExternalScanner) {
+			defer wg.Done()
+
+			if err := sem.Acquire(ctx, 1); err != nil {
+				return
+			}
+			defer sem.Release(1)
+
+			issues, stderr, err := runExternalScanner(ctx, external, paths)
+			if err != nil {
+				if ctx.Err() != nil {
+					// The scan itself ran out of time; that is reported as an incomplete scan
+					return
+				}
+				s.logger.WithFields(logrus.Fields{
+					"scanner": external.Name,
+					"error":   err,
+				}).Warn("External scanner failed")
+				issues = []models.Issue{externalFailureIssue(external, err, stderr)}
+			}
+
+			mu.Lock()
+			allIssues = append(allIssues, issues...)
+			mu.Unlock()
+		}(external)
+	}
+	wg.Wait()
+
+	return allIssues
+}
+
+// runExternalScanner runs one scanner in its own process group under its
+// timeout and parses its stdout. stderr is returned separately.
+func runExternalScanner(ctx context.Context, external models.ExternalScanner, paths []string) ([]models.Issue, string, error) {
+	timeout := external.Timeout
+	if timeout <= 0 {
+		timeout = DefaultExternalTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, external.Command, externalArgs(external.Args, paths)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = externalWaitDelay
+	killProcessGroup(cmd)
+
+	runErr := cmd.Run()
+	if ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, stderr.String(), fmt.Errorf("timed out after %s", timeout)
+		}
+		return nil, stderr.String(), ctx.Err()
+	}
+
+	// Linters commonly exit non-zero when they find something, so a non-zero
+	// exit only fails the run when there is no output to read
+	var exitErr *exec.ExitError
+	if runErr != nil && !(errors.As(runErr, &exitErr) && stdout.Len() > 0) {
+		return nil, stderr.String(), fmt.Errorf("failed to run %s: %w", external.Command, runErr)
+	}
+
+	issues, err := parseExternalOutput(external, stdout.Bytes())
+	if err != nil {
+		return nil, stderr.String(), err
+	}
+	return issues, stderr.String(), nil
+}
+
+// externalArgs expands {paths} in args into the scanned paths
+func externalArgs(args, paths []string) []string {
+	expanded := make([]string, 0, len(args)+len(paths))
+	for _, arg := range args {
+		if arg == externalPathsArg {
+			expanded = append(expanded, paths...)
+			continue
+		}
+		expanded = append(expanded, arg)
+	}
+	return expanded
+}
+
+// parseExternalOutput reads a scanner's findings and attributes them to it
+func parseExternalOutput(external models.ExternalScanner, output []byte) ([]models.Issue, error) {
+	var (
+		issues []models.Issue
+		err    error
+	)
+	switch strings.ToLower(external.Format) {
+	case "", ExternalFormatSARIF:
+		issues, err = parseSARIF(output)
+	case ExternalFormatNDJSON:
+		issues, err = parseIssueLines(output)
+	default:
+		return nil, fmt.Errorf("unsupported output format %q (supported: %s, %s)", external.Format, ExternalFormatSARIF, ExternalFormatNDJSON)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s output: %w", external.Name, err)
+	}
+
+	for i := range issues {
+		if issues[i].Type == "" {
+			issues[i].Type = externalVibe(external)
+		}
+		if issues[i].Metadata == nil {
+			issues[i].Metadata = make(map[string]interface{})
+		}
+		issues[i].Metadata["scanner"] = external.Name
+	}
+	return issues, nil
+}
+
+// sarifLog is the part of a SARIF 2.1.0 log that findings are read from
+type sarifLog struct {
+	Runs []struct {
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine   int `json:"startLine"`
+						StartColumn int `json:"startColumn"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func parseSARIF(output []byte) ([]models.Issue, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+	var log sarifLog
+	if err := json.Unmarshal(output, &log); err != nil {
+		return nil, err
+	}
+
+	var issues []models.Issue
+	for _, run := range log.Runs {
+		for _, result := range run.Results {
+			issue := models.Issue{
+				Severity:   sarifSeverity(result.Level),
+				Title:      result.RuleID,
+				Message:    result.Message.Text,
+				Rule:       result.RuleID,
+				Confidence: 1.0,
+				CreatedAt:  time.Now(),
+			}
+			if len(result.Locations) > 0 {
+				location := result.Locations[0].PhysicalLocation
+				issue.File = strings.TrimPrefix(location.ArtifactLocation.URI, "file://")
+				issue.Line = location.Region.StartLine
+				issue.Column = location.Region.StartColumn
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+func sarifSeverity(level string) models.SeverityLevel {
+	switch level {
+	case "error":
+		return models.SeverityError
+	case "note", "none":
+		return models.SeverityInfo
+	default:
+		return models.SeverityWarning
+	}
+}
+
+// parseIssueLines reads one JSON issue per line, skipping blank lines
+func parseIssueLines(output []byte) ([]models.Issue, error) {
+	var issues []models.Issue
+	lines := bufio.NewScanner(bytes.NewReader(output))
+	lines.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for number := 1; lines.Scan(); number++ {
+		line := bytes.TrimSpace(lines.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var issue models.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		if issue.CreatedAt.IsZero() {
+			issue.CreatedAt = time.Now()
+		}
+		issues = append(issues, issue)
+	}
+	return issues, lines.Err()
+}
+
+// externalFailureIssue reports a failed scanner run along with what it wrote to stderr
+func externalFailureIssue(external models.ExternalScanner, err error, stderr string) models.Issue {
+	stderr = strings.TrimSpace(stderr)
+	if len(stderr) > maxExternalStderr {
+		stderr = "..." + stderr[len(stderr)-maxExternalStderr:]
+	}
+	return models.Issue{
+		Type:          externalVibe(external),
+		Severity:      models.SeverityWarning,
+		Title:         fmt.Sprintf("External scanner %s failed", external.Name),
+		Message:       fmt.Sprintf("External scanner %s failed: %v", external.Name, err),
+		Rule:          externalFailureRule,
+		Context:       utils.TruncateString(stderr, 200),
+		FixSuggestion: "Run the scanner by hand to see why it failed, or disable it in advanced.external_scanners",
+		Confidence:    1.0,
+		CreatedAt:     time.Now(),
+		Metadata: map[string]interface{}{
+			"scanner": external.Name,
+			"stderr":  stderr,
+		},
+	}
+}
+
+func externalVibe(external models.ExternalScanner) models.VibeType {
+	if external.Vibe != "" {
+		return external.Vibe
+	}
+	return models.VibeTypeSecurity
+}
@@ -0,0 +1,141 @@
+//go:build !windows
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+const testSARIF = `{"version":"2.1.0","runs":[{"results":[
+{"ruleId":"G101","level":"error","message":{"text":"Potential hardcoded credentials"},
+ "locations":[{"physicalLocation":{"artifactLocation":{"uri":"file://config.go"},"region":{"startLine":7,"startColumn":2}}}]},
+{"ruleId":"G104","level":"note","message":{"text":"Errors unhandled"}}]}]}`
+
+func newExternalTestScanner(t *testing.T, concurrency int, externals ...models.ExternalScanner) *Scanner {
+	config := &models.Configuration{
+		Scanner: models.ScannerConfig{MaxConcurrency: 2, Timeout: 10},
+		Advanced: models.AdvancedConfig{
+			ExternalScanners:    externals,
+			ExternalConcurrency: concurrency,
+		},
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	scanner, err := NewScanner(config, logger)
+	require.NoError(t, err)
+	return scanner
+}
+
+func shellScanner(name, script string) models.ExternalScanner {
+	return models.ExternalScanner{Name: name, Enabled: true, Command: "sh", Args: []string{"-c", script}}
+}
+
+func TestRunExternalScanners_SARIF(t *testing.T) {
+	external := shellScanner("gosec", "cat <<'EOF'\n"+testSARIF+"\nEOF")
+	scanner := newExternalTestScanner(t, 0, external)
+
+	issues := scanner.runExternalScanners(context.Background(), []string{"."})
+	require.Len(t, issues, 2)
+	assert.Equal(t, "G101", issues[0].Rule)
+	assert.Equal(t, models.SeverityError, issues[0].Severity)
+	assert.Equal(t, "config.go", issues[0].File)
+	assert.Equal(t, 7, issues[0].Line)
+	assert.Equal(t, 2, issues[0].Column)
+	assert.Equal(t, models.VibeTypeSecurity, issues[0].Type)
+	assert.Equal(t, "gosec", issues[0].Metadata["scanner"])
+	assert.Equal(t, models.SeverityInfo, issues[1].Severity)
+}
+
+func TestRunExternalScanners_NonZeroExitWithFindings(t *testing.T) {
+	external := shellScanner("lint", `echo '{"rule":"no-eval","file":"a.js","line":3,"severity":"error"}'; echo warn >&2; exit 1`)
+	external.Format = ExternalFormatNDJSON
+	external.Vibe = models.VibeTypeCode
+	scanner := newExternalTestScanner(t, 0, external)
+
+	issues := scanner.runExternalScanners(context.Background(), nil)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "no-eval", issues[0].Rule)
+	assert.Equal(t, models.VibeTypeCode, issues[0].Type)
+}
+
+func TestRunExternalScanners_FailureKeepsStderr(t *testing.T) {
+	scanner := newExternalTestScanner(t, 0,
+		shellScanner("broken", "echo 'config not found' >&2; exit 3"),
+		models.ExternalScanner{Name: "disabled", Command: "false"},
+	)
+
+	issues := scanner.runExternalScanners(context.Background(), nil)
+	require.Len(t, issues, 1)
+	assert.Equal(t, externalFailureRule, issues[0].Rule)
+	assert.Contains(t, issues[0].Message, "exit status 3")
+	assert.Equal(t, "config not found", issues[0].Metadata["stderr"])
+	assert.Equal(t, "broken", issues[0].Metadata["scanner"])
+}
+
+func TestRunExternalScanners_TimeoutKillsProcessGroup(t *testing.T) {
+	// The background sleep inherits stdout; only killing the group lets the run finish promptly
+	external := shellScanner("hung", "sleep 30 & sleep 30")
+	external.Timeout = 200 * time.Millisecond
+	scanner := newExternalTestScanner(t, 0, external)
+
+	start := time.Now()
+	issues := scanner.runExternalScanners(context.Background(), nil)
+	assert.Less(t, time.Since(start), externalWaitDelay)
+	require.Len(t, issues, 1)
+	assert.Equal(t, externalFailureRule, issues[0].Rule)
+	assert.Contains(t, issues[0].Message, "timed out after 200ms")
+}
+
+func TestRunExternalScanners_BoundedConcurrency(t *testing.T) {
+	dir := t.TempDir()
+	// Each run records how many runs were in flight when it started
+	script := `ls ` + dir + `/running | wc -l >> ` + dir + `/seen; touch ` + dir + `/running/$$; sleep 0.1; rm ` + dir + `/running/$$`
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "running"), 0755))
+
+	var externals []models.ExternalScanner
+	for _, name := range []string{"a", "b", "c", "d"} {
+		externals = append(externals, shellScanner(name, script))
+	}
+	scanner := newExternalTestScanner(t, 1, externals...)
+
+	issues := scanner.runExternalScanners(context.Background(), nil)
+	assert.Empty(t, issues)
+
+	seen, err := os.ReadFile(filepath.Join(dir, "seen"))
+	require.NoError(t, err)
+	counts := strings.Fields(string(seen))
+	assert.Len(t, counts, 4)
+	for _, count := range counts {
+		assert.Equal(t, "0", count, "scanners must run one at a time")
+	}
+}
+
+func TestRunExternalScanners_ScanCancelled(t *testing.T) {
+	scanner := newExternalTestScanner(t, 0, shellScanner("slow", "sleep 30"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Empty(t, scanner.runExternalScanners(ctx, nil), "a cancelled scan is incomplete, not a scanner failure")
+}
+
+func TestExternalArgs(t *testing.T) {
+	assert.Equal(t, []string{"-fmt", "sarif", "a", "b", "-quiet"},
+		externalArgs([]string{"-fmt", "sarif", "{paths}", "-quiet"}, []string{"a", "b"}))
+	assert.Empty(t, externalArgs(nil, []string{"a"}))
+}
+
+func TestParseExternalOutput_UnknownFormat(t *testing.T) {
+	_, err := parseExternalOutput(models.ExternalScanner{Name: "x", Format: "xml"}, []byte("<x/>"))
+	assert.ErrorContains(t, err, `unsupported output format "xml"`)
+}
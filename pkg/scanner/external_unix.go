@@ -0,0 +1,17 @@
+//go:build !windows
+
+package scanner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroup starts the scanner in its own process group and kills the
+// whole group on cancellation, so tools that fork workers leave nothing behind
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package scanner
+
+import "os/exec"
+
+// killProcessGroup leaves cancellation to exec's default, which kills the scanner process
+func killProcessGroup(cmd *exec.Cmd) {}
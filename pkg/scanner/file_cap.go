@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"sort"
+
+	"kodevibe/internal/models"
+)
+
+// TruncatedIssuesKey is the result metadata key mapping each file over
+// scanner.max_issues_per_file to the number of its issues that were dropped
+const TruncatedIssuesKey = "truncated_issues"
+
+// capIssuesPerFile keeps at most limit issues in each file, preferring the
+// most severe and then the earliest. Kept issues stay in their original order.
+// It returns the number of issues dropped from each file over the limit.
+func capIssuesPerFile(issues []models.Issue, limit int) ([]models.Issue, map[string]int) {
+	if limit <= 0 {
+		return issues, nil
+	}
+
+	byFile := make(map[string][]int)
+	for i, issue := range issues {
+		byFile[issue.File] = append(byFile[issue.File], i)
+	}
+
+	drop := make(map[int]bool)
+	truncated := make(map[string]int)
+	for file, indexes := range byFile {
+		if len(indexes) <= limit {
+			continue
+		}
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return severityWeight(issues[indexes[a]].Severity) > severityWeight(issues[indexes[b]].Severity)
+		})
+		for _, index := range indexes[limit:] {
+			drop[index] = true
+		}
+		truncated[file] = len(indexes) - limit
+	}
+	if len(truncated) == 0 {
+		return issues, nil
+	}
+
+	kept := issues[:0]
+	for i, issue := range issues {
+		if !drop[i] {
+			kept = append(kept, issue)
+		}
+	}
+	return kept, truncated
+}
+
+// severityWeight orders severities from least to most severe
+func severityWeight(severity models.SeverityLevel) int {
+	switch severity {
+	case models.SeverityError:
+		return 3
+	case models.SeverityWarning:
+		return 2
+	case models.SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,34 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"kodevibe/internal/models"
+)
+
+func TestCapIssuesPerFile(t *testing.T) {
+	issues := []models.Issue{
+		{File: "gen/big.go", Line: 1, Severity: models.SeverityInfo},
+		{File: "gen/big.go", Line: 2, Severity: models.SeverityWarning},
+		{File: "gen/big.go", Line: 3, Severity: models.SeverityError},
+		{File: "gen/big.go", Line: 4, Severity: models.SeverityInfo},
+		{File: "gen/big.go", Line: 5, Severity: models.SeverityWarning},
+		{File: "main.go", Line: 1, Severity: models.SeverityInfo},
+		{File: "main.go", Line: 2, Severity: models.SeverityInfo},
+	}
+
+	kept, truncated := capIssuesPerFile(append([]models.Issue(nil), issues...), 2)
+	assert.Equal(t, map[string]int{"gen/big.go": 3}, truncated)
+	assert.Equal(t, []models.Issue{issues[1], issues[2], issues[5], issues[6]}, kept,
+		"the most severe, then earliest, issues are kept in their original order")
+
+	kept, truncated = capIssuesPerFile(append([]models.Issue(nil), issues...), 0)
+	assert.Nil(t, truncated)
+	assert.Equal(t, issues, kept, "0 means no limit")
+
+	kept, truncated = capIssuesPerFile(append([]models.Issue(nil), issues...), 5)
+	assert.Nil(t, truncated)
+	assert.Len(t, kept, len(issues))
+}
@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// checkFiles runs a per-file checker over files on up to maxConcurrency
+// workers, so a vibe with many files uses more than one core. Issues come
+// back in file order whatever order the workers finish in. Like Check, it
+// skips the files the checker fails on, and returns the issues found so far
+// with the context's error when ctx ends.
+func (s *Scanner) checkFiles(ctx context.Context, checker vibes.PerFileChecker, files []string) ([]models.Issue, error) {
+	workers := s.maxConcurrency
+	if workers > len(files) {
+		workers = len(files)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Each file's issues go to its own slot, so workers never share a slice
+	results := make([][]models.Issue, len(files))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(files) || ctx.Err() != nil {
+					return
+				}
+				if !checker.Supports(files[i]) {
+					continue
+				}
+				issues, err := checker.CheckFile(ctx, files[i])
+				if err != nil {
+					s.logger.WithError(err).WithFields(logrus.Fields{
+						"vibe": checker.Type(),
+						"file": files[i],
+					}).Debug("Skipping file the vibe could not check")
+					continue
+				}
+				results[i] = issues
+			}
+		}()
+	}
+	wg.Wait()
+
+	var issues []models.Issue
+	for _, fileIssues := range results {
+		issues = append(issues, fileIssues...)
+	}
+	return issues, ctx.Err()
+}
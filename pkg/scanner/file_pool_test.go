@@ -0,0 +1,129 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// perFileChecker reports one issue per file, skips .txt files and fails on
+// files named "bad", recording how many files it checks at once
+type perFileChecker struct {
+	vibes.Checker
+	delay   time.Duration
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (c *perFileChecker) Type() models.VibeType { return "per-file" }
+func (c *perFileChecker) Supports(file string) bool {
+	return !strings.HasSuffix(file, ".txt")
+}
+
+func (c *perFileChecker) CheckFile(ctx context.Context, file string) ([]models.Issue, error) {
+	running := c.running.Add(1)
+	defer c.running.Add(-1)
+	for peak := c.peak.Load(); running > peak && !c.peak.CompareAndSwap(peak, running); peak = c.peak.Load() {
+	}
+	time.Sleep(c.delay)
+
+	if filepath.Base(file) == "bad" {
+		return nil, errors.New("unreadable")
+	}
+	return []models.Issue{{File: file, Rule: "per-file", Severity: models.SeverityInfo, Message: "checked"}}, nil
+}
+
+func TestScanner_CheckFiles(t *testing.T) {
+	s, err := NewScanner(&models.Configuration{Scanner: models.ScannerConfig{MaxConcurrency: 4}}, logrus.New())
+	require.NoError(t, err)
+
+	var files, want []string
+	for i := 0; i < 40; i++ {
+		file := fmt.Sprintf("f%02d.go", i)
+		files = append(files, file)
+		want = append(want, file)
+	}
+	files = append(files, "bad", "notes.txt")
+
+	checker := &perFileChecker{delay: 5 * time.Millisecond}
+	issues, err := s.checkFiles(context.Background(), checker, files)
+	require.NoError(t, err)
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.File)
+	}
+	assert.Equal(t, want, got, "issues come back in file order, without failed or unsupported files")
+	assert.Greater(t, checker.peak.Load(), int32(1), "files are checked concurrently")
+	assert.LessOrEqual(t, checker.peak.Load(), int32(4), "no more workers than max_concurrency")
+}
+
+func TestScanner_CheckFilesCancelled(t *testing.T) {
+	s, err := NewScanner(&models.Configuration{Scanner: models.ScannerConfig{MaxConcurrency: 2}}, logrus.New())
+	require.NoError(t, err)
+
+	files := make([]string, 200)
+	for i := range files {
+		files[i] = fmt.Sprintf("f%03d.go", i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	issues, err := s.checkFiles(ctx, &perFileChecker{delay: 5 * time.Millisecond}, files)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotEmpty(t, issues, "issues found before the deadline are kept")
+	assert.Less(t, len(issues), len(files))
+}
+
+// BenchmarkScanner_CodeVibe scans a synthetic tree of 500 files with the
+// code vibe alone, so only the per-file workers can use more than one core.
+// Compare the workers=1 time with the others on a multi-core machine.
+func BenchmarkScanner_CodeVibe(b *testing.B) {
+	root := b.TempDir()
+	var source strings.Builder
+	source.WriteString("package synthetic\n\n")
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&source, "// Sum%d adds up values\nfunc Sum%d(values []int) int {\n\ttotal := 0\n", i, i)
+		source.WriteString("\tfor _, value := range values {\n\t\tif value > 0 {\n\t\t\ttotal += value\n\t\t}\n\t}\n\treturn total\n}\n\n")
+	}
+	for i := 0; i < 500; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%02d", i%25))
+		require.NoError(b, os.MkdirAll(dir, 0755))
+		require.NoError(b, os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%04d.go", i)), []byte(source.String()), 0644))
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	counts := []int{1, 2, 4}
+	if cpus := runtime.NumCPU(); cpus > 4 {
+		counts = append(counts, cpus)
+	}
+	for _, workers := range counts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			config := &models.Configuration{Scanner: models.ScannerConfig{MaxConcurrency: workers}}
+			s, err := NewScanner(config, logger)
+			require.NoError(b, err)
+			request := &models.ScanRequest{Paths: []string{root}, Vibes: []string{string(models.VibeTypeCode)}}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := s.Scan(context.Background(), request)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
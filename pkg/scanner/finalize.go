@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"kodevibe/internal/models"
+)
+
+// finalizeIssues runs once every vibe goroutine has joined. It orders issues
+// deterministically, drops duplicate findings and assigns IDs in a single
+// pass, so the result never depends on goroutine scheduling or on issues
+// replayed from the cache.
+func finalizeIssues(issues []models.Issue) []models.Issue {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issueLess(issues[i], issues[j])
+	})
+
+	finalized := issues[:0]
+	for _, issue := range issues {
+		if len(finalized) > 0 && sameFinding(finalized[len(finalized)-1], issue) {
+			continue
+		}
+		finalized = append(finalized, issue)
+	}
+
+	createdAt := time.Now()
+	for i := range finalized {
+		finalized[i].ID = uuid.New().String()
+		finalized[i].CreatedAt = createdAt
+		finalized[i].MessageTemplate = finalized[i].Template()
+	}
+
+	return finalized
+}
+
+// issueLess orders issues by location, then vibe, rule, message and severity
+func issueLess(a, b models.Issue) bool {
+	if a.File != b.File {
+		return a.File < b.File
+	}
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	if a.Column != b.Column {
+		return a.Column < b.Column
+	}
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	if a.Rule != b.Rule {
+		return a.Rule < b.Rule
+	}
+	if a.Message != b.Message {
+		return a.Message < b.Message
+	}
+	return a.Severity < b.Severity
+}
+
+// sameFinding reports whether two issues describe the same finding at the same place
+func sameFinding(a, b models.Issue) bool {
+	return a.File == b.File &&
+		a.Line == b.Line &&
+		a.Column == b.Column &&
+		a.Type == b.Type &&
+		a.Rule == b.Rule &&
+		a.Message == b.Message &&
+		a.Severity == b.Severity
+}
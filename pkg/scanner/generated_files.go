@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// defaultGeneratedFilePatterns identify generated API stubs from protobuf,
+// thrift and openapi/swagger code generators. They are matched like test file patterns.
+var defaultGeneratedFilePatterns = []string{
+	// protobuf and gRPC
+	"*.pb.go", "*.pb.gw.go", "*_pb2.py", "*_pb2.pyi", "*_pb2_grpc.py",
+	"*_pb.js", "*_pb.d.ts", "*_grpc_pb.js", "*_grpc_pb.d.ts", "*.pb.cc", "*.pb.h", "*.pb.swift",
+	// thrift writes each language into gen-<lang>/
+	"**/gen-go/**", "**/gen-py/**", "**/gen-java/**", "**/gen-js/**", "**/gen-nodejs/**", "**/gen-cpp/**", "**/gen-rb/**",
+	// openapi/swagger and other TypeScript codegen
+	"*.generated.ts", "*.generated.js", "*.swagger.go",
+}
+
+// generatedOutputMarkers are directories openapi-generator and swagger-codegen
+// write at the root of their output; every file below one is generated
+var generatedOutputMarkers = []string{".openapi-generator", ".swagger-codegen"}
+
+// defaultGeneratedFileVibes still scan generated files; everything else skips them
+var defaultGeneratedFileVibes = []string{string(models.VibeTypeSecurity)}
+
+// scansGeneratedFiles reports whether a vibe should see generated files
+func (s *Scanner) scansGeneratedFiles(vibeType models.VibeType) bool {
+	vibes := s.config.Scanner.GeneratedFiles.Vibes
+	if len(vibes) == 0 {
+		vibes = defaultGeneratedFileVibes
+	}
+	return utils.ContainsString(vibes, VibesAll) || utils.ContainsString(vibes, string(vibeType))
+}
+
+// dropGeneratedFiles removes generated files from files
+func (s *Scanner) dropGeneratedFiles(files []string) []string {
+	patterns := append(append([]string(nil), defaultGeneratedFilePatterns...), s.config.Scanner.GeneratedFiles.Patterns...)
+	markerDirs := make(map[string]bool)
+
+	filtered := make([]string, 0, len(files))
+	for _, file := range files {
+		if s.isTestFile(file, patterns) || underGeneratedOutput(file, markerDirs) {
+			continue
+		}
+		filtered = append(filtered, file)
+	}
+	return filtered
+}
+
+// underGeneratedOutput reports whether a directory above file holds a code
+// generator marker. Results are memoized per directory in seen.
+func underGeneratedOutput(file string, seen map[string]bool) bool {
+	var visited []string
+	generated := false
+
+	for dir := filepath.Dir(file); ; dir = filepath.Dir(dir) {
+		if known, ok := seen[dir]; ok {
+			generated = known
+			break
+		}
+		visited = append(visited, dir)
+
+		for _, marker := range generatedOutputMarkers {
+			if info, err := os.Stat(filepath.Join(dir, marker)); err == nil && info.IsDir() {
+				generated = true
+			}
+		}
+		if generated || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	for _, dir := range visited {
+		seen[dir] = generated
+	}
+	return generated
+}
@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	"kodevibe/pkg/vibes"
+)
+
+// HistoryKey is the result metadata summarizing a git history scan
+const HistoryKey = "history"
+
+// HistorySummary describes what a git history scan walked
+type HistorySummary struct {
+	Since      string `json:"since,omitempty" yaml:"since,omitempty"`
+	MaxCommits int    `json:"max_commits,omitempty" yaml:"max_commits,omitempty"`
+	// Commits is the number of commits that added lines to scanned files
+	Commits int `json:"commits" yaml:"commits"`
+	Secrets int `json:"secrets" yaml:"secrets"`
+	// Incomplete is set when the scan timeout ended the walk early
+	Incomplete bool `json:"incomplete,omitempty" yaml:"incomplete,omitempty"`
+}
+
+// scanHistory runs the secret checks over the lines past commits added in
+// the repository holding path, reporting each secret once, at the commit,
+// file and line that introduced it. Secrets still in the working tree are
+// reported by the regular scan as well.
+func (s *Scanner) scanHistory(ctx context.Context, registry *vibes.Registry, path string, options models.HistoryOptions) ([]models.Issue, *HistorySummary, error) {
+	checker, err := registry.GetChecker(models.VibeTypeSecurity)
+	if err != nil {
+		return nil, nil, err
+	}
+	security, ok := checker.(*vibes.SecurityChecker)
+	if !ok {
+		return nil, nil, fmt.Errorf("the security vibe does not support history scans")
+	}
+
+	dir := path
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	git := utils.NewGitUtil(dir)
+	root, err := git.TopLevel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("history scan needs a git repository: %w", err)
+	}
+
+	summary := &HistorySummary{Since: options.Since, MaxCommits: options.MaxCommits}
+	commits := make(map[string]bool)
+	reported := make(map[string]bool)
+	var issues []models.Issue
+	err = git.AddedLines(ctx, options.Since, options.MaxCommits, func(added utils.CommitLine) {
+		file := filepath.Join(root, filepath.FromSlash(added.File))
+		if s.shouldExcludeFile(file) {
+			return
+		}
+		commits[added.Commit] = true
+
+		for _, issue := range security.CheckSecretsInLine(file, added.Text, added.Line) {
+			key := issue.Rule + "\x00" + file + "\x00" + issueSecretHash(issue)
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+			issues = append(issues, historyIssue(issue, added))
+		}
+	})
+	summary.Commits = len(commits)
+	summary.Secrets = len(issues)
+	if err != nil && ctx.Err() != nil {
+		// Keep what the walk found before the scan ran out of time
+		summary.Incomplete = true
+		return issues, summary, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, summary, nil
+}
+
+// historyIssue records the commit that introduced a secret on its finding
+func historyIssue(issue models.Issue, added utils.CommitLine) models.Issue {
+	short := added.Commit
+	if len(short) > 12 {
+		short = short[:12]
+	}
+	issue.Message = fmt.Sprintf("%s (introduced in commit %s)", issue.Message, short)
+	issue.FixSuggestion = "Rotate the credential: it stays readable in git history even after the file is changed"
+	if issue.Metadata == nil {
+		issue.Metadata = make(map[string]interface{})
+	}
+	issue.Metadata["commit"] = added.Commit
+	issue.Metadata["commit_author"] = added.Author
+	issue.Metadata["commit_date"] = added.Date
+	issue.Metadata[HistoryKey] = true
+	return issue
+}
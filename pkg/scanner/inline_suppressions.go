@@ -0,0 +1,97 @@
+package scanner
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	"kodevibe/pkg/vibes"
+)
+
+// InlineIgnoreDirective is the comment that suppresses issues on its own
+// line, or on the next line when the comment stands alone. Rules may follow
+// it, separated by spaces or commas; without any it suppresses every rule.
+const InlineIgnoreDirective = "kodevibe:ignore"
+
+var inlineIgnorePattern = regexp.MustCompile(`(?://|#|/\*)\s*` + regexp.QuoteMeta(InlineIgnoreDirective) + `(?:[\s,]+([\w.,\s-]*?))?\s*(?:\*/.*)?$`)
+
+// inlineIgnore is what the directives covering one line suppress
+type inlineIgnore struct {
+	all   bool
+	rules []string
+}
+
+func (ignore *inlineIgnore) covers(rule string) bool {
+	return ignore.all || utils.ContainsString(ignore.rules, rule)
+}
+
+// add merges another directive's rules; an empty list covers every rule
+func (ignore *inlineIgnore) add(rules []string) {
+	if len(rules) == 0 {
+		ignore.all = true
+	}
+	ignore.rules = append(ignore.rules, rules...)
+}
+
+// parseInlineIgnores finds the kodevibe:ignore comments of a file, keyed by
+// the 1-based lines they cover
+func parseInlineIgnores(content []byte) map[int]*inlineIgnore {
+	ignores := make(map[int]*inlineIgnore)
+	cover := func(line int, rules []string) {
+		if ignores[line] == nil {
+			ignores[line] = &inlineIgnore{}
+		}
+		ignores[line].add(rules)
+	}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		if !strings.Contains(line, InlineIgnoreDirective) {
+			continue
+		}
+		match := inlineIgnorePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		rules := strings.FieldsFunc(match[1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\r'
+		})
+		cover(i+1, rules)
+		// A comment alone on its line covers the line below
+		trimmed := strings.TrimSpace(line)
+		if vibes.IsCommentLine(trimmed) {
+			cover(i+2, rules)
+		}
+	}
+	return ignores
+}
+
+// applyInlineSuppressions drops the issues that kodevibe:ignore comments in
+// their files suppress, returning the rest and how many of each rule were
+// dropped. Files are read through contents; issues found in git history are
+// kept, since the files on disk no longer hold their lines.
+func applyInlineSuppressions(issues []models.Issue, contents *vibes.FileContents) ([]models.Issue, map[string]int) {
+	files := make(map[string]map[int]*inlineIgnore)
+	suppressed := make(map[string]int)
+	kept := issues[:0]
+	for _, issue := range issues {
+		if issue.File == "" || issue.Line <= 0 || issue.Metadata["commit"] != nil {
+			kept = append(kept, issue)
+			continue
+		}
+		ignores, read := files[issue.File]
+		if !read {
+			if content, err := contents.Read(issue.File); err == nil && bytes.Contains(content, []byte(InlineIgnoreDirective)) {
+				ignores = parseInlineIgnores(content)
+			}
+			files[issue.File] = ignores
+		}
+		if ignore := ignores[issue.Line]; ignore != nil && ignore.covers(issue.Rule) {
+			suppressed[issue.Rule]++
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept, suppressed
+}
@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+func TestParseInlineIgnores(t *testing.T) {
+	ignores := parseInlineIgnores([]byte(`x = 1 // kodevibe:ignore no-console-log
+# kodevibe:ignore
+y = 2
+z = 3 /* kodevibe:ignore rule-a, rule-b */
+// kodevibe:ignore-next is not a directive
+w = 4 # kodevibe:ignore rule-c
+v = 5
+`))
+
+	require.Contains(t, ignores, 1)
+	assert.True(t, ignores[1].covers("no-console-log"))
+	assert.False(t, ignores[1].covers("other"))
+
+	assert.True(t, ignores[2].covers("anything"))
+	assert.True(t, ignores[3].covers("anything"), "a comment alone on its line covers the next line")
+
+	assert.True(t, ignores[4].covers("rule-a"))
+	assert.True(t, ignores[4].covers("rule-b"))
+	assert.NotContains(t, ignores, 5)
+	assert.True(t, ignores[6].covers("rule-c"))
+	assert.NotContains(t, ignores, 7, "a trailing comment covers its own line only")
+}
+
+func TestScanner_InlineSuppressions(t *testing.T) {
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "app.js")
+	require.NoError(t, os.WriteFile(file, []byte(`console.log("kept");
+console.log("same line"); // kodevibe:ignore no-console-log
+// kodevibe:ignore no-console-log
+console.log("line above");
+/* kodevibe:ignore */
+console.log("every rule");
+console.log("other rule"); // kodevibe:ignore no-debugger
+`), 0644))
+
+	config := &models.Configuration{
+		Scanner: models.ScannerConfig{MaxConcurrency: 2},
+		Vibes: map[models.VibeType]models.VibeConfig{
+			models.VibeTypeCode: vibes.NewCodeChecker().DefaultConfig(),
+		},
+	}
+	scanner, err := NewScanner(config, logrus.New())
+	require.NoError(t, err)
+
+	result, err := scanner.Scan(context.Background(), &models.ScanRequest{Paths: []string{tempDir}, Vibes: []string{"code"}})
+	require.NoError(t, err)
+
+	var lines []int
+	for _, issue := range result.Issues {
+		if issue.Rule == "no-console-log" {
+			lines = append(lines, issue.Line)
+		}
+	}
+	assert.Equal(t, []int{1, 7}, lines)
+	assert.Equal(t, 3, result.Summary.InlineSuppressed)
+	assert.Equal(t, 3, result.Summary.InlineSuppressedByRule["no-console-log"])
+}
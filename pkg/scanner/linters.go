@@ -0,0 +1,367 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"kodevibe/internal/models"
+)
+
+// LinterOverlapKey is the result metadata listing the rules left to other linters
+const LinterOverlapKey = "linter_overlap"
+
+// LinterOverlap records KodeVibe rules turned off because a linter the
+// project runs enforces them
+type LinterOverlap struct {
+	Linter     string   `json:"linter" yaml:"linter"`
+	Config     string   `json:"config" yaml:"config"`
+	LinterRule string   `json:"linter_rule" yaml:"linter_rule"`
+	Rules      []string `json:"rules" yaml:"rules"`
+}
+
+// linter describes a linter whose configuration KodeVibe reads
+type linter struct {
+	name string
+	// files are its config files, in the order the linter looks for them
+	files []string
+	// extensions are the files it lints; covered rules still run on others
+	extensions []string
+	// enabledRules reads the rules a config file turns on; known are the
+	// mapped rules, for configs that turn on all of them
+	enabledRules func(path string, known []string) ([]string, error)
+}
+
+var linters = []linter{
+	{
+		name: "eslint",
+		files: []string{
+			"eslint.config.js", "eslint.config.mjs", "eslint.config.cjs", "eslint.config.ts",
+			".eslintrc.js", ".eslintrc.cjs", ".eslintrc.yaml", ".eslintrc.yml", ".eslintrc.json", ".eslintrc", "package.json",
+		},
+		extensions:   []string{".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".mts", ".cts", ".vue"},
+		enabledRules: eslintRules,
+	},
+	{
+		name:         "golangci",
+		files:        []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"},
+		extensions:   []string{".go"},
+		enabledRules: golangciLinters,
+	},
+}
+
+// defaultLinterMapping lists the KodeVibe rules each linter rule enforces
+var defaultLinterMapping = map[string][]string{
+	"eslint:no-var":                 {"no-var"},
+	"eslint:eqeqeq":                 {"strict-equality"},
+	"eslint:no-console":             {"no-console-log"},
+	"eslint:no-debugger":            {"no-debugger"},
+	"eslint:no-alert":               {"no-browser-dialogs"},
+	"eslint:max-len":                {"line-length"},
+	"eslint:complexity":             {"cyclomatic-complexity"},
+	"eslint:max-depth":              {"nesting-depth"},
+	"eslint:max-lines-per-function": {"function-length"},
+	"eslint:no-magic-numbers":       {"magic-numbers"},
+	"eslint:no-warning-comments":    {"todo-comments"},
+	"eslint:no-empty":               {"swallowed-error"},
+	"eslint:no-eval":                {"eval-usage"},
+	"eslint:jest/no-focused-tests":  {"skipped-test"},
+	"eslint:jest/no-disabled-tests": {"skipped-test"},
+	"golangci:errcheck":             {"unchecked-error"},
+	"golangci:lll":                  {"line-length"},
+	"golangci:gocyclo":              {"cyclomatic-complexity"},
+	"golangci:cyclop":               {"cyclomatic-complexity"},
+	"golangci:funlen":               {"function-length"},
+	"golangci:nestif":               {"nesting-depth"},
+	"golangci:mnd":                  {"magic-numbers"},
+	"golangci:gomnd":                {"magic-numbers"},
+	"golangci:godox":                {"todo-comments"},
+	"golangci:dupl":                 {"duplicate-code"},
+	"golangci:forbidigo":            {"debug-statement"},
+	"golangci:gosec":                {"insecure-randomness", "sql-injection-risk", "command-injection-risk"},
+}
+
+// eslintRecommended are the mapped rules of eslint:recommended
+var eslintRecommended = []string{"no-debugger", "no-empty"}
+
+// golangciStandard are the linters golangci-lint runs by default
+var golangciStandard = []string{"errcheck", "gosimple", "govet", "ineffassign", "staticcheck", "unused"}
+
+// linterMapping merges the configured mapping over the built-in one
+func (s *Scanner) linterMapping() map[string][]string {
+	mapping := make(map[string][]string, len(defaultLinterMapping))
+	for key, rules := range defaultLinterMapping {
+		mapping[key] = rules
+	}
+	for key, rules := range s.config.Linters.Mapping {
+		mapping[key] = rules
+	}
+	return mapping
+}
+
+// linterOverlaps finds the KodeVibe rules that the project's eslint and
+// golangci-lint configs already enforce. A config file that can't be parsed
+// is skipped with a warning; a configured file that doesn't exist is an error.
+func (s *Scanner) linterOverlaps(paths []string) ([]LinterOverlap, error) {
+	if !s.config.Linters.Enabled {
+		return nil, nil
+	}
+	mapping := s.linterMapping()
+
+	var overlaps []LinterOverlap
+	for _, l := range linters {
+		configured := s.config.Linters.ESLintConfig
+		if l.name == "golangci" {
+			configured = s.config.Linters.GolangciConfig
+		}
+		path := configured
+		if path == "" {
+			path = findLinterConfig(paths, l.files)
+		} else if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("failed to read %s config: %w", l.name, err)
+		}
+		if path == "" {
+			continue
+		}
+
+		var known []string
+		for key := range mapping {
+			if name, ok := strings.CutPrefix(key, l.name+":"); ok {
+				known = append(known, name)
+			}
+		}
+		enabled, err := l.enabledRules(path, known)
+		if err != nil {
+			s.logger.WithError(err).WithField("config", path).Warnf("Could not read the %s config; its rules stay enabled in KodeVibe", l.name)
+			continue
+		}
+		for _, rule := range enabled {
+			if covered := mapping[l.name+":"+rule]; len(covered) > 0 {
+				overlaps = append(overlaps, LinterOverlap{Linter: l.name, Config: path, LinterRule: rule, Rules: covered})
+			}
+		}
+	}
+	return overlaps, nil
+}
+
+// dropLinterCovered removes the issues of covered rules in the files their
+// linter checks
+func dropLinterCovered(issues []models.Issue, overlaps []LinterOverlap) []models.Issue {
+	if len(overlaps) == 0 {
+		return issues
+	}
+	covered := make(map[string]bool)
+	for _, overlap := range overlaps {
+		for _, l := range linters {
+			if l.name != overlap.Linter {
+				continue
+			}
+			for _, rule := range overlap.Rules {
+				for _, ext := range l.extensions {
+					covered[rule+ext] = true
+				}
+			}
+		}
+	}
+
+	kept := issues[:0]
+	for _, issue := range issues {
+		if !covered[issue.Rule+strings.ToLower(filepath.Ext(issue.File))] {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// findLinterConfig returns the first of files found in the scanned directories
+func findLinterConfig(paths []string, files []string) string {
+	for _, root := range paths {
+		dir := root
+		if info, err := os.Stat(root); err == nil && !info.IsDir() {
+			dir = filepath.Dir(root)
+		}
+		for _, file := range files {
+			path := filepath.Join(dir, file)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			if file == "package.json" && !packageHasESLintConfig(path) {
+				continue
+			}
+			return path
+		}
+	}
+	return ""
+}
+
+func packageHasESLintConfig(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		ESLintConfig json.RawMessage `json:"eslintConfig"`
+	}
+	return json.Unmarshal(data, &pkg) == nil && len(pkg.ESLintConfig) > 0
+}
+
+// eslintConfig is the part of a legacy .eslintrc KodeVibe reads
+type eslintConfig struct {
+	Extends interface{}            `yaml:"extends" json:"extends"`
+	Rules   map[string]interface{} `yaml:"rules" json:"rules"`
+}
+
+var (
+	// eslintJSRulePattern matches rule settings such as 'no-var': 'error' or
+	// eqeqeq: ["warn", "always"] in JavaScript configs
+	eslintJSRulePattern = regexp.MustCompile(`["']?(@?[\w-]+(?:/[\w-]+)?)["']?\s*:\s*\[?\s*["']?(off|warn|error|0|1|2)["']?\s*[,\]}\n]`)
+	// eslintJSRecommendedPattern matches eslint's recommended rule set
+	eslintJSRecommendedPattern = regexp.MustCompile(`js\.configs\.recommended|["']eslint:recommended["']`)
+)
+
+// eslintRules returns the rules an ESLint config turns on. JSON and YAML
+// configs are parsed; JavaScript configs, which can't be evaluated here,
+// are read for literal rule settings.
+func eslintRules(path string, _ []string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config eslintConfig
+	switch base := filepath.Base(path); {
+	case base == "package.json":
+		var pkg struct {
+			ESLintConfig eslintConfig `json:"eslintConfig"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return nil, fmt.Errorf("invalid package.json: %w", err)
+		}
+		config = pkg.ESLintConfig
+	case strings.HasSuffix(base, "js") || strings.HasSuffix(base, ".ts"):
+		config.Rules = make(map[string]interface{})
+		for _, match := range eslintJSRulePattern.FindAllStringSubmatch(string(data), -1) {
+			config.Rules[match[1]] = match[2]
+		}
+		if eslintJSRecommendedPattern.Match(data) {
+			config.Extends = "eslint:recommended"
+		}
+	default:
+		// YAML is a superset of JSON, so this reads .eslintrc.json too
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("invalid ESLint config: %w", err)
+		}
+	}
+
+	enabled := make(map[string]bool)
+	if extendsRecommended(config.Extends) {
+		for _, rule := range eslintRecommended {
+			enabled[rule] = true
+		}
+	}
+	for rule, setting := range config.Rules {
+		enabled[rule] = eslintRuleOn(setting)
+	}
+	return trueKeys(enabled), nil
+}
+
+func extendsRecommended(extends interface{}) bool {
+	switch extends := extends.(type) {
+	case string:
+		return extends == "eslint:recommended"
+	case []interface{}:
+		for _, item := range extends {
+			if item == "eslint:recommended" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// eslintRuleOn reports whether a rule setting such as "error", 1 or
+// ["warn", {...}] turns the rule on
+func eslintRuleOn(setting interface{}) bool {
+	if list, ok := setting.([]interface{}); ok {
+		if len(list) == 0 {
+			return false
+		}
+		setting = list[0]
+	}
+	switch level := fmt.Sprint(setting); level {
+	case "warn", "error", "1", "2":
+		return true
+	default:
+		return false
+	}
+}
+
+// golangciConfig is the part of a golangci-lint config KodeVibe reads, in
+// the formats of both version 1 and 2
+type golangciConfig struct {
+	Linters struct {
+		Default    string   `yaml:"default" toml:"default"`
+		EnableAll  bool     `yaml:"enable-all" toml:"enable-all"`
+		DisableAll bool     `yaml:"disable-all" toml:"disable-all"`
+		Enable     []string `yaml:"enable" toml:"enable"`
+		Disable    []string `yaml:"disable" toml:"disable"`
+	} `yaml:"linters" toml:"linters"`
+}
+
+// golangciLinters returns the linters a golangci-lint config runs; with
+// enable-all, those among known
+func golangciLinters(path string, known []string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config golangciConfig
+	if filepath.Ext(path) == ".toml" {
+		err = toml.Unmarshal(data, &config)
+	} else {
+		err = yaml.Unmarshal(data, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid golangci-lint config: %w", err)
+	}
+
+	enabled := make(map[string]bool)
+	switch {
+	case config.Linters.EnableAll || config.Linters.Default == "all":
+		for _, name := range known {
+			enabled[name] = true
+		}
+	case config.Linters.DisableAll || (config.Linters.Default != "" && config.Linters.Default != "standard"):
+		// Only the linters enabled by name run
+	default:
+		for _, name := range golangciStandard {
+			enabled[name] = true
+		}
+	}
+	for _, name := range config.Linters.Enable {
+		enabled[name] = true
+	}
+	for _, name := range config.Linters.Disable {
+		enabled[name] = false
+	}
+	return trueKeys(enabled), nil
+}
+
+// trueKeys returns the keys set to true, sorted
+func trueKeys(set map[string]bool) []string {
+	var keys []string
+	for key, on := range set {
+		if on {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
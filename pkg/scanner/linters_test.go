@@ -0,0 +1,130 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+func TestESLintRules(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	rules, err := eslintRules(write(".eslintrc.json", `{
+  "extends": ["eslint:recommended"],
+  "rules": {"no-var": "error", "eqeqeq": ["warn", "always"], "no-console": "off", "no-empty": 0}
+}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eqeqeq", "no-debugger", "no-var"}, rules)
+
+	rules, err = eslintRules(write(".eslintrc.yml", "rules:\n  max-len: [2, 120]\n"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"max-len"}, rules)
+
+	rules, err = eslintRules(write("package.json", `{"name": "app", "eslintConfig": {"rules": {"no-eval": "error"}}}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"no-eval"}, rules)
+
+	rules, err = eslintRules(write("eslint.config.js", `import js from "@eslint/js";
+export default [
+  js.configs.recommended,
+  { rules: { "no-var": "error", eqeqeq: ["error", "smart"], 'no-console': 'off' } },
+];
+`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eqeqeq", "no-debugger", "no-empty", "no-var"}, rules)
+
+	_, err = eslintRules(write(".eslintrc", "{ not json"), nil)
+	assert.Error(t, err)
+}
+
+func TestGolangciLinters(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	known := []string{"errcheck", "lll", "funlen"}
+
+	linters, err := golangciLinters(write(".golangci.yml", "linters:\n  enable: [lll, gofmt]\n"), known)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"errcheck", "gofmt", "gosimple", "govet", "ineffassign", "lll", "staticcheck", "unused"}, linters)
+
+	linters, err = golangciLinters(write(".golangci.yml", "linters:\n  disable-all: true\n  enable: [funlen]\n"), known)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"funlen"}, linters)
+
+	linters, err = golangciLinters(write(".golangci.yml", "version: \"2\"\nlinters:\n  default: all\n  disable: [lll]\n"), known)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"errcheck", "funlen"}, linters)
+
+	linters, err = golangciLinters(write(".golangci.toml", "[linters]\ndefault = \"none\"\nenable = [\"lll\"]\n"), known)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lll"}, linters)
+}
+
+func TestScanner_LinterOverlap(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".eslintrc.json"), []byte(`{"rules": {"no-var": "error"}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".golangci.yml"), []byte("linters:\n  disable-all: true\n  enable: [lll]\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.js"), []byte("var count = 1;\nconsole.log(count);\n"), 0644))
+
+	scan := func(linters models.LintersConfig) *models.ScanResult {
+		config := &models.Configuration{Vibes: vibes.DefaultVibeConfigs(), Linters: linters}
+		s, err := NewScanner(config, logrus.New())
+		require.NoError(t, err)
+		result, err := s.Scan(context.Background(), &models.ScanRequest{Paths: []string{dir}, Vibes: []string{"code"}})
+		require.NoError(t, err)
+		return result
+	}
+	rules := func(result *models.ScanResult) []string {
+		var found []string
+		for _, issue := range result.Issues {
+			found = append(found, issue.Rule)
+		}
+		return found
+	}
+
+	// Off by default
+	assert.Contains(t, rules(scan(models.LintersConfig{})), "no-var")
+
+	result := scan(models.LintersConfig{Enabled: true})
+	assert.NotContains(t, rules(result), "no-var")
+	assert.Contains(t, rules(result), "no-console-log")
+	overlaps, ok := result.Metadata[LinterOverlapKey].([]LinterOverlap)
+	require.True(t, ok)
+	assert.Contains(t, overlaps, LinterOverlap{Linter: "golangci", Config: filepath.Join(dir, ".golangci.yml"), LinterRule: "lll", Rules: []string{"line-length"}})
+
+	// The configured mapping overrides the built-in one
+	result = scan(models.LintersConfig{Enabled: true, Mapping: map[string][]string{"eslint:no-var": {}}})
+	assert.Contains(t, rules(result), "no-var")
+}
+
+func TestDropLinterCovered(t *testing.T) {
+	issues := []models.Issue{
+		{Rule: "line-length", File: "main.go"},
+		{Rule: "line-length", File: "app.py"},
+		{Rule: "no-var", File: "web/App.JSX"},
+	}
+	overlaps := []LinterOverlap{
+		{Linter: "golangci", LinterRule: "lll", Rules: []string{"line-length"}},
+		{Linter: "eslint", LinterRule: "no-var", Rules: []string{"no-var"}},
+	}
+
+	kept := dropLinterCovered(issues, overlaps)
+	require.Len(t, kept, 1)
+	assert.Equal(t, "app.py", kept[0].File, "rules still run on files the linter doesn't check")
+}
@@ -0,0 +1,214 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"kodevibe/internal/models"
+)
+
+// DefaultRepoConcurrency is how many repositories are scanned at once when no limit is given
+const DefaultRepoConcurrency = 4
+
+// RepoTarget is one entry of a repository list: a local path or a git URL to clone
+type RepoTarget struct {
+	Name   string
+	Source string
+	Remote bool
+}
+
+// ParseRepoList reads one repository per line. Blank lines and lines starting
+// with # are skipped; URLs (scheme:// or git@host:) are cloned, anything else is a local path.
+func ParseRepoList(r io.Reader) ([]RepoTarget, error) {
+	var targets []RepoTarget
+	names := make(map[string]int)
+
+	lines := bufio.NewScanner(r)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		target := RepoTarget{Source: line, Remote: isRemoteRepo(line)}
+		target.Name = repoName(line)
+
+		// Keep names unique so per-repo rows can be told apart
+		names[target.Name]++
+		if n := names[target.Name]; n > 1 {
+			target.Name = fmt.Sprintf("%s-%d", target.Name, n)
+		}
+		targets = append(targets, target)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read repository list: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("repository list is empty")
+	}
+
+	return targets, nil
+}
+
+func isRemoteRepo(source string) bool {
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@")
+}
+
+// repoName derives a short display name from a path or URL
+func repoName(source string) string {
+	name := strings.TrimRight(source, "/")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(name, ".git")
+	if name == "" || name == "." {
+		if abs, err := filepath.Abs(source); err == nil {
+			name = filepath.Base(abs)
+		}
+	}
+	return name
+}
+
+// ScanRepos scans each repository independently with at most concurrency scans
+// in flight. Every scan reuses the request as a template with its own paths.
+// Results keep the order of targets; a repository that fails to clone or scan
+// is reported with its error instead of aborting the others.
+func (s *Scanner) ScanRepos(ctx context.Context, targets []RepoTarget, request *models.ScanRequest, concurrency int) []models.RepoScanResult {
+	if concurrency <= 0 {
+		concurrency = DefaultRepoConcurrency
+	}
+
+	results := make([]models.RepoScanResult, len(targets))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target RepoTarget) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = s.scanRepo(ctx, target, request)
+		}(i, target)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// scanRepo scans one repository, cloning it first when it is remote
+func (s *Scanner) scanRepo(ctx context.Context, target RepoTarget, template *models.ScanRequest) models.RepoScanResult {
+	repo := models.RepoScanResult{Name: target.Name, Source: target.Source}
+	log := s.logger.WithFields(logrus.Fields{"repo": target.Name, "source": target.Source})
+
+	root := target.Source
+	if target.Remote {
+		dir, err := os.MkdirTemp("", "kodevibe-repo-*")
+		if err != nil {
+			repo.Error = fmt.Sprintf("failed to create clone directory: %v", err)
+			return repo
+		}
+		defer os.RemoveAll(dir)
+
+		if err := cloneRepo(ctx, target.Source, dir); err != nil {
+			log.WithError(err).Warn("Failed to clone repository")
+			repo.Error = err.Error()
+			return repo
+		}
+		root = dir
+	} else if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		repo.Error = fmt.Sprintf("%s is not a directory", root)
+		return repo
+	}
+
+	request := models.ScanRequest{CreatedAt: time.Now()}
+	if template != nil {
+		request = *template
+	}
+	request.ID = ""
+	request.Paths = []string{root}
+
+	result, err := s.Scan(ctx, &request)
+	if err != nil && !errors.Is(err, ErrScanIncomplete) {
+		log.WithError(err).Warn("Repository scan failed")
+		repo.Error = err.Error()
+		return repo
+	}
+	if err != nil {
+		repo.Error = err.Error()
+	}
+
+	// Report cloned files relative to the repository, not the temporary checkout
+	if target.Remote {
+		for i := range result.Issues {
+			if rel, relErr := filepath.Rel(root, result.Issues[i].File); relErr == nil {
+				result.Issues[i].File = filepath.ToSlash(rel)
+			}
+		}
+	}
+	result.ProjectPath = target.Source
+	repo.Result = result
+
+	return repo
+}
+
+// cloneRepo makes a shallow clone of url into dir
+func cloneRepo(ctx context.Context, url, dir string) error {
+	cmd := exec.CommandContext(ctx, "git", "clone", "--quiet", "--depth", "1", "--", url, dir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone %s failed: %w: %s", url, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RollupRepos aggregates per-repository results into an organisation summary.
+// The average score covers only repositories that were scanned.
+func RollupRepos(repos []models.RepoScanResult, gradeThresholds []models.GradeThreshold) models.OrgRollup {
+	rollup := models.OrgRollup{
+		Repos:            len(repos),
+		IssuesBySeverity: make(map[models.SeverityLevel]int),
+	}
+
+	total := 0.0
+	for _, repo := range repos {
+		if repo.Result == nil {
+			rollup.ReposFailed++
+			continue
+		}
+
+		result := repo.Result
+		rollup.ReposScanned++
+		rollup.FilesScanned += result.FilesScanned
+		rollup.TotalIssues += len(result.Issues)
+		for _, issue := range result.Issues {
+			rollup.IssuesBySeverity[issue.Severity]++
+		}
+
+		score := result.Summary.Score
+		total += score
+		if rollup.LowestRepo == "" || score < rollup.LowestScore {
+			rollup.LowestScore = score
+			rollup.LowestRepo = repo.Name
+		}
+	}
+
+	if rollup.ReposScanned > 0 {
+		rollup.AverageScore = total / float64(rollup.ReposScanned)
+		rollup.Grade = models.GradeForScore(rollup.AverageScore, gradeThresholds)
+	}
+
+	return rollup
+}
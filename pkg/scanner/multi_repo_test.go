@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestParseRepoList(t *testing.T) {
+	input := `# platform repos
+/src/api
+
+https://github.com/acme/api.git
+git@github.com:acme/web.git
+./web/
+`
+	targets, err := ParseRepoList(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, targets, 4)
+
+	assert.Equal(t, RepoTarget{Name: "api", Source: "/src/api"}, targets[0])
+	assert.Equal(t, RepoTarget{Name: "api-2", Source: "https://github.com/acme/api.git", Remote: true}, targets[1])
+	assert.Equal(t, RepoTarget{Name: "web", Source: "git@github.com:acme/web.git", Remote: true}, targets[2])
+	assert.Equal(t, RepoTarget{Name: "web-2", Source: "./web/"}, targets[3])
+
+	_, err = ParseRepoList(strings.NewReader("# nothing here\n\n"))
+	assert.Error(t, err)
+}
+
+func TestScanner_ScanRepos(t *testing.T) {
+	root := t.TempDir()
+	clean := filepath.Join(root, "clean")
+	noisy := filepath.Join(root, "noisy")
+	require.NoError(t, os.MkdirAll(clean, 0755))
+	require.NoError(t, os.MkdirAll(noisy, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(clean, "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(noisy, "app.js"), []byte("console.log('a');\nconsole.log('b');\n"), 0644))
+
+	scanner, err := NewScanner(&models.Configuration{Scanner: models.ScannerConfig{MaxConcurrency: 2}}, logrus.New())
+	require.NoError(t, err)
+
+	targets := []RepoTarget{
+		{Name: "clean", Source: clean},
+		{Name: "noisy", Source: noisy},
+		{Name: "missing", Source: filepath.Join(root, "missing")},
+	}
+	repos := scanner.ScanRepos(context.Background(), targets, &models.ScanRequest{Vibes: []string{"code"}}, 2)
+	require.Len(t, repos, 3)
+
+	assert.Equal(t, "clean", repos[0].Name)
+	require.NotNil(t, repos[0].Result)
+	assert.Empty(t, repos[0].Result.Issues)
+	assert.Equal(t, clean, repos[0].Result.ProjectPath)
+
+	require.NotNil(t, repos[1].Result)
+	assert.NotEmpty(t, repos[1].Result.Issues)
+	assert.NotEqual(t, repos[0].Result.ID, repos[1].Result.ID, "each repository gets its own scan")
+
+	assert.Nil(t, repos[2].Result)
+	assert.Contains(t, repos[2].Error, "not a directory")
+
+	rollup := RollupRepos(repos, nil)
+	assert.Equal(t, 3, rollup.Repos)
+	assert.Equal(t, 2, rollup.ReposScanned)
+	assert.Equal(t, 1, rollup.ReposFailed)
+	assert.Equal(t, 2, rollup.FilesScanned)
+	assert.Equal(t, len(repos[1].Result.Issues), rollup.TotalIssues)
+	assert.Equal(t, "noisy", rollup.LowestRepo)
+	assert.InDelta(t, (repos[0].Result.Summary.Score+repos[1].Result.Summary.Score)/2, rollup.AverageScore, 0.001)
+	assert.NotEmpty(t, rollup.Grade)
+}
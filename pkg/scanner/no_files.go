@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kodevibe/internal/models"
+)
+
+// NoFilesScannedKey is the result metadata key holding a NoFilesWarning
+// when a scan matched no files
+const NoFilesScannedKey = "no_files_scanned"
+
+// NoFilesWarning explains why a scan examined no files, with the filters
+// that were in effect
+type NoFilesWarning struct {
+	Reason          string   `json:"reason" yaml:"reason"`
+	Paths           []string `json:"paths" yaml:"paths"`
+	DiscoveredFiles int      `json:"discovered_files" yaml:"discovered_files"`
+	// ExcludedBy counts the discovered files each exclude pattern removed
+	ExcludedBy   map[string]int `json:"excluded_by,omitempty" yaml:"excluded_by,omitempty"`
+	Languages    []string       `json:"languages,omitempty" yaml:"languages,omitempty"`
+	StagedOnly   bool           `json:"staged_only,omitempty" yaml:"staged_only,omitempty"`
+	DiffTarget   string         `json:"diff_target,omitempty" yaml:"diff_target,omitempty"`
+	ExcludeFiles []string       `json:"exclude_files,omitempty" yaml:"exclude_files,omitempty"`
+	// ExcludePatterns match file names, ExcludePaths directory paths
+	ExcludePatterns []string `json:"exclude_patterns,omitempty" yaml:"exclude_patterns,omitempty"`
+	ExcludePaths    []string `json:"exclude_paths,omitempty" yaml:"exclude_paths,omitempty"`
+}
+
+// noFilesWarning describes why none of the discovered files are left to scan
+func (s *Scanner) noFilesWarning(request *models.ScanRequest, discovered []string) NoFilesWarning {
+	warning := NoFilesWarning{
+		Paths:           request.Paths,
+		DiscoveredFiles: len(discovered),
+		Languages:       request.Languages,
+		StagedOnly:      request.StagedOnly,
+		DiffTarget:      request.DiffTarget,
+		ExcludeFiles:    s.config.Exclude.Files,
+		ExcludePatterns: s.config.Exclude.Patterns,
+		ExcludePaths:    s.config.Exclude.Paths,
+	}
+
+	excluded := 0
+	for _, file := range discovered {
+		if pattern := s.excludedBy(file); pattern != "" {
+			if warning.ExcludedBy == nil {
+				warning.ExcludedBy = make(map[string]int)
+			}
+			warning.ExcludedBy[pattern]++
+			excluded++
+		}
+	}
+
+	switch {
+	case len(discovered) == 0 && request.StagedOnly:
+		warning.Reason = "no staged files were found under the scan paths"
+	case len(discovered) == 0 && request.DiffTarget != "":
+		warning.Reason = fmt.Sprintf("no files changed since %s under the scan paths", request.DiffTarget)
+	case len(discovered) == 0:
+		warning.Reason = fmt.Sprintf("no files were found under %s; check the paths exist and are not ignored", strings.Join(request.Paths, ", "))
+	case excluded == len(discovered):
+		warning.Reason = fmt.Sprintf("all %d discovered files were excluded by %s", len(discovered), excludedBySummary(warning.ExcludedBy))
+	case excluded > 0:
+		warning.Reason = fmt.Sprintf("%d of %d discovered files were excluded by %s, and --languages %s matched none of the rest",
+			excluded, len(discovered), excludedBySummary(warning.ExcludedBy), strings.Join(request.Languages, ","))
+	default:
+		warning.Reason = fmt.Sprintf("none of the %d discovered files are written in --languages %s", len(discovered), strings.Join(request.Languages, ","))
+	}
+	return warning
+}
+
+// excludedBySummary lists exclude patterns by how many files they removed, most first
+func excludedBySummary(excludedBy map[string]int) string {
+	patterns := make([]string, 0, len(excludedBy))
+	for pattern := range excludedBy {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if excludedBy[patterns[i]] != excludedBy[patterns[j]] {
+			return excludedBy[patterns[i]] > excludedBy[patterns[j]]
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	parts := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		parts[i] = fmt.Sprintf("%q (%d)", pattern, excludedBy[pattern])
+	}
+	return "exclude patterns " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/codeowners"
+)
+
+// assignOwners records in each issue's metadata the owners its file has in
+// the CODEOWNERS file: scanner.codeowners.file, or the one found in the
+// repository of the first scanned path. A configured file that doesn't
+// exist is an error; a repository without one leaves every issue unowned.
+func (s *Scanner) assignOwners(paths []string, issues []models.Issue, log *logrus.Entry) error {
+	settings := s.config.Scanner.CodeOwners
+	if !settings.Enabled || len(issues) == 0 {
+		return nil
+	}
+
+	var owners *codeowners.File
+	if settings.File != "" {
+		if _, err := os.Stat(settings.File); err != nil {
+			return fmt.Errorf("failed to read scanner.codeowners.file: %w", err)
+		}
+		root := codeowners.RepoRoot(settings.File)
+		if root == "" {
+			root = filepath.Dir(settings.File)
+		}
+		file, err := codeowners.Load(settings.File, root)
+		if err != nil {
+			return err
+		}
+		owners = file
+	} else if len(paths) > 0 {
+		file, err := codeowners.Find(paths[0])
+		if err != nil {
+			// A broken CODEOWNERS shouldn't fail a scan that didn't ask for it
+			log.WithError(err).Warn("Could not read CODEOWNERS; findings are left unowned")
+			return nil
+		}
+		owners = file
+	}
+	if owners == nil {
+		return nil
+	}
+
+	owners.Annotate(issues)
+	log.WithFields(logrus.Fields{
+		"codeowners": owners.Path,
+		"rules":      len(owners.Rules),
+	}).Debug("Assigned findings to their owners")
+	return nil
+}
+
+// issuesByOwner counts the issues of each owner, under codeowners.Unowned for
+// files without one. It is nil when no issue has an owner.
+func issuesByOwner(issues []models.Issue) map[string]int {
+	groups := codeowners.GroupByOwner(issues)
+	if _, onlyUnowned := groups[codeowners.Unowned]; len(groups) == 0 || (onlyUnowned && len(groups) == 1) {
+		return nil
+	}
+	counts := make(map[string]int, len(groups))
+	for owner, owned := range groups {
+		counts[owner] = len(owned)
+	}
+	return counts
+}
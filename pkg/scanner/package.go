@@ -0,0 +1,88 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// goPackage contains the fields of `go list -json` used to resolve package files
+type goPackage struct {
+	Dir            string   `json:"Dir"`
+	GoFiles        []string `json:"GoFiles"`
+	CgoFiles       []string `json:"CgoFiles"`
+	TestGoFiles    []string `json:"TestGoFiles"`
+	XTestGoFiles   []string `json:"XTestGoFiles"`
+	IgnoredGoFiles []string `json:"IgnoredGoFiles"`
+}
+
+// ResolvePackage returns the paths to scan for a single package or module.
+// Go packages, given as an import path or a directory, resolve to exactly
+// their source and test files via `go list`. Any other directory is scanned
+// as a whole.
+func ResolvePackage(ctx context.Context, target string) ([]string, error) {
+	info, err := os.Stat(target)
+	isDir := err == nil && info.IsDir()
+
+	if isDir && !hasGoFiles(target) {
+		return []string{target}, nil
+	}
+
+	// Directories are listed from inside so relative and absolute paths both work
+	dir, pattern := "", target
+	if isDir {
+		dir, pattern = target, "."
+	}
+
+	files, err := listGoPackage(ctx, dir, pattern)
+	if err != nil {
+		if isDir {
+			return []string{target}, nil
+		}
+		return nil, fmt.Errorf("failed to resolve package %s: %w", target, err)
+	}
+
+	return files, nil
+}
+
+// listGoPackage runs `go list` for a single package and returns its files
+func listGoPackage(ctx context.Context, dir, pattern string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-json", "--", pattern)
+	cmd.Dir = dir
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("go list failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("go list failed: %w", err)
+	}
+
+	var pkg goPackage
+	if err := json.Unmarshal(output, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse go list output: %w", err)
+	}
+
+	var files []string
+	for _, group := range [][]string{pkg.GoFiles, pkg.CgoFiles, pkg.TestGoFiles, pkg.XTestGoFiles, pkg.IgnoredGoFiles} {
+		for _, name := range group {
+			files = append(files, filepath.Join(pkg.Dir, name))
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("package %s has no Go files", pattern)
+	}
+
+	return files, nil
+}
+
+// hasGoFiles reports whether a directory directly contains Go source files
+func hasGoFiles(dir string) bool {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	return err == nil && len(matches) > 0
+}
@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePackage_GoPackage(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	moduleDir := t.TempDir()
+	pkgDir := filepath.Join(moduleDir, "widget")
+	require.NoError(t, os.MkdirAll(filepath.Join(pkgDir, "internal"), 0755))
+
+	files := map[string]string{
+		filepath.Join(moduleDir, "go.mod"):             "module example.com/demo\n\ngo 1.21\n",
+		filepath.Join(pkgDir, "widget.go"):             "package widget\n",
+		filepath.Join(pkgDir, "widget_test.go"):        "package widget\n",
+		filepath.Join(pkgDir, "export_test.go"):        "package widget_test\n",
+		filepath.Join(pkgDir, "README.md"):             "# widget\n",
+		filepath.Join(pkgDir, "internal", "helper.go"): "package internal\n",
+		filepath.Join(moduleDir, "main.go"):            "package main\n",
+	}
+	for path, content := range files {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	resolved, err := ResolvePackage(context.Background(), pkgDir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range resolved {
+		names = append(names, filepath.Base(file))
+	}
+	assert.ElementsMatch(t, []string{"widget.go", "widget_test.go", "export_test.go"}, names)
+}
+
+func TestResolvePackage_DirectoryFallback(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.js"), []byte("export {}\n"), 0644))
+
+	resolved, err := ResolvePackage(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{dir}, resolved)
+}
+
+func TestResolvePackage_Unknown(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	_, err := ResolvePackage(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
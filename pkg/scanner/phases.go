@@ -0,0 +1,29 @@
+package scanner
+
+import (
+	"context"
+	"time"
+)
+
+// IncompletePhaseKey is the result metadata naming the phase that ran out of
+// time when a scan is incomplete
+const IncompletePhaseKey = "incomplete_phase"
+
+// Scan phases, in the order they run
+const (
+	PhaseDiscovery = "discovery"
+	PhaseAnalysis  = "analysis"
+)
+
+// discoveryContext bounds file discovery to scanner.discovery_budget of the
+// time left before ctx's deadline. Time discovery doesn't use stays with the
+// analysis phase.
+func (s *Scanner) discoveryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	budget := s.config.Scanner.DiscoveryBudget
+	deadline, ok := ctx.Deadline()
+	if !ok || budget <= 0 || budget >= 1 {
+		return context.WithCancel(ctx)
+	}
+	share := time.Duration(float64(time.Until(deadline)) * budget)
+	return context.WithTimeout(ctx, share)
+}
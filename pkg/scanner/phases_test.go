@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+func TestScanner_discoveryContext(t *testing.T) {
+	s, err := NewScanner(&models.Configuration{Scanner: models.ScannerConfig{DiscoveryBudget: 0.25}}, logrus.New())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+	defer cancel()
+	discoveryCtx, cancelDiscovery := s.discoveryContext(ctx)
+	defer cancelDiscovery()
+	deadline, ok := discoveryCtx.Deadline()
+	require.True(t, ok)
+	assert.InDelta(t, float64(time.Second), float64(time.Until(deadline)), float64(100*time.Millisecond))
+
+	// Without a scan deadline, or a budget, discovery isn't bounded
+	discoveryCtx, cancelDiscovery = s.discoveryContext(context.Background())
+	defer cancelDiscovery()
+	_, ok = discoveryCtx.Deadline()
+	assert.False(t, ok)
+
+	s.config.Scanner.DiscoveryBudget = 0
+	discoveryCtx, cancelDiscovery = s.discoveryContext(ctx)
+	defer cancelDiscovery()
+	deadline, _ = discoveryCtx.Deadline()
+	parent, _ := ctx.Deadline()
+	assert.Equal(t, parent, deadline)
+}
+
+func TestScanner_ScanReportsDiscoveryTimeout(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+
+	config := &models.Configuration{Vibes: vibes.DefaultVibeConfigs(), Scanner: models.ScannerConfig{DiscoveryBudget: 0.25}}
+	s, err := NewScanner(config, logrus.New())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	result, err := s.Scan(ctx, &models.ScanRequest{Paths: []string{dir}})
+	require.ErrorIs(t, err, ErrScanIncomplete)
+	assert.Equal(t, PhaseDiscovery, result.Metadata[IncompletePhaseKey])
+	assert.Equal(t, "timeout", result.Metadata["incomplete_reason"])
+	assert.Equal(t, true, result.Metadata["partial"])
+}
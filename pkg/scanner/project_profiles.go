@@ -0,0 +1,208 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// ProjectTypeAutoDetect is the project.type value that detects the type from the scanned paths
+const ProjectTypeAutoDetect = "auto-detect"
+
+// projectProfile is the default vibe set and thresholds for one project type
+type projectProfile struct {
+	Vibes []models.VibeType
+	// Settings replace vibe settings the configuration leaves at their defaults
+	Settings map[models.VibeType]map[string]interface{}
+}
+
+// projectProfiles are used by zero-config scans of a detected (or configured) project type.
+// The performance vibe's bundle and DOM rules only make sense for JavaScript projects.
+var projectProfiles = map[string]projectProfile{
+	"go": {
+		Vibes: []models.VibeType{models.VibeTypeCode, models.VibeTypeDependency, models.VibeTypeFile, models.VibeTypeGit, models.VibeTypeSecurity},
+	},
+	"python": {
+		Vibes: []models.VibeType{models.VibeTypeCode, models.VibeTypeDependency, models.VibeTypeDocumentation, models.VibeTypeFile, models.VibeTypeGit, models.VibeTypeSecurity},
+		Settings: map[models.VibeType]map[string]interface{}{
+			// black's line length, and shorter functions than the generic default
+			models.VibeTypeCode: {"max_line_length": 88, "max_function_length": 40},
+		},
+	},
+	"javascript": {
+		Vibes: []models.VibeType{models.VibeTypeCode, models.VibeTypeDependency, models.VibeTypeFile, models.VibeTypeGit, models.VibeTypePerformance, models.VibeTypeSecurity},
+		Settings: map[models.VibeType]map[string]interface{}{
+			models.VibeTypeCode: {"max_line_length": 100},
+		},
+	},
+	"typescript": {
+		Vibes: []models.VibeType{models.VibeTypeCode, models.VibeTypeDependency, models.VibeTypeFile, models.VibeTypeGit, models.VibeTypePerformance, models.VibeTypeSecurity},
+		Settings: map[models.VibeType]map[string]interface{}{
+			models.VibeTypeCode: {"max_line_length": 100},
+		},
+	},
+	"java": {
+		Vibes: []models.VibeType{models.VibeTypeCode, models.VibeTypeDependency, models.VibeTypeFile, models.VibeTypeGit, models.VibeTypeSecurity},
+	},
+	"rust": {
+		Vibes: []models.VibeType{models.VibeTypeCode, models.VibeTypeDependency, models.VibeTypeFile, models.VibeTypeGit, models.VibeTypeSecurity},
+		Settings: map[models.VibeType]map[string]interface{}{
+			// rustfmt's max_width
+			models.VibeTypeCode: {"max_line_length": 100},
+		},
+	},
+}
+
+// projectMarkers are the files at a project root that identify its type
+var projectMarkers = []struct {
+	projectType string
+	files       []string
+}{
+	{"go", []string{"go.mod"}},
+	{"python", []string{"pyproject.toml", "setup.py", "setup.cfg", "requirements.txt", "Pipfile"}},
+	{"typescript", []string{"tsconfig.json"}},
+	{"javascript", []string{"package.json"}},
+	{"java", []string{"pom.xml", "build.gradle", "build.gradle.kts"}},
+	{"rust", []string{"Cargo.toml"}},
+}
+
+// DetectProjectType returns the type of project the scanned paths belong to
+// from marker files such as go.mod or package.json. It returns "" when no
+// marker is found or the paths mix project types.
+func DetectProjectType(paths []string) string {
+	detected := make(map[string]bool)
+	for _, root := range paths {
+		dir := root
+		if info, err := os.Stat(root); err == nil && !info.IsDir() {
+			dir = filepath.Dir(root)
+		}
+		for _, marker := range projectMarkers {
+			for _, file := range marker.files {
+				if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+					detected[marker.projectType] = true
+					break
+				}
+			}
+		}
+	}
+
+	// A package.json next to a tsconfig.json is a TypeScript project
+	if detected["typescript"] {
+		delete(detected, "javascript")
+	}
+	if len(detected) != 1 {
+		return ""
+	}
+	for projectType := range detected {
+		return projectType
+	}
+	return ""
+}
+
+// projectType returns the project type whose profile applies to a scan, or ""
+// when profiles are off. project.type is either a profile name, "auto-detect"
+// or anything else (e.g. "generic") to always use the configured vibes.
+func (s *Scanner) projectType(paths []string) string {
+	configured := strings.ToLower(s.config.Project.Type)
+	if configured == ProjectTypeAutoDetect {
+		return DetectProjectType(paths)
+	}
+	if _, known := projectProfiles[configured]; known {
+		return configured
+	}
+	return ""
+}
+
+// vibesCustomized reports whether the configuration pins the vibes to run,
+// either through scanner.enabled_vibes or by enabling or disabling a vibe
+// differently from its default
+func (s *Scanner) vibesCustomized() bool {
+	if len(s.vibes) > 0 {
+		return true
+	}
+	for _, checker := range vibes.BuiltinCheckers() {
+		if vibeConfig, exists := s.config.Vibes[checker.Type()]; exists && vibeConfig.Enabled != checker.DefaultConfig().Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// registryFor returns the vibe registry for a scan of projectType, configured
+// with the profile's thresholds where the configuration keeps the defaults
+func (s *Scanner) registryFor(projectType string) (*vibes.Registry, error) {
+	profile, ok := projectProfiles[projectType]
+	if !ok || len(profile.Settings) == 0 {
+		return s.vibeRegistry, nil
+	}
+
+	s.profileMutex.Lock()
+	defer s.profileMutex.Unlock()
+
+	if registry, ok := s.profileRegistries[projectType]; ok {
+		return registry, nil
+	}
+
+	config := *s.config
+	config.Vibes = make(map[models.VibeType]models.VibeConfig, len(s.config.Vibes))
+	for vibeType, vibeConfig := range s.config.Vibes {
+		config.Vibes[vibeType] = vibeConfig
+	}
+
+	defaults := vibes.DefaultVibeConfigs()
+	for vibeType, settings := range profile.Settings {
+		vibeConfig, exists := config.Vibes[vibeType]
+		if !exists {
+			vibeConfig = defaults[vibeType]
+		}
+		merged := make(map[string]interface{}, len(vibeConfig.Settings)+len(settings))
+		for key, value := range vibeConfig.Settings {
+			merged[key] = value
+		}
+		for key, value := range settings {
+			current, set := vibeConfig.Settings[key]
+			if !set || reflect.DeepEqual(current, defaults[vibeType].Settings[key]) {
+				merged[key] = value
+			}
+		}
+		vibeConfig.Settings = merged
+		config.Vibes[vibeType] = vibeConfig
+	}
+
+	registry := vibes.NewRegistry()
+	if err := registry.RegisterAllVibes(&config); err != nil {
+		return nil, err
+	}
+	if err := keepCustomCheckers(registry, s.vibeRegistry); err != nil {
+		return nil, err
+	}
+	s.profileRegistries[projectType] = registry
+	return registry, nil
+}
+
+// keepCustomCheckers makes a profile registry run the same vibes as the
+// scanner's own: checkers an embedder added or swapped in replace the
+// profile's, and vibes removed from the scanner's registry are removed
+func keepCustomCheckers(profile, base *vibes.Registry) error {
+	baseCheckers := base.GetAllCheckers()
+	for vibeType := range profile.GetAllCheckers() {
+		if _, ok := baseCheckers[vibeType]; !ok {
+			profile.UnregisterChecker(vibeType)
+		}
+	}
+	for vibeType, checker := range baseCheckers {
+		builtIn, err := profile.GetChecker(vibeType)
+		if err == nil && reflect.TypeOf(builtIn) == reflect.TypeOf(checker) {
+			continue
+		}
+		profile.UnregisterChecker(vibeType)
+		if err := profile.Register(checker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+func TestDetectProjectType(t *testing.T) {
+	project := func(markers ...string) string {
+		dir := t.TempDir()
+		for _, marker := range markers {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, marker), []byte("{}"), 0644))
+		}
+		return dir
+	}
+
+	assert.Equal(t, "go", DetectProjectType([]string{project("go.mod")}))
+	assert.Equal(t, "python", DetectProjectType([]string{project("pyproject.toml", "requirements.txt")}))
+	assert.Equal(t, "javascript", DetectProjectType([]string{project("package.json")}))
+	assert.Equal(t, "typescript", DetectProjectType([]string{project("package.json", "tsconfig.json")}))
+	assert.Equal(t, "rust", DetectProjectType([]string{project("Cargo.toml")}))
+
+	// A file path is detected from its directory
+	goProject := project("go.mod")
+	assert.Equal(t, "go", DetectProjectType([]string{filepath.Join(goProject, "go.mod")}))
+
+	// Mixed and unknown projects have no type
+	assert.Equal(t, "", DetectProjectType([]string{project("go.mod", "package.json")}))
+	assert.Equal(t, "", DetectProjectType([]string{project("go.mod"), project("Cargo.toml")}))
+	assert.Equal(t, "", DetectProjectType([]string{project()}))
+}
+
+func TestScanner_getVibesToRunProjectProfile(t *testing.T) {
+	config := &models.Configuration{
+		Project: models.ProjectConfig{Type: ProjectTypeAutoDetect},
+		Vibes:   vibes.DefaultVibeConfigs(),
+	}
+	scanner, err := NewScanner(config, logrus.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, projectProfiles["go"].Vibes, scanner.getVibesToRun(nil, "go"))
+	assert.NotContains(t, scanner.getVibesToRun(nil, "go"), models.VibeTypePerformance)
+	assert.Contains(t, scanner.getVibesToRun(nil, "python"), models.VibeTypeDocumentation)
+	// Requested vibes win over the profile
+	assert.Equal(t, []models.VibeType{models.VibeTypePerformance},
+		scanner.getVibesToRun([]models.VibeType{models.VibeTypePerformance}, "go"))
+
+	// Enabling or disabling a vibe in config turns profiles off
+	custom := vibes.DefaultVibeConfigs()
+	security := custom[models.VibeTypeSecurity]
+	security.Enabled = false
+	custom[models.VibeTypeSecurity] = security
+	customized, err := NewScanner(&models.Configuration{Vibes: custom}, logrus.New())
+	require.NoError(t, err)
+	assert.NotContains(t, customized.getVibesToRun(nil, "go"), models.VibeTypeSecurity)
+	assert.Contains(t, customized.getVibesToRun(nil, "go"), models.VibeTypePerformance)
+}
+
+func TestScanner_projectType(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module app\n"), 0644))
+
+	for configured, expected := range map[string]string{
+		ProjectTypeAutoDetect: "go",
+		"Python":              "python",
+		"generic":             "",
+		"":                    "",
+	} {
+		scanner, err := NewScanner(&models.Configuration{Project: models.ProjectConfig{Type: configured}}, logrus.New())
+		require.NoError(t, err)
+		assert.Equal(t, expected, scanner.projectType([]string{dir}), configured)
+	}
+}
+
+func TestScanner_ScanAppliesProjectProfile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[project]\n"), 0644))
+	// 95 characters: over black's 88 but under the generic 120
+	line := "value = '" + strings.Repeat("x", 85) + "'"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.py"), []byte(line+"\n"), 0644))
+
+	config := &models.Configuration{
+		Project: models.ProjectConfig{Type: ProjectTypeAutoDetect},
+		Vibes:   vibes.DefaultVibeConfigs(),
+		Scanner: models.ScannerConfig{MaxConcurrency: 2, Timeout: 10},
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	scanner, err := NewScanner(config, logger)
+	require.NoError(t, err)
+
+	result, err := scanner.Scan(context.Background(), &models.ScanRequest{Paths: []string{dir}})
+	require.NoError(t, err)
+	assert.Equal(t, "python", result.Metadata["project_type"])
+
+	hasLineLength := false
+	for _, issue := range result.Issues {
+		if issue.Rule == "line-length" {
+			hasLineLength = true
+		}
+	}
+	assert.True(t, hasLineLength, "python profile should lower max_line_length to 88")
+
+	// A configured threshold is kept
+	config.Vibes[models.VibeTypeCode].Settings["max_line_length"] = 100
+	scanner, err = NewScanner(config, logger)
+	require.NoError(t, err)
+	result, err = scanner.Scan(context.Background(), &models.ScanRequest{Paths: []string{dir}})
+	require.NoError(t, err)
+	for _, issue := range result.Issues {
+		assert.NotEqual(t, "line-length", issue.Rule)
+	}
+}
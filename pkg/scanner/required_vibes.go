@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// ErrRequiredVibesMissing is returned alongside the result when a vibe listed
+// in ScanRequest.RequiredVibes did not run, examined no files or was cut short
+var ErrRequiredVibesMissing = errors.New("required vibes did not run")
+
+// MissingVibe is a required vibe and why it does not count as having run
+type MissingVibe struct {
+	Vibe   models.VibeType `json:"vibe"`
+	Reason string          `json:"reason"`
+}
+
+func (m MissingVibe) String() string {
+	return fmt.Sprintf("%s (%s)", m.Vibe, m.Reason)
+}
+
+// missingRequiredVibes checks the required vibes against what each vibe examined
+func missingRequiredVibes(required []string, runs map[models.VibeType]models.VibeRun) []MissingVibe {
+	var missing []MissingVibe
+	seen := make(map[models.VibeType]bool)
+	for _, name := range required {
+		vibeType := models.VibeType(strings.ToLower(strings.TrimSpace(name)))
+		if vibeType == "" || seen[vibeType] {
+			continue
+		}
+		seen[vibeType] = true
+
+		run, ran := runs[vibeType]
+		switch {
+		case !ran:
+			missing = append(missing, MissingVibe{Vibe: vibeType, Reason: "not run"})
+		case run.Incomplete:
+			missing = append(missing, MissingVibe{Vibe: vibeType, Reason: "did not finish"})
+		case run.FilesExamined == 0:
+			missing = append(missing, MissingVibe{Vibe: vibeType, Reason: "examined 0 files"})
+		}
+	}
+	return missing
+}
+
+// requiredVibesError describes the missing vibes
+func requiredVibesError(missing []MissingVibe) error {
+	reasons := make([]string, len(missing))
+	for i, vibe := range missing {
+		reasons[i] = vibe.String()
+	}
+	return fmt.Errorf("%w: %s", ErrRequiredVibesMissing, strings.Join(reasons, ", "))
+}
+
+// countExaminable counts the files a checker supports and can read. Checkers
+// skip unreadable files, so without this a vibe whose files are all
+// unreadable would look like it found nothing.
+func countExaminable(checker vibes.Checker, files []string) int {
+	examined := 0
+	for _, file := range files {
+		if !checker.Supports(file) {
+			continue
+		}
+		handle, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		handle.Close()
+		examined++
+	}
+	return examined
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestMissingRequiredVibes(t *testing.T) {
+	runs := map[models.VibeType]models.VibeRun{
+		models.VibeTypeSecurity: {FilesExamined: 3, Issues: 0},
+		models.VibeTypeCode:     {FilesExamined: 0},
+		models.VibeTypeGit:      {FilesExamined: 2, Incomplete: true},
+	}
+
+	missing := missingRequiredVibes([]string{"security", " Code", "git", "dependency", "code", ""}, runs)
+	assert.Equal(t, []MissingVibe{
+		{Vibe: models.VibeTypeCode, Reason: "examined 0 files"},
+		{Vibe: models.VibeTypeGit, Reason: "did not finish"},
+		{Vibe: models.VibeTypeDependency, Reason: "not run"},
+	}, missing)
+
+	err := requiredVibesError(missing)
+	assert.ErrorIs(t, err, ErrRequiredVibesMissing)
+	assert.Contains(t, err.Error(), "code (examined 0 files), git (did not finish), dependency (not run)")
+
+	assert.Empty(t, missingRequiredVibes([]string{"security"}, runs))
+	assert.Empty(t, missingRequiredVibes(nil, runs))
+}
+
+func TestScanner_ScanRequiredVibes(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "notes.txt"), []byte("nothing to see\n"), 0644))
+
+	config := &models.Configuration{
+		Scanner: models.ScannerConfig{MaxConcurrency: 2, Timeout: 10},
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	scanner, err := NewScanner(config, logger)
+	require.NoError(t, err)
+
+	// The code vibe supports no .txt files, so it finds nothing because it looked at nothing
+	result, err := scanner.Scan(context.Background(), &models.ScanRequest{
+		Paths:         []string{tempDir},
+		Vibes:         []string{"code"},
+		RequiredVibes: []string{"code", "git"},
+	})
+	require.ErrorIs(t, err, ErrRequiredVibesMissing)
+	require.NotNil(t, result)
+	assert.Equal(t, models.VibeRun{}, result.VibeRuns[models.VibeTypeCode])
+	assert.Equal(t, []MissingVibe{
+		{Vibe: models.VibeTypeCode, Reason: "examined 0 files"},
+		{Vibe: models.VibeTypeGit, Reason: "not run"},
+	}, result.Metadata["missing_required_vibes"])
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "app.js"), []byte("var x = 1;\n"), 0644))
+	result, err = scanner.Scan(context.Background(), &models.ScanRequest{
+		Paths:         []string{tempDir},
+		Vibes:         []string{"code"},
+		RequiredVibes: []string{"code"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.VibeRuns[models.VibeTypeCode].FilesExamined)
+	assert.Equal(t, len(result.Issues), result.VibeRuns[models.VibeTypeCode].Issues)
+}
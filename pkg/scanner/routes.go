@@ -0,0 +1,117 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+// validateRoutes checks that every scanner.routes entry matches files and
+// names registered vibes
+func validateRoutes(routes []models.VibeRoute, registry *vibes.Registry) error {
+	for i, route := range routes {
+		if len(route.Files) == 0 {
+			return fmt.Errorf("scanner.routes[%d] needs at least one file pattern", i)
+		}
+		if len(route.Vibes) == 0 {
+			return fmt.Errorf("scanner.routes[%d] needs at least one vibe", i)
+		}
+		for _, vibe := range route.Vibes {
+			if strings.EqualFold(vibe, VibesAll) || strings.EqualFold(vibe, VibesDefault) {
+				continue
+			}
+			if _, err := registry.GetChecker(models.VibeType(vibe)); err != nil {
+				return fmt.Errorf("scanner.routes[%d]: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// routeFor returns the first route whose patterns match file, or nil
+func (s *Scanner) routeFor(file string) *models.VibeRoute {
+	for i := range s.config.Scanner.Routes {
+		if s.isTestFile(file, s.config.Scanner.Routes[i].Files) {
+			return &s.config.Scanner.Routes[i]
+		}
+	}
+	return nil
+}
+
+// vibesForFile returns the vibes that check file: those of the first route
+// matching it, or else vibesToRun. When the vibes were requested explicitly,
+// a route only narrows them.
+func (s *Scanner) vibesForFile(file string, vibesToRun []models.VibeType, requested bool) []models.VibeType {
+	route := s.routeFor(file)
+	if route == nil {
+		return vibesToRun
+	}
+
+	seen := make(map[models.VibeType]bool)
+	var routed []models.VibeType
+	add := func(vibeTypes ...models.VibeType) {
+		for _, vibeType := range vibeTypes {
+			if !seen[vibeType] {
+				seen[vibeType] = true
+				routed = append(routed, vibeType)
+			}
+		}
+	}
+	for _, vibe := range route.Vibes {
+		switch strings.ToLower(vibe) {
+		case VibesAll:
+			all := s.vibeRegistry.ListAvailableVibes()
+			sortVibeTypes(all)
+			add(all...)
+		case VibesDefault:
+			add(vibesToRun...)
+		default:
+			add(models.VibeType(vibe))
+		}
+	}
+	if !requested {
+		return routed
+	}
+
+	selected := make(map[models.VibeType]bool, len(vibesToRun))
+	for _, vibeType := range vibesToRun {
+		selected[vibeType] = true
+	}
+	narrowed := routed[:0]
+	for _, vibeType := range routed {
+		if selected[vibeType] {
+			narrowed = append(narrowed, vibeType)
+		}
+	}
+	return narrowed
+}
+
+// routeFiles splits files between the vibes that check them. It returns the
+// files of each vibe and the vibes to run: vibesToRun, then the vibes only
+// routes selected.
+func (s *Scanner) routeFiles(files []string, vibesToRun []models.VibeType, requested bool) (map[models.VibeType][]string, []models.VibeType) {
+	filesByVibe := make(map[models.VibeType][]string, len(vibesToRun))
+	if len(s.config.Scanner.Routes) == 0 {
+		for _, vibeType := range vibesToRun {
+			filesByVibe[vibeType] = files
+		}
+		return filesByVibe, vibesToRun
+	}
+
+	for _, vibeType := range vibesToRun {
+		filesByVibe[vibeType] = nil
+	}
+	var routedOnly []models.VibeType
+	for _, file := range files {
+		for _, vibeType := range s.vibesForFile(file, vibesToRun, requested) {
+			if _, ok := filesByVibe[vibeType]; !ok {
+				routedOnly = append(routedOnly, vibeType)
+			}
+			filesByVibe[vibeType] = append(filesByVibe[vibeType], file)
+		}
+	}
+	sortVibeTypes(routedOnly)
+	return filesByVibe, append(append([]models.VibeType(nil), vibesToRun...), routedOnly...)
+}
@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/vibes"
+)
+
+func TestScanner_routeFiles(t *testing.T) {
+	config := &models.Configuration{
+		Vibes: vibes.DefaultVibeConfigs(),
+		Scanner: models.ScannerConfig{Routes: []models.VibeRoute{
+			{Files: []string{"*.sql"}, Vibes: []string{"performance", "security"}},
+			{Files: []string{"*.md", "docs/**"}, Vibes: []string{"documentation", "security"}},
+			{Files: []string{"*.env"}, Vibes: []string{"security"}},
+		}},
+	}
+	s, err := NewScanner(config, logrus.New())
+	require.NoError(t, err)
+
+	files := []string{"db/schema.sql", "README.md", "docs/guide.txt", "prod.env", "main.go"}
+	global := []models.VibeType{models.VibeTypeCode, models.VibeTypeSecurity}
+
+	filesByVibe, vibesToRun := s.routeFiles(files, global, false)
+	assert.Equal(t, []models.VibeType{models.VibeTypeCode, models.VibeTypeSecurity, models.VibeTypeDocumentation, models.VibeTypePerformance}, vibesToRun)
+	assert.Equal(t, []string{"main.go"}, filesByVibe[models.VibeTypeCode])
+	assert.Equal(t, files, filesByVibe[models.VibeTypeSecurity])
+	assert.Equal(t, []string{"db/schema.sql"}, filesByVibe[models.VibeTypePerformance])
+	assert.Equal(t, []string{"README.md", "docs/guide.txt"}, filesByVibe[models.VibeTypeDocumentation])
+
+	// Vibes requested explicitly are only narrowed
+	filesByVibe, vibesToRun = s.routeFiles(files, []models.VibeType{models.VibeTypeCode}, true)
+	assert.Equal(t, []models.VibeType{models.VibeTypeCode}, vibesToRun)
+	assert.Equal(t, []string{"main.go"}, filesByVibe[models.VibeTypeCode])
+
+	// Without routes every vibe gets every file
+	plain, err := NewScanner(&models.Configuration{Vibes: vibes.DefaultVibeConfigs()}, logrus.New())
+	require.NoError(t, err)
+	filesByVibe, vibesToRun = plain.routeFiles(files, global, false)
+	assert.Equal(t, global, vibesToRun)
+	assert.Equal(t, files, filesByVibe[models.VibeTypeCode])
+}
+
+func TestScanner_routeDefault(t *testing.T) {
+	config := &models.Configuration{
+		Vibes:   vibes.DefaultVibeConfigs(),
+		Scanner: models.ScannerConfig{Routes: []models.VibeRoute{{Files: []string{"*.sql"}, Vibes: []string{"default", "performance"}}}},
+	}
+	s, err := NewScanner(config, logrus.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, []models.VibeType{models.VibeTypeCode, models.VibeTypePerformance},
+		s.vibesForFile("q.sql", []models.VibeType{models.VibeTypeCode}, false))
+}
+
+func TestNewScanner_invalidRoutes(t *testing.T) {
+	for _, route := range []models.VibeRoute{
+		{Vibes: []string{"security"}},
+		{Files: []string{"*.sql"}},
+		{Files: []string{"*.sql"}, Vibes: []string{"no-such-vibe"}},
+	} {
+		_, err := NewScanner(&models.Configuration{Scanner: models.ScannerConfig{Routes: []models.VibeRoute{route}}}, logrus.New())
+		assert.Error(t, err)
+	}
+}
+
+func TestScanner_ScanRoutes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("console.log('x')\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.js"), []byte("console.log('x')\n"), 0644))
+
+	config := &models.Configuration{
+		Vibes:   vibes.DefaultVibeConfigs(),
+		Scanner: models.ScannerConfig{Routes: []models.VibeRoute{{Files: []string{"*.md"}, Vibes: []string{"security"}}}},
+	}
+	s, err := NewScanner(config, logrus.New())
+	require.NoError(t, err)
+	result, err := s.Scan(context.Background(), &models.ScanRequest{Paths: []string{dir}, Vibes: []string{"code", "security"}})
+	require.NoError(t, err)
+
+	for _, issue := range result.Issues {
+		if issue.Type == models.VibeTypeCode {
+			assert.Equal(t, "app.js", filepath.Base(issue.File), "the code vibe doesn't see routed files")
+		}
+	}
+	assert.Equal(t, 1, result.VibeRuns[models.VibeTypeCode].FilesExamined)
+}
@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"kodevibe/internal/models"
+)
+
+// sampleFiles keeps about options.Percent of files. Each file is kept or
+// dropped by a hash of its path and the seed, so the same seed picks the
+// same files whatever order they were discovered in, and adding a file does
+// not reshuffle the rest. At least one file is kept when there are any.
+func sampleFiles(files []string, options models.SampleOptions) ([]string, error) {
+	if options.Percent <= 0 || options.Percent > 100 {
+		return nil, fmt.Errorf("sample percent must be above 0 and at most 100, got %g", options.Percent)
+	}
+	if options.Percent == 100 || len(files) == 0 {
+		return files, nil
+	}
+
+	threshold := uint64(options.Percent / 100 * math.MaxUint32)
+	var (
+		sampled  []string
+		smallest string
+		minHash  uint64 = math.MaxUint64
+	)
+	for _, file := range files {
+		hash := sampleHash(file, options.Seed)
+		if hash < threshold {
+			sampled = append(sampled, file)
+		}
+		if hash < minHash {
+			minHash, smallest = hash, file
+		}
+	}
+	if len(sampled) == 0 {
+		sampled = []string{smallest}
+	}
+	return sampled, nil
+}
+
+// sampleHash maps a file and seed to [0, 2^32)
+func sampleHash(file string, seed int64) uint64 {
+	h := fnv.New64a()
+	var seedBytes [8]byte
+	binary.LittleEndian.PutUint64(seedBytes[:], uint64(seed))
+	h.Write(seedBytes[:])
+	h.Write([]byte(file))
+	return h.Sum64() >> 32
+}
+
+// sampleConfidence is the confidence in a score extrapolated from sampled of
+// discovered files: the square root of the share of files scanned
+func sampleConfidence(sampled, discovered int) float64 {
+	if discovered == 0 || sampled >= discovered {
+		return 1
+	}
+	return math.Round(math.Sqrt(float64(sampled)/float64(discovered))*100) / 100
+}
@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestSampleFiles(t *testing.T) {
+	var files []string
+	for i := 0; i < 1000; i++ {
+		files = append(files, fmt.Sprintf("src/pkg%d/file%d.go", i%10, i))
+	}
+
+	sampled, err := sampleFiles(files, models.SampleOptions{Percent: 10, Seed: 7})
+	require.NoError(t, err)
+	assert.InDelta(t, 100, len(sampled), 40)
+
+	// The same seed picks the same files in any discovery order
+	reversed := make([]string, len(files))
+	for i, file := range files {
+		reversed[len(files)-1-i] = file
+	}
+	again, err := sampleFiles(reversed, models.SampleOptions{Percent: 10, Seed: 7})
+	require.NoError(t, err)
+	sort.Strings(again)
+	sortedSample := append([]string(nil), sampled...)
+	sort.Strings(sortedSample)
+	assert.Equal(t, sortedSample, again)
+
+	other, err := sampleFiles(files, models.SampleOptions{Percent: 10, Seed: 8})
+	require.NoError(t, err)
+	assert.NotEqual(t, sampled, other)
+
+	// A tiny sample still scans something
+	one, err := sampleFiles(files[:3], models.SampleOptions{Percent: 0.01})
+	require.NoError(t, err)
+	assert.Len(t, one, 1)
+
+	all, err := sampleFiles(files, models.SampleOptions{Percent: 100})
+	require.NoError(t, err)
+	assert.Len(t, all, len(files))
+
+	_, err = sampleFiles(files, models.SampleOptions{Percent: 0})
+	assert.Error(t, err)
+	_, err = sampleFiles(files, models.SampleOptions{Percent: 150})
+	assert.Error(t, err)
+}
+
+func TestScanner_ScanSample(t *testing.T) {
+	tempDir := t.TempDir()
+	for i := 0; i < 40; i++ {
+		name := filepath.Join(tempDir, fmt.Sprintf("file%d.js", i))
+		require.NoError(t, os.WriteFile(name, []byte("console.log('x');\n"), 0644))
+	}
+
+	scanner, err := NewScanner(&models.Configuration{Scanner: models.ScannerConfig{MaxConcurrency: 2}}, logrus.New())
+	require.NoError(t, err)
+
+	result, err := scanner.Scan(context.Background(), &models.ScanRequest{
+		Paths:  []string{tempDir},
+		Vibes:  []string{"code"},
+		Sample: &models.SampleOptions{Percent: 25, Seed: 1},
+	})
+	require.NoError(t, err)
+
+	sample := result.Summary.Sample
+	require.NotNil(t, sample, "a sampled result is labeled")
+	assert.Equal(t, 40, sample.FilesDiscovered)
+	assert.Equal(t, result.FilesScanned, sample.FilesSampled)
+	assert.Less(t, sample.FilesSampled, 40)
+	assert.Less(t, sample.Confidence, 1.0)
+	assert.Greater(t, sample.Confidence, 0.0)
+
+	assert.LessOrEqual(t, result.Summary.Score, 100.0)
+}
+
+func TestSampleConfidence(t *testing.T) {
+	assert.Equal(t, 1.0, sampleConfidence(10, 10))
+	assert.Equal(t, 0.5, sampleConfidence(25, 100))
+	assert.Equal(t, 0.1, sampleConfidence(1, 100))
+}
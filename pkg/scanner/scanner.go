@@ -2,9 +2,13 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -15,35 +19,78 @@ import (
 
 	"kodevibe/internal/models"
 	"kodevibe/internal/utils"
+	"kodevibe/pkg/ai"
+	"kodevibe/pkg/ignore"
 	"kodevibe/pkg/vibes"
 )
 
+// Vibe selectors accepted in place of vibe names
+const (
+	// VibesAll selects every registered vibe, regardless of config enablement
+	VibesAll = "all"
+	// VibesDefault selects the vibes enabled in configuration
+	VibesDefault = "default"
+)
+
+// ErrScanIncomplete is returned alongside a partial result when the scan
+// context is cancelled or times out before every vibe finishes
+var ErrScanIncomplete = errors.New("scan incomplete")
+
 // Scanner represents the main scanning engine
 type Scanner struct {
 	config         *models.Configuration
 	vibeRegistry   *vibes.Registry
 	logger         *logrus.Logger
 	cache          *utils.Cache
+	fileHashes     *fileHashes
+	ignores        *ignore.Matcher
 	metrics        *utils.Metrics
 	maxConcurrency int
+	maxDepth       int
 	timeout        time.Duration
 	vibes          []string
+	aiAnalyzer     ai.Analyzer
+	aiConfig       ai.Config
+
+	// profileRegistries hold vibes configured with a project profile's thresholds
+	profileRegistries map[string]*vibes.Registry
+	profileMutex      sync.Mutex
 }
 
 // NewScanner creates a new scanner instance
 func NewScanner(config *models.Configuration, logger *logrus.Logger) (*Scanner, error) {
+	return NewScannerWithRegistry(config, logger, nil)
+}
+
+// NewScannerWithRegistry creates a scanner that runs the checkers of registry,
+// e.g. the built-in vibes from RegisterAllVibes plus an embedder's own
+// checkers added with Register. A nil registry gets the built-in vibes.
+func NewScannerWithRegistry(config *models.Configuration, logger *logrus.Logger, registry *vibes.Registry) (*Scanner, error) {
 	if config == nil {
 		return nil, fmt.Errorf("configuration is required")
 	}
 
 	if logger == nil {
 		logger = logrus.New()
+		utils.InstallRedaction(logger)
+	}
+
+	concurrency, err := config.Scanner.ResolveConcurrency(runtime.NumCPU())
+	if err != nil {
+		return nil, err
 	}
+	logger.WithField("concurrency", concurrency).Debug("Resolved scan concurrency")
 
 	// Initialize vibe registry
-	registry := vibes.NewRegistry()
-	if err := registry.RegisterAllVibes(config); err != nil {
-		return nil, fmt.Errorf("failed to register vibes: %w", err)
+	if registry == nil {
+		registry = vibes.NewRegistry()
+		if err := registry.RegisterAllVibes(config); err != nil {
+			return nil, fmt.Errorf("failed to register vibes: %w", err)
+		}
+	}
+
+	if err := validateRoutes(config.Scanner.Routes, registry); err != nil {
+		return nil, err
 	}
 
 	// Initialize cache if enabled
@@ -55,15 +102,31 @@ func NewScanner(config *models.Configuration, logger *logrus.Logger) (*Scanner,
 	// Initialize metrics
 	metrics := utils.NewMetrics()
 
+	// Initialize the AI reviewer; the no-op provider is used unless AI detection is enabled
+	aiConfig := ai.ConfigFromAdvanced(config.Advanced)
+	aiAnalyzer, err := ai.NewRegistry().New(aiConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AI detection: %w", err)
+	}
+
 	return &Scanner{
-		config:         config,
-		vibeRegistry:   registry,
-		logger:         logger,
-		cache:          cache,
+		config:       config,
+		vibeRegistry: registry,
+		logger:       logger,
+		cache:        cache,
+		fileHashes:   newFileHashes(),
+		ignores: ignore.NewMatcher(func(path string, err error) {
+			logger.WithError(err).WithField("file", path).Warn("Could not read ignore file; its rules are skipped")
+		}),
 		metrics:        metrics,
-		maxConcurrency: config.Scanner.MaxConcurrency,
+		maxConcurrency: concurrency,
+		maxDepth:       config.Scanner.MaxDepth,
 		timeout:        time.Duration(config.Scanner.Timeout) * time.Second,
 		vibes:          config.Scanner.EnabledVibes,
+		aiAnalyzer:     aiAnalyzer,
+		aiConfig:       aiConfig,
+
+		profileRegistries: make(map[string]*vibes.Registry),
 	}, nil
 }
 
@@ -79,14 +142,21 @@ func (s *Scanner) Scan(ctx context.Context, request *models.ScanRequest) (*model
 		scanID = uuid.New().String()
 	}
 
-	s.logger.WithFields(logrus.Fields{
-		"scan_id": scanID,
-		"paths":   request.Paths,
-		"vibes":   request.Vibes,
+	// Every log line for this scan carries its ID, and the request ID when a server request started it
+	log := s.logger.WithField("scan_id", scanID)
+	requestID := RequestIDFromContext(ctx)
+	if requestID != "" {
+		log = log.WithField("request_id", requestID)
+	}
+
+	log.WithFields(logrus.Fields{
+		"paths": request.Paths,
+		"vibes": request.Vibes,
 	}).Info("Starting scan")
 
 	// Initialize scan result
 	result := &models.ScanResult{
+		SchemaVersion: models.ScanResultSchemaVersion,
 		ScanID:        scanID,
 		ID:            scanID,
 		StartTime:     startTime,
@@ -95,47 +165,204 @@ func (s *Scanner) Scan(ctx context.Context, request *models.ScanRequest) (*model
 		Issues:        []models.Issue{},
 		Metadata:      make(map[string]interface{}),
 	}
-
-	// Discover files to scan
-	files, err := s.discoverFiles(request.Paths, request.StagedOnly, request.DiffTarget)
-	if err != nil {
+	if requestID != "" {
+		result.Metadata["request_id"] = requestID
+	}
+	result.Commit = headCommit(request.Paths)
+
+	// Discover files to scan, within the discovery phase's share of the time.
+	// Ignore files are read afresh, so edits between scans apply.
+	s.ignores.Reset()
+	discoveryCtx, cancelDiscovery := s.discoveryContext(ctx)
+	files, err := s.discoverFiles(discoveryCtx, request.Paths, request.StagedOnly, request.DiffTarget)
+	discoveryErr := discoveryCtx.Err()
+	cancelDiscovery()
+	if err != nil && discoveryErr == nil {
 		return nil, fmt.Errorf("failed to discover files: %w", err)
 	}
+	if discoveryErr != nil {
+		// Check the files found so far rather than nothing at all
+		log.WithField("files", len(files)).Warn("File discovery ran out of time, scanning the files found so far")
+	}
 
 	// Filter files based on exclusion patterns
 	filteredFiles := s.filterFiles(files)
-	result.FilesScanned = len(filteredFiles)
+
+	// Restrict to the requested languages
+	if len(request.Languages) > 0 {
+		filteredFiles, err = filterLanguages(filteredFiles, request.Languages)
+		if err != nil {
+			return nil, err
+		}
+	}
 	result.FilesSkipped = len(files) - len(filteredFiles)
 
-	s.logger.WithFields(logrus.Fields{
+	// Check a sample of the files for a quick estimate
+	var sample *models.SampleSummary
+	if request.Sample != nil {
+		discovered := len(filteredFiles)
+		filteredFiles, err = sampleFiles(filteredFiles, *request.Sample)
+		if err != nil {
+			return nil, err
+		}
+		sample = &models.SampleSummary{
+			Percent:         request.Sample.Percent,
+			Seed:            request.Sample.Seed,
+			FilesDiscovered: discovered,
+			FilesSampled:    len(filteredFiles),
+			Confidence:      sampleConfidence(len(filteredFiles), discovered),
+		}
+		log.WithFields(logrus.Fields{
+			"percent": sample.Percent,
+			"seed":    sample.Seed,
+			"sampled": sample.FilesSampled,
+		}).Info("Scanning a sample of the files")
+	}
+	result.FilesScanned = len(filteredFiles)
+
+	log.WithFields(logrus.Fields{
 		"total_files":    len(files),
 		"filtered_files": len(filteredFiles),
 		"skipped_files":  result.FilesSkipped,
 	}).Info("File discovery completed")
 
+	// A scan that examined nothing must not be mistaken for a clean one
+	if len(filteredFiles) == 0 {
+		warning := s.noFilesWarning(request, files)
+		result.Metadata[NoFilesScannedKey] = warning
+		log.WithFields(logrus.Fields{
+			"paths":            request.Paths,
+			"discovered_files": warning.DiscoveredFiles,
+			"excluded_by":      warning.ExcludedBy,
+		}).Warn("No files matched the scan: " + warning.Reason)
+	}
+
 	// Determine which vibes to run
 	var vibeTypes []models.VibeType
 	for _, v := range request.Vibes {
 		vibeTypes = append(vibeTypes, models.VibeType(v))
 	}
-	vibesToRun := s.getVibesToRun(vibeTypes)
+	projectType := s.projectType(request.Paths)
+	if projectType != "" {
+		result.Metadata["project_type"] = projectType
+	}
+	vibesToRun := s.getVibesToRun(vibeTypes, projectType)
+	// Routes pick the vibes of the files they match
+	filesByVibe, vibesToRun := s.routeFiles(filteredFiles, vibesToRun, len(vibeTypes) > 0)
+	registry, err := s.registryFor(projectType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure vibes for %s project: %w", projectType, err)
+	}
 
-	// Run vibe checks concurrently
-	issues, err := s.runVibeChecks(ctx, filteredFiles, vibesToRun)
+	overlaps, err := s.linterOverlaps(request.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run vibe checks concurrently, reading each file once for all of them
+	contents := vibes.NewFileContents()
+	issues, vibeRuns, err := s.runVibeChecks(vibes.WithFileContents(ctx, contents), registry, filesByVibe, vibesToRun)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run vibe checks: %w", err)
 	}
+	log.WithField("files_read", contents.Len()).Debug("Read the scanned files once for every vibe")
+	result.VibeRuns = vibeRuns
+	incompleteVibes := incompleteVibeRuns(vibeRuns)
+
+	// Leave rules to the linters the project already runs
+	if len(overlaps) > 0 {
+		issues = dropLinterCovered(issues, overlaps)
+		result.Metadata[LinterOverlapKey] = overlaps
+		log.WithField("overlaps", len(overlaps)).Info("Left rules enforced by other linters to them")
+	}
+
+	// Add findings from external tools such as semgrep or gosec
+	issues = append(issues, s.runExternalScanners(ctx, request.Paths)...)
+
+	// Look for secrets that past commits added, even if they are gone now
+	if request.History != nil && len(request.Paths) > 0 {
+		historyIssues, summary, err := s.scanHistory(ctx, registry, request.Paths[0], *request.History)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan git history: %w", err)
+		}
+		issues = append(issues, historyIssues...)
+		result.Metadata[HistoryKey] = summary
+		if summary.Incomplete {
+			run := vibeRuns[models.VibeTypeSecurity]
+			run.Incomplete = true
+			vibeRuns[models.VibeTypeSecurity] = run
+			incompleteVibes = incompleteVibeRuns(vibeRuns)
+		}
+		log.WithFields(logrus.Fields{
+			"commits": summary.Commits,
+			"secrets": summary.Secrets,
+		}).Info("Git history scan completed")
+	}
+
+	// Sort, dedup and assign IDs now that every vibe has finished
+	issues = finalizeIssues(issues)
+	s.assignCWEs(issues)
+	if err := s.assignOwners(request.Paths, issues, log); err != nil {
+		return nil, err
+	}
+
+	// Drop what kodevibe:ignore comments suppress, but keep count of it
+	issues, inlineSuppressed := applyInlineSuppressions(issues, contents)
+	if len(inlineSuppressed) > 0 {
+		log.WithField("rules", inlineSuppressed).Debug("Dropped issues suppressed by inline comments")
+	}
+
+	// List accepted complexity apart instead of reporting it
+	issues, exempted := separateExempted(issues)
+	if len(exempted) > 0 {
+		result.Metadata[ExemptedIssuesKey] = exempted
+		log.WithField("exempted", len(exempted)).Info("Left out issues in functions exempt from the complexity rules")
+	}
+
+	// Escalate rules that fire more often than configured
+	escalated := s.escalateIssues(issues)
+
+	// Keep one pathological file from drowning out the rest of the report
+	issues, truncated := capIssuesPerFile(issues, s.config.Scanner.MaxIssuesPerFile)
+	if len(truncated) > 0 {
+		result.Metadata[TruncatedIssuesKey] = truncated
+		log.WithFields(logrus.Fields{
+			"max_issues_per_file": s.config.Scanner.MaxIssuesPerFile,
+			"files":               truncated,
+		}).Warn("Dropped the least severe issues of files over the per-file limit")
+	}
 
-	// Set results
+	// Ask the AI provider for a second opinion on suspicious findings (opt-in)
+	s.reviewWithAI(ctx, issues)
+
+	// Set results, keeping "issues": [] in the JSON of a clean scan
+	if issues == nil {
+		issues = []models.Issue{}
+	}
 	result.Issues = issues
 	result.EndTime = time.Now()
 	result.Duration = result.EndTime.Sub(result.StartTime)
 
 	// Generate summary
 	result.Summary = s.generateSummary(issues)
+	if len(escalated) > 0 {
+		result.Summary.EscalatedRules = escalated
+	}
+	for _, count := range inlineSuppressed {
+		result.Summary.InlineSuppressed += count
+	}
+	if len(inlineSuppressed) > 0 {
+		result.Summary.InlineSuppressedByRule = inlineSuppressed
+	}
+	if sample != nil {
+		// Extrapolate the findings of the sample to every discovered file
+		result.Summary.Sample = sample
+		result.Summary.Score = sample.Extrapolate(result.Summary.Score)
+		result.Summary.Grade = models.GradeForScore(result.Summary.Score, s.config.Reporting.GradeThresholds)
+	}
+	result.ReproducibilityHash = result.ComputeReproducibilityHash()
 
-	s.logger.WithFields(logrus.Fields{
-		"scan_id":      scanID,
+	log.WithFields(logrus.Fields{
 		"duration":     result.Duration,
 		"total_issues": len(issues),
 		"errors":       result.Summary.ErrorIssues,
@@ -146,23 +373,77 @@ func (s *Scanner) Scan(ctx context.Context, request *models.ScanRequest) (*model
 	// Update metrics
 	s.metrics.RecordScan(result)
 
-	return result, nil
+	// Keep what was found before the context ended, but flag the result as partial
+	var incompleteErr error
+	if discoveryErr != nil || len(incompleteVibes) > 0 {
+		phase, cause := PhaseAnalysis, ctx.Err()
+		if discoveryErr != nil {
+			phase, cause = PhaseDiscovery, discoveryErr
+		}
+		reason := incompleteReason(cause)
+		result.Metadata["partial"] = true
+		result.Metadata["incomplete_reason"] = reason
+		result.Metadata[IncompletePhaseKey] = phase
+		if len(incompleteVibes) > 0 {
+			result.Metadata["incomplete_vibes"] = vibeTypeStrings(incompleteVibes)
+		}
+
+		log.WithFields(logrus.Fields{
+			"reason": reason,
+			"phase":  phase,
+			"vibes":  incompleteVibes,
+		}).Warn("Scan incomplete, returning partial results")
+
+		incompleteErr = fmt.Errorf("%w (%s)", ErrScanIncomplete, reason)
+		if cause != nil {
+			// Wrap the cause too, so callers can tell a timeout from a cancellation
+			incompleteErr = fmt.Errorf("%w: %w", incompleteErr, cause)
+		}
+	}
+
+	// A required vibe that didn't look at anything must not pass as clean
+	if missing := missingRequiredVibes(request.RequiredVibes, vibeRuns); len(missing) > 0 {
+		result.Metadata["missing_required_vibes"] = missing
+		log.WithField("vibes", missing).Warn("Required vibes did not run")
+		return result, requiredVibesError(missing)
+	}
+
+	return result, incompleteErr
+}
+
+// incompleteReason describes why a scan context ended early
+func incompleteReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return "cancelled"
+}
+
+func vibeTypeStrings(vibeTypes []models.VibeType) []string {
+	strs := make([]string, len(vibeTypes))
+	for i, vibeType := range vibeTypes {
+		strs[i] = string(vibeType)
+	}
+	return strs
 }
 
-// discoverFiles discovers all files to be scanned
-func (s *Scanner) discoverFiles(paths []string, stagedOnly bool, diffTarget string) ([]string, error) {
+// discoverFiles discovers all files to be scanned. When ctx ends it returns
+// the files found so far with ctx's error.
+func (s *Scanner) discoverFiles(ctx context.Context, paths []string, stagedOnly bool, diffTarget string) ([]string, error) {
 	var allFiles []string
 
 	for _, path := range paths {
-		files, err := s.discoverFilesInPath(path, stagedOnly, diffTarget)
+		files, err := s.discoverFilesInPath(ctx, path, stagedOnly, diffTarget)
+		allFiles = append(allFiles, files...)
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			s.logger.WithFields(logrus.Fields{
 				"path":  path,
 				"error": err.Error(),
 			}).Warn("Failed to discover files in path, skipping")
-			continue
 		}
-		allFiles = append(allFiles, files...)
 	}
 
 	// Remove duplicates
@@ -175,11 +456,12 @@ func (s *Scanner) discoverFiles(paths []string, stagedOnly bool, diffTarget stri
 		}
 	}
 
-	return uniqueFiles, nil
+	return uniqueFiles, ctx.Err()
 }
 
-// discoverFilesInPath discovers files in a specific path
-func (s *Scanner) discoverFilesInPath(path string, stagedOnly bool, diffTarget string) ([]string, error) {
+// discoverFilesInPath discovers files in a specific path; a walk cut short by
+// ctx returns the files found so far
+func (s *Scanner) discoverFilesInPath(ctx context.Context, path string, stagedOnly bool, diffTarget string) ([]string, error) {
 	var files []string
 
 	// Handle git-specific file discovery
@@ -192,9 +474,19 @@ func (s *Scanner) discoverFilesInPath(path string, stagedOnly bool, diffTarget s
 		if err != nil {
 			return err
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-		// Skip directories
+		// Skip directories, pruning those at the depth limit and those
+		// .kodevibeignore leaves out, whose files can't be re-included
 		if info.IsDir() {
+			if s.maxDepth > 0 && filePath != path && pathDepth(path, filePath) >= s.maxDepth {
+				return filepath.SkipDir
+			}
+			if filePath != path && s.ignoreRule(filePath, true).Ignores() {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
@@ -203,8 +495,9 @@ func (s *Scanner) discoverFilesInPath(path string, stagedOnly bool, diffTarget s
 			return nil
 		}
 
-		// Check if file should be ignored based on patterns
-		if s.shouldIgnore(filePath) {
+		// Check if file should be ignored based on patterns; a negation in
+		// .kodevibeignore re-includes it
+		if rule := s.ignoreRule(filePath, false); rule.Ignores() || (rule == nil && s.shouldIgnore(filePath)) {
 			return nil
 		}
 
@@ -214,12 +507,24 @@ func (s *Scanner) discoverFilesInPath(path string, stagedOnly bool, diffTarget s
 	})
 
 	if err != nil {
+		if ctx.Err() != nil {
+			return files, err
+		}
 		return nil, fmt.Errorf("failed to walk directory: %w", err)
 	}
 
 	return files, nil
 }
 
+// pathDepth returns how many levels below root the given path is
+func pathDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return len(strings.Split(rel, string(filepath.Separator)))
+}
+
 // discoverGitFiles discovers git-specific files (staged or diff)
 func (s *Scanner) discoverGitFiles(path string, stagedOnly bool, diffTarget string) ([]string, error) {
 	gitUtil := utils.NewGitUtil(path)
@@ -235,6 +540,46 @@ func (s *Scanner) discoverGitFiles(path string, stagedOnly bool, diffTarget stri
 	return nil, fmt.Errorf("invalid git file discovery parameters")
 }
 
+// headCommit is the commit checked out where the first path is, or "" outside a git repository
+func headCommit(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	dir := paths[0]
+	if info, err := os.Stat(dir); err != nil {
+		return ""
+	} else if !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	if !insideGitRepo(dir) {
+		// Skip starting git for the common case of scanning outside a repository
+		return ""
+	}
+	commit, err := utils.NewGitUtil(dir).HeadCommit()
+	if err != nil {
+		return ""
+	}
+	return commit
+}
+
+// insideGitRepo reports whether dir or a parent holds a .git entry
+func insideGitRepo(dir string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
 // filterFiles filters files based on exclusion patterns
 func (s *Scanner) filterFiles(files []string) []string {
 	var filteredFiles []string
@@ -248,75 +593,227 @@ func (s *Scanner) filterFiles(files []string) []string {
 	return filteredFiles
 }
 
+// filterLanguages keeps only files written in one of the given languages
+func filterLanguages(files []string, languages []string) ([]string, error) {
+	extensions, err := vibes.LanguageExtensions(languages)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []string
+	for _, file := range files {
+		if vibes.HasExtension(file, extensions) {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered, nil
+}
+
 // shouldExcludeFile checks if a file should be excluded based on configuration
 func (s *Scanner) shouldExcludeFile(file string) bool {
+	return s.excludedBy(file) != ""
+}
+
+// excludedBy returns the exclude pattern that matches a file, or "". The
+// .kodevibeignore files come first: their rules win over the configured
+// excludes, so a negation there scans a file the config excludes.
+func (s *Scanner) excludedBy(file string) string {
+	if rule := s.ignoreRule(file, false); rule != nil {
+		if rule.Ignores() {
+			return rule.String()
+		}
+		return ""
+	}
+
+	// Compare with forward slashes so Windows paths match Unix-style patterns
+	file = utils.ToSlashPath(file)
+
 	// Check file patterns
 	for _, pattern := range s.config.Exclude.Files {
-		if matched, err := filepath.Match(pattern, file); err == nil && matched {
-			return true
+		if utils.MatchPathPattern(pattern, file) {
+			return pattern
 		}
 
 		// Check with glob patterns
 		if strings.Contains(pattern, "**") {
 			// Simplified glob matching
 			if s.matchGlob(file, pattern) {
-				return true
+				return pattern
 			}
 		}
 	}
 
 	// Check filename patterns
-	filename := filepath.Base(file)
+	filename := path.Base(file)
 	for _, pattern := range s.config.Exclude.Patterns {
-		if matched, err := filepath.Match(pattern, filename); err == nil && matched {
-			return true
+		if utils.MatchPathPattern(pattern, filename) {
+			return pattern
 		}
 	}
 
 	// Check directory paths
 	for _, pattern := range s.config.Exclude.Paths {
-		if strings.Contains(file, pattern) {
-			return true
+		if strings.Contains(file, utils.ToSlashPath(pattern)) {
+			return pattern
 		}
 	}
 
-	return false
+	return ""
 }
 
-// matchGlob provides basic glob pattern matching
+// matchGlob provides basic glob pattern matching where "**" spans any
+// number of directories
 func (s *Scanner) matchGlob(file, pattern string) bool {
-	// Simple implementation - in production, use a proper glob library
-	if strings.Contains(pattern, "**") {
-		parts := strings.Split(pattern, "**")
-		if len(parts) == 2 {
-			prefix := parts[0]
-			suffix := parts[1]
-			return strings.HasPrefix(file, prefix) && strings.HasSuffix(file, suffix)
+	file = utils.ToSlashPath(file)
+	pattern = utils.ToSlashPath(pattern)
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		// Try "**" against every number of leading segments, including none
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
 		}
+		return false
 	}
-	return false
+
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// ValidateVibes checks that each requested vibe is registered or is one of
+// the VibesAll and VibesDefault keywords, so a typo fails before any file is
+// discovered
+func (s *Scanner) ValidateVibes(requestedVibes []models.VibeType) error {
+	available := s.vibeRegistry.ListAvailableVibes()
+	sortVibeTypes(available)
+	registered := make(map[models.VibeType]bool, len(available))
+	names := []string{VibesAll, VibesDefault}
+	for _, vibeType := range available {
+		registered[vibeType] = true
+		names = append(names, string(vibeType))
+	}
+
+	for _, requested := range requestedVibes {
+		switch strings.ToLower(string(requested)) {
+		case VibesAll, VibesDefault:
+			continue
+		}
+		if !registered[requested] {
+			return fmt.Errorf("unknown vibe %q (available: %s)", requested, strings.Join(names, ", "))
+		}
+	}
+	return nil
 }
 
-// getVibesToRun determines which vibes should be executed
-func (s *Scanner) getVibesToRun(requestedVibes []models.VibeType) []models.VibeType {
-	if len(requestedVibes) > 0 {
-		return requestedVibes
+// getVibesToRun determines which vibes should be executed.
+//
+// Precedence: no requested vibes means the detected project type's profile
+// (unless the config customizes which vibes are enabled), falling back to the
+// config-enabled vibes. Otherwise each
+// requested entry is expanded: "all" adds every registered vibe regardless of
+// config, "default" adds the config-enabled vibes, and any other name is run
+// as given. Duplicates are removed.
+func (s *Scanner) getVibesToRun(requestedVibes []models.VibeType, projectType string) []models.VibeType {
+	if len(requestedVibes) == 0 {
+		if profile, ok := projectProfiles[projectType]; ok && !s.vibesCustomized() {
+			return append(append([]models.VibeType(nil), profile.Vibes...), s.customVibes()...)
+		}
+		return s.enabledVibes()
 	}
 
-	// Return all enabled vibes from configuration
+	seen := make(map[models.VibeType]bool)
+	var vibesToRun []models.VibeType
+	add := func(vibeTypes ...models.VibeType) {
+		for _, vibeType := range vibeTypes {
+			if !seen[vibeType] {
+				seen[vibeType] = true
+				vibesToRun = append(vibesToRun, vibeType)
+			}
+		}
+	}
+
+	for _, requested := range requestedVibes {
+		switch strings.ToLower(string(requested)) {
+		case VibesAll:
+			all := s.vibeRegistry.ListAvailableVibes()
+			sortVibeTypes(all)
+			add(all...)
+		case VibesDefault:
+			add(s.enabledVibes()...)
+		default:
+			add(requested)
+		}
+	}
+
+	return vibesToRun
+}
+
+// enabledVibes returns the vibes enabled in configuration and the custom
+// vibes, in a stable order
+func (s *Scanner) enabledVibes() []models.VibeType {
 	var enabledVibes []models.VibeType
 	for vibeType, vibeConfig := range s.config.Vibes {
 		if vibeConfig.Enabled {
 			enabledVibes = append(enabledVibes, vibeType)
 		}
 	}
+	for _, vibeType := range s.customVibes() {
+		if _, configured := s.config.Vibes[vibeType]; !configured {
+			enabledVibes = append(enabledVibes, vibeType)
+		}
+	}
 
+	sortVibeTypes(enabledVibes)
 	return enabledVibes
 }
 
-// runVibeChecks executes all vibe checks concurrently
-func (s *Scanner) runVibeChecks(ctx context.Context, files []string, vibesToRun []models.VibeType) ([]models.Issue, error) {
+// customVibes returns the vibes of checkers an embedder registered, other
+// than those the configuration disables. Unlike built-in vibes they run by
+// default, as registering a checker is how an embedder asks for it.
+func (s *Scanner) customVibes() []models.VibeType {
+	builtIn := make(map[models.VibeType]bool)
+	for _, checker := range vibes.BuiltinCheckers() {
+		builtIn[checker.Type()] = true
+	}
+
+	var custom []models.VibeType
+	for _, vibeType := range s.vibeRegistry.ListAvailableVibes() {
+		if builtIn[vibeType] {
+			continue
+		}
+		if vibeConfig, configured := s.config.Vibes[vibeType]; configured && !vibeConfig.Enabled {
+			continue
+		}
+		custom = append(custom, vibeType)
+	}
+	sortVibeTypes(custom)
+	return custom
+}
+
+func sortVibeTypes(vibeTypes []models.VibeType) {
+	sort.Slice(vibeTypes, func(i, j int) bool { return vibeTypes[i] < vibeTypes[j] })
+}
+
+// runVibeChecks executes all vibe checks concurrently, each over its files,
+// and records what each vibe examined. Vibes that were cut short by the
+// context are marked incomplete, and any issues they found before it ended are kept.
+func (s *Scanner) runVibeChecks(ctx context.Context, registry *vibes.Registry, filesByVibe map[models.VibeType][]string, vibesToRun []models.VibeType) ([]models.Issue, map[models.VibeType]models.VibeRun, error) {
 	var allIssues []models.Issue
+	runs := make(map[models.VibeType]models.VibeRun, len(vibesToRun))
 	var mu sync.Mutex
 
 	// Create semaphore for concurrency control
@@ -334,21 +831,45 @@ func (s *Scanner) runVibeChecks(ctx context.Context, files []string, vibesToRun
 
 			// Acquire semaphore
 			if err := sem.Acquire(ctx, 1); err != nil {
+				if ctx.Err() != nil {
+					mu.Lock()
+					runs[vType] = models.VibeRun{Incomplete: true}
+					mu.Unlock()
+					return
+				}
 				errChan <- fmt.Errorf("failed to acquire semaphore: %w", err)
 				return
 			}
 			defer sem.Release(1)
 
 			// Get vibe checker
-			checker, err := s.vibeRegistry.GetChecker(vType)
+			checker, err := registry.GetChecker(vType)
 			if err != nil {
 				errChan <- fmt.Errorf("failed to get checker for vibe %s: %w", vType, err)
 				return
 			}
 
+			vibeFiles := s.filesForVibe(filesByVibe[vType], vType)
+			run := models.VibeRun{FilesExamined: countExaminable(checker, vibeFiles)}
+			if len(vibeFiles) == 0 {
+				mu.Lock()
+				runs[vType] = run
+				mu.Unlock()
+				return
+			}
+
 			// Run vibe check
-			issues, err := s.runSingleVibeCheck(ctx, checker, files, vType)
+			issues, err := s.runSingleVibeCheck(ctx, checker, vibeFiles, vType)
+			run.Issues = len(issues)
 			if err != nil {
+				if ctx.Err() != nil {
+					run.Incomplete = true
+					mu.Lock()
+					allIssues = append(allIssues, issues...)
+					runs[vType] = run
+					mu.Unlock()
+					return
+				}
 				errChan <- fmt.Errorf("failed to run vibe check %s: %w", vType, err)
 				return
 			}
@@ -356,12 +877,13 @@ func (s *Scanner) runVibeChecks(ctx context.Context, files []string, vibesToRun
 			// Add issues to result
 			mu.Lock()
 			allIssues = append(allIssues, issues...)
+			runs[vType] = run
 			mu.Unlock()
 
 			s.logger.WithFields(logrus.Fields{
 				"vibe":   vType,
 				"issues": len(issues),
-				"files":  len(files),
+				"files":  len(vibeFiles),
 			}).Debug("Vibe check completed")
 		}(vibeType)
 	}
@@ -372,48 +894,76 @@ func (s *Scanner) runVibeChecks(ctx context.Context, files []string, vibesToRun
 	// Check for errors
 	for err := range errChan {
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	return allIssues, nil
+	return allIssues, runs, nil
+}
+
+// incompleteVibeRuns returns the vibes that were cut short, in a stable order
+func incompleteVibeRuns(runs map[models.VibeType]models.VibeRun) []models.VibeType {
+	var incomplete []models.VibeType
+	for vibeType, run := range runs {
+		if run.Incomplete {
+			incomplete = append(incomplete, vibeType)
+		}
+	}
+	sortVibeTypes(incomplete)
+	return incomplete
 }
 
 // runSingleVibeCheck executes a single vibe check
 func (s *Scanner) runSingleVibeCheck(ctx context.Context, checker vibes.Checker, files []string, vibeType models.VibeType) ([]models.Issue, error) {
 	var issues []models.Issue
 
-	// Check if we can use cache
+	// Check if we can use cache. The key is taken before the check so results
+	// are stored under the content they were computed from.
+	var cacheKey string
 	if s.cache != nil {
-		cacheKey := s.generateCacheKey(files, vibeType)
+		cacheKey = s.generateCacheKey(ctx, files, vibeType)
 		if cachedIssues, found := s.cache.Get(cacheKey); found {
 			if cachedIssuesList, ok := cachedIssues.([]models.Issue); ok {
 				s.logger.WithField("vibe", vibeType).Debug("Using cached results")
-				return cachedIssuesList, nil
+				// Return a copy so concurrent scans never share issues
+				return copyIssues(cachedIssuesList), nil
 			}
 		}
 	}
 
-	// Execute the check
+	// Execute the check, spreading the files over workers when the checker
+	// can check them one at a time
 	startTime := time.Now()
-	vibeIssues, err := checker.Check(ctx, files)
-	if err != nil {
-		return nil, fmt.Errorf("vibe check failed: %w", err)
+	var vibeIssues []models.Issue
+	var err error
+	if perFile, ok := checker.(vibes.PerFileChecker); ok {
+		vibeIssues, err = s.checkFiles(ctx, perFile, files)
+	} else {
+		vibeIssues, err = checker.Check(ctx, files)
 	}
 
-	// Add vibe type to all issues
+	// Add vibe type, and a category where the checker did not set one, to all issues
 	for i := range vibeIssues {
 		vibeIssues[i].Type = vibeType
-		vibeIssues[i].ID = uuid.New().String()
-		vibeIssues[i].CreatedAt = time.Now()
+		if vibeIssues[i].Category == "" {
+			vibeIssues[i].Category = models.DefaultCategory(vibeType)
+		}
+	}
+	vibeIssues = applySeverityOverrides(vibeIssues, s.config.Vibes[vibeType].SeverityOverrides)
+
+	if err != nil {
+		// Partial results are still useful when the scan was cut short, but never cached
+		if ctx.Err() != nil {
+			return vibeIssues, fmt.Errorf("vibe check interrupted: %w", err)
+		}
+		return nil, fmt.Errorf("vibe check failed: %w", err)
 	}
 
 	issues = append(issues, vibeIssues...)
 
 	// Cache results if cache is enabled
 	if s.cache != nil {
-		cacheKey := s.generateCacheKey(files, vibeType)
-		s.cache.Set(cacheKey, issues)
+		s.cache.Set(cacheKey, copyIssues(issues))
 	}
 
 	// Record metrics
@@ -422,24 +972,35 @@ func (s *Scanner) runSingleVibeCheck(ctx context.Context, checker vibes.Checker,
 	return issues, nil
 }
 
-// generateCacheKey generates a cache key for vibe check results
-func (s *Scanner) generateCacheKey(files []string, vibeType models.VibeType) string {
-	// Create a hash of file paths and modification times
-	var keyParts []string
-	keyParts = append(keyParts, string(vibeType))
-
-	for _, file := range files {
-		info, err := os.Stat(file)
-		if err == nil {
-			keyParts = append(keyParts, fmt.Sprintf("%s:%d", file, info.ModTime().Unix()))
+// copyIssues copies issues together with their Metadata maps, which later
+// scan phases such as escalation and history write to
+func copyIssues(issues []models.Issue) []models.Issue {
+	if issues == nil {
+		return nil
+	}
+	copied := make([]models.Issue, len(issues))
+	for i, issue := range issues {
+		if issue.Metadata != nil {
+			metadata := make(map[string]interface{}, len(issue.Metadata))
+			for key, value := range issue.Metadata {
+				metadata[key] = value
+			}
+			issue.Metadata = metadata
 		}
+		copied[i] = issue
 	}
-
-	return utils.HashStrings(keyParts)
+	return copied
 }
 
 // generateSummary generates a summary of scan results
 func (s *Scanner) generateSummary(issues []models.Issue) models.ScanSummary {
+	return Summarize(issues, s.config.Reporting.GradeThresholds)
+}
+
+// Summarize counts issues by type and severity and scores them. Everything
+// that recomputes a summary after filtering issues uses it, so summaries agree
+// wherever they were made.
+func Summarize(issues []models.Issue, gradeThresholds []models.GradeThreshold) models.ScanSummary {
 	summary := models.ScanSummary{
 		TotalIssues:      len(issues),
 		IssuesByType:     make(map[models.VibeType]int),
@@ -466,10 +1027,10 @@ func (s *Scanner) generateSummary(issues []models.Issue) models.ScanSummary {
 
 	// Calculate score (higher is better)
 	totalPossibleScore := 100.0
-	criticalPenalty := float64(summary.CriticalIssues) * 25.0
-	errorPenalty := float64(summary.ErrorIssues) * 10.0
-	warningPenalty := float64(summary.WarningIssues) * 5.0
-	infoPenalty := float64(summary.InfoIssues) * 1.0
+	criticalPenalty := float64(summary.CriticalIssues) * models.SeverityPenalties[models.SeverityCritical]
+	errorPenalty := float64(summary.ErrorIssues) * models.SeverityPenalties[models.SeverityError]
+	warningPenalty := float64(summary.WarningIssues) * models.SeverityPenalties[models.SeverityWarning]
+	infoPenalty := float64(summary.InfoIssues) * models.SeverityPenalties[models.SeverityInfo]
 
 	summary.Score = totalPossibleScore - criticalPenalty - errorPenalty - warningPenalty - infoPenalty
 	if summary.Score < 0 {
@@ -477,33 +1038,59 @@ func (s *Scanner) generateSummary(issues []models.Issue) models.ScanSummary {
 	}
 
 	// Determine grade
-	switch {
-	case summary.Score >= 90:
-		summary.Grade = "A"
-	case summary.Score >= 80:
-		summary.Grade = "B"
-	case summary.Score >= 70:
-		summary.Grade = "C"
-	case summary.Score >= 60:
-		summary.Grade = "D"
-	default:
-		summary.Grade = "F"
-	}
-
-	// Generate top issues
-	ruleCount := make(map[string]int)
+	summary.Grade = models.GradeForScore(summary.Score, gradeThresholds)
+
+	summary.TopIssues = TopIssues(issues, MaxTopIssues)
+	summary.IssuesByOwner = issuesByOwner(issues)
+
+	return summary
+}
+
+// MaxTopIssues is how many recurring findings the summary lists
+const MaxTopIssues = 10
+
+// TopIssues lists the findings that recur most, grouped by rule and message
+// template so that messages differing only in numbers or names count together
+func TopIssues(issues []models.Issue, limit int) []string {
+	type group struct {
+		rule, template string
+		count          int
+	}
+	groups := make(map[string]*group)
 	for _, issue := range issues {
-		ruleCount[issue.Rule]++
+		template := issue.Template()
+		key := issue.Rule + "\x00" + template
+		if groups[key] == nil {
+			groups[key] = &group{rule: issue.Rule, template: template}
+		}
+		groups[key].count++
 	}
 
-	// Sort rules by frequency (simplified)
-	for rule, count := range ruleCount {
-		if count > 1 {
-			summary.TopIssues = append(summary.TopIssues, fmt.Sprintf("%s (%d occurrences)", rule, count))
+	var recurring []*group
+	for _, g := range groups {
+		if g.count > 1 {
+			recurring = append(recurring, g)
 		}
 	}
+	sort.Slice(recurring, func(i, j int) bool {
+		a, b := recurring[i], recurring[j]
+		if a.count != b.count {
+			return a.count > b.count
+		}
+		if a.rule != b.rule {
+			return a.rule < b.rule
+		}
+		return a.template < b.template
+	})
+	if limit > 0 && len(recurring) > limit {
+		recurring = recurring[:limit]
+	}
 
-	return summary
+	top := make([]string, 0, len(recurring))
+	for _, g := range recurring {
+		top = append(top, fmt.Sprintf("%s: %s (%d occurrences)", g.rule, g.template, g.count))
+	}
+	return top
 }
 
 // GetMetrics returns scanner metrics
@@ -518,6 +1105,12 @@ func (s *Scanner) ClearCache() {
 	}
 }
 
+// Excludes reports whether scans leave file out: it matches a .kodevibeignore
+// rule, the exclude config or scanner.exclude_patterns
+func (s *Scanner) Excludes(file string) bool {
+	return s.shouldExcludeFile(file) || s.shouldIgnore(file)
+}
+
 // ScanFile scans a single file
 func (s *Scanner) ScanFile(ctx context.Context, filePath string, vibes []models.VibeType) ([]models.Issue, error) {
 	// Convert VibeType slice to string slice
@@ -583,24 +1176,36 @@ func (s *Scanner) ValidateConfiguration() error {
 	return nil
 }
 
+// ignoreRule returns the .kodevibeignore rule deciding whether a path is
+// scanned, or nil when none matches it
+func (s *Scanner) ignoreRule(path string, isDir bool) *ignore.Rule {
+	if s.ignores == nil {
+		return nil
+	}
+	return s.ignores.Match(path, isDir)
+}
+
 // shouldIgnore checks if a file should be ignored based on patterns
-func (s *Scanner) shouldIgnore(path string) bool {
+func (s *Scanner) shouldIgnore(filePath string) bool {
+	filePath = utils.ToSlashPath(filePath)
 	for _, pattern := range s.config.Scanner.ExcludePatterns {
+		pattern = utils.ToSlashPath(pattern)
+
 		// Check filename pattern (e.g., "*.txt")
-		if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+		if utils.MatchPathPattern(pattern, path.Base(filePath)) {
 			return true
 		}
 
 		// Handle directory patterns like "node_modules/*", ".git/*"
 		if strings.HasSuffix(pattern, "/*") {
 			dirPattern := strings.TrimSuffix(pattern, "/*")
-			if strings.Contains(path, dirPattern+"/") || strings.HasPrefix(path, dirPattern+"/") {
+			if strings.Contains(filePath, dirPattern+"/") || strings.HasPrefix(filePath, dirPattern+"/") {
 				return true
 			}
 		}
 
 		// Check full path pattern
-		if matched, _ := filepath.Match(pattern, path); matched {
+		if utils.MatchPathPattern(pattern, filePath) {
 			return true
 		}
 	}
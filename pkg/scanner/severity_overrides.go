@@ -0,0 +1,35 @@
+package scanner
+
+import (
+	"kodevibe/internal/models"
+)
+
+// overriddenFromKey is the issue metadata recording the severity a checker
+// gave an issue before its vibe's severity_overrides replaced it
+const overriddenFromKey = "severity_overridden_from"
+
+// applySeverityOverrides replaces the severity of every issue whose rule has
+// an override, and drops the issues of rules overridden to ignore
+func applySeverityOverrides(issues []models.Issue, overrides map[string]models.SeverityLevel) []models.Issue {
+	if len(overrides) == 0 {
+		return issues
+	}
+
+	kept := issues[:0]
+	for _, issue := range issues {
+		severity, exists := overrides[issue.Rule]
+		switch {
+		case !exists || severity == issue.Severity:
+		case severity == models.SeverityIgnore:
+			continue
+		default:
+			if issue.Metadata == nil {
+				issue.Metadata = make(map[string]interface{})
+			}
+			issue.Metadata[overriddenFromKey] = string(issue.Severity)
+			issue.Severity = severity
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
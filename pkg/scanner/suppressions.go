@@ -0,0 +1,155 @@
+package scanner
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"kodevibe/internal/models"
+)
+
+// Suppression silences one rule in one file, optionally on a single line, or
+// one secret in a file wherever it moves
+type Suppression struct {
+	// Rule is the suppressed rule; a secret suppression without one covers every secret rule
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	File string `json:"file" yaml:"file"`
+	// Line limits the suppression to one line; 0 covers the whole file
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+	// SecretHash is the hex SHA-1 of a secret value, as in detect-secrets
+	// baselines. It matches findings of that secret in the file, on any line
+	// unless Line is set.
+	SecretHash string    `json:"secret_hash,omitempty" yaml:"secret_hash,omitempty"`
+	Reason     string    `json:"reason,omitempty" yaml:"reason,omitempty"`
+	AddedAt    time.Time `json:"added_at,omitempty" yaml:"added_at,omitempty"`
+}
+
+// Suppressions is the contents of the project's suppressions file (.kodevibe/suppressions.yaml)
+type Suppressions struct {
+	Suppressions []Suppression `json:"suppressions" yaml:"suppressions"`
+}
+
+// LoadSuppressions reads a suppressions file. A missing file holds no suppressions.
+func LoadSuppressions(path string) (*Suppressions, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Suppressions{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suppressions: %w", err)
+	}
+
+	var suppressions Suppressions
+	if err := yaml.Unmarshal(data, &suppressions); err != nil {
+		return nil, fmt.Errorf("failed to parse suppressions %s: %w", path, err)
+	}
+	return &suppressions, nil
+}
+
+// Save writes the suppressions file, creating its directory if needed
+func (s *Suppressions) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal suppressions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create suppressions directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write suppressions: %w", err)
+	}
+	return nil
+}
+
+// Add suppresses an issue's rule on its line. It returns false if the issue is already suppressed.
+func (s *Suppressions) Add(issue models.Issue, reason string) bool {
+	if s.Suppressed(issue) {
+		return false
+	}
+	s.Suppressions = append(s.Suppressions, Suppression{
+		Rule:    issue.Rule,
+		File:    suppressionPath(issue.File),
+		Line:    issue.Line,
+		Reason:  reason,
+		AddedAt: time.Now().UTC().Truncate(time.Second),
+	})
+	return true
+}
+
+// Suppressed reports whether an issue matches a suppression
+func (s *Suppressions) Suppressed(issue models.Issue) bool {
+	file := suppressionPath(issue.File)
+	secretHash := ""
+	for _, suppression := range s.Suppressions {
+		if suppressionPath(suppression.File) != file {
+			continue
+		}
+		if suppression.SecretHash != "" {
+			if suppression.Rule != "" && suppression.Rule != issue.Rule {
+				continue
+			}
+			if suppression.Rule == "" && issue.Type != models.VibeTypeSecurity {
+				continue
+			}
+			if suppression.Line != 0 && suppression.Line != issue.Line {
+				continue
+			}
+			if secretHash == "" {
+				secretHash = issueSecretHash(issue)
+			}
+			if secretHash != "" && strings.EqualFold(suppression.SecretHash, secretHash) {
+				return true
+			}
+			continue
+		}
+		if suppression.Rule != issue.Rule {
+			continue
+		}
+		if suppression.Line == 0 || suppression.Line == issue.Line {
+			return true
+		}
+	}
+	return false
+}
+
+// issueSecretKeys are the issue metadata keys holding a secret finding's value, most precise first
+var issueSecretKeys = []string{"secret", "match", "string"}
+
+// issueSecretHash is the hex SHA-1 of the secret an issue found, or "" if it isn't a secret finding
+func issueSecretHash(issue models.Issue) string {
+	for _, key := range issueSecretKeys {
+		if value, ok := issue.Metadata[key].(string); ok && value != "" {
+			sum := sha1.Sum([]byte(value))
+			return hex.EncodeToString(sum[:])
+		}
+	}
+	return ""
+}
+
+// Filter drops suppressed issues and returns the rest with the number dropped
+func (s *Suppressions) Filter(issues []models.Issue) ([]models.Issue, int) {
+	if len(s.Suppressions) == 0 {
+		return issues, 0
+	}
+
+	kept := make([]models.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !s.Suppressed(issue) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept, len(issues) - len(kept)
+}
+
+// suppressionPath normalizes a path so "./a/b.go" and "a\b.go" match "a/b.go"
+func suppressionPath(file string) string {
+	return path.Clean(strings.ReplaceAll(file, `\`, "/"))
+}
@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestSuppressions_AddAndFilter(t *testing.T) {
+	suppressions := &Suppressions{}
+	issue := models.Issue{Rule: "no-console-log", File: "./src/app.js", Line: 12}
+
+	assert.True(t, suppressions.Add(issue, "debug output is intended"))
+	assert.False(t, suppressions.Add(issue, "again"), "an issue is only suppressed once")
+	assert.Equal(t, "src/app.js", suppressions.Suppressions[0].File)
+
+	issues := []models.Issue{
+		{Rule: "no-console-log", File: "src/app.js", Line: 12},
+		{Rule: "no-console-log", File: `src\app.js`, Line: 12},
+		{Rule: "no-console-log", File: "src/app.js", Line: 13},
+		{Rule: "strict-equality", File: "src/app.js", Line: 12},
+	}
+	kept, dropped := suppressions.Filter(issues)
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, issues[2:], kept)
+}
+
+func TestSuppressions_WholeFile(t *testing.T) {
+	suppressions := &Suppressions{Suppressions: []Suppression{{Rule: "long-line", File: "gen/data.go"}}}
+
+	assert.True(t, suppressions.Suppressed(models.Issue{Rule: "long-line", File: "gen/data.go", Line: 40}))
+	assert.False(t, suppressions.Suppressed(models.Issue{Rule: "long-line", File: "gen/other.go", Line: 40}))
+}
+
+func TestSuppressions_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".kodevibe", "suppressions.yaml")
+
+	missing, err := LoadSuppressions(path)
+	require.NoError(t, err)
+	assert.Empty(t, missing.Suppressions)
+
+	suppressions := &Suppressions{}
+	suppressions.Add(models.Issue{Rule: "hardcoded-credentials", File: "config/test.py", Line: 3}, "test fixture")
+	require.NoError(t, suppressions.Save(path))
+
+	loaded, err := LoadSuppressions(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Suppressions, 1)
+	assert.Equal(t, "test fixture", loaded.Suppressions[0].Reason)
+	assert.Equal(t, 3, loaded.Suppressions[0].Line)
+	assert.False(t, loaded.Suppressions[0].AddedAt.IsZero())
+}
@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"path"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// defaultTestFilePatterns identify test files for vibes with exclude_tests set.
+// Patterns without a slash match the file name; others match the whole path.
+var defaultTestFilePatterns = []string{
+	"*_test.go",
+	"*.test.js", "*.spec.js", "*.test.jsx", "*.spec.jsx",
+	"*.test.ts", "*.spec.ts", "*.test.tsx", "*.spec.tsx",
+	"test_*.py", "*_test.py",
+	"*Test.java", "*Tests.java",
+	"*_spec.rb",
+	"**/__tests__/**",
+	"**/testdata/**",
+}
+
+// filesForVibe drops the files a vibe should not see: generated API stubs
+// unless the vibe is listed in scanner.generated_files.vibes, and test files
+// when the vibe is configured with exclude_tests, so e.g. performance findings
+// are not reported for test setup while security still scans it
+func (s *Scanner) filesForVibe(files []string, vibeType models.VibeType) []string {
+	if !s.scansGeneratedFiles(vibeType) {
+		filtered := s.dropGeneratedFiles(files)
+		if skipped := len(files) - len(filtered); skipped > 0 {
+			s.logger.WithField("vibe", vibeType).WithField("skipped", skipped).Debug("Excluded generated files from vibe")
+		}
+		files = filtered
+	}
+
+	vibeConfig, exists := s.config.Vibes[vibeType]
+	if !exists || !vibeConfig.ExcludeTests {
+		return files
+	}
+
+	patterns := vibeConfig.TestPatterns
+	if len(patterns) == 0 {
+		patterns = defaultTestFilePatterns
+	}
+
+	filtered := make([]string, 0, len(files))
+	for _, file := range files {
+		if !s.isTestFile(file, patterns) {
+			filtered = append(filtered, file)
+		}
+	}
+
+	if skipped := len(files) - len(filtered); skipped > 0 {
+		s.logger.WithField("vibe", vibeType).WithField("skipped", skipped).Debug("Excluded test files from vibe")
+	}
+
+	return filtered
+}
+
+// isTestFile reports whether file matches any of the test file patterns
+func (s *Scanner) isTestFile(file string, patterns []string) bool {
+	file = utils.ToSlashPath(file)
+	for _, pattern := range patterns {
+		pattern = utils.ToSlashPath(pattern)
+		switch {
+		case strings.Contains(pattern, "**"):
+			if s.matchGlob(file, pattern) {
+				return true
+			}
+		case strings.Contains(pattern, "/"):
+			if utils.MatchPathPattern(pattern, file) {
+				return true
+			}
+		default:
+			if utils.MatchPathPattern(pattern, path.Base(file)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -15,14 +15,15 @@ type AdvancedScoringEngine struct {
 	penalties     map[string]float64
 	bonuses       map[string]float64
 	trendAnalysis *TrendAnalysis
+	grades        []models.GradeThreshold
 }
 
 // ScoreThreshold defines scoring thresholds for different metrics
 type ScoreThreshold struct {
-	Excellent float64 // 90-100
-	Good      float64 // 70-89
-	Fair      float64 // 50-69
-	Poor      float64 // Below 50
+	Excellent float64 `json:"excellent" yaml:"excellent"` // 90-100
+	Good      float64 `json:"good" yaml:"good"`           // 70-89
+	Fair      float64 `json:"fair" yaml:"fair"`           // 50-69
+	Poor      float64 `json:"poor" yaml:"poor"`           // Below 50
 }
 
 // TrendAnalysis tracks scoring trends over time
@@ -43,16 +44,16 @@ type HistoricalScore struct {
 
 // ScoringMetrics contains detailed scoring information
 type ScoringMetrics struct {
-	BaseScore         float64
-	WeightedScore     float64
-	FinalScore        float64
-	Grade             string
-	Confidence        float64
-	Breakdown         map[string]float64
-	Penalties         map[string]float64
-	Bonuses           map[string]float64
-	TrendAdjustment   float64
-	QualityIndicators map[string]float64
+	BaseScore         float64            `json:"base_score"`
+	WeightedScore     float64            `json:"weighted_score"`
+	FinalScore        float64            `json:"final_score"`
+	Grade             string             `json:"grade"`
+	Confidence        float64            `json:"confidence"`
+	Breakdown         map[string]float64 `json:"breakdown"`
+	Penalties         map[string]float64 `json:"penalties"`
+	Bonuses           map[string]float64 `json:"bonuses"`
+	TrendAdjustment   float64            `json:"trend_adjustment"`
+	QualityIndicators map[string]float64 `json:"quality_indicators"`
 }
 
 // NewAdvancedScoringEngine creates a new advanced scoring engine
@@ -110,18 +111,22 @@ func (e *AdvancedScoringEngine) CalculateAdvancedScore(result *models.AnalysisRe
 	// Calculate base scores for each vibe
 	vibeScores := e.calculateVibeScores(result.VibeResults)
 
-	// Calculate weighted average
+	// Calculate weighted average, summing in vibe order so equal inputs give
+	// bit-for-bit equal scores
 	weightedSum := 0.0
 	totalWeight := 0.0
 
-	for vibe, score := range vibeScores {
+	for _, vibe := range sortedKeys(vibeScores) {
+		score := vibeScores[vibe]
 		weight := e.weights[vibe]
 		weightedSum += score * weight
 		totalWeight += weight
 		metrics.Breakdown[vibe] = score
 	}
 
-	metrics.BaseScore = weightedSum / totalWeight
+	if totalWeight > 0 {
+		metrics.BaseScore = weightedSum / totalWeight
+	}
 	metrics.WeightedScore = metrics.BaseScore
 
 	// Apply issue-based penalties
@@ -306,34 +311,12 @@ func (e *AdvancedScoringEngine) calculateConfidence(result *models.AnalysisResul
 
 // assignGrade assigns a letter grade based on the final score
 func (e *AdvancedScoringEngine) assignGrade(score float64) string {
-	switch {
-	case score >= 95:
-		return "A+"
-	case score >= 90:
-		return "A"
-	case score >= 85:
-		return "A-"
-	case score >= 80:
-		return "B+"
-	case score >= 75:
-		return "B"
-	case score >= 70:
-		return "B-"
-	case score >= 65:
-		return "C+"
-	case score >= 60:
-		return "C"
-	case score >= 55:
-		return "C-"
-	case score >= 50:
-		return "D+"
-	case score >= 45:
-		return "D"
-	case score >= 40:
-		return "D-"
-	default:
-		return "F"
-	}
+	return models.GradeForScore(score, e.grades)
+}
+
+// SetGradeThresholds replaces the grade scale used by the engine
+func (e *AdvancedScoringEngine) SetGradeThresholds(thresholds []models.GradeThreshold) {
+	e.grades = thresholds
 }
 
 // calculateQualityIndicators computes various quality metrics
@@ -440,6 +423,16 @@ func (e *AdvancedScoringEngine) calculateTrendMetrics() {
 	}
 }
 
+// sortedKeys returns the keys of scores in order
+func sortedKeys(scores map[string]float64) []string {
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (e *AdvancedScoringEngine) calculateDataQualityFactor(result *models.AnalysisResult) float64 {
 	// Higher confidence for more comprehensive analysis
 	if result.FilesAnalyzed > 50 && result.LinesAnalyzed > 5000 {
@@ -461,8 +454,8 @@ func (e *AdvancedScoringEngine) calculateCoverageFactor(result *models.AnalysisR
 func (e *AdvancedScoringEngine) calculateConsistencyFactor(metrics *ScoringMetrics) float64 {
 	// Check consistency across vibe scores
 	scores := make([]float64, 0, len(metrics.Breakdown))
-	for _, score := range metrics.Breakdown {
-		scores = append(scores, score)
+	for _, vibe := range sortedKeys(metrics.Breakdown) {
+		scores = append(scores, metrics.Breakdown[vibe])
 	}
 
 	if len(scores) < 2 {
@@ -541,7 +534,10 @@ func (e *AdvancedScoringEngine) calculateCodeHealthScore(result *models.Analysis
 	baseHealth := totalScore / float64(count)
 
 	// Adjust for issue density
-	issueRatio := float64(len(result.Issues)) / float64(result.LinesAnalyzed) * 1000
+	issueRatio := 0.0
+	if result.LinesAnalyzed > 0 {
+		issueRatio = float64(len(result.Issues)) / float64(result.LinesAnalyzed) * 1000
+	}
 	healthAdjustment := math.Max(0, 10-issueRatio) // Penalty for high issue density
 
 	return math.Min(100, baseHealth+healthAdjustment)
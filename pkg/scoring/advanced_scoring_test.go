@@ -443,6 +443,19 @@ func TestAdvancedScoringEngine_GradeAssignment(t *testing.T) {
 	}
 }
 
+func TestAdvancedScoringEngine_CustomGradeThresholds(t *testing.T) {
+	engine := NewAdvancedScoringEngine()
+	engine.SetGradeThresholds([]models.GradeThreshold{
+		{Grade: "A", MinScore: 95},
+		{Grade: "B", MinScore: 85},
+		{Grade: "F", MinScore: 0},
+	})
+
+	assert.Equal(t, "A", engine.assignGrade(96))
+	assert.Equal(t, "B", engine.assignGrade(92))
+	assert.Equal(t, "F", engine.assignGrade(50))
+}
+
 func TestAdvancedScoringEngine_QualityIndicators(t *testing.T) {
 	engine := NewAdvancedScoringEngine()
 
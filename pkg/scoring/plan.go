@@ -0,0 +1,169 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"kodevibe/internal/models"
+)
+
+// Effort estimates how much work a remediation step takes
+type Effort string
+
+const (
+	// EffortAutoFix steps can be applied with kodevibe fix
+	EffortAutoFix Effort = "auto-fix"
+	// EffortManual steps need someone to review and change the code
+	EffortManual Effort = "manual"
+)
+
+// PlanStep groups the findings of one rule with the score gained by fixing them all
+type PlanStep struct {
+	Rule        string               `json:"rule"`
+	Vibe        models.VibeType      `json:"vibe"`
+	Category    string               `json:"category,omitempty"`
+	Title       string               `json:"title,omitempty"`
+	Severity    models.SeverityLevel `json:"severity"`
+	Count       int                  `json:"count"`
+	Files       int                  `json:"files"`
+	Effort      Effort               `json:"effort"`
+	ScoreImpact float64              `json:"score_impact"`
+}
+
+// Plan is a remediation plan for a scan result, ordered by score impact
+type Plan struct {
+	CurrentScore   float64    `json:"current_score"`
+	CurrentGrade   string     `json:"current_grade"`
+	ProjectedScore float64    `json:"projected_score"`
+	ProjectedGrade string     `json:"projected_grade"`
+	Steps          []PlanStep `json:"steps"`
+}
+
+// severityRank orders severities from least to most severe
+var severityRank = map[models.SeverityLevel]int{
+	models.SeverityInfo:     1,
+	models.SeverityWarning:  2,
+	models.SeverityError:    3,
+	models.SeverityCritical: 4,
+}
+
+// BuildPlan groups a scan result's issues by rule and estimates, with the
+// scoring engine, how much the score rises when each group is fixed. Steps are
+// ordered by score impact, then cheaper auto-fixes first, then by count.
+func BuildPlan(result *models.ScanResult, config ScoringConfig) (*Plan, error) {
+	if result == nil {
+		return nil, fmt.Errorf("scan result is required")
+	}
+
+	current, err := Rescore(result, config)
+	if err != nil {
+		return nil, err
+	}
+	projected, err := Rescore(withIssues(result, nil), config)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		CurrentScore:   current.FinalScore,
+		CurrentGrade:   current.Grade,
+		ProjectedScore: projected.FinalScore,
+		ProjectedGrade: projected.Grade,
+	}
+
+	type group struct {
+		step    *PlanStep
+		files   map[string]bool
+		fixable bool
+	}
+	groups := make(map[string]*group)
+	var keys []string
+
+	for _, issue := range result.Issues {
+		key := string(issue.Type) + "/" + issue.Rule
+		g, exists := groups[key]
+		if !exists {
+			g = &group{
+				step: &PlanStep{
+					Rule:     issue.Rule,
+					Vibe:     issue.Type,
+					Category: issue.Category,
+					Title:    issue.Title,
+					Severity: issue.Severity,
+				},
+				files:   make(map[string]bool),
+				fixable: true,
+			}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+
+		g.step.Count++
+		g.files[issue.File] = true
+		g.fixable = g.fixable && issue.Fixable
+		if severityRank[issue.Severity] > severityRank[g.step.Severity] {
+			g.step.Severity = issue.Severity
+		}
+	}
+
+	for _, key := range keys {
+		g := groups[key]
+		step := g.step
+		step.Files = len(g.files)
+		step.Effort = EffortManual
+		if g.fixable {
+			step.Effort = EffortAutoFix
+		}
+
+		var remaining []models.Issue
+		for _, issue := range result.Issues {
+			if string(issue.Type)+"/"+issue.Rule != key {
+				remaining = append(remaining, issue)
+			}
+		}
+		fixed, err := Rescore(withIssues(result, remaining), config)
+		if err != nil {
+			return nil, err
+		}
+		step.ScoreImpact = math.Round((fixed.FinalScore-current.FinalScore)*10) / 10
+
+		plan.Steps = append(plan.Steps, *step)
+	}
+
+	sort.SliceStable(plan.Steps, func(i, j int) bool {
+		a, b := plan.Steps[i], plan.Steps[j]
+		if a.ScoreImpact != b.ScoreImpact {
+			return a.ScoreImpact > b.ScoreImpact
+		}
+		if a.Effort != b.Effort {
+			return a.Effort == EffortAutoFix
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Rule < b.Rule
+	})
+
+	return plan, nil
+}
+
+// Summary describes the step in one line, e.g. "auto-fix 45 no-console-log findings (+2.3 score)"
+func (s PlanStep) Summary() string {
+	verb := "review"
+	if s.Effort == EffortAutoFix {
+		verb = "auto-fix"
+	}
+	noun := "findings"
+	if s.Count == 1 {
+		noun = "finding"
+	}
+	return fmt.Sprintf("%s %d %s %s (%+.1f score)", verb, s.Count, s.Rule, noun, s.ScoreImpact)
+}
+
+// withIssues returns a shallow copy of result with its issues replaced
+func withIssues(result *models.ScanResult, issues []models.Issue) *models.ScanResult {
+	copied := *result
+	copied.Issues = issues
+	return &copied
+}
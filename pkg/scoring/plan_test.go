@@ -0,0 +1,72 @@
+package scoring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestBuildPlan(t *testing.T) {
+	result := &models.ScanResult{FilesScanned: 4}
+	for i := 0; i < 6; i++ {
+		result.Issues = append(result.Issues, models.Issue{
+			Type: models.VibeTypeCode, Category: models.CategoryBestPractices, Severity: models.SeverityWarning,
+			Rule: "no-console-log", File: "app.js", Fixable: true,
+		})
+	}
+	for _, file := range []string{"db.go", "api.go"} {
+		result.Issues = append(result.Issues, models.Issue{
+			Type: models.VibeTypeSecurity, Category: models.CategorySecurity, Severity: models.SeverityCritical,
+			Rule: "sql-injection-risk", File: file,
+		})
+	}
+	result.Issues = append(result.Issues,
+		models.Issue{Type: models.VibeTypeCode, Category: models.CategoryMaintainability, Severity: models.SeverityInfo, Rule: "todo-comments", File: "a.go"},
+		models.Issue{Type: models.VibeTypeCode, Category: models.CategoryMaintainability, Severity: models.SeverityWarning, Rule: "todo-comments", File: "a.go", Fixable: true},
+	)
+
+	plan, err := BuildPlan(result, ScoringConfig{})
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 3)
+
+	baseline, err := Rescore(result, ScoringConfig{})
+	require.NoError(t, err)
+	assert.InDelta(t, baseline.FinalScore, plan.CurrentScore, 0.001)
+	assert.Greater(t, plan.ProjectedScore, plan.CurrentScore)
+
+	sqli := plan.Steps[0]
+	assert.Equal(t, "sql-injection-risk", sqli.Rule)
+	assert.Equal(t, EffortManual, sqli.Effort)
+	assert.Equal(t, 2, sqli.Count)
+	assert.Equal(t, 2, sqli.Files)
+	assert.Equal(t, models.SeverityCritical, sqli.Severity)
+
+	console := plan.Steps[1]
+	assert.Equal(t, "no-console-log", console.Rule)
+	assert.Equal(t, EffortAutoFix, console.Effort)
+	assert.Equal(t, 1, console.Files)
+	assert.Greater(t, sqli.ScoreImpact, console.ScoreImpact)
+	assert.Equal(t, "auto-fix 6 no-console-log findings (+"+formatImpact(console.ScoreImpact)+" score)", console.Summary())
+
+	todo := plan.Steps[2]
+	assert.Equal(t, EffortManual, todo.Effort, "a group is only auto-fixable when every finding is")
+	assert.Equal(t, models.SeverityWarning, todo.Severity, "a group takes its most severe finding")
+	for i := 1; i < len(plan.Steps); i++ {
+		assert.GreaterOrEqual(t, plan.Steps[i-1].ScoreImpact, plan.Steps[i].ScoreImpact)
+	}
+
+	empty, err := BuildPlan(&models.ScanResult{}, ScoringConfig{})
+	require.NoError(t, err)
+	assert.Empty(t, empty.Steps)
+
+	_, err = BuildPlan(nil, ScoringConfig{})
+	assert.Error(t, err)
+}
+
+func formatImpact(impact float64) string {
+	return fmt.Sprintf("%.1f", impact)
+}
@@ -0,0 +1,126 @@
+package scoring
+
+import (
+	"fmt"
+	"sort"
+
+	"kodevibe/internal/models"
+)
+
+// ScoringConfig overrides the engine's weights, thresholds, penalties, bonuses
+// and grade scale. Keys that are not set keep their default values.
+type ScoringConfig struct {
+	Weights    map[string]float64        `json:"weights,omitempty" yaml:"weights,omitempty"`
+	Thresholds map[string]ScoreThreshold `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
+	Penalties  map[string]float64        `json:"penalties,omitempty" yaml:"penalties,omitempty"`
+	Bonuses    map[string]float64        `json:"bonuses,omitempty" yaml:"bonuses,omitempty"`
+	Grades     []models.GradeThreshold   `json:"grades,omitempty" yaml:"grades,omitempty"`
+}
+
+// Configure applies scoring overrides on top of the engine's current settings
+func (e *AdvancedScoringEngine) Configure(config ScoringConfig) error {
+	for dimension, weight := range config.Weights {
+		if weight < 0 {
+			return fmt.Errorf("weight for %s must not be negative", dimension)
+		}
+		e.weights[dimension] = weight
+	}
+	for dimension, threshold := range config.Thresholds {
+		if !(threshold.Excellent >= threshold.Good && threshold.Good >= threshold.Fair && threshold.Fair >= threshold.Poor) {
+			return fmt.Errorf("thresholds for %s must be ordered excellent >= good >= fair >= poor", dimension)
+		}
+		e.thresholds[dimension] = threshold
+	}
+	for name, penalty := range config.Penalties {
+		e.penalties[name] = penalty
+	}
+	for name, bonus := range config.Bonuses {
+		e.bonuses[name] = bonus
+	}
+	if len(config.Grades) > 0 {
+		e.SetGradeThresholds(config.Grades)
+	}
+
+	return nil
+}
+
+// categoryDimensions maps issue categories to the engine's scoring dimensions
+var categoryDimensions = map[string]string{
+	models.CategorySecurity:        "security",
+	models.CategoryDependencies:    "security",
+	models.CategoryPerformance:     "performance",
+	models.CategoryReadability:     "readability",
+	models.CategoryMaintainability: "maintainability",
+	models.CategoryBestPractices:   "maintainability",
+	models.CategoryErrorHandling:   "maintainability",
+	models.CategoryComplexity:      "complexity",
+	models.CategoryDocumentation:   "documentation",
+}
+
+// AnalysisResultFromScan derives the engine's input from a scan result alone,
+// without reading any files. Each scoring dimension starts at 100 and loses
+// points per issue according to severity; testing has no scan data and is left out.
+func AnalysisResultFromScan(result *models.ScanResult) *models.AnalysisResult {
+	scores := make(map[string]float64)
+	for _, dimension := range categoryDimensions {
+		scores[dimension] = 100
+	}
+
+	for _, issue := range result.Issues {
+		category := issue.Category
+		if category == "" {
+			category = models.DefaultCategory(issue.Type)
+		}
+		dimension, ok := categoryDimensions[category]
+		if !ok {
+			dimension = "maintainability"
+		}
+		scores[dimension] -= models.SeverityPenalties[issue.Severity]
+	}
+
+	dimensions := make([]string, 0, len(scores))
+	for dimension := range scores {
+		dimensions = append(dimensions, dimension)
+	}
+	sort.Strings(dimensions)
+
+	analysis := &models.AnalysisResult{
+		FilesAnalyzed: result.FilesScanned,
+		Duration:      result.Duration,
+		Issues:        result.Issues,
+		Timestamp:     result.Timestamp,
+		Commit:        result.Commit,
+		ResultHash:    result.ReproducibilityHash,
+	}
+	if lines, ok := result.Metadata["lines_scanned"].(float64); ok {
+		analysis.LinesAnalyzed = int(lines)
+	} else if lines, ok := result.Metadata["lines_scanned"].(int); ok {
+		analysis.LinesAnalyzed = lines
+	}
+
+	for _, dimension := range dimensions {
+		score := scores[dimension]
+		if score < 0 {
+			score = 0
+		}
+		analysis.VibeResults = append(analysis.VibeResults, models.VibeResult{Name: dimension, Score: score})
+	}
+
+	return analysis
+}
+
+// Rescore recomputes the score and grade of an existing scan result with the
+// given scoring settings. It reads no files and uses a fresh engine with no
+// trend history, so callers can try different weights and compare results directly.
+func Rescore(result *models.ScanResult, config ScoringConfig) (*ScoringMetrics, error) {
+	if result == nil {
+		return nil, fmt.Errorf("scan result is required")
+	}
+
+	engine := NewAdvancedScoringEngine()
+	if err := engine.Configure(config); err != nil {
+		return nil, fmt.Errorf("invalid scoring config: %w", err)
+	}
+
+	return engine.CalculateAdvancedScore(AnalysisResultFromScan(result)), nil
+}
@@ -0,0 +1,66 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func rescoreTestResult() *models.ScanResult {
+	return &models.ScanResult{
+		ScanID:       "scan-1",
+		FilesScanned: 3,
+		Issues: []models.Issue{
+			{Type: models.VibeTypeSecurity, Category: models.CategorySecurity, Severity: models.SeverityError},
+			{Type: models.VibeTypeSecurity, Category: models.CategorySecurity, Severity: models.SeverityError},
+			{Type: models.VibeTypeCode, Category: models.CategoryReadability, Severity: models.SeverityWarning},
+			{Type: models.VibeTypePerformance, Severity: models.SeverityInfo},
+			{Type: models.VibeTypeDocumentation, Category: models.CategoryDocumentation, Severity: models.SeverityCritical},
+		},
+	}
+}
+
+func TestAnalysisResultFromScan(t *testing.T) {
+	analysis := AnalysisResultFromScan(rescoreTestResult())
+
+	scores := make(map[string]float64)
+	for _, vibe := range analysis.VibeResults {
+		scores[vibe.Name] = vibe.Score
+	}
+
+	assert.Equal(t, 80.0, scores["security"])
+	assert.Equal(t, 95.0, scores["readability"])
+	assert.Equal(t, 99.0, scores["performance"], "uncategorized issues fall back to their vibe's category")
+	assert.Equal(t, 75.0, scores["documentation"], "a critical issue costs what it costs the scan's score")
+	assert.NotContains(t, scores, "testing")
+	assert.Equal(t, 3, analysis.FilesAnalyzed)
+}
+
+func TestRescore(t *testing.T) {
+	result := rescoreTestResult()
+
+	baseline, err := Rescore(result, ScoringConfig{})
+	require.NoError(t, err)
+	assert.False(t, math.IsNaN(baseline.FinalScore))
+
+	securityHeavy, err := Rescore(result, ScoringConfig{Weights: map[string]float64{"security": 5}})
+	require.NoError(t, err)
+	assert.Less(t, securityHeavy.BaseScore, baseline.BaseScore, "weighting the worst dimension up lowers the score")
+
+	again, err := Rescore(result, ScoringConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, baseline.FinalScore, again.FinalScore, "rescoring is repeatable")
+
+	strict, err := Rescore(result, ScoringConfig{Grades: []models.GradeThreshold{{Grade: "PASS", MinScore: 101}, {Grade: "FAIL", MinScore: 0}}})
+	require.NoError(t, err)
+	assert.Equal(t, "FAIL", strict.Grade)
+
+	_, err = Rescore(result, ScoringConfig{Weights: map[string]float64{"security": -1}})
+	assert.Error(t, err)
+	_, err = Rescore(nil, ScoringConfig{})
+	assert.Error(t, err)
+}
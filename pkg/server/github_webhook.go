@@ -0,0 +1,373 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/scanner"
+)
+
+const (
+	defaultGitHubAPIURL   = "https://api.github.com"
+	githubStatusContext   = "kodevibe"
+	githubWebhookTimeout  = 10 * time.Minute
+	githubSignaturePrefix = "sha256="
+	// maxGitHubPayloadBytes is the largest payload GitHub delivers
+	maxGitHubPayloadBytes = 25 << 20
+	// maxGitHubWebhookScans bounds the webhook scans running at once; each
+	// clones a repository and scans it
+	maxGitHubWebhookScans = 2
+)
+
+// githubSHAPattern matches a full commit SHA, the only revision a webhook may ask for
+var githubSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// githubPushEvent contains the fields of a push event used for scanning
+type githubPushEvent struct {
+	After      string           `json:"after"`
+	Deleted    bool             `json:"deleted"`
+	Repository githubRepository `json:"repository"`
+}
+
+// githubPullRequestEvent contains the fields of a pull_request event used for scanning
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Head struct {
+			SHA  string           `json:"sha"`
+			Repo githubRepository `json:"repo"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Repository githubRepository `json:"repository"`
+}
+
+type githubRepository struct {
+	FullName string `json:"full_name"`
+	CloneURL string `json:"clone_url"`
+}
+
+// githubScanTarget identifies the commit a webhook asks us to scan
+type githubScanTarget struct {
+	Event    string
+	Repo     string
+	CloneURL string
+	SHA      string
+}
+
+// handleGitHubWebhook validates a GitHub webhook delivery and scans the referenced commit
+func (s *Server) handleGitHubWebhook(c *gin.Context) {
+	secret := s.config.Integrations.GitHub.WebhookSecret
+	if secret == "" {
+		errorJSON(c, http.StatusServiceUnavailable, "GitHub webhook secret is not configured")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxGitHubPayloadBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			errorJSON(c, http.StatusRequestEntityTooLarge, "payload too large")
+			return
+		}
+		errorJSON(c, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	if !verifyGitHubSignature(secret, body, c.GetHeader("X-Hub-Signature-256")) {
+		errorJSON(c, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	event := c.GetHeader("X-GitHub-Event")
+	target, err := parseGitHubEvent(event, body, s.githubCloneHost())
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if target == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "event ignored",
+			"event":   event,
+		})
+		return
+	}
+
+	select {
+	case s.webhookScans <- struct{}{}:
+	default:
+		c.Header("Retry-After", "60")
+		errorJSON(c, http.StatusServiceUnavailable, "too many webhook scans in progress")
+		return
+	}
+	if !s.trackScan() {
+		<-s.webhookScans
+		errorJSON(c, http.StatusServiceUnavailable, "server is shutting down")
+		return
+	}
+	go s.scanGitHubCommit(*target, requestID(c))
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "scheduled",
+		"event":  target.Event,
+		"repo":   target.Repo,
+		"sha":    target.SHA,
+	})
+}
+
+// verifyGitHubSignature checks the X-Hub-Signature-256 header against the payload
+func verifyGitHubSignature(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, githubSignaturePrefix) {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, githubSignaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// parseGitHubEvent extracts the scan target from a webhook payload, whose
+// clone URL must point at cloneHost. It returns nil without error for events
+// that do not trigger a scan.
+func parseGitHubEvent(event string, body []byte, cloneHost string) (*githubScanTarget, error) {
+	switch event {
+	case "push":
+		var payload githubPushEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid push payload: %w", err)
+		}
+		if payload.Deleted {
+			return nil, nil
+		}
+		target := &githubScanTarget{
+			Event:    event,
+			Repo:     payload.Repository.FullName,
+			CloneURL: payload.Repository.CloneURL,
+			SHA:      payload.After,
+		}
+		return target, target.validate(cloneHost)
+
+	case "pull_request":
+		var payload githubPullRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid pull_request payload: %w", err)
+		}
+		switch payload.Action {
+		case "opened", "synchronize", "reopened":
+		default:
+			return nil, nil
+		}
+		target := &githubScanTarget{
+			Event:    event,
+			Repo:     payload.Repository.FullName,
+			CloneURL: payload.PullRequest.Head.Repo.CloneURL,
+			SHA:      payload.PullRequest.Head.SHA,
+		}
+		return target, target.validate(cloneHost)
+
+	default:
+		return nil, nil
+	}
+}
+
+// validate checks the target before its values reach git: the SHA must be a
+// full commit SHA and the clone URL an https URL on cloneHost
+func (t *githubScanTarget) validate(cloneHost string) error {
+	if t.Repo == "" || t.CloneURL == "" || t.SHA == "" {
+		return fmt.Errorf("%s payload is missing repository or commit SHA", t.Event)
+	}
+	if !githubSHAPattern.MatchString(t.SHA) {
+		return fmt.Errorf("%s payload has an invalid commit SHA %q", t.Event, t.SHA)
+	}
+	cloneURL, err := url.Parse(t.CloneURL)
+	if err != nil || cloneURL.Scheme != "https" || cloneURL.User != nil ||
+		!strings.EqualFold(cloneURL.Hostname(), cloneHost) {
+		return fmt.Errorf("%s payload has a clone URL outside https://%s", t.Event, cloneHost)
+	}
+	return nil
+}
+
+// githubCloneHost returns the host repositories are cloned from: the host of
+// the configured API URL, without the "api." of api.github.com
+func (s *Server) githubCloneHost() string {
+	apiURL := s.config.Integrations.GitHub.APIURL
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+	parsed, err := url.Parse(apiURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "api.")
+}
+
+// scanGitHubCommit clones the target commit, scans it and reports a commit
+// status, then frees its webhook scan slot
+func (s *Server) scanGitHubCommit(target githubScanTarget, reqID string) {
+	defer s.scans.Done()
+	defer func() { <-s.webhookScans }()
+	ctx, cancel := context.WithTimeout(scanner.WithRequestID(s.scanCtx, reqID), githubWebhookTimeout)
+	defer cancel()
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"repo":       target.Repo,
+		"sha":        target.SHA,
+		"request_id": reqID,
+	})
+
+	if err := s.postGitHubStatus(ctx, target, "pending", "KodeVibe scan in progress"); err != nil {
+		logger.Warnf("Failed to post pending status: %v", err)
+	}
+
+	result, err := s.scanGitHubCheckout(ctx, target)
+	if err != nil {
+		logger.Errorf("GitHub webhook scan failed: %v", err)
+		if err := s.postGitHubStatus(ctx, target, "error", "KodeVibe scan failed"); err != nil {
+			logger.Warnf("Failed to post error status: %v", err)
+		}
+		return
+	}
+
+	state := "success"
+	if result.Summary.CriticalIssues > 0 || result.Summary.ErrorIssues > 0 {
+		state = "failure"
+	}
+	description := fmt.Sprintf("%d issues, score %.1f (%s)",
+		result.Summary.TotalIssues, result.Summary.Score, result.Summary.Grade)
+
+	if err := s.postGitHubStatus(ctx, target, state, description); err != nil {
+		logger.Errorf("Failed to post commit status: %v", err)
+		return
+	}
+
+	logger.Infof("GitHub webhook scan completed: %s", description)
+	s.broadcastScanResult(result)
+}
+
+// scanGitHubCheckout checks out the target commit into a temporary directory and scans it
+func (s *Server) scanGitHubCheckout(ctx context.Context, target githubScanTarget) (*models.ScanResult, error) {
+	workDir, err := os.MkdirTemp("", "kodevibe-webhook-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := s.checkoutGitHubCommit(ctx, workDir, target); err != nil {
+		return nil, err
+	}
+
+	// Only the working tree is scanned
+	if err := os.RemoveAll(filepath.Join(workDir, ".git")); err != nil {
+		return nil, fmt.Errorf("failed to remove git metadata: %w", err)
+	}
+
+	request := &models.ScanRequest{
+		ID:        target.SHA,
+		Paths:     []string{workDir},
+		Config:    s.config,
+		CreatedAt: time.Now(),
+	}
+
+	result, err := s.scanner.Scan(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s@%s: %w", target.Repo, target.SHA, err)
+	}
+
+	return result, nil
+}
+
+// checkoutGitHubCommit fetches a single commit without writing the token to disk
+func (s *Server) checkoutGitHubCommit(ctx context.Context, dir string, target githubScanTarget) error {
+	var authArgs []string
+	if token := s.config.Integrations.GitHub.Token; token != "" {
+		basic := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+		authArgs = []string{"-c", "http.extraheader=AUTHORIZATION: basic " + basic}
+	}
+
+	steps := []struct {
+		name string
+		args []string
+	}{
+		{"init", []string{"init", "--quiet"}},
+		{"fetch", append(authArgs, "fetch", "--quiet", "--depth", "1", "--", target.CloneURL, target.SHA)},
+		{"checkout", []string{"checkout", "--quiet", "FETCH_HEAD"}},
+	}
+
+	for _, step := range steps {
+		cmd := exec.CommandContext(ctx, "git", step.args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s failed: %w: %s", step.name, err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
+
+// postGitHubStatus publishes a commit status for the scanned SHA
+func (s *Server) postGitHubStatus(ctx context.Context, target githubScanTarget, state, description string) error {
+	token := s.config.Integrations.GitHub.Token
+	if token == "" {
+		return fmt.Errorf("GitHub token is not configured")
+	}
+
+	apiURL := s.config.Integrations.GitHub.APIURL
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"state":       state,
+		"description": description,
+		"context":     githubStatusContext,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/statuses/%s", strings.TrimRight(apiURL, "/"), target.Repo, target.SHA)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create status request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GitHub API returned %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// RequestIDHeader carries the correlation ID of a request and its response
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDContextKey = "request_id"
+)
+
+// validRequestID limits inbound IDs to safe characters so they can't inject into logs or headers
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// requestIDMiddleware gives every request a correlation ID, reusing a valid
+// inbound X-Request-ID, and echoes it on the response
+func (s *Server) requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(requestIDContextKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// requestID returns the correlation ID assigned to the request
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
+
+// errorJSON writes an error response that carries the request's correlation ID
+func errorJSON(c *gin.Context, status int, message string) {
+	c.JSON(status, gin.H{
+		"error":      message,
+		"request_id": requestID(c),
+	})
+}
@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +18,7 @@ import (
 	"kodevibe/internal/models"
 	"kodevibe/pkg/report"
 	"kodevibe/pkg/scanner"
+	"kodevibe/pkg/scoring"
 )
 
 // Server represents the KodeVibe HTTP server
@@ -23,8 +27,50 @@ type Server struct {
 	logger   *logrus.Logger
 	scanner  *scanner.Scanner
 	reporter *report.Reporter
+	store    *report.Store
 	upgrader websocket.Upgrader
-	clients  map[string]*websocket.Conn
+
+	// mu guards httpServer, clients and closing
+	mu         sync.Mutex
+	httpServer *http.Server
+	clients    map[string]*wsClient
+	closing    bool
+
+	// scans tracks the scans createScan started, which Shutdown waits for;
+	// scanCtx is cancelled when Shutdown gives up on them
+	scans       sync.WaitGroup
+	scanCtx     context.Context
+	cancelScans context.CancelFunc
+	// webhookScans holds a slot for each running GitHub webhook scan
+	webhookScans chan struct{}
+}
+
+// DefaultShutdownTimeout is how long Run waits for in-flight requests and
+// scans when server.shutdown_timeout is unset
+const DefaultShutdownTimeout = 30 * time.Second
+
+// shutdownCloseTimeout bounds sending the close frame to a WebSocket client
+const shutdownCloseTimeout = time.Second
+
+// wsWriteTimeout bounds sending one message to a WebSocket client, so a
+// client that stops reading cannot stall broadcasts
+const wsWriteTimeout = 10 * time.Second
+
+// wsClient is a connected WebSocket client. The connection allows one writer
+// at a time, so writes are serialized by writeMu rather than by Server.mu.
+type wsClient struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// write sends message to the client, giving up after wsWriteTimeout
+func (c *wsClient) write(message interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+	return c.conn.WriteJSON(message)
 }
 
 // NewServer creates a new HTTP server instance
@@ -32,21 +78,33 @@ func NewServer(config *models.Configuration, logger *logrus.Logger) *Server {
 	scannerInstance, _ := scanner.NewScanner(config, logger)
 	reporter := report.NewReporter(config)
 
+	// Reports are only stored when a store directory is configured
+	var store *report.Store
+	if storeConfig := config.Reporting.Store; storeConfig.Dir != "" {
+		store = report.NewStore(storeConfig.Dir, storeConfig.MaxAge, storeConfig.MaxEntries)
+	}
+
+	scanCtx, cancelScans := context.WithCancel(context.Background())
 	return &Server{
 		config:   config,
 		logger:   logger,
 		scanner:  scannerInstance,
 		reporter: reporter,
+		store:    store,
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true // Allow all origins in development
 			},
 		},
-		clients: make(map[string]*websocket.Conn),
+		clients:      make(map[string]*wsClient),
+		scanCtx:      scanCtx,
+		cancelScans:  cancelScans,
+		webhookScans: make(chan struct{}, maxGitHubWebhookScans),
 	}
 }
 
-// Start starts the HTTP server
+// Start starts the HTTP server and blocks until it fails or Shutdown stops
+// it, in which case it returns nil
 func (s *Server) Start(host string, port int, tlsEnabled bool, certFile, keyFile string) error {
 	// Set Gin mode
 	if s.logger.Level == logrus.DebugLevel {
@@ -56,6 +114,7 @@ func (s *Server) Start(host string, port int, tlsEnabled bool, certFile, keyFile
 	}
 
 	router := gin.New()
+	router.Use(s.requestIDMiddleware())
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(s.corsMiddleware())
@@ -72,14 +131,126 @@ func (s *Server) Start(host string, port int, tlsEnabled bool, certFile, keyFile
 		Handler: router,
 	}
 
+	if tlsEnabled && (certFile == "" || keyFile == "") {
+		return fmt.Errorf("TLS certificate and key files are required for TLS mode")
+	}
+
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return nil
+	}
+	s.httpServer = server
+	s.mu.Unlock()
+
+	var err error
 	if tlsEnabled {
-		if certFile == "" || keyFile == "" {
-			return fmt.Errorf("TLS certificate and key files are required for TLS mode")
+		err = server.ListenAndServeTLS(certFile, keyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Run starts the server and, once ctx ends, shuts it down gracefully,
+// waiting at most timeout (DefaultShutdownTimeout if zero)
+func (s *Server) Run(ctx context.Context, host string, port int, tlsEnabled bool, certFile, keyFile string, timeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Start(host, port, tlsEnabled, certFile, keyFile)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	s.logger.Infof("Shutting down, waiting up to %s for in-flight requests and scans", timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; err != nil {
+		return err
+	}
+	s.logger.Info("Server stopped")
+	return nil
+}
+
+// Shutdown stops the server gracefully: it stops accepting connections,
+// lets in-flight requests and the scans they started finish, then closes
+// WebSocket clients, which receive the results of those scans first. When
+// ctx ends before that, the remaining scans are cancelled and ctx's error is
+// returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closing = true
+	server := s.httpServer
+	s.mu.Unlock()
+	defer s.cancelScans()
+
+	var err error
+	if server != nil {
+		if shutdownErr := server.Shutdown(ctx); shutdownErr != nil {
+			err = fmt.Errorf("failed to drain HTTP requests: %w", shutdownErr)
 		}
-		return server.ListenAndServeTLS(certFile, keyFile)
 	}
 
-	return server.ListenAndServe()
+	done := make(chan struct{})
+	go func() {
+		s.scans.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Warn("Shutdown timed out, cancelling running scans")
+		s.cancelScans()
+		if err == nil {
+			err = fmt.Errorf("scans still running at shutdown: %w", ctx.Err())
+		}
+	}
+
+	s.closeClients()
+	return err
+}
+
+// trackScan registers a background scan for Shutdown to wait for; the scan
+// must call s.scans.Done when it ends. It reports false, registering
+// nothing, once the server is shutting down.
+func (s *Server) trackScan() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing {
+		return false
+	}
+	s.scans.Add(1)
+	return true
+}
+
+// closeClients sends every WebSocket client a going-away close frame and
+// closes its connection
+func (s *Server) closeClients() {
+	s.mu.Lock()
+	clients := s.clients
+	s.clients = make(map[string]*wsClient)
+	s.mu.Unlock()
+
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	for clientID, client := range clients {
+		if err := client.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(shutdownCloseTimeout)); err != nil {
+			s.logger.Debugf("Failed to send close frame to client %s: %v", clientID, err)
+		}
+		client.conn.Close()
+	}
 }
 
 // setupRoutes configures all API routes
@@ -98,6 +269,7 @@ func (s *Server) setupRoutes(router *gin.Engine) {
 		v1.GET("/scan/:id", s.getScan)
 		v1.GET("/scans", s.listScans)
 		v1.DELETE("/scan/:id", s.deleteScan)
+		v1.POST("/score", s.rescore)
 
 		// Configuration endpoints
 		v1.GET("/config", s.getConfig)
@@ -131,6 +303,12 @@ func (s *Server) setupRoutes(router *gin.Engine) {
 		v1.POST("/integrations/github/webhook", s.githubWebhook)
 	}
 
+	// GitHub webhook endpoint
+	router.POST("/webhook/github", s.handleGitHubWebhook)
+
+	// Shareable links to stored reports
+	router.GET("/report/:id", s.getReport)
+
 	// WebSocket endpoint
 	router.GET("/ws", s.handleWebSocket)
 
@@ -174,11 +352,12 @@ func (s *Server) loggingMiddleware() gin.HandlerFunc {
 		}
 
 		s.logger.WithFields(logrus.Fields{
-			"status":    statusCode,
-			"latency":   latency,
-			"client_ip": clientIP,
-			"method":    method,
-			"path":      path,
+			"request_id": requestID(c),
+			"status":     statusCode,
+			"latency":    latency,
+			"client_ip":  clientIP,
+			"method":     method,
+			"path":       path,
 		}).Info("HTTP request")
 	}
 }
@@ -196,7 +375,7 @@ func (s *Server) healthCheck(c *gin.Context) {
 func (s *Server) createScan(c *gin.Context) {
 	var request models.ScanRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorJSON(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -206,22 +385,38 @@ func (s *Server) createScan(c *gin.Context) {
 	}
 	request.CreatedAt = time.Now()
 
-	// Run scan asynchronously
+	if !s.trackScan() {
+		errorJSON(c, http.StatusServiceUnavailable, "server is shutting down")
+		return
+	}
+
+	// Run scan asynchronously, tagged with the request ID so its logs and result can be traced
+	reqID := requestID(c)
 	go func() {
-		ctx := context.Background()
+		defer s.scans.Done()
+		ctx := scanner.WithRequestID(s.scanCtx, reqID)
 		result, err := s.scanner.Scan(ctx, &request)
 		if err != nil {
-			s.logger.Errorf("Scan failed: %v", err)
+			s.logger.WithFields(logrus.Fields{
+				"scan_id":    request.ID,
+				"request_id": reqID,
+			}).Errorf("Scan failed: %v", err)
 			return
 		}
 
+		// Keep immutable snapshots that /report/{hash} can serve later
+		if s.store != nil && s.config.Reporting.GenerateReports {
+			s.storeReports(result)
+		}
+
 		// Broadcast result to WebSocket clients
 		s.broadcastScanResult(result)
 	}()
 
 	c.JSON(http.StatusAccepted, gin.H{
-		"scan_id": request.ID,
-		"status":  "started",
+		"scan_id":    request.ID,
+		"request_id": reqID,
+		"status":     "started",
 	})
 }
 
@@ -230,8 +425,9 @@ func (s *Server) getScan(c *gin.Context) {
 
 	// TODO: Implement scan storage and retrieval
 	c.JSON(http.StatusNotImplemented, gin.H{
-		"error":   "Scan storage not implemented yet",
-		"scan_id": scanID,
+		"error":      "Scan storage not implemented yet",
+		"request_id": requestID(c),
+		"scan_id":    scanID,
 	})
 }
 
@@ -253,6 +449,40 @@ func (s *Server) deleteScan(c *gin.Context) {
 	})
 }
 
+// rescoreRequest is an existing scan result and the scoring settings to apply to it
+type rescoreRequest struct {
+	Result  *models.ScanResult    `json:"result" binding:"required"`
+	Scoring scoring.ScoringConfig `json:"scoring"`
+}
+
+// rescore recomputes the score and grade of a posted scan result without re-scanning
+func (s *Server) rescore(c *gin.Context) {
+	var req rescoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(req.Scoring.Grades) == 0 && s.config != nil {
+		req.Scoring.Grades = s.config.Reporting.GradeThresholds
+	}
+
+	metrics, err := scoring.Rescore(req.Result, req.Scoring)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scan_id":        req.Result.ScanID,
+		"score":          metrics.FinalScore,
+		"grade":          metrics.Grade,
+		"previous_score": req.Result.Summary.Score,
+		"previous_grade": req.Result.Summary.Grade,
+		"metrics":        metrics,
+	})
+}
+
 func (s *Server) getConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, s.config)
 }
@@ -260,7 +490,7 @@ func (s *Server) getConfig(c *gin.Context) {
 func (s *Server) updateConfig(c *gin.Context) {
 	var newConfig models.Configuration
 	if err := c.ShouldBindJSON(&newConfig); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorJSON(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -275,7 +505,7 @@ func (s *Server) updateConfig(c *gin.Context) {
 func (s *Server) validateConfig(c *gin.Context) {
 	var configToValidate models.Configuration
 	if err := c.ShouldBindJSON(&configToValidate); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorJSON(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -287,33 +517,70 @@ func (s *Server) validateConfig(c *gin.Context) {
 }
 
 func (s *Server) listReports(c *gin.Context) {
-	// TODO: Implement report listing
+	if s.store == nil {
+		errorJSON(c, http.StatusServiceUnavailable, "report store is not configured")
+		return
+	}
+
+	reports, err := s.store.List()
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"reports": []gin.H{},
-		"total":   0,
+		"reports": reports,
+		"total":   len(reports),
 	})
 }
 
+// getReport serves a stored report by reproducibility hash, as html unless ?format= says otherwise
 func (s *Server) getReport(c *gin.Context) {
-	reportID := c.Param("id")
-
-	// TODO: Implement report retrieval
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error":     "Report storage not implemented yet",
-		"report_id": reportID,
-	})
+	s.serveStoredReport(c, false)
 }
 
 func (s *Server) downloadReport(c *gin.Context) {
-	reportID := c.Param("id")
-	format := c.DefaultQuery("format", "html")
+	s.serveStoredReport(c, true)
+}
 
-	// TODO: Implement report download
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error":     "Report download not implemented yet",
-		"report_id": reportID,
-		"format":    format,
-	})
+func (s *Server) serveStoredReport(c *gin.Context, attachment bool) {
+	if s.store == nil {
+		errorJSON(c, http.StatusServiceUnavailable, "report store is not configured")
+		return
+	}
+
+	hash := c.Param("id")
+	format := strings.ToLower(c.DefaultQuery("format", "html"))
+
+	content, err := s.store.Get(hash, format)
+	if errors.Is(err, report.ErrReportNotFound) {
+		errorJSON(c, http.StatusNotFound, fmt.Sprintf("no %s report stored for %s", format, hash))
+		return
+	}
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Stored reports never change, so clients may cache them indefinitely
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	if attachment {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="kodevibe-%s.%s"`, hash[:12], format))
+	}
+	c.Data(http.StatusOK, report.ContentType(format), content)
+}
+
+// storeReports saves the json, html and csv reports of a finished scan
+func (s *Server) storeReports(result *models.ScanResult) {
+	for _, format := range []string{"json", "html", "csv"} {
+		output, err := s.reporter.Generate(result, format)
+		if err == nil {
+			_, err = s.store.Save(result, format, []byte(output))
+		}
+		if err != nil {
+			s.logger.WithField("scan_id", result.ID).Warnf("Failed to store %s report: %v", format, err)
+		}
+	}
 }
 
 func (s *Server) listVibes(c *gin.Context) {
@@ -354,7 +621,7 @@ func (s *Server) autoFix(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorJSON(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -372,8 +639,9 @@ func (s *Server) getFixResult(c *gin.Context) {
 
 	// TODO: Implement fix result retrieval
 	c.JSON(http.StatusNotImplemented, gin.H{
-		"error":  "Fix result storage not implemented yet",
-		"fix_id": fixID,
+		"error":      "Fix result storage not implemented yet",
+		"request_id": requestID(c),
+		"fix_id":     fixID,
 	})
 }
 
@@ -385,7 +653,7 @@ func (s *Server) startWatch(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorJSON(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -418,7 +686,7 @@ func (s *Server) startProfile(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorJSON(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -438,6 +706,7 @@ func (s *Server) getProfileResult(c *gin.Context) {
 	// TODO: Implement profile result retrieval
 	c.JSON(http.StatusNotImplemented, gin.H{
 		"error":      "Profile result storage not implemented yet",
+		"request_id": requestID(c),
 		"profile_id": profileID,
 	})
 }
@@ -450,10 +719,7 @@ func (s *Server) slackWebhook(c *gin.Context) {
 }
 
 func (s *Server) githubWebhook(c *gin.Context) {
-	// TODO: Implement GitHub webhook
-	c.JSON(http.StatusOK, gin.H{
-		"message": "GitHub webhook received",
-	})
+	s.handleGitHubWebhook(c)
 }
 
 // WebSocket handling
@@ -467,7 +733,10 @@ func (s *Server) handleWebSocket(c *gin.Context) {
 	defer conn.Close()
 
 	clientID := uuid.New().String()
-	s.clients[clientID] = conn
+	client := &wsClient{conn: conn}
+	s.mu.Lock()
+	s.clients[clientID] = client
+	s.mu.Unlock()
 
 	s.logger.Infof("WebSocket client connected: %s", clientID)
 
@@ -477,7 +746,7 @@ func (s *Server) handleWebSocket(c *gin.Context) {
 		"client_id": clientID,
 		"message":   "Connected to KodeVibe",
 	}
-	if err := conn.WriteJSON(welcome); err != nil {
+	if err := client.write(welcome); err != nil {
 		s.logger.Errorf("Failed to send welcome message: %v", err)
 		return
 	}
@@ -488,12 +757,12 @@ func (s *Server) handleWebSocket(c *gin.Context) {
 		err := conn.ReadJSON(&msg)
 		if err != nil {
 			s.logger.Infof("WebSocket client disconnected: %s", clientID)
-			delete(s.clients, clientID)
+			s.removeClient(clientID)
 			break
 		}
 
 		// Echo message back (for now)
-		if err := conn.WriteJSON(gin.H{
+		if err := client.write(gin.H{
 			"type": "echo",
 			"data": msg,
 		}); err != nil {
@@ -503,17 +772,35 @@ func (s *Server) handleWebSocket(c *gin.Context) {
 	}
 }
 
+// removeClient forgets a WebSocket client; its handler closes the connection
+func (s *Server) removeClient(clientID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, clientID)
+}
+
+// broadcastScanResult sends a finished scan to every WebSocket client. It
+// writes outside s.mu so a slow client only delays the broadcast itself.
 func (s *Server) broadcastScanResult(result *models.ScanResult) {
 	message := gin.H{
 		"type": "scan_complete",
 		"data": result,
 	}
 
-	for clientID, conn := range s.clients {
-		err := conn.WriteJSON(message)
-		if err != nil {
+	s.mu.Lock()
+	clients := make(map[string]*wsClient, len(s.clients))
+	for clientID, client := range s.clients {
+		clients[clientID] = client
+	}
+	s.mu.Unlock()
+
+	for clientID, client := range clients {
+		if err := client.write(message); err != nil {
 			s.logger.Errorf("Failed to send message to client %s: %v", clientID, err)
-			delete(s.clients, clientID)
+			// A failed write leaves the connection unusable; closing it also
+			// ends the client's read loop
+			s.removeClient(clientID)
+			client.conn.Close()
 		}
 	}
 }
@@ -2,18 +2,26 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"kodevibe/internal/models"
+	"kodevibe/pkg/report"
 )
 
 func TestNewServer(t *testing.T) {
@@ -130,6 +138,53 @@ func TestServer_createScan_InvalidJSON(t *testing.T) {
 	assert.Contains(t, response["error"], "invalid")
 }
 
+func TestServer_requestIDMiddleware(t *testing.T) {
+	server := setupTestServer()
+	var logs bytes.Buffer
+	server.logger.SetOutput(&logs)
+	server.logger.SetLevel(logrus.InfoLevel)
+	server.logger.SetFormatter(&logrus.JSONFormatter{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(server.requestIDMiddleware())
+	router.Use(server.loggingMiddleware())
+	router.POST("/api/v1/scan", server.createScan)
+
+	send := func(requestID string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req, err := http.NewRequest("POST", "/api/v1/scan", bytes.NewBufferString("invalid json"))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if requestID != "" {
+			req.Header.Set(RequestIDHeader, requestID)
+		}
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr, response
+	}
+
+	// An inbound ID is honoured, echoed and included in error bodies and logs
+	rr, response := send("trace-123")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "trace-123", rr.Header().Get(RequestIDHeader))
+	assert.Equal(t, "trace-123", response["request_id"])
+	assert.Contains(t, logs.String(), `"request_id":"trace-123"`)
+	assert.Contains(t, logs.String(), `"status":400`)
+
+	// Without one, or with an unsafe one, a fresh ID is generated
+	rr, response = send("")
+	generated := rr.Header().Get(RequestIDHeader)
+	assert.NotEmpty(t, generated)
+	assert.Equal(t, generated, response["request_id"])
+
+	rr, _ = send("bad id\nwith newline")
+	assert.NotContains(t, rr.Header().Get(RequestIDHeader), "bad id")
+}
+
 func TestServer_getScan(t *testing.T) {
 	server := setupTestServer()
 
@@ -176,6 +231,101 @@ func TestServer_listScans(t *testing.T) {
 	assert.Equal(t, float64(0), response["total"])
 }
 
+func TestServer_storedReports(t *testing.T) {
+	server := setupTestServer()
+	server.store = report.NewStore(t.TempDir(), 0, 0)
+
+	result := &models.ScanResult{
+		FilesScanned: 1,
+		Issues:       []models.Issue{{Type: models.VibeTypeCode, Rule: "no-console-log", File: "app.js", Line: 1, Message: "console.log"}},
+	}
+	server.storeReports(result)
+	hash := result.ComputeReproducibilityHash()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/report/:id", server.getReport)
+	router.GET("/api/v1/reports", server.listReports)
+	router.POST("/api/v1/report/:id/download", server.downloadReport)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve("GET", "/report/"+hash)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rr.Body.String(), "KodeVibe Scan Report")
+
+	rr = serve("POST", "/api/v1/report/"+hash+"/download?format=json")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "attachment")
+	var stored models.ScanResult
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stored))
+	assert.Len(t, stored.Issues, 1)
+
+	rr = serve("GET", "/api/v1/reports")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var listing map[string]interface{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &listing))
+	assert.Equal(t, float64(1), listing["total"])
+
+	assert.Equal(t, http.StatusNotFound, serve("GET", "/report/"+strings.Repeat("0", 64)).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("GET", "/report/not-a-hash").Code)
+
+	// Without a configured store the endpoints say so
+	server.store = nil
+	assert.Equal(t, http.StatusServiceUnavailable, serve("GET", "/report/"+hash).Code)
+}
+
+func TestServer_rescore(t *testing.T) {
+	server := setupTestServer()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/score", server.rescore)
+
+	body := `{
+		"result": {
+			"scan_id": "scan-1",
+			"summary": {"score": 70, "grade": "C"},
+			"issues": [
+				{"type": "security", "category": "security", "severity": "error", "file": "a.go"},
+				{"type": "code", "category": "readability", "severity": "warning", "file": "b.go"}
+			]
+		},
+		"scoring": {"weights": {"security": 2}}
+	}`
+
+	req, err := http.NewRequest("POST", "/api/v1/score", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	assert.Equal(t, "scan-1", response["scan_id"])
+	assert.Equal(t, 70.0, response["previous_score"])
+	assert.NotEmpty(t, response["grade"])
+	assert.Contains(t, response["metrics"], "breakdown")
+
+	req, err = http.NewRequest("POST", "/api/v1/score", bytes.NewBufferString(`{"scoring": {}}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
 func TestServer_getConfig(t *testing.T) {
 	server := setupTestServer()
 
@@ -467,6 +617,128 @@ func TestServer_broadcastScanResult(t *testing.T) {
 	})
 }
 
+func TestServer_broadcastScanResult_BusyClient(t *testing.T) {
+	server := setupTestServer()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", server.handleWebSocket)
+	httpServer := httptest.NewServer(router)
+	defer httpServer.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	var message map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&message))
+
+	server.mu.Lock()
+	require.Len(t, server.clients, 1)
+	var client *wsClient
+	for _, c := range server.clients {
+		client = c
+	}
+	server.mu.Unlock()
+
+	// Hold the client's writer so the broadcast blocks on it
+	client.writeMu.Lock()
+	done := make(chan struct{})
+	go func() {
+		server.broadcastScanResult(&models.ScanResult{ScanID: "test-scan"})
+		close(done)
+	}()
+
+	// The blocked write must not hold up the rest of the server
+	tracked := make(chan bool, 1)
+	go func() { tracked <- server.trackScan() }()
+	select {
+	case ok := <-tracked:
+		assert.True(t, ok)
+		server.scans.Done()
+	case <-time.After(2 * time.Second):
+		t.Fatal("trackScan blocked behind a WebSocket write")
+	}
+
+	client.writeMu.Unlock()
+	<-done
+	require.NoError(t, conn.ReadJSON(&message))
+	assert.Equal(t, "scan_complete", message["type"])
+}
+
+func TestServer_ShutdownWaitsForScans(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "app.js"), []byte("console.log(\"hi\");\n"), 0644))
+
+	server := setupTestServer()
+	// An external scanner that sleeps keeps the scan running past the shutdown call
+	server.config.Advanced.ExternalScanners = []models.ExternalScanner{
+		{Name: "slow", Enabled: true, Command: "sh", Args: []string{"-c", "sleep 1"}, Format: "ndjson"},
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- server.Start("127.0.0.1", port, false, "", "")
+	}()
+
+	var conn *websocket.Conn
+	require.Eventually(t, func() bool {
+		conn, _, err = websocket.DefaultDialer.Dial(fmt.Sprintf("ws://127.0.0.1:%d/ws", port), nil)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	defer conn.Close()
+
+	var message map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&message))
+	assert.Equal(t, "welcome", message["type"])
+
+	body := fmt.Sprintf(`{"paths":[%q],"vibes":["code"]}`, tempDir)
+	resp, err := http.Post(fmt.Sprintf("http://127.0.0.1:%d/api/v1/scan", port), "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	started := time.Now()
+	require.NoError(t, server.Shutdown(ctx))
+	assert.GreaterOrEqual(t, time.Since(started), 500*time.Millisecond, "Shutdown should wait for the running scan")
+	require.NoError(t, <-startErr)
+
+	// The scan's result reaches the client before the server closes the connection
+	require.NoError(t, conn.ReadJSON(&message))
+	assert.Equal(t, "scan_complete", message["type"])
+	err = conn.ReadJSON(&message)
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error: %v", err)
+
+	// No new scans start once shut down
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/scan", strings.NewReader(body))
+	router := gin.New()
+	router.POST("/api/v1/scan", server.createScan)
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
+
+func TestServer_ShutdownTimeout(t *testing.T) {
+	server := setupTestServer()
+	require.True(t, server.trackScan())
+	go func() {
+		<-server.scanCtx.Done()
+		server.scans.Done()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := server.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Error(t, server.scanCtx.Err(), "running scans should be cancelled")
+	assert.False(t, server.trackScan())
+}
+
 // Benchmark tests
 func BenchmarkServer_healthCheck(b *testing.B) {
 	server := setupTestServer()
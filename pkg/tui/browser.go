@@ -0,0 +1,375 @@
+// Package tui provides an interactive terminal browser for triaging scan findings.
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// Ways the issue list can be grouped
+const (
+	GroupByFile     = "file"
+	GroupBySeverity = "severity"
+)
+
+const (
+	defaultPageSize = 20
+	contextLines    = 3
+	clearScreen     = "\033[H\033[2J"
+)
+
+// SuppressDecision is an issue the user chose to suppress
+type SuppressDecision struct {
+	Issue  models.Issue
+	Reason string
+}
+
+// Decisions are what the user marked while browsing
+type Decisions struct {
+	Suppress []SuppressDecision
+	Fix      []models.Issue
+}
+
+// Browser is a line-oriented terminal UI for browsing findings grouped by
+// file or severity, reading an issue's code context, and marking issues to
+// suppress or auto-fix. Commands are read a line at a time, so it works in
+// any terminal without raw mode.
+type Browser struct {
+	// PageSize is the number of issues listed per page
+	PageSize int
+	// ClearScreen redraws each view on a cleared terminal
+	ClearScreen bool
+
+	issues   []models.Issue
+	fixable  func(models.Issue) bool
+	groupBy  string
+	severity models.SeverityLevel
+	view     []int
+	page     int
+
+	suppress map[int]string
+	fix      map[int]bool
+	sources  map[string][]string
+	out      io.Writer
+}
+
+// NewBrowser creates a browser over issues. fixable reports whether an issue
+// can be auto-fixed; nil means none can.
+func NewBrowser(issues []models.Issue, fixable func(models.Issue) bool) *Browser {
+	if fixable == nil {
+		fixable = func(models.Issue) bool { return false }
+	}
+	b := &Browser{
+		PageSize: defaultPageSize,
+		issues:   issues,
+		fixable:  fixable,
+		groupBy:  GroupByFile,
+		suppress: make(map[int]string),
+		fix:      make(map[int]bool),
+		sources:  make(map[string][]string),
+	}
+	b.refresh()
+	return b
+}
+
+// Run browses until the user quits. Quitting with q returns the marked
+// decisions; Q discards them and returns nil.
+func (b *Browser) Run(in io.Reader, out io.Writer) (*Decisions, error) {
+	b.out = out
+	input := bufio.NewScanner(in)
+
+	current := -1 // position in view of the open issue; -1 is the list
+	for {
+		if current < 0 {
+			b.renderList()
+		} else {
+			b.renderIssue(current)
+		}
+		fmt.Fprint(out, "> ")
+
+		if !input.Scan() {
+			if err := input.Err(); err != nil {
+				return nil, fmt.Errorf("failed to read input: %w", err)
+			}
+			return b.decisions(), nil
+		}
+		command, arg, _ := strings.Cut(strings.TrimSpace(input.Text()), " ")
+		arg = strings.TrimSpace(arg)
+
+		switch command {
+		case "q":
+			return b.decisions(), nil
+		case "Q":
+			return nil, nil
+		case "h", "?":
+			b.renderHelp()
+			continue
+		}
+
+		if current < 0 {
+			current = b.listCommand(command, arg)
+		} else {
+			current = b.issueCommand(current, command, arg)
+		}
+	}
+}
+
+// listCommand handles a command in the list view and returns the issue to open, or -1
+func (b *Browser) listCommand(command, arg string) int {
+	switch command {
+	case "n":
+		if (b.page+1)*b.PageSize < len(b.view) {
+			b.page++
+		}
+	case "p":
+		if b.page > 0 {
+			b.page--
+		}
+	case "g":
+		if b.groupBy == GroupByFile {
+			b.groupBy = GroupBySeverity
+		} else {
+			b.groupBy = GroupByFile
+		}
+		b.refresh()
+	case "f":
+		switch strings.ToLower(arg) {
+		case "", "all":
+			b.severity = ""
+		case string(models.SeverityError), string(models.SeverityWarning), string(models.SeverityInfo):
+			b.severity = models.SeverityLevel(strings.ToLower(arg))
+		}
+		b.refresh()
+	default:
+		if number, err := strconv.Atoi(command); err == nil && number >= 1 && number <= len(b.view) {
+			return number - 1
+		}
+	}
+	return -1
+}
+
+// issueCommand handles a command while an issue is open and returns the issue to show next, or -1 for the list
+func (b *Browser) issueCommand(current int, command, arg string) int {
+	index := b.view[current]
+	switch command {
+	case "b":
+		b.page = current / b.PageSize
+		return -1
+	case "n":
+		if current+1 < len(b.view) {
+			return current + 1
+		}
+	case "p":
+		if current > 0 {
+			return current - 1
+		}
+	case "s":
+		if _, marked := b.suppress[index]; marked {
+			delete(b.suppress, index)
+		} else {
+			b.suppress[index] = arg
+		}
+	case "a":
+		if b.fixable(b.issues[index]) {
+			b.fix[index] = !b.fix[index]
+		}
+	}
+	return current
+}
+
+// refresh rebuilds the filtered, sorted view and returns to its first page
+func (b *Browser) refresh() {
+	b.view = b.view[:0]
+	for i, issue := range b.issues {
+		if b.severity == "" || issue.Severity == b.severity {
+			b.view = append(b.view, i)
+		}
+	}
+
+	sort.SliceStable(b.view, func(i, j int) bool {
+		a, c := b.issues[b.view[i]], b.issues[b.view[j]]
+		if b.groupBy == GroupBySeverity && severityRank(a.Severity) != severityRank(c.Severity) {
+			return severityRank(a.Severity) < severityRank(c.Severity)
+		}
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		return a.Line < c.Line
+	})
+	b.page = 0
+}
+
+func (b *Browser) renderList() {
+	b.startScreen()
+
+	filter := "all severities"
+	if b.severity != "" {
+		filter = string(b.severity)
+	}
+	fmt.Fprintf(b.out, "🌊 KodeVibe triage: %d issues (%s, grouped by %s)\n\n", len(b.view), filter, b.groupBy)
+	if len(b.view) == 0 {
+		fmt.Fprintln(b.out, "No issues to show.")
+	}
+
+	start := b.page * b.PageSize
+	end := start + b.PageSize
+	if end > len(b.view) {
+		end = len(b.view)
+	}
+
+	group := ""
+	for position := start; position < end; position++ {
+		issue := b.issues[b.view[position]]
+		if key := b.groupKey(issue); key != group {
+			group = key
+			fmt.Fprintf(b.out, "%s (%d)\n", group, b.groupSize(key))
+		}
+
+		location := fmt.Sprintf("%d", issue.Line)
+		if b.groupBy == GroupBySeverity {
+			location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+		}
+		fmt.Fprintf(b.out, "  %3d %s %-7s %s %s: %s\n",
+			position+1, b.marks(b.view[position]), issue.Severity, location, issue.Rule, utils.TruncateString(issue.Message, 80))
+	}
+
+	if pages := (len(b.view) + b.PageSize - 1) / b.PageSize; pages > 1 {
+		fmt.Fprintf(b.out, "\nPage %d of %d\n", b.page+1, pages)
+	}
+	fmt.Fprintf(b.out, "\n%d marked to suppress, %d marked to fix. <number> open, n/p page, g group, f <severity> filter, h help, q done\n",
+		len(b.suppress), len(b.fix))
+}
+
+func (b *Browser) renderIssue(position int) {
+	b.startScreen()
+
+	index := b.view[position]
+	issue := b.issues[index]
+	fmt.Fprintf(b.out, "Issue %d of %d %s\n\n", position+1, len(b.view), b.marks(index))
+	fmt.Fprintf(b.out, "%s [%s] %s\n", strings.ToUpper(string(issue.Severity)), issue.Rule, issue.Title)
+	fmt.Fprintf(b.out, "%s:%d\n", issue.File, issue.Line)
+	fmt.Fprintf(b.out, "%s\n", issue.Message)
+	if issue.FixSuggestion != "" {
+		fmt.Fprintf(b.out, "💡 %s\n", issue.FixSuggestion)
+	}
+	fmt.Fprintln(b.out)
+
+	if lines := b.source(issue.File); issue.Line > 0 && issue.Line <= len(lines) {
+		first, last := issue.Line-contextLines, issue.Line+contextLines
+		if first < 1 {
+			first = 1
+		}
+		if last > len(lines) {
+			last = len(lines)
+		}
+		for number := first; number <= last; number++ {
+			marker := " "
+			if number == issue.Line {
+				marker = ">"
+			}
+			fmt.Fprintf(b.out, "%s %5d | %s\n", marker, number, lines[number-1])
+		}
+	} else if issue.Context != "" {
+		fmt.Fprintf(b.out, "  %s\n", issue.Context)
+	}
+
+	fixHint := ""
+	if b.fixable(issue) {
+		fixHint = ", a toggle auto-fix"
+	}
+	fmt.Fprintf(b.out, "\ns [reason] toggle suppress%s, n/p next/previous, b back, q done\n", fixHint)
+}
+
+func (b *Browser) renderHelp() {
+	fmt.Fprintln(b.out, `List:   <number> open an issue, n/p next/previous page, g group by file/severity,
+        f <error|warning|info|all> filter by severity
+Issue:  s [reason] mark/unmark for suppression, a mark/unmark for auto-fix,
+        n/p next/previous issue, b back to the list
+Always: h help, q finish and apply marks, Q quit without applying`)
+}
+
+func (b *Browser) startScreen() {
+	if b.ClearScreen {
+		fmt.Fprint(b.out, clearScreen)
+	}
+}
+
+// marks shows whether an issue is marked to suppress (S) or fix (F)
+func (b *Browser) marks(index int) string {
+	marks := []byte("  ")
+	if _, marked := b.suppress[index]; marked {
+		marks[0] = 'S'
+	}
+	if b.fix[index] {
+		marks[1] = 'F'
+	}
+	return "[" + string(marks) + "]"
+}
+
+func (b *Browser) groupKey(issue models.Issue) string {
+	if b.groupBy == GroupBySeverity {
+		return strings.ToUpper(string(issue.Severity))
+	}
+	return issue.File
+}
+
+func (b *Browser) groupSize(key string) int {
+	size := 0
+	for _, index := range b.view {
+		if b.groupKey(b.issues[index]) == key {
+			size++
+		}
+	}
+	return size
+}
+
+// source returns a file's lines, reading each file once
+func (b *Browser) source(file string) []string {
+	if lines, ok := b.sources[file]; ok {
+		return lines
+	}
+	var lines []string
+	if content, err := os.ReadFile(file); err == nil {
+		lines = strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = utils.TrimLineEnding(line)
+		}
+	}
+	b.sources[file] = lines
+	return lines
+}
+
+// decisions collects the marked issues in view order
+func (b *Browser) decisions() *Decisions {
+	decisions := &Decisions{}
+	for i := range b.issues {
+		if reason, marked := b.suppress[i]; marked {
+			decisions.Suppress = append(decisions.Suppress, SuppressDecision{Issue: b.issues[i], Reason: reason})
+		}
+		if b.fix[i] {
+			decisions.Fix = append(decisions.Fix, b.issues[i])
+		}
+	}
+	return decisions
+}
+
+func severityRank(severity models.SeverityLevel) int {
+	switch severity {
+	case models.SeverityError:
+		return 0
+	case models.SeverityWarning:
+		return 1
+	case models.SeverityInfo:
+		return 2
+	default:
+		return 3
+	}
+}
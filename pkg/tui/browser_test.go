@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func testIssues() []models.Issue {
+	return []models.Issue{
+		{Rule: "long-line", File: "b.go", Line: 3, Severity: models.SeverityInfo, Message: "line too long"},
+		{Rule: "no-console-log", File: "a.js", Line: 2, Severity: models.SeverityWarning, Message: "console.log found"},
+		{Rule: "hardcoded-credentials", File: "a.js", Line: 1, Severity: models.SeverityError, Message: "password in code"},
+	}
+}
+
+func fixableConsoleLog(issue models.Issue) bool {
+	return issue.Rule == "no-console-log"
+}
+
+func TestBrowser_GroupsByFile(t *testing.T) {
+	var out strings.Builder
+	_, err := NewBrowser(testIssues(), nil).Run(strings.NewReader("q\n"), &out)
+	require.NoError(t, err)
+
+	listing := out.String()
+	assert.Contains(t, listing, "a.js (2)")
+	assert.Contains(t, listing, "b.go (1)")
+	assert.Less(t, strings.Index(listing, "hardcoded-credentials"), strings.Index(listing, "no-console-log"), "issues are ordered by line within a file")
+	assert.Less(t, strings.Index(listing, "a.js (2)"), strings.Index(listing, "b.go (1)"))
+}
+
+func TestBrowser_GroupsBySeverityAndFilters(t *testing.T) {
+	var out strings.Builder
+	_, err := NewBrowser(testIssues(), nil).Run(strings.NewReader("g\nf warning\nq\n"), &out)
+	require.NoError(t, err)
+
+	screens := strings.Split(out.String(), "🌊")
+	require.Len(t, screens, 4)
+	assert.Contains(t, screens[2], "ERROR (1)")
+	assert.Less(t, strings.Index(screens[2], "ERROR"), strings.Index(screens[2], "INFO"))
+	assert.Contains(t, screens[3], "1 issues (warning, grouped by severity)")
+	assert.NotContains(t, screens[3], "long-line")
+}
+
+func TestBrowser_ShowsCodeContext(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.js")
+	require.NoError(t, os.WriteFile(file, []byte("one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\n"), 0644))
+
+	issues := []models.Issue{{Rule: "no-console-log", File: file, Line: 5, Severity: models.SeverityWarning}}
+	var out strings.Builder
+	_, err := NewBrowser(issues, nil).Run(strings.NewReader("1\nq\n"), &out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), ">     5 | five")
+	assert.Contains(t, out.String(), "      2 | two")
+	assert.Contains(t, out.String(), "      8 | eight")
+	assert.NotContains(t, out.String(), "| one")
+	assert.NotContains(t, out.String(), "| nine")
+}
+
+func TestBrowser_MarksSuppressionsAndFixes(t *testing.T) {
+	// Open the first issue (the credentials), suppress it, move to the
+	// console.log and mark it for fixing, then try to fix the long line
+	script := "1\ns test fixture\nn\na\nn\na\nq\n"
+	var out strings.Builder
+	decisions, err := NewBrowser(testIssues(), fixableConsoleLog).Run(strings.NewReader(script), &out)
+	require.NoError(t, err)
+	require.NotNil(t, decisions)
+
+	require.Len(t, decisions.Suppress, 1)
+	assert.Equal(t, "hardcoded-credentials", decisions.Suppress[0].Issue.Rule)
+	assert.Equal(t, "test fixture", decisions.Suppress[0].Reason)
+	require.Len(t, decisions.Fix, 1, "only fixable issues can be marked for fixing")
+	assert.Equal(t, "no-console-log", decisions.Fix[0].Rule)
+}
+
+func TestBrowser_ToggleAndDiscard(t *testing.T) {
+	decisions, err := NewBrowser(testIssues(), nil).Run(strings.NewReader("1\ns\ns\nq\n"), &strings.Builder{})
+	require.NoError(t, err)
+	assert.Empty(t, decisions.Suppress, "suppressing twice unmarks the issue")
+
+	decisions, err = NewBrowser(testIssues(), nil).Run(strings.NewReader("1\ns\nQ\n"), &strings.Builder{})
+	require.NoError(t, err)
+	assert.Nil(t, decisions)
+}
+
+func TestBrowser_Pages(t *testing.T) {
+	browser := NewBrowser(testIssues(), nil)
+	browser.PageSize = 2
+
+	var out strings.Builder
+	_, err := browser.Run(strings.NewReader("n\nn\nq\n"), &out)
+	require.NoError(t, err)
+
+	screens := strings.Split(out.String(), "🌊")
+	require.Len(t, screens, 4)
+	assert.Contains(t, screens[1], "Page 1 of 2")
+	assert.NotContains(t, screens[1], "long-line")
+	assert.Contains(t, screens[2], "Page 2 of 2")
+	assert.Contains(t, screens[2], "long-line")
+	assert.Contains(t, screens[3], "Page 2 of 2", "paging stops at the last page")
+}
@@ -0,0 +1,130 @@
+package vibes
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sync"
+)
+
+// DefaultASTCacheBytes bounds the Go sources whose syntax trees are cached
+// when ast_cache_bytes is unset. A tree takes several times its source.
+const DefaultASTCacheBytes = 8 << 20
+
+// ASTCache keeps the parsed syntax trees of Go sources keyed by a hash of
+// their content, so re-checking an unchanged file (in watch mode, or on the
+// next scan by the same checker) skips parsing it. Once the cached sources
+// exceed the bound, the least recently used trees are dropped. It is safe
+// for concurrent use.
+type ASTCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	bytes    int
+	entries  map[string]*list.Element
+	// recent orders the entries from most to least recently used
+	recent *list.List
+	hits   int
+	misses int
+}
+
+// parsedGoFile is a cached parse of one source
+type parsedGoFile struct {
+	key  string
+	fset *token.FileSet
+	file *ast.File
+	err  error
+	size int
+}
+
+// ASTCacheStats describes an ASTCache's contents and how often it was useful
+type ASTCacheStats struct {
+	Entries int
+	Bytes   int
+	Hits    int
+	Misses  int
+}
+
+// NewASTCache creates a cache holding up to maxBytes of source; 0 or less
+// disables caching
+func NewASTCache(maxBytes int) *ASTCache {
+	return &ASTCache{
+		maxBytes: maxBytes,
+		entries:  make(map[string]*list.Element),
+		recent:   list.New(),
+	}
+}
+
+// Parse returns the syntax tree of a Go source and the file set its positions
+// belong to, parsing it only if the same content isn't cached. Trees are
+// shared between callers, who must not modify them; positions report the
+// filename the content was first parsed under, so use their line and column.
+func (c *ASTCache) Parse(filename string, src []byte) (*token.FileSet, *ast.File, error) {
+	sum := sha256.Sum256(src)
+	key := hex.EncodeToString(sum[:])
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.recent.MoveToFront(element)
+		c.hits++
+		parsed := element.Value.(*parsedGoFile)
+		c.mu.Unlock()
+		return parsed.fset, parsed.file, parsed.err
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Parse outside the lock; two goroutines racing on the same content
+	// both parse it and the first stored tree wins
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution|parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	c.store(&parsedGoFile{key: key, fset: fset, file: file, err: err, size: len(src)})
+	return fset, file, err
+}
+
+// Resize changes the bound, dropping the least recently used trees over it
+func (c *ASTCache) Resize(maxBytes int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxBytes = maxBytes
+	c.evict()
+}
+
+// Stats returns the cache's size and hit counts
+func (c *ASTCache) Stats() ASTCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ASTCacheStats{Entries: len(c.entries), Bytes: c.bytes, Hits: c.hits, Misses: c.misses}
+}
+
+// store adds a parse unless it is bigger than the whole cache
+func (c *ASTCache) store(parsed *parsedGoFile) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if parsed.size > c.maxBytes {
+		return
+	}
+	if _, exists := c.entries[parsed.key]; exists {
+		return
+	}
+	c.entries[parsed.key] = c.recent.PushFront(parsed)
+	c.bytes += parsed.size
+	c.evict()
+}
+
+// evict drops the least recently used trees until the cache fits its bound
+func (c *ASTCache) evict() {
+	for c.bytes > c.maxBytes && c.recent.Len() > 0 {
+		oldest := c.recent.Back()
+		parsed := oldest.Value.(*parsedGoFile)
+		c.recent.Remove(oldest)
+		delete(c.entries, parsed.key)
+		c.bytes -= parsed.size
+	}
+}
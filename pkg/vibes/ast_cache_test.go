@@ -0,0 +1,99 @@
+package vibes
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestASTCache_ReusesUnchangedSources(t *testing.T) {
+	cache := NewASTCache(DefaultASTCacheBytes)
+	src := []byte("package app\n\nfunc Run() error { return nil }\n")
+
+	_, first, err := cache.Parse("app.go", src)
+	require.NoError(t, err)
+	_, again, err := cache.Parse("app.go", src)
+	require.NoError(t, err)
+	assert.Same(t, first, again, "unchanged content is not parsed again")
+
+	_, edited, err := cache.Parse("app.go", append(src, []byte("\nfunc Stop() {}\n")...))
+	require.NoError(t, err)
+	assert.NotSame(t, first, edited, "changed content is parsed")
+	assert.Len(t, edited.Decls, 2)
+
+	_, _, err = cache.Parse("broken.go", []byte("package app\nfunc {"))
+	assert.Error(t, err)
+	_, _, err = cache.Parse("broken.go", []byte("package app\nfunc {"))
+	assert.Error(t, err, "parse errors are cached too")
+
+	stats := cache.Stats()
+	assert.Equal(t, 3, stats.Entries)
+	assert.Equal(t, 2, stats.Hits)
+	assert.Equal(t, 3, stats.Misses)
+}
+
+func TestASTCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	source := func(name string) []byte {
+		return []byte(fmt.Sprintf("package %s\n", name))
+	}
+	size := len(source("aa"))
+	cache := NewASTCache(2 * size)
+
+	_, a, _ := cache.Parse("a.go", source("aa"))
+	cache.Parse("b.go", source("bb"))
+	cache.Parse("a.go", source("aa")) // a is now the most recently used
+	cache.Parse("c.go", source("cc"))
+
+	stats := cache.Stats()
+	assert.Equal(t, 2, stats.Entries)
+	assert.LessOrEqual(t, stats.Bytes, 2*size)
+	_, cached, _ := cache.Parse("a.go", source("aa"))
+	assert.Same(t, a, cached, "the recently used tree is kept")
+	misses := cache.Stats().Misses
+	cache.Parse("b.go", source("bb"))
+	assert.Equal(t, misses+1, cache.Stats().Misses, "the least recently used tree was dropped")
+
+	cache.Resize(0)
+	assert.Equal(t, 0, cache.Stats().Entries, "a zero bound disables caching")
+	cache.Parse("a.go", source("aa"))
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestASTCache_Concurrent(t *testing.T) {
+	cache := NewASTCache(1 << 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				src := fmt.Sprintf("package p\n\nvar v%d = %d\n", (i+j)%20, j%3)
+				_, file, err := cache.Parse("p.go", []byte(src))
+				assert.NoError(t, err)
+				assert.Equal(t, "p", file.Name.Name)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, cache.Stats().Bytes, 1<<10)
+}
+
+func TestCodeChecker_CachesGoSyntaxTrees(t *testing.T) {
+	checker := NewCodeChecker()
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"ast_cache_bytes": 1 << 20}}))
+	lines := strings.Split("package app\n\nimport \"os\"\n\nfunc clean() {\n\tos.Remove(\"tmp\")\n}\n", "\n")
+
+	first := checker.checkUncheckedErrors("app.go", lines)
+	second := checker.checkUncheckedErrors("app.go", lines)
+	assert.Equal(t, first, second)
+	assert.Len(t, first, 1)
+	assert.Equal(t, 1, checker.goASTs.Stats().Hits)
+
+	assert.Error(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"ast_cache_bytes": -1}}))
+}
@@ -0,0 +1,286 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// bannedSymbol is a function, method or import an organisation has banned
+type bannedSymbol struct {
+	Symbol   string
+	Severity models.SeverityLevel
+	Message  string
+
+	usage *regexp.Regexp
+	// unless, when set, exempts lines that match it (e.g. yaml.load with SafeLoader)
+	unless *regexp.Regexp
+	// extensions limits the symbol to one language; nil applies it everywhere
+	extensions map[string]bool
+}
+
+// importParser extracts the module paths imported by a line. inBlock tracks
+// multi-line import blocks such as Go's import ( ... ).
+type importParser func(line string, inBlock *bool) []string
+
+var (
+	goImportLine       = regexp.MustCompile(`^\s*import\s+(?:[\w.]+\s+)?"([^"]+)"`)
+	goImportBlockStart = regexp.MustCompile(`^\s*import\s*\(`)
+	goImportBlockEntry = regexp.MustCompile(`^\s*(?:[\w.]+\s+)?"([^"]+)"`)
+	pyImportLine       = regexp.MustCompile(`^\s*import\s+(.+)$`)
+	pyFromImportLine   = regexp.MustCompile(`^\s*from\s+([\w.]+)\s+import\s+\(?([^)#]+)`)
+	jsImportFrom       = regexp.MustCompile(`\bfrom\s+['"]([^'"]+)['"]|^\s*import\s+['"]([^'"]+)['"]|\b(?:require|import)\s*\(\s*['"]([^'"]+)['"]\s*\)`)
+	javaImportLine     = regexp.MustCompile(`^\s*import\s+(?:static\s+)?([\w.]+)(?:\.\*)?\s*;?`)
+)
+
+// importParsers are keyed by file extension
+var importParsers = map[string]importParser{
+	".go":    parseGoImports,
+	".py":    parsePythonImports,
+	".pyw":   parsePythonImports,
+	".js":    parseJSImports,
+	".jsx":   parseJSImports,
+	".mjs":   parseJSImports,
+	".cjs":   parseJSImports,
+	".ts":    parseJSImports,
+	".tsx":   parseJSImports,
+	".java":  parseJavaImports,
+	".kt":    parseJavaImports,
+	".scala": parseJavaImports,
+}
+
+func parseGoImports(line string, inBlock *bool) []string {
+	if *inBlock {
+		if strings.HasPrefix(strings.TrimSpace(line), ")") {
+			*inBlock = false
+			return nil
+		}
+		if m := goImportBlockEntry.FindStringSubmatch(line); m != nil {
+			return []string{m[1]}
+		}
+		return nil
+	}
+	if goImportBlockStart.MatchString(line) {
+		*inBlock = true
+		return nil
+	}
+	if m := goImportLine.FindStringSubmatch(line); m != nil {
+		return []string{m[1]}
+	}
+	return nil
+}
+
+func parsePythonImports(line string, _ *bool) []string {
+	if m := pyFromImportLine.FindStringSubmatch(line); m != nil {
+		module := m[1]
+		paths := []string{module}
+		for _, name := range strings.Split(m[2], ",") {
+			if fields := strings.Fields(name); len(fields) > 0 && fields[0] != "*" {
+				paths = append(paths, module+"."+fields[0])
+			}
+		}
+		return paths
+	}
+	if m := pyImportLine.FindStringSubmatch(line); m != nil {
+		var paths []string
+		for _, name := range strings.Split(m[1], ",") {
+			if fields := strings.Fields(name); len(fields) > 0 {
+				paths = append(paths, fields[0])
+			}
+		}
+		return paths
+	}
+	return nil
+}
+
+func parseJSImports(line string, _ *bool) []string {
+	var paths []string
+	for _, m := range jsImportFrom.FindAllStringSubmatch(line, -1) {
+		for _, path := range m[1:] {
+			if path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+func parseJavaImports(line string, _ *bool) []string {
+	if m := javaImportLine.FindStringSubmatch(line); m != nil {
+		return []string{m[1]}
+	}
+	return nil
+}
+
+// configureBannedSymbols reads the banned_symbols setting: a list of entries
+// with a symbol and optional language, severity, message and unless pattern
+func (sc *SecurityChecker) configureBannedSymbols(settings map[string]interface{}) error {
+	sc.bannedSymbols = nil
+
+	value, exists := settings["banned_symbols"]
+	if !exists {
+		return nil
+	}
+	entries, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("setting banned_symbols must be a list")
+	}
+
+	for i, entry := range entries {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("banned_symbols[%d] must be a map", i)
+		}
+
+		symbol, err := bannedSymbolFromSettings(fields)
+		if err != nil {
+			return fmt.Errorf("banned_symbols[%d]: %w", i, err)
+		}
+		sc.bannedSymbols = append(sc.bannedSymbols, symbol)
+	}
+
+	return nil
+}
+
+func bannedSymbolFromSettings(fields map[string]interface{}) (*bannedSymbol, error) {
+	str := func(key string) (string, error) {
+		value, exists := fields[key]
+		if !exists {
+			return "", nil
+		}
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%s must be a string", key)
+		}
+		return strings.TrimSpace(s), nil
+	}
+
+	name, err := str("symbol")
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+
+	symbol := &bannedSymbol{
+		Symbol:   name,
+		Severity: models.SeverityWarning,
+		Message:  fmt.Sprintf("'%s' is banned in this codebase", name),
+		// Match the symbol as a whole identifier path, so yaml.load does not match yaml.load_all or myyaml.load
+		usage: regexp.MustCompile(`(?:^|[^\w.$])` + regexp.QuoteMeta(name) + `(?:[^\w$]|$)`),
+	}
+
+	if severity, err := str("severity"); err != nil {
+		return nil, err
+	} else if severity != "" {
+		switch level := models.SeverityLevel(strings.ToLower(severity)); level {
+		case models.SeverityCritical, models.SeverityError, models.SeverityWarning, models.SeverityInfo:
+			symbol.Severity = level
+		default:
+			return nil, fmt.Errorf("unknown severity %q", severity)
+		}
+	}
+
+	if message, err := str("message"); err != nil {
+		return nil, err
+	} else if message != "" {
+		symbol.Message = message
+	}
+
+	if language, err := str("language"); err != nil {
+		return nil, err
+	} else if language != "" {
+		if symbol.extensions, err = LanguageExtensions([]string{language}); err != nil {
+			return nil, err
+		}
+	}
+
+	if unless, err := str("unless"); err != nil {
+		return nil, err
+	} else if unless != "" {
+		if symbol.unless, err = regexp.Compile(unless); err != nil {
+			return nil, fmt.Errorf("invalid unless pattern: %w", err)
+		}
+	}
+
+	return symbol, nil
+}
+
+// importMatches reports whether an imported path is the symbol or lives under it
+func (b *bannedSymbol) importMatches(path string) bool {
+	return path == b.Symbol ||
+		strings.HasPrefix(path, b.Symbol+"/") ||
+		strings.HasPrefix(path, b.Symbol+".")
+}
+
+// checkBannedSymbols flags imports and usages of banned symbols in a file
+func (sc *SecurityChecker) checkBannedSymbols(filename string, lines []string) []models.Issue {
+	var issues []models.Issue
+	if len(sc.bannedSymbols) == 0 {
+		return issues
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	parseImports := importParsers[ext]
+	inImportBlock := false
+
+	for index, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if IsCommentLine(trimmed) {
+			continue
+		}
+
+		var imports []string
+		if parseImports != nil {
+			imports = parseImports(line, &inImportBlock)
+		}
+
+		for _, symbol := range sc.bannedSymbols {
+			if symbol.extensions != nil && !symbol.extensions[ext] {
+				continue
+			}
+			if symbol.unless != nil && symbol.unless.MatchString(line) {
+				continue
+			}
+
+			kind := ""
+			for _, path := range imports {
+				if symbol.importMatches(path) {
+					kind = "import"
+					break
+				}
+			}
+			if kind == "" && len(imports) == 0 && symbol.usage.MatchString(line) {
+				kind = "usage"
+			}
+			if kind == "" {
+				continue
+			}
+
+			issues = append(issues, models.Issue{
+				Type:          models.VibeTypeSecurity,
+				Severity:      symbol.Severity,
+				Title:         "Banned symbol",
+				Message:       symbol.Message,
+				File:          filename,
+				Line:          index + 1,
+				Rule:          "banned-symbol",
+				Category:      models.CategorySecurity,
+				Context:       utils.TruncateString(line, 100),
+				FixSuggestion: fmt.Sprintf("Remove the %s of '%s' or use an approved alternative", kind, symbol.Symbol),
+				Confidence:    0.9,
+				Metadata: map[string]interface{}{
+					"symbol": symbol.Symbol,
+					"kind":   kind,
+				},
+			})
+		}
+	}
+
+	return issues
+}
@@ -2,12 +2,13 @@ package vibes
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"kodevibe/internal/models"
 	"kodevibe/internal/utils"
@@ -21,19 +22,54 @@ type CodeChecker struct {
 	maxLineLength       int
 	languageRules       map[string]*LanguageRules
 	complexityThreshold int
+	maxLinesPerFile     int
+	debugStatementRules map[string]*debugStatementRule
+	ruleExemptions      map[string][]string
+	// todoMaxAge escalates TODOs older than this (by git blame) to warnings; 0 disables it
+	todoMaxAge time.Duration
+	// uncheckedErrorExemptions are call name globs the unchecked-error rule ignores
+	uncheckedErrorExemptions []string
+	goPackages               *goPackageCache
+	// goASTs keeps the syntax trees of Go files between scans
+	goASTs *ASTCache
+	// God class and file thresholds; 0 turns a limit off
+	maxMethods    int
+	maxFields     int
+	maxClassLines int
+	maxFileLines  int
+	// complexityExemptions are function name globs exempt from the
+	// cyclomatic-complexity and function-length rules
+	complexityExemptions []string
+	// swallowedErrorExtensions are the files the swallowed-error rule checks
+	swallowedErrorExtensions    map[string]bool
+	swallowedErrorAllowComments bool
+
+	// nonPortablePathExtensions are the files the non-portable-path rule checks
+	nonPortablePathExtensions map[string]bool
+	nonPortablePathAllowed    []string
 }
 
 // LanguageRules contains language-specific code quality rules
 type LanguageRules struct {
-	Extensions       []string
-	FunctionPatterns []*regexp.Regexp
-	ClassPatterns    []*regexp.Regexp
-	CommentPatterns  []*regexp.Regexp
-	ImportPatterns   []*regexp.Regexp
-	VariablePatterns []*regexp.Regexp
-	DeadCodePatterns []*regexp.Regexp
-	AntiPatterns     []*AntiPattern
-	StyleRules       []*StyleRule
+	Extensions          []string
+	FunctionPatterns    []*regexp.Regexp
+	ClassPatterns       []*regexp.Regexp
+	CommentPatterns     []*regexp.Regexp
+	ImportPatterns      []*regexp.Regexp
+	VariablePatterns    []*regexp.Regexp
+	DeadCodePatterns    []*regexp.Regexp
+	SkippedTestPatterns []*regexp.Regexp
+	AntiPatterns        []*AntiPattern
+	StyleRules          []*StyleRule
+}
+
+// languageExtensions maps language names used in settings to a representative extension
+var languageExtensions = map[string]string{
+	"javascript": ".js",
+	"typescript": ".ts",
+	"python":     ".py",
+	"go":         ".go",
+	"java":       ".java",
 }
 
 // AntiPattern represents a code anti-pattern
@@ -61,8 +97,21 @@ func NewCodeChecker() *CodeChecker {
 		maxNestingDepth:     4,
 		maxLineLength:       120,
 		complexityThreshold: 10,
+		maxLinesPerFile:     defaultMaxLinesPerFile,
 		languageRules:       make(map[string]*LanguageRules),
+		debugStatementRules: defaultDebugStatementRules(),
+		ruleExemptions:      defaultRuleExemptions(),
+		goPackages:          newGoPackageCache(),
+		goASTs:              NewASTCache(DefaultASTCacheBytes),
+		maxMethods:          defaultMaxMethods,
+		maxFields:           defaultMaxFields,
+		maxClassLines:       defaultMaxClassLines,
+		maxFileLines:        defaultMaxFileLines,
+
+		uncheckedErrorExemptions: defaultUncheckedErrorExemptions,
 	}
+	checker.swallowedErrorExtensions, _ = LanguageExtensions(swallowedErrorLanguages())
+	checker.nonPortablePathExtensions, _ = LanguageExtensions(nonPortablePathLanguages())
 
 	checker.initializeLanguageRules()
 	return checker
@@ -107,20 +156,74 @@ func (cc *CodeChecker) Configure(config models.VibeConfig) error {
 		}
 	}
 
+	maxLines, err := maxLinesPerFileSetting(config.Settings)
+	if err != nil {
+		return err
+	}
+	cc.maxLinesPerFile = maxLines
+
+	if err := cc.configureDebugStatements(config.Settings); err != nil {
+		return err
+	}
+
+	if err := cc.configureRuleExemptions(config.Settings); err != nil {
+		return err
+	}
+
+	if err := cc.configureUncheckedErrors(config.Settings); err != nil {
+		return err
+	}
+
+	if err := cc.configureSwallowedErrors(config.Settings); err != nil {
+		return err
+	}
+
+	if err := cc.configureNonPortablePaths(config.Settings); err != nil {
+		return err
+	}
+
+	if err := cc.configureGodClasses(config.Settings); err != nil {
+		return err
+	}
+
+	if err := cc.configureComplexityExemptions(config.Settings); err != nil {
+		return err
+	}
+
+	if cc.todoMaxAge, err = settingDuration(config.Settings, "todo_max_age"); err != nil {
+		return err
+	}
+
+	astCacheBytes, err := settingInt(config.Settings, "ast_cache_bytes", DefaultASTCacheBytes)
+	if err != nil {
+		return err
+	}
+	cc.goASTs.Resize(astCacheBytes)
+
+	skippedTestPatterns, err := settingStringLists(config.Settings, "skipped_test_patterns")
+	if err != nil {
+		return err
+	}
+	for language, patterns := range skippedTestPatterns {
+		ext, known := languageExtensions[language]
+		if !known {
+			return fmt.Errorf("unknown language %q in skipped_test_patterns", language)
+		}
+		compiled, err := compilePatterns(patterns)
+		if err != nil {
+			return fmt.Errorf("invalid skipped_test_patterns for %s: %w", language, err)
+		}
+		cc.languageRules[ext].SkippedTestPatterns = compiled
+	}
+
 	return nil
 }
 
 // Supports returns true if the checker supports the given file
 func (cc *CodeChecker) Supports(filename string) bool {
 	ext := strings.ToLower(filepath.Ext(filename))
-	supportedExtensions := []string{
-		".js", ".jsx", ".ts", ".tsx", ".py", ".go", ".java", ".c", ".cpp", ".h", ".hpp",
-		".rb", ".php", ".sh", ".bash", ".zsh", ".rs", ".kt", ".swift", ".scala",
-		".cs", ".vb", ".dart", ".lua", ".r", ".matlab", ".perl", ".groovy",
-	}
-
-	for _, supportedExt := range supportedExtensions {
-		if ext == supportedExt {
+	for _, extensions := range codeLanguageExtensions {
+		if utils.ContainsString(extensions, ext) {
 			return true
 		}
 	}
@@ -128,6 +231,57 @@ func (cc *CodeChecker) Supports(filename string) bool {
 	return false
 }
 
+// DefaultConfig returns the default code checker configuration
+func (cc *CodeChecker) DefaultConfig() models.VibeConfig {
+	return models.VibeConfig{
+		Enabled: true,
+		Level:   "moderate",
+		Settings: map[string]interface{}{
+			"max_function_length":  50,
+			"max_nesting_depth":    4,
+			"max_line_length":      120,
+			"complexity_threshold": 10,
+			"max_lines_per_file":   defaultMaxLinesPerFile,
+			"max_methods":          defaultMaxMethods,
+			"max_fields":           defaultMaxFields,
+			"max_class_lines":      defaultMaxClassLines,
+			"max_file_lines":       defaultMaxFileLines,
+		},
+	}
+}
+
+// Rules returns the rules emitted by the code checker
+func (cc *CodeChecker) Rules() []RuleInfo {
+	return []RuleInfo{
+		{ID: "line-length", Title: "Line too long", Description: "Lines longer than max_line_length", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "todo-comments", Title: "TODO/FIXME comment found", Description: "TODO, FIXME, HACK, XXX and BUG markers that should be tracked as issues; TODO(owner) and FIXME(TICKET-1) annotations are recorded, and todo_max_age escalates old ones", Severity: models.SeverityInfo},
+		{ID: "commented-code", Title: "Commented-out code detected", Description: "Comments that contain code-like statements", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "magic-numbers", Title: "Magic number detected", Description: "Numeric literals that should be named constants", Severity: models.SeverityInfo, Fixable: true},
+		{ID: "debug-statement", Title: "Debug statement found", Description: "Leftover debugger breakpoints and debug output such as binding.pry, var_dump and fmt.Println outside main packages", Severity: models.SeverityWarning},
+		{ID: "no-debugger", Title: "Debugger statement found", Description: "debugger statements in JavaScript/TypeScript", Severity: models.SeverityError, Fixable: true},
+		{ID: "no-browser-dialogs", Title: "Browser dialog found", Description: "alert(), confirm() and prompt() calls in JavaScript/TypeScript", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "skipped-test", Title: "Skipped or focused test", Description: "Skipped or focused tests such as t.Skip, it.only or @Disabled", Severity: models.SeverityWarning},
+		{ID: "function-length", Title: "Function too long", Description: "Functions longer than max_function_length", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "nesting-depth", Title: "Excessive nesting depth", Description: "Nesting deeper than max_nesting_depth", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "duplicate-code", Title: "Duplicate code detected", Description: "Identical blocks of five or more lines within a file", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "cyclomatic-complexity", Title: "High cyclomatic complexity", Description: "Functions whose complexity exceeds complexity_threshold", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "no-console-log", Title: "Console.log statement found", Description: "console.log calls left in JavaScript/TypeScript", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "strict-equality", Title: "Use strict equality", Description: "Loose == comparisons in JavaScript/TypeScript", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "no-var", Title: "Use let/const instead of var", Description: "var declarations in JavaScript/TypeScript", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "no-print", Title: "Print statement found", Description: "print calls in Python", Severity: models.SeverityInfo, Fixable: true},
+		{ID: "no-context-todo", Title: "context.TODO() usage", Description: "context.TODO() left in Go code", Severity: models.SeverityInfo, Fixable: true},
+		{ID: "no-panic", Title: "Panic usage detected", Description: "panic calls in Go code outside main, init and tests", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "unchecked-error", Title: "Unchecked error", Description: "Go errors discarded with _ or dropped by calling an error-returning function as a statement", Severity: models.SeverityWarning},
+		{ID: "non-portable-path", Title: "Non-portable path", Description: "Hardcoded /tmp and C:\\ paths, backslash-separated paths and \"/\" concatenation where os.TempDir, filepath.Join, tempfile and similar portable APIs belong", Severity: models.SeverityWarning},
+		{ID: "swallowed-error", Title: "Swallowed error", Description: "Empty or comment-only catch blocks, Go 'if err != nil' blocks and Python except clauses that only pass", Severity: models.SeverityWarning},
+		{ID: "no-unwrap", Title: "unwrap() usage detected", Description: "unwrap() and try! in Rust code outside main and tests", Severity: models.SeverityWarning},
+		{ID: "god-class", Title: "Class too large", Description: "Classes, and Go struct types with their methods, with more than max_methods methods, max_fields fields or max_class_lines lines", Severity: models.SeverityWarning},
+		{ID: "oversized-file", Title: "File too large", Description: "Files longer than max_file_lines", Severity: models.SeverityWarning},
+		{ID: "no-system-out", Title: "System.out.println found", Description: "System.out.println calls in Java", Severity: models.SeverityWarning, Fixable: true},
+		largeFileRule(models.VibeTypeCode),
+	}
+}
+
 // Check performs code quality checks on the provided files
 func (cc *CodeChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
 	var issues []models.Issue
@@ -137,7 +291,7 @@ func (cc *CodeChecker) Check(ctx context.Context, files []string) ([]models.Issu
 			continue
 		}
 
-		fileIssues, err := cc.checkFile(file)
+		fileIssues, err := cc.checkFile(ctx, file)
 		if err != nil {
 			// Log error but continue with other files
 			continue
@@ -156,24 +310,31 @@ func (cc *CodeChecker) Check(ctx context.Context, files []string) ([]models.Issu
 	return issues, nil
 }
 
+// CheckFile performs code quality checks on one file
+func (cc *CodeChecker) CheckFile(ctx context.Context, file string) ([]models.Issue, error) {
+	if !cc.Supports(file) {
+		return nil, nil
+	}
+	return cc.checkFile(ctx, file)
+}
+
 // checkFile performs code quality checks on a single file
-func (cc *CodeChecker) checkFile(filename string) ([]models.Issue, error) {
+func (cc *CodeChecker) checkFile(ctx context.Context, filename string) ([]models.Issue, error) {
 	var issues []models.Issue
 
-	file, err := os.Open(filename)
+	content, err := readFile(ctx, filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
 	lines := []string{}
 	lineNumber := 0
 
 	// Read all lines
 	for scanner.Scan() {
 		lineNumber++
-		line := scanner.Text()
+		line := utils.TrimLineEnding(scanner.Text())
 		lines = append(lines, line)
 
 		// Check individual line issues
@@ -185,11 +346,26 @@ func (cc *CodeChecker) checkFile(filename string) ([]models.Issue, error) {
 		return issues, fmt.Errorf("error reading file: %w", err)
 	}
 
+	// Date TODOs with git blame when todo_max_age is set
+	cc.applyTodoAges(ctx, filename, issues)
+
+	// Check for leftover debugging statements, which depend on the whole file (e.g. Go's package clause)
+	issues = append(issues, cc.checkDebugStatements(filename, lines)...)
+
+	issues = append(issues, cc.checkOversizedFile(filename, lines)...)
+
+	// Multi-line checks are superlinear, so very large (usually generated) files only get per-line checks
+	if exceedsMaxLines(len(lines), cc.maxLinesPerFile) {
+		issues = cc.dropExemptIssues(filename, lines, issues)
+		return append(issues, largeFileIssue(models.VibeTypeCode, filename, len(lines), cc.maxLinesPerFile)), nil
+	}
+
 	// Check multi-line issues
 	multiLineIssues := cc.checkMultiLine(filename, lines)
 	issues = append(issues, multiLineIssues...)
 
-	return issues, nil
+	// Drop e.g. panics in func main or test files where rule_exemptions allow them
+	return cc.dropExemptIssues(filename, lines, issues), nil
 }
 
 // checkLine performs checks on a single line
@@ -206,6 +382,7 @@ func (cc *CodeChecker) checkLine(filename, line string, lineNumber int) []models
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "line-length",
+			Category:      models.CategoryReadability,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Break long lines into multiple lines",
@@ -214,27 +391,13 @@ func (cc *CodeChecker) checkLine(filename, line string, lineNumber int) []models
 		issues = append(issues, issue)
 	}
 
-	// Check for TODO/FIXME comments
-	todoPattern := regexp.MustCompile(`(?i)\b(TODO|FIXME|HACK|XXX|BUG)\b`)
-	if todoPattern.MatchString(line) {
-		issue := models.Issue{
-			Type:          models.VibeTypeCode,
-			Severity:      models.SeverityInfo,
-			Title:         "TODO/FIXME comment found",
-			Message:       "TODO/FIXME comments should be tracked in issues",
-			File:          filename,
-			Line:          lineNumber,
-			Rule:          "todo-comments",
-			Context:       utils.TruncateString(line, 100),
-			Fixable:       false,
-			FixSuggestion: "Create an issue to track this task",
-			Confidence:    1.0,
-		}
-		issues = append(issues, issue)
+	// Check for TODO/FIXME comments, with their owner and ticket when annotated
+	if issue := checkTodoComment(filename, line, lineNumber); issue != nil {
+		issues = append(issues, *issue)
 	}
 
 	// Check for commented-out code
-	if cc.isCommentedOutCode(line) {
+	if IsCommentedOutCode(line) {
 		issue := models.Issue{
 			Type:          models.VibeTypeCode,
 			Severity:      models.SeverityWarning,
@@ -243,6 +406,7 @@ func (cc *CodeChecker) checkLine(filename, line string, lineNumber int) []models
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "commented-code",
+			Category:      models.CategoryMaintainability,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Remove commented-out code or use version control",
@@ -255,10 +419,58 @@ func (cc *CodeChecker) checkLine(filename, line string, lineNumber int) []models
 	magicNumberIssues := cc.checkMagicNumbers(filename, line, lineNumber)
 	issues = append(issues, magicNumberIssues...)
 
+	// Check for skipped or focused tests
+	skippedTestIssues := cc.checkSkippedTests(filename, line, lineNumber)
+	issues = append(issues, skippedTestIssues...)
+
 	// Check language-specific issues
 	langIssues := cc.checkLanguageSpecific(filename, line, lineNumber)
 	issues = append(issues, langIssues...)
 
+	// Check for hardcoded temp directories and platform-specific paths
+	issues = append(issues, cc.checkNonPortablePaths(filename, line, lineNumber)...)
+
+	return issues
+}
+
+// checkSkippedTests flags tests that are skipped or focused and silently reduce coverage
+func (cc *CodeChecker) checkSkippedTests(filename, line string, lineNumber int) []models.Issue {
+	var issues []models.Issue
+
+	rules := cc.getLanguageRules(strings.ToLower(filepath.Ext(filename)))
+	if rules == nil {
+		return issues
+	}
+
+	trimmed := strings.TrimSpace(line)
+	if IsCommentLine(trimmed) {
+		return issues
+	}
+
+	for _, pattern := range rules.SkippedTestPatterns {
+		match := pattern.FindString(line)
+		if match == "" {
+			continue
+		}
+
+		issue := models.Issue{
+			Type:          models.VibeTypeCode,
+			Severity:      models.SeverityWarning,
+			Title:         "Skipped or focused test",
+			Message:       fmt.Sprintf("'%s' disables test coverage and is easy to ship by accident", strings.TrimSpace(match)),
+			File:          filename,
+			Line:          lineNumber,
+			Rule:          "skipped-test",
+			Category:      models.CategoryMaintainability,
+			Context:       utils.TruncateString(line, 100),
+			Fixable:       false,
+			FixSuggestion: "Re-enable the test or remove it, and track the reason in an issue",
+			Confidence:    0.9,
+		}
+		issues = append(issues, issue)
+		break
+	}
+
 	return issues
 }
 
@@ -282,6 +494,18 @@ func (cc *CodeChecker) checkMultiLine(filename string, lines []string) []models.
 	complexityIssues := cc.checkComplexity(filename, lines)
 	issues = append(issues, complexityIssues...)
 
+	// Check for ignored errors in Go
+	issues = append(issues, cc.checkUncheckedErrors(filename, lines)...)
+
+	// Check for empty catch, except and "if err != nil" blocks
+	issues = append(issues, cc.checkSwallowedErrors(filename, lines)...)
+
+	// Check for classes with too many methods, fields or lines
+	issues = append(issues, cc.checkGodClasses(filename, lines)...)
+
+	// Check for debugger statements and alert/confirm/prompt in JavaScript
+	issues = append(issues, cc.checkJavaScriptStatements(filename, lines)...)
+
 	return issues
 }
 
@@ -310,11 +534,13 @@ func (cc *CodeChecker) checkFunctionLength(filename string, lines []string) []mo
 						File:          filename,
 						Line:          i + 1,
 						Rule:          "function-length",
+						Category:      models.CategoryComplexity,
 						Context:       utils.TruncateString(line, 100),
 						Fixable:       true,
 						FixSuggestion: "Break long functions into smaller, more focused functions",
 						Confidence:    0.9,
 					}
+					cc.exemptComplexity(&issue, lines, i)
 					issues = append(issues, issue)
 				}
 			}
@@ -360,6 +586,7 @@ func (cc *CodeChecker) checkNestingDepth(filename string, lines []string) []mode
 			File:          filename,
 			Line:          maxDepthLine,
 			Rule:          "nesting-depth",
+			Category:      models.CategoryComplexity,
 			Context:       utils.TruncateString(lines[maxDepthLine-1], 100),
 			Fixable:       true,
 			FixSuggestion: "Refactor code to reduce nesting using early returns or helper functions",
@@ -399,6 +626,7 @@ func (cc *CodeChecker) checkDuplicateCode(filename string, lines []string) []mod
 					File:          filename,
 					Line:          lineNum,
 					Rule:          "duplicate-code",
+					Category:      models.CategoryMaintainability,
 					Context:       utils.TruncateString(block, 100),
 					Fixable:       true,
 					FixSuggestion: "Extract duplicate code into a reusable function",
@@ -439,11 +667,13 @@ func (cc *CodeChecker) checkComplexity(filename string, lines []string) []models
 						File:          filename,
 						Line:          i + 1,
 						Rule:          "cyclomatic-complexity",
+						Category:      models.CategoryComplexity,
 						Context:       utils.TruncateString(line, 100),
 						Fixable:       true,
 						FixSuggestion: "Break complex function into smaller functions",
 						Confidence:    0.8,
 					}
+					cc.exemptComplexity(&issue, lines, i)
 					issues = append(issues, issue)
 				}
 			}
@@ -480,6 +710,7 @@ func (cc *CodeChecker) checkMagicNumbers(filename, line string, lineNumber int)
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "magic-numbers",
+			Category:      models.CategoryReadability,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Replace magic number with a named constant",
@@ -505,6 +736,8 @@ func (cc *CodeChecker) checkLanguageSpecific(filename, line string, lineNumber i
 		issues = append(issues, cc.checkGo(filename, line, lineNumber)...)
 	case ".java":
 		issues = append(issues, cc.checkJava(filename, line, lineNumber)...)
+	case ".rs":
+		issues = append(issues, cc.checkRust(filename, line, lineNumber)...)
 	}
 
 	return issues
@@ -524,6 +757,7 @@ func (cc *CodeChecker) checkJavaScript(filename, line string, lineNumber int) []
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "no-console-log",
+			Category:      models.CategoryBestPractices,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Remove console.log or use a proper logging library",
@@ -542,6 +776,7 @@ func (cc *CodeChecker) checkJavaScript(filename, line string, lineNumber int) []
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "strict-equality",
+			Category:      models.CategoryBestPractices,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Replace == with ===",
@@ -560,6 +795,7 @@ func (cc *CodeChecker) checkJavaScript(filename, line string, lineNumber int) []
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "no-var",
+			Category:      models.CategoryBestPractices,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Replace var with let or const",
@@ -585,6 +821,7 @@ func (cc *CodeChecker) checkPython(filename, line string, lineNumber int) []mode
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "no-print",
+			Category:      models.CategoryBestPractices,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Use logging module instead of print",
@@ -618,6 +855,7 @@ func (cc *CodeChecker) checkGo(filename, line string, lineNumber int) []models.I
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "no-context-todo",
+			Category:      models.CategoryBestPractices,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Use context.Background() or pass context from caller",
@@ -636,6 +874,7 @@ func (cc *CodeChecker) checkGo(filename, line string, lineNumber int) []models.I
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "no-panic",
+			Category:      models.CategoryErrorHandling,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Return error instead of using panic",
@@ -661,6 +900,7 @@ func (cc *CodeChecker) checkJava(filename, line string, lineNumber int) []models
 			File:          filename,
 			Line:          lineNumber,
 			Rule:          "no-system-out",
+			Category:      models.CategoryBestPractices,
 			Context:       utils.TruncateString(line, 100),
 			Fixable:       true,
 			FixSuggestion: "Use a logging framework like SLF4J",
@@ -672,9 +912,44 @@ func (cc *CodeChecker) checkJava(filename, line string, lineNumber int) []models
 	return issues
 }
 
+// rustUnwrapPattern matches calls that panic on None or Err
+var rustUnwrapPattern = regexp.MustCompile(`\.unwrap\(\)|\btry!\s*\(`)
+
+// checkRust performs Rust-specific checks
+func (cc *CodeChecker) checkRust(filename, line string, lineNumber int) []models.Issue {
+	var issues []models.Issue
+
+	if strings.HasPrefix(strings.TrimSpace(line), "//") {
+		return issues
+	}
+
+	// Check for unwrap() and the deprecated try! macro
+	if match := rustUnwrapPattern.FindString(line); match != "" {
+		issue := models.Issue{
+			Type:          models.VibeTypeCode,
+			Severity:      models.SeverityWarning,
+			Title:         "unwrap() usage detected",
+			Message:       fmt.Sprintf("'%s' panics on error; propagate or handle the error instead", strings.TrimSpace(match)),
+			File:          filename,
+			Line:          lineNumber,
+			Rule:          "no-unwrap",
+			Category:      models.CategoryErrorHandling,
+			Context:       utils.TruncateString(line, 100),
+			Fixable:       false,
+			FixSuggestion: "Use the ? operator, match or if let to handle the error",
+			Confidence:    0.8,
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
 // Helper methods
 
-func (cc *CodeChecker) isCommentedOutCode(line string) bool {
+// IsCommentedOutCode reports whether line is a comment that holds code, such as
+// an assignment, call or return, rather than prose
+func IsCommentedOutCode(line string) bool {
 	trimmed := strings.TrimSpace(line)
 
 	// Check for common comment patterns followed by code-like content
@@ -748,7 +1023,7 @@ func (cc *CodeChecker) normalizeBlock(block string) string {
 
 	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
-		if trimmed != "" && !strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "#") {
+		if trimmed != "" && !IsCommentLine(trimmed) {
 			normalized = append(normalized, trimmed)
 		}
 	}
@@ -838,24 +1113,28 @@ func (cc *CodeChecker) getLanguageRules(ext string) *LanguageRules {
 	return cc.languageRules[ext]
 }
 
+// compilePatterns compiles a list of regular expressions
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
 func (cc *CodeChecker) initializeLanguageRules() {
-	// JavaScript/TypeScript rules
-	jsRules := &LanguageRules{
-		Extensions: []string{".js", ".jsx", ".ts", ".tsx"},
-		FunctionPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`function\s+\w+\s*\(`),
-			regexp.MustCompile(`\w+\s*:\s*function\s*\(`),
-			regexp.MustCompile(`\w+\s*=>\s*{`),
-			regexp.MustCompile(`\w+\s*=\s*function\s*\(`),
-		},
-		ClassPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`class\s+\w+`),
-		},
+	// JavaScript and TypeScript share their patterns but not their rules, so
+	// settings for one language leave the other alone
+	for _, extensions := range [][]string{{".js", ".jsx"}, {".ts", ".tsx"}} {
+		rules := newJSRules(extensions)
+		for _, ext := range extensions {
+			cc.languageRules[ext] = rules
+		}
 	}
-	cc.languageRules[".js"] = jsRules
-	cc.languageRules[".jsx"] = jsRules
-	cc.languageRules[".ts"] = jsRules
-	cc.languageRules[".tsx"] = jsRules
 
 	// Python rules
 	pyRules := &LanguageRules{
@@ -866,6 +1145,11 @@ func (cc *CodeChecker) initializeLanguageRules() {
 		ClassPatterns: []*regexp.Regexp{
 			regexp.MustCompile(`class\s+\w+`),
 		},
+		SkippedTestPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`@pytest\.mark\.(?:skip|skipif)\b`),
+			regexp.MustCompile(`@unittest\.skip\w*`),
+			regexp.MustCompile(`\bpytest\.skip\s*\(`),
+		},
 	}
 	cc.languageRules[".py"] = pyRules
 
@@ -876,6 +1160,9 @@ func (cc *CodeChecker) initializeLanguageRules() {
 			regexp.MustCompile(`func\s+\w+\s*\(`),
 			regexp.MustCompile(`func\s*\(\w*\s*\*?\w+\)\s*\w+\s*\(`),
 		},
+		SkippedTestPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\b[tb]\.Skip(?:f|Now)?\s*\(`),
+		},
 	}
 	cc.languageRules[".go"] = goRules
 
@@ -888,6 +1175,30 @@ func (cc *CodeChecker) initializeLanguageRules() {
 		ClassPatterns: []*regexp.Regexp{
 			regexp.MustCompile(`(public|private)?\s*class\s+\w+`),
 		},
+		SkippedTestPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`@Disabled\b`),
+			regexp.MustCompile(`@Ignore\b`),
+		},
 	}
 	cc.languageRules[".java"] = javaRules
 }
+
+// newJSRules returns the JavaScript-family rules for extensions
+func newJSRules(extensions []string) *LanguageRules {
+	return &LanguageRules{
+		Extensions: extensions,
+		FunctionPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`function\s+\w+\s*\(`),
+			regexp.MustCompile(`\w+\s*:\s*function\s*\(`),
+			regexp.MustCompile(`\w+\s*=>\s*{`),
+			regexp.MustCompile(`\w+\s*=\s*function\s*\(`),
+		},
+		ClassPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`class\s+\w+`),
+		},
+		SkippedTestPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\b(?:it|test|describe|context)\.(?:skip|only)\s*\(`),
+			regexp.MustCompile(`\b(?:xit|xtest|xdescribe|fit|fdescribe)\s*\(`),
+		},
+	}
+}
@@ -1,9 +1,16 @@
 package vibes
 
 import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"kodevibe/internal/models"
 )
@@ -79,6 +86,7 @@ func TestCodeChecker_Check_JavaScriptIssues(t *testing.T) {
 	for _, issue := range issues {
 		if issue.Rule == "no-var" {
 			hasVarIssue = true
+			assert.Equal(t, models.CategoryBestPractices, issue.Category)
 		}
 	}
 	assert.True(t, hasVarIssue)
@@ -102,6 +110,12 @@ func TestCodeChecker_Check_JavaScriptIssues(t *testing.T) {
 		}
 	}
 	assert.True(t, hasEqualityIssue)
+	// Every issue the code checker emits carries a category
+	for _, line := range []string{"var x = 1;", `console.log("test");`, "// TODO: fix", "const timeout = 86400;"} {
+		for _, issue := range checker.checkLine(tempFile, line, 1) {
+			assert.NotEmpty(t, issue.Category, "rule %s has no category", issue.Rule)
+		}
+	}
 }
 
 func TestCodeChecker_Check_PythonIssues(t *testing.T) {
@@ -145,6 +159,91 @@ func TestCodeChecker_Check_GoIssues(t *testing.T) {
 	assert.True(t, hasPanicIssue)
 }
 
+func TestCodeChecker_Check_PanicAndUnwrapExemptions(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	goMain := write("cmd/main.go", `package main
+
+func init() {
+	if broken() { panic("bad config") }
+}
+
+func main() {
+	panic("unreachable")
+}
+
+func helper() {
+	panic("not allowed")
+}
+`)
+	goTest := write("pkg/foo_test.go", "package foo\n\nfunc TestFoo(t *testing.T) {\n\tpanic(\"fail\")\n}\n")
+	rustLib := write("src/lib.rs", `pub fn parse(s: &str) -> u32 {
+    s.parse().unwrap()
+}
+
+fn main() {
+    let x = "{".parse::<u32>().unwrap();
+}
+
+#[cfg(test)]
+mod tests {
+    #[test]
+    fn parses() {
+        assert_eq!(super::parse("1"), "1".parse().unwrap());
+    }
+}
+
+fn after() -> u32 {
+    try!(read())
+}
+`)
+	rustTest := write("tests/integration.rs", "fn it_works() {\n    run().unwrap();\n}\n")
+
+	lines := func(checker *CodeChecker, rule string) map[string][]int {
+		issues, err := checker.Check(context.Background(), []string{goMain, goTest, rustLib, rustTest})
+		require.NoError(t, err)
+
+		found := make(map[string][]int)
+		for _, issue := range issues {
+			if issue.Rule == rule {
+				rel, err := filepath.Rel(dir, issue.File)
+				require.NoError(t, err)
+				found[filepath.ToSlash(rel)] = append(found[filepath.ToSlash(rel)], issue.Line)
+			}
+		}
+		return found
+	}
+
+	checker := NewCodeChecker()
+	assert.Equal(t, map[string][]int{"cmd/main.go": {12}}, lines(checker, "no-panic"))
+	assert.Equal(t, map[string][]int{"src/lib.rs": {2, 18}}, lines(checker, "no-unwrap"))
+
+	require.NoError(t, checker.Configure(models.VibeConfig{
+		Settings: map[string]interface{}{
+			"rule_exemptions": map[string]interface{}{
+				"no-panic":  []interface{}{"tests"},
+				"no-unwrap": []interface{}{},
+			},
+		},
+	}))
+	assert.Equal(t, map[string][]int{"cmd/main.go": {4, 8, 12}}, lines(checker, "no-panic"))
+	assert.Equal(t, map[string][]int{"src/lib.rs": {2, 6, 13, 18}, "tests/integration.rs": {2}}, lines(checker, "no-unwrap"))
+
+	for _, exemptions := range []map[string]interface{}{
+		{"no-var": []interface{}{"tests"}},
+		{"no-unwrap": []interface{}{"init"}},
+	} {
+		err := checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"rule_exemptions": exemptions}})
+		assert.Error(t, err, "%v", exemptions)
+	}
+}
+
 func TestCodeChecker_Check_JavaIssues(t *testing.T) {
 	checker := NewCodeChecker()
 
@@ -160,6 +259,188 @@ func TestCodeChecker_Check_JavaIssues(t *testing.T) {
 	assert.True(t, hasSystemOutIssue)
 }
 
+func TestCodeChecker_Check_SkippedTests(t *testing.T) {
+	checker := NewCodeChecker()
+
+	tests := []struct {
+		filename string
+		line     string
+		expected bool
+	}{
+		{"foo_test.go", `	t.Skip("flaky")`, true},
+		{"foo_test.go", `	t.Skipf("needs %s", env)`, true},
+		{"app.spec.js", `it.only("renders", () => {`, true},
+		{"app.spec.ts", `describe.skip("suite", () => {`, true},
+		{"app.spec.js", `fdescribe("suite", () => {`, true},
+		{"test_app.py", `@pytest.mark.skip(reason="slow")`, true},
+		{"AppTest.java", `    @Disabled`, true},
+		{"app.spec.js", `it("renders", () => {`, false},
+		{"foo_test.go", `	// t.Skip("documented")`, false},
+		{"notes.txt", `it.only(`, false},
+	}
+
+	for _, tt := range tests {
+		issues := checker.checkLine(tt.filename, tt.line, 1)
+
+		found := false
+		for _, issue := range issues {
+			if issue.Rule == "skipped-test" {
+				found = true
+				assert.Equal(t, models.SeverityWarning, issue.Severity)
+			}
+		}
+		assert.Equal(t, tt.expected, found, "%s: %s", tt.filename, tt.line)
+	}
+}
+
+func TestCodeChecker_Configure_SkippedTestPatterns(t *testing.T) {
+	checker := NewCodeChecker()
+
+	err := checker.Configure(models.VibeConfig{
+		Settings: map[string]interface{}{
+			"skipped_test_patterns": map[string]interface{}{
+				"go": []interface{}{`\bskipUnlessIntegration\(`},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	hasRule := func(issues []models.Issue) bool {
+		for _, issue := range issues {
+			if issue.Rule == "skipped-test" {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, hasRule(checker.checkLine("a_test.go", "skipUnlessIntegration(t)", 1)))
+	assert.False(t, hasRule(checker.checkLine("a_test.go", `t.Skip("replaced")`, 1)))
+
+	// JavaScript and TypeScript are configured separately
+	err = checker.Configure(models.VibeConfig{
+		Settings: map[string]interface{}{
+			"skipped_test_patterns": map[string]interface{}{
+				"typescript": []interface{}{`\bpending\(`},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, hasRule(checker.checkLine("a.test.tsx", "pending('later')", 1)))
+	assert.False(t, hasRule(checker.checkLine("a.test.ts", "it.skip('x', () => {})", 1)))
+	assert.True(t, hasRule(checker.checkLine("a.test.js", "it.skip('x', () => {})", 1)))
+	assert.False(t, hasRule(checker.checkLine("a.test.jsx", "pending('later')", 1)))
+
+	err = checker.Configure(models.VibeConfig{
+		Settings: map[string]interface{}{
+			"skipped_test_patterns": map[string]interface{}{"cobol": []interface{}{"SKIP"}},
+		},
+	})
+	assert.Error(t, err)
+
+	err = checker.Configure(models.VibeConfig{
+		Settings: map[string]interface{}{
+			"skipped_test_patterns": map[string]interface{}{"go": []interface{}{"("}},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestCodeChecker_checkDebugStatements(t *testing.T) {
+	checker := NewCodeChecker()
+
+	tests := []struct {
+		filename string
+		content  string
+		lines    []int
+	}{
+		// JavaScript debugger statements are reported by no-debugger instead
+		{"app.js", "function f() {\n  debugger;\n}", nil},
+		{"user.rb", "def show\n  binding.pry\n  byebug\nend", []int{2, 3}},
+		{"index.php", "<?php\nvar_dump($user);\ndd($request);\n$this->dd($x);", []int{2, 3}},
+		{"service.go", "package service\n\nfunc Run() {\n\tfmt.Println(\"here\")\n\tprintln(x)\n\tlog.Println(\"ok\")\n}", []int{4, 5}},
+		{"main.go", "package main\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}", nil},
+		{"App.java", "class App {\n  void run() {\n    System.err.println(\"x\");\n    e.printStackTrace();\n    System.out.println(\"y\");\n  }\n}", []int{3, 4}},
+		{"app.py", "import pdb\npdb.set_trace()\nbreakpoint()", []int{2, 3}},
+		{"notes.txt", "debugger;", nil},
+	}
+
+	for _, tt := range tests {
+		var lines []int
+		for _, issue := range checker.checkDebugStatements(tt.filename, strings.Split(tt.content, "\n")) {
+			assert.Equal(t, "debug-statement", issue.Rule)
+			assert.Equal(t, models.SeverityWarning, issue.Severity)
+			lines = append(lines, issue.Line)
+		}
+		assert.Equal(t, tt.lines, lines, tt.filename)
+	}
+}
+
+func TestCodeChecker_checkJavaScriptStatements(t *testing.T) {
+	checker := NewCodeChecker()
+	content := strings.Join([]string{
+		"function f() {",
+		"  debugger;",
+		"  const debuggerEnabled = true;",
+		"  // debugger;",
+		"  const help = `pause here with",
+		"    debugger; or alert(msg)`;",
+		"  log('debugger;', \"prompt(x)\");",
+		"  if (x) { debugger }",
+		"  alert('saved');",
+		"  if (window.confirm(`Delete ${name}?`)) remove();",
+		"  const answer = prompt('Name?');",
+		"  toast.alert(message); showAlert(message);",
+		"  /* alert(x) */ config.debugger = true;",
+		"}",
+		"function confirm(message) { return true; }",
+	}, "\n")
+
+	found := make(map[string][]int)
+	for _, issue := range checker.checkJavaScriptStatements("app.ts", strings.Split(content, "\n")) {
+		assert.True(t, issue.Fixable)
+		assert.NotEmpty(t, issue.FixSuggestion)
+		switch issue.Rule {
+		case "no-debugger":
+			assert.Equal(t, models.SeverityError, issue.Severity)
+		case "no-browser-dialogs":
+			assert.Equal(t, models.SeverityWarning, issue.Severity)
+		}
+		found[issue.Rule] = append(found[issue.Rule], issue.Line)
+	}
+
+	assert.Equal(t, map[string][]int{
+		"no-debugger":        {2, 8},
+		"no-browser-dialogs": {9, 10, 11},
+	}, found, "strings, template literals and comments are not flagged")
+	assert.Empty(t, checker.checkJavaScriptStatements("app.py", []string{"debugger;", "alert(x)"}))
+}
+
+func TestCodeChecker_Configure_DebugStatementPatterns(t *testing.T) {
+	checker := NewCodeChecker()
+
+	err := checker.Configure(models.VibeConfig{
+		Settings: map[string]interface{}{
+			"debug_statement_patterns": map[string]interface{}{
+				"javascript": []interface{}{`\bconsole\.debug\(`},
+				"ruby":       []interface{}{},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, checker.checkDebugStatements("app.js", []string{"console.debug(x);", "debugger;"}), 1)
+	assert.Empty(t, checker.checkDebugStatements("user.rb", []string{"binding.pry"}))
+	assert.Len(t, checker.checkDebugStatements("app.py", []string{"breakpoint()"}), 1, "python keeps its defaults")
+
+	err = checker.Configure(models.VibeConfig{
+		Settings: map[string]interface{}{
+			"debug_statement_patterns": map[string]interface{}{"cobol": []interface{}{"DISPLAY"}},
+		},
+	})
+	assert.Error(t, err)
+}
+
 func TestCodeChecker_Check_LineLength(t *testing.T) {
 	checker := NewCodeChecker()
 	checker.maxLineLength = 50
@@ -188,6 +469,58 @@ func TestCodeChecker_Check_LineLength(t *testing.T) {
 	assert.True(t, hasLineLengthIssue)
 }
 
+func TestCodeChecker_Check_MaxLinesPerFile(t *testing.T) {
+	block := "function f() {\n  var total = a + b;\n  total = total * c;\n  total = total - d;\n  return total;\n}\n"
+	content := strings.Repeat(block, 4)
+	file := filepath.Join(t.TempDir(), "generated.js")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	rules := func(maxLines interface{}) map[string]int {
+		checker := NewCodeChecker()
+		require.NoError(t, checker.Configure(models.VibeConfig{
+			Settings: map[string]interface{}{"max_lines_per_file": maxLines},
+		}))
+		issues, err := checker.Check(context.Background(), []string{file})
+		require.NoError(t, err)
+
+		counts := make(map[string]int)
+		for _, issue := range issues {
+			counts[issue.Rule]++
+		}
+		return counts
+	}
+
+	unlimited := rules(0)
+	assert.Positive(t, unlimited["duplicate-code"])
+	assert.Zero(t, unlimited["code-large-file-skipped"])
+
+	limited := rules(10)
+	assert.Zero(t, limited["duplicate-code"], "multi-line checks are skipped")
+	assert.Equal(t, 1, limited["code-large-file-skipped"])
+	assert.Equal(t, unlimited["no-var"], limited["no-var"], "per-line checks still run")
+
+	checker := NewCodeChecker()
+	assert.Error(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"max_lines_per_file": "big"}}))
+}
+
+func TestCodeChecker_Check_LineLengthCRLF(t *testing.T) {
+	checker := NewCodeChecker()
+	checker.maxLineLength = 20
+
+	// Lines are exactly at the limit; the carriage returns must not count
+	content := "const a = 1234567890;\r\r\nconst b = 1234567890;\r\n"
+	file := filepath.Join(t.TempDir(), "windows.js")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	checker.maxLineLength = len("const a = 1234567890;")
+	issues, err := checker.Check(context.Background(), []string{file})
+	require.NoError(t, err)
+
+	for _, issue := range issues {
+		assert.NotEqual(t, "line-length", issue.Rule, "line %d", issue.Line)
+	}
+}
+
 func TestCodeChecker_Check_TODOComments(t *testing.T) {
 	checker := NewCodeChecker()
 
@@ -219,6 +552,130 @@ func TestCodeChecker_Check_TODOComments(t *testing.T) {
 	}
 }
 
+func TestCodeChecker_TODOAnnotations(t *testing.T) {
+	tests := []struct {
+		line    string
+		message string
+		owner   string
+		ticket  string
+	}{
+		{"// TODO: implement this", "TODO comments should be tracked in issues", "", ""},
+		{"// TODO(alice): cache this", "TODO owned by alice should be tracked in an issue", "alice", ""},
+		{"# FIXME(JIRA-123): flaky", "FIXME is tracked in JIRA-123", "", "JIRA-123"},
+		{"// todo(@bob, #42) retry", "TODO is tracked in #42", "bob", "#42"},
+	}
+
+	for _, test := range tests {
+		issue := checkTodoComment("app.go", test.line, 7)
+		require.NotNil(t, issue, test.line)
+		assert.Equal(t, "todo-comments", issue.Rule)
+		assert.Equal(t, test.message, issue.Message, test.line)
+		assert.Equal(t, test.owner != "", issue.Metadata["owner"] != nil, test.line)
+		if test.owner != "" {
+			assert.Equal(t, test.owner, issue.Metadata["owner"])
+		}
+		if test.ticket != "" {
+			assert.Equal(t, test.ticket, issue.Metadata["ticket"])
+		}
+	}
+
+	assert.Nil(t, checkTodoComment("app.go", "// all done", 1))
+}
+
+func TestParseBlame(t *testing.T) {
+	output := strings.Join([]string{
+		"4f1c2b9e8d7a6f5e4d3c2b1a0f9e8d7c6b5a4f3e 1 1 2",
+		"author Alice",
+		"author-time 1600000000",
+		"author-tz +0000",
+		"filename app.go",
+		"\tpackage app",
+		"4f1c2b9e8d7a6f5e4d3c2b1a0f9e8d7c6b5a4f3e 2 2",
+		"author Alice",
+		"author-time 1600000000",
+		"filename app.go",
+		"\t// TODO: old",
+		"0000000000000000000000000000000000000000 3 3 1",
+		"author Not Committed Yet",
+		"author-time 1700000000",
+		"filename app.go",
+		"\t// TODO: new",
+	}, "\n")
+
+	lines := parseBlame([]byte(output))
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Alice", lines[2].Author)
+	assert.Equal(t, int64(1600000000), lines[2].Time.Unix())
+	_, uncommitted := lines[3]
+	assert.False(t, uncommitted)
+}
+
+func TestCodeChecker_TODOMaxAge(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.go")
+	require.NoError(t, os.WriteFile(file, []byte("package app\n\n// TODO(alice): old\n"), 0644))
+
+	git := func(env []string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	oldDate := []string{"GIT_AUTHOR_DATE=2020-01-01T00:00:00Z", "GIT_COMMITTER_DATE=2020-01-01T00:00:00Z"}
+	git(nil, "init", "--quiet")
+	git(nil, "add", "app.go")
+	git(oldDate, "-c", "user.name=Alice", "-c", "user.email=alice@example.com", "commit", "--quiet", "-m", "init")
+
+	// A TODO added since stays below the limit
+	require.NoError(t, os.WriteFile(file, []byte("package app\n\n// TODO(alice): old\n// TODO: new\n"), 0644))
+
+	checker := NewCodeChecker()
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"todo_max_age": "90d"}}))
+	issues, err := checker.Check(context.Background(), []string{file})
+	require.NoError(t, err)
+
+	var todos []models.Issue
+	for _, issue := range issues {
+		if issue.Rule == "todo-comments" {
+			todos = append(todos, issue)
+		}
+	}
+	require.Len(t, todos, 2)
+
+	assert.Equal(t, models.SeverityWarning, todos[0].Severity)
+	assert.Equal(t, "Alice", todos[0].Metadata["blame_author"])
+	assert.Greater(t, todos[0].Metadata["age_days"], 365)
+	assert.Contains(t, todos[0].Message, "days old, max 90")
+
+	assert.Equal(t, models.SeverityInfo, todos[1].Severity)
+	assert.Nil(t, todos[1].Metadata["age_days"])
+
+	assert.Error(t, NewCodeChecker().Configure(models.VibeConfig{Settings: map[string]interface{}{"todo_max_age": "soon"}}))
+}
+
+func TestParseDuration(t *testing.T) {
+	for input, expected := range map[string]time.Duration{
+		"90d": 90 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+		"0":   0,
+	} {
+		duration, err := parseDuration(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, duration, input)
+	}
+
+	for _, input := range []string{"", "d", "-1d", "soon", "-5h"} {
+		_, err := parseDuration(input)
+		assert.Error(t, err, input)
+	}
+}
+
 func TestCodeChecker_Check_CommentedOutCode(t *testing.T) {
 	checker := NewCodeChecker()
 
@@ -0,0 +1,154 @@
+package vibes
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// ExemptionKey is the issue metadata recording why an issue was exempted.
+// The scanner keeps exempted issues out of the results and lists them apart.
+const ExemptionKey = "exemption"
+
+// Ways a function is exempted from the complexity rules
+const (
+	ExemptedByAnnotation = "annotation"
+	ExemptedByConfig     = "complexity_exemptions"
+)
+
+// ComplexityOKAnnotation marks a function whose complexity is accepted
+const ComplexityOKAnnotation = "kodevibe:complexity-ok"
+
+// Exemption records which function an issue was exempted in, and how
+type Exemption struct {
+	By       string `json:"by"`
+	Function string `json:"function,omitempty"`
+	// Pattern is the complexity_exemptions entry that matched
+	Pattern string `json:"pattern,omitempty"`
+	// Reason is the text following the annotation
+	Reason string `json:"reason,omitempty"`
+}
+
+var (
+	complexityOKPattern = regexp.MustCompile(regexp.QuoteMeta(ComplexityOKAnnotation) + `(?:[\s:]+(.*?))?\s*(?:\*/|-->)?\s*$`)
+	goFuncName          = regexp.MustCompile(`^\s*func\s+(?:\(\s*(?:\w+\s+)?\*?\s*(\w+)[^)]*\)\s*)?(\w+)`)
+	namedFunction       = regexp.MustCompile(`\b(?:function|def|fn)\s+(\w+)`)
+	assignedFunction    = regexp.MustCompile(`(\w+)\s*[:=]\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>|\w+\s*=>)`)
+	calledName          = regexp.MustCompile(`(\w+)\s*\(`)
+	notFunctionNames    = []string{"if", "for", "while", "switch", "catch", "return", "function", "new"}
+)
+
+// configureComplexityExemptions reads complexity_exemptions, globs of the
+// function names (or Type.method for Go methods) the complexity rules skip
+func (cc *CodeChecker) configureComplexityExemptions(settings map[string]interface{}) error {
+	cc.complexityExemptions = nil
+	if _, exists := settings["complexity_exemptions"]; !exists {
+		return nil
+	}
+	patterns, ok := settingStrings(settings, "complexity_exemptions")
+	if !ok {
+		return fmt.Errorf("setting complexity_exemptions must be a list of strings")
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid complexity_exemptions pattern %q: %w", pattern, err)
+		}
+	}
+	cc.complexityExemptions = patterns
+	return nil
+}
+
+// complexityExemption returns why the function declared on lines[start] is
+// exempt from the complexity rules, or nil: a kodevibe:complexity-ok comment
+// on its declaration or in the comments and annotations directly above it,
+// or a complexity_exemptions pattern matching its name
+func (cc *CodeChecker) complexityExemption(lines []string, start int) *Exemption {
+	name := functionName(lines[start])
+
+	for i := start; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if i < start && !isCommentOrAnnotation(trimmed) {
+			break
+		}
+		if match := complexityOKPattern.FindStringSubmatch(trimmed); match != nil {
+			return &Exemption{By: ExemptedByAnnotation, Function: name, Reason: strings.TrimSpace(match[1])}
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+	names := []string{name}
+	if receiver, method, ok := strings.Cut(name, "."); ok {
+		names = []string{method, receiver + "." + method}
+	}
+	for _, pattern := range cc.complexityExemptions {
+		for _, candidate := range names {
+			if matched, _ := path.Match(pattern, candidate); matched {
+				return &Exemption{By: ExemptedByConfig, Function: name, Pattern: pattern}
+			}
+		}
+	}
+	return nil
+}
+
+// exemptComplexity records in an issue's metadata when the function it was
+// found in is exempt from the complexity rules
+func (cc *CodeChecker) exemptComplexity(issue *models.Issue, lines []string, start int) {
+	exemption := cc.complexityExemption(lines, start)
+	if exemption == nil {
+		return
+	}
+	if issue.Metadata == nil {
+		issue.Metadata = make(map[string]interface{})
+	}
+	issue.Metadata[ExemptionKey] = *exemption
+}
+
+// functionName returns the name of the function declared on a line, with
+// the receiver type for Go methods (Type.method), or ""
+func functionName(line string) string {
+	if match := goFuncName.FindStringSubmatch(line); match != nil {
+		if match[1] != "" {
+			return match[1] + "." + match[2]
+		}
+		return match[2]
+	}
+	if match := namedFunction.FindStringSubmatch(line); match != nil {
+		return match[1]
+	}
+	if match := assignedFunction.FindStringSubmatch(line); match != nil {
+		return match[1]
+	}
+	for _, match := range calledName.FindAllStringSubmatch(line, -1) {
+		name := match[1]
+		if !utils.ContainsString(notFunctionNames, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// isCommentOrAnnotation reports whether a trimmed line is a comment, or a
+// Java annotation or Python/TypeScript decorator, that may sit above a function
+func isCommentOrAnnotation(trimmed string) bool {
+	if IsCommentLine(trimmed) {
+		return true
+	}
+	for _, prefix := range []string{"@", "--", "<!--"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExempted reports whether a checker exempted an issue
+func IsExempted(issue models.Issue) bool {
+	_, exempted := issue.Metadata[ExemptionKey]
+	return exempted
+}
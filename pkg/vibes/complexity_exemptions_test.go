@@ -0,0 +1,103 @@
+package vibes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestFunctionName(t *testing.T) {
+	tests := map[string]string{
+		"func parse(input string) error {":            "parse",
+		"func (p *Parser) parse() error {":            "Parser.parse",
+		"func (Parser) parse() error {":               "Parser.parse",
+		"def handle_request(self, request):":          "handle_request",
+		"export async function loadUser(id) {":        "loadUser",
+		"const render = (props) => {":                 "render",
+		"    public void processOrder(Order order) {": "processOrder",
+		"if (ready) {":                                "",
+	}
+	for line, want := range tests {
+		assert.Equal(t, want, functionName(line), line)
+	}
+}
+
+func TestCodeChecker_ComplexityExemptions(t *testing.T) {
+	checker := NewCodeChecker()
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"max_function_length":   5,
+		"complexity_exemptions": []interface{}{"Generated*", "Lexer.next"},
+	}}))
+
+	body := strings.Repeat("\tx++\n", 6)
+	source := fmt.Sprintf(`package app
+
+// parseTable is a state machine that reads best as one function.
+// kodevibe:complexity-ok mirrors the grammar
+func parseTable() {
+%s}
+
+func GeneratedMarshal() {
+%s}
+
+func (l *Lexer) next() {
+%s}
+
+func (p *Parser) next() {
+%s}
+`, body, body, body, body)
+	lines := strings.Split(source, "\n")
+
+	issues := checker.checkFunctionLength("app.go", lines)
+	require.Len(t, issues, 4)
+
+	exemptions := make(map[string]interface{})
+	for _, issue := range issues {
+		exemptions[functionName(lines[issue.Line-1])] = issue.Metadata[ExemptionKey]
+	}
+	assert.Equal(t, Exemption{By: ExemptedByAnnotation, Function: "parseTable", Reason: "mirrors the grammar"}, exemptions["parseTable"])
+	assert.Equal(t, Exemption{By: ExemptedByConfig, Function: "GeneratedMarshal", Pattern: "Generated*"}, exemptions["GeneratedMarshal"])
+	assert.Equal(t, Exemption{By: ExemptedByConfig, Function: "Lexer.next", Pattern: "Lexer.next"}, exemptions["Lexer.next"])
+	assert.Nil(t, exemptions["Parser.next"], "patterns with a type only exempt that type's methods")
+}
+
+func TestCodeChecker_ComplexityExemptionAnnotations(t *testing.T) {
+	checker := NewCodeChecker()
+
+	tests := []struct {
+		name   string
+		source string
+		reason string
+		exempt bool
+	}{
+		{"same line", "def parse(): # kodevibe:complexity-ok", "", true},
+		{"block comment", "/* kodevibe:complexity-ok: legacy */\nfunction parse() {", "legacy", true},
+		{"above decorator", "# kodevibe:complexity-ok\n@cached\ndef parse():", "", true},
+		{"separated by code", "// kodevibe:complexity-ok\nvar x = 1\nfunc parse() {", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(tt.source, "\n")
+			exemption := checker.complexityExemption(lines, len(lines)-1)
+			if !tt.exempt {
+				assert.Nil(t, exemption)
+				return
+			}
+			require.NotNil(t, exemption)
+			assert.Equal(t, ExemptedByAnnotation, exemption.By)
+			assert.Equal(t, "parse", exemption.Function)
+			assert.Equal(t, tt.reason, exemption.Reason)
+		})
+	}
+}
+
+func TestCodeChecker_InvalidComplexityExemptions(t *testing.T) {
+	checker := NewCodeChecker()
+	assert.Error(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"complexity_exemptions": []interface{}{"[parse"}}}))
+	assert.Error(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"complexity_exemptions": 3}}))
+}
@@ -0,0 +1,100 @@
+package vibes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileContents reads each file of a scan once and shares the bytes between
+// every checker that looks at it, and with whatever hashes the file for
+// caching. It is safe for concurrent use and lives as long as one scan.
+type FileContents struct {
+	mu    sync.Mutex
+	files map[string]*fileContent
+}
+
+// fileContent is one file's bytes and hash, read by the first caller
+type fileContent struct {
+	once sync.Once
+	data []byte
+	hash string
+	err  error
+}
+
+type fileContentsKey struct{}
+
+// NewFileContents creates an empty read-once buffer of file contents
+func NewFileContents() *FileContents {
+	return &FileContents{files: make(map[string]*fileContent)}
+}
+
+// WithFileContents returns a context whose checkers read files through contents
+func WithFileContents(ctx context.Context, contents *FileContents) context.Context {
+	return context.WithValue(ctx, fileContentsKey{}, contents)
+}
+
+// FileContentsFromContext returns the buffer carried by ctx, or nil if there is none
+func FileContentsFromContext(ctx context.Context) *FileContents {
+	contents, _ := ctx.Value(fileContentsKey{}).(*FileContents)
+	return contents
+}
+
+// Read returns the file's bytes, reading it on the first call only. Callers
+// must not modify the returned slice.
+func (c *FileContents) Read(path string) ([]byte, error) {
+	content := c.load(path)
+	return content.data, content.err
+}
+
+// Hash returns the hex SHA-256 of the file's bytes, computed from the same
+// read that Read returns
+func (c *FileContents) Hash(path string) (string, error) {
+	content := c.load(path)
+	return content.hash, content.err
+}
+
+// Len returns how many files have been read
+func (c *FileContents) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.files)
+}
+
+func (c *FileContents) load(path string) *fileContent {
+	c.mu.Lock()
+	content, exists := c.files[path]
+	if !exists {
+		content = &fileContent{}
+		c.files[path] = content
+	}
+	c.mu.Unlock()
+
+	// Concurrent callers wait for the one read rather than reading again
+	content.once.Do(func() {
+		content.data, content.err = os.ReadFile(path)
+		if content.err != nil {
+			content.err = fmt.Errorf("failed to read file: %w", content.err)
+			return
+		}
+		sum := sha256.Sum256(content.data)
+		content.hash = hex.EncodeToString(sum[:])
+	})
+	return content
+}
+
+// readFile reads a file through the scan's buffer when ctx carries one, and
+// straight from disk otherwise
+func readFile(ctx context.Context, path string) ([]byte, error) {
+	if contents := FileContentsFromContext(ctx); contents != nil {
+		return contents.Read(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
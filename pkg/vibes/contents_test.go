@@ -0,0 +1,73 @@
+package vibes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileContents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.js")
+	require.NoError(t, os.WriteFile(path, []byte("var a = 1;\n"), 0644))
+
+	contents := NewFileContents()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := contents.Read(path)
+			assert.NoError(t, err)
+			assert.Equal(t, "var a = 1;\n", string(data))
+		}()
+	}
+	wg.Wait()
+
+	// Later reads share the first one's bytes, and the hash is of those bytes
+	require.NoError(t, os.WriteFile(path, []byte("let a = 1;\n"), 0644))
+	data, err := contents.Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, "var a = 1;\n", string(data))
+	sum := sha256.Sum256(data)
+	hash, err := contents.Hash(path)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+	assert.Equal(t, 1, contents.Len())
+
+	_, err = contents.Read(filepath.Join(t.TempDir(), "missing.js"))
+	assert.Error(t, err)
+	_, err = contents.Hash(filepath.Join(t.TempDir(), "missing.js"))
+	assert.Error(t, err)
+}
+
+func TestCheckersShareFileContents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.js")
+	require.NoError(t, os.WriteFile(path, []byte("var count = 1;\n"), 0644))
+	ctx := WithFileContents(context.Background(), NewFileContents())
+
+	_, err := NewCodeChecker().Check(ctx, []string{path})
+	require.NoError(t, err)
+
+	// The security vibe scans the bytes the code vibe read, not the file as it is now
+	require.NoError(t, os.WriteFile(path, []byte(`password = "hunter2hunter2"`+"\n"), 0644))
+	issues, err := NewSecurityChecker().Check(ctx, []string{path})
+	require.NoError(t, err)
+	for _, issue := range issues {
+		assert.NotEqual(t, "hardcoded-credentials", issue.Rule)
+	}
+
+	issues, err = NewSecurityChecker().Check(context.Background(), []string{path})
+	require.NoError(t, err)
+	var rules []string
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	assert.Contains(t, rules, "hardcoded-credentials", "without a buffer the file is read from disk")
+}
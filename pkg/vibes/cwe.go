@@ -0,0 +1,50 @@
+package vibes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// cwePattern matches CWE identifiers such as CWE-89
+var cwePattern = regexp.MustCompile(`^CWE-[1-9][0-9]*$`)
+
+// ruleCWEs maps built-in rules to the weakness they detect
+var ruleCWEs = map[string]string{
+	"sql-injection-risk":     "CWE-89",
+	"xss-risk":               "CWE-79",
+	"command-injection-risk": "CWE-78",
+	"eval-usage":             "CWE-95",
+	"hardcoded-credentials":  "CWE-798",
+	"high-entropy-string":    "CWE-798",
+	"insecure-randomness":    "CWE-338",
+	"log-injection":          "CWE-117",
+	"weak-cipher":            "CWE-327",
+	"insecure-tls-verify":    "CWE-295",
+	"weak-tls-version":       "CWE-326",
+	"unchecked-error":        "CWE-252",
+	"swallowed-error":        "CWE-390",
+	"debug-statement":        "CWE-489",
+	"no-debugger":            "CWE-489",
+	"memory-leak-potential":  "CWE-401",
+}
+
+// RuleCWE returns the CWE a built-in rule detects, or "" if it maps to none.
+// Every secret detection rule is a hard-coded credential.
+func RuleCWE(ruleID string) string {
+	if cwe, exists := ruleCWEs[ruleID]; exists {
+		return cwe
+	}
+	if strings.HasPrefix(ruleID, "secret-detection-") {
+		return "CWE-798"
+	}
+	return ""
+}
+
+// ValidateCWE checks that id is a CWE identifier such as CWE-89
+func ValidateCWE(id string) error {
+	if !cwePattern.MatchString(id) {
+		return fmt.Errorf("%q is not a CWE identifier such as CWE-89", id)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// debugStatementRule lists leftover debugging statements for one language
+type debugStatementRule struct {
+	Extensions []string
+	// ExemptIf, when set, exempts whole files with a matching line (e.g. Go main packages may print)
+	ExemptIf *regexp.Regexp
+	Patterns []*regexp.Regexp
+}
+
+// defaultDebugStatementRules returns the built-in rules keyed by language name.
+// JavaScript and TypeScript have no patterns by default: their debugger
+// statements are reported by the no-debugger rule.
+func defaultDebugStatementRules() map[string]*debugStatementRule {
+	return map[string]*debugStatementRule{
+		"go": {
+			Extensions: []string{".go"},
+			ExemptIf:   regexp.MustCompile(`^package\s+main\b`),
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`\bfmt\.Print(?:ln|f)?\s*\(`),
+				regexp.MustCompile(`(?:^|[^\w.])print(?:ln)?\s*\(`),
+			},
+		},
+		"javascript": {
+			Extensions: []string{".js", ".jsx", ".mjs", ".cjs"},
+		},
+		"typescript": {
+			Extensions: []string{".ts", ".tsx"},
+		},
+		"python": {
+			Extensions: []string{".py"},
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`\bbreakpoint\s*\(\s*\)`),
+				regexp.MustCompile(`\b(?:i?pdb)\.set_trace\s*\(`),
+			},
+		},
+		"ruby": {
+			Extensions: []string{".rb"},
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`\bbinding\.(?:pry|irb)\b`),
+				regexp.MustCompile(`(?:^|[^\w.])byebug\b`),
+			},
+		},
+		"php": {
+			Extensions: []string{".php"},
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`\bvar_dump\s*\(`),
+				regexp.MustCompile(`(?:^|[^\w>$:])dd\s*\(`),
+			},
+		},
+		"java": {
+			Extensions: []string{".java"},
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`\bSystem\.err\.print(?:ln|f)?\s*\(`),
+				regexp.MustCompile(`\.printStackTrace\s*\(\s*\)`),
+			},
+		},
+	}
+}
+
+// configureDebugStatements applies debug_statement_patterns on top of the defaults.
+// Patterns replace a language's built-in set; an empty list turns the rule off for it.
+func (cc *CodeChecker) configureDebugStatements(settings map[string]interface{}) error {
+	cc.debugStatementRules = defaultDebugStatementRules()
+
+	patterns, err := settingStringLists(settings, "debug_statement_patterns")
+	if err != nil {
+		return err
+	}
+	for language, languagePatterns := range patterns {
+		rule, known := cc.debugStatementRules[language]
+		if !known {
+			return fmt.Errorf("unknown language %q in debug_statement_patterns", language)
+		}
+		compiled, err := compilePatterns(languagePatterns)
+		if err != nil {
+			return fmt.Errorf("invalid debug_statement_patterns for %s: %w", language, err)
+		}
+		rule.Patterns = compiled
+	}
+
+	return nil
+}
+
+// debugStatementRuleFor returns the rule that applies to a file, if any
+func (cc *CodeChecker) debugStatementRuleFor(filename string, lines []string) *debugStatementRule {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, rule := range cc.debugStatementRules {
+		if !utils.ContainsString(rule.Extensions, ext) {
+			continue
+		}
+		if rule.ExemptIf != nil && anyLineMatches(rule.ExemptIf, lines) {
+			return nil
+		}
+		return rule
+	}
+	return nil
+}
+
+// checkDebugStatements flags debugger breakpoints and debug output left in a file
+func (cc *CodeChecker) checkDebugStatements(filename string, lines []string) []models.Issue {
+	var issues []models.Issue
+
+	rule := cc.debugStatementRuleFor(filename, lines)
+	if rule == nil {
+		return issues
+	}
+
+	for index, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if IsCommentLine(trimmed) {
+			continue
+		}
+
+		for _, pattern := range rule.Patterns {
+			match := pattern.FindString(line)
+			if match == "" {
+				continue
+			}
+
+			issue := models.Issue{
+				Type:          models.VibeTypeCode,
+				Severity:      models.SeverityWarning,
+				Title:         "Debug statement found",
+				Message:       fmt.Sprintf("'%s' looks like a leftover debugging statement", strings.Trim(match, ";{} \t")),
+				File:          filename,
+				Line:          index + 1,
+				Rule:          "debug-statement",
+				Category:      models.CategoryBestPractices,
+				Context:       utils.TruncateString(line, 100),
+				Fixable:       false,
+				FixSuggestion: "Remove the debugging statement or replace it with proper logging",
+				Confidence:    0.8,
+			}
+			issues = append(issues, issue)
+			break
+		}
+	}
+
+	return issues
+}
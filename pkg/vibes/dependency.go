@@ -19,6 +19,18 @@ func (dc *DependencyChecker) Configure(config models.VibeConfig) error {
 	return nil
 }
 func (dc *DependencyChecker) Supports(filename string) bool { return false } // Would check package.json, go.mod, etc.
+func (dc *DependencyChecker) Rules() []RuleInfo             { return []RuleInfo{} }
+
+// DefaultConfig returns the default dependency checker configuration
+func (dc *DependencyChecker) DefaultConfig() models.VibeConfig {
+	return models.VibeConfig{
+		Enabled: true,
+		Level:   "moderate",
+		Settings: map[string]interface{}{
+			"check_vulnerabilities": true,
+		},
+	}
+}
 
 func (dc *DependencyChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
 	// Would check for outdated dependencies, vulnerabilities, etc.
@@ -19,6 +19,12 @@ func (dc *DocumentationChecker) Configure(config models.VibeConfig) error {
 	return nil
 }
 func (dc *DocumentationChecker) Supports(filename string) bool { return false } // Would check for missing docs
+func (dc *DocumentationChecker) Rules() []RuleInfo             { return []RuleInfo{} }
+
+// DefaultConfig returns the default documentation checker configuration
+func (dc *DocumentationChecker) DefaultConfig() models.VibeConfig {
+	return models.VibeConfig{Enabled: false, Level: "moderate"}
+}
 
 func (dc *DocumentationChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
 	// Would check for missing README, API docs, comments, etc.
@@ -0,0 +1,163 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// Contexts in which a rule can be exempted through rule_exemptions
+const (
+	exemptMain  = "main"
+	exemptInit  = "init"
+	exemptTests = "tests"
+)
+
+// exemptableRules lists the contexts each rule may be exempted in; they are also the defaults
+var exemptableRules = map[string][]string{
+	"no-panic":  {exemptMain, exemptInit, exemptTests},
+	"no-unwrap": {exemptMain, exemptTests},
+}
+
+var (
+	goFuncDecl     = regexp.MustCompile(`^func\s+(?:\([^)]*\)\s*)?(\w+)`)
+	rustFuncDecl   = regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:unsafe\s+)?fn\s+(\w+)`)
+	rustTestAttr   = regexp.MustCompile(`^\s*#\[(?:cfg\(test\)|test|tokio::test|rstest)`)
+	codeStringsRe  = regexp.MustCompile("\"(?:\\\\.|[^\"\\\\])*\"|`[^`]*`|'(?:\\\\.|[^'\\\\])'")
+	lineCommentsRe = regexp.MustCompile(`//.*$`)
+)
+
+// defaultRuleExemptions returns a copy of the built-in exemptions
+func defaultRuleExemptions() map[string][]string {
+	exemptions := make(map[string][]string, len(exemptableRules))
+	for rule, contexts := range exemptableRules {
+		exemptions[rule] = append([]string(nil), contexts...)
+	}
+	return exemptions
+}
+
+// configureRuleExemptions reads rule_exemptions, a map of rule to the contexts
+// (main, init, tests) it is allowed in. An empty list removes all exemptions for a rule.
+func (cc *CodeChecker) configureRuleExemptions(settings map[string]interface{}) error {
+	cc.ruleExemptions = defaultRuleExemptions()
+
+	exemptions, err := settingStringLists(settings, "rule_exemptions")
+	if err != nil {
+		return err
+	}
+	for rule, contexts := range exemptions {
+		allowed, known := exemptableRules[rule]
+		if !known {
+			return fmt.Errorf("rule %q in rule_exemptions cannot be exempted", rule)
+		}
+		for _, context := range contexts {
+			if !utils.ContainsString(allowed, context) {
+				return fmt.Errorf("rule %s cannot be exempted in %q (supported: %s)", rule, context, strings.Join(allowed, ", "))
+			}
+		}
+		cc.ruleExemptions[rule] = contexts
+	}
+
+	return nil
+}
+
+// lineScope is what a line sits in: its top-level function and whether it is test-only code
+type lineScope struct {
+	Function string
+	Test     bool
+}
+
+// scopesFor works out the scope of every line of a Go or Rust file by tracking braces
+func scopesFor(filename string, lines []string) []lineScope {
+	var funcDecl, testAttr *regexp.Regexp
+	testFile := false
+
+	path := filepath.ToSlash(filename)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".go":
+		funcDecl = goFuncDecl
+		testFile = strings.HasSuffix(path, "_test.go")
+	case ".rs":
+		funcDecl = rustFuncDecl
+		testAttr = rustTestAttr
+		testFile = strings.HasPrefix(path, "tests/") || strings.Contains(path, "/tests/")
+	default:
+		return nil
+	}
+
+	scopes := make([]lineScope, len(lines))
+	depth := 0
+	function := ""
+	testDepth := -1 // brace depth the current test item opened at, -1 outside one
+	pendingTest := false
+
+	for i, line := range lines {
+		code := lineCommentsRe.ReplaceAllString(codeStringsRe.ReplaceAllString(line, `""`), "")
+
+		if depth == 0 {
+			if m := funcDecl.FindStringSubmatch(code); m != nil {
+				function = m[1]
+			}
+		}
+		if testAttr != nil && testDepth < 0 && testAttr.MatchString(code) {
+			pendingTest = true
+		}
+		if pendingTest && strings.Contains(code, "{") {
+			testDepth = depth
+			pendingTest = false
+		}
+
+		scopes[i] = lineScope{Function: function, Test: testFile || testDepth >= 0}
+
+		depth += strings.Count(code, "{") - strings.Count(code, "}")
+		if depth < 0 {
+			depth = 0
+		}
+		if testDepth >= 0 && depth <= testDepth {
+			testDepth = -1
+		}
+		if depth == 0 {
+			function = ""
+		}
+	}
+
+	return scopes
+}
+
+// dropExemptIssues removes issues raised in contexts their rule is exempted in
+func (cc *CodeChecker) dropExemptIssues(filename string, lines []string, issues []models.Issue) []models.Issue {
+	var scopes []lineScope
+	scoped := false
+
+	kept := issues[:0]
+	for _, issue := range issues {
+		contexts := cc.ruleExemptions[issue.Rule]
+		if len(contexts) > 0 && issue.Line > 0 && issue.Line <= len(lines) {
+			if !scoped {
+				scopes, scoped = scopesFor(filename, lines), true
+			}
+			if scopes != nil && exempted(scopes[issue.Line-1], contexts) {
+				continue
+			}
+		}
+		kept = append(kept, issue)
+	}
+
+	return kept
+}
+
+func exempted(scope lineScope, contexts []string) bool {
+	for _, context := range contexts {
+		switch {
+		case context == exemptTests && scope.Test,
+			context == exemptMain && scope.Function == "main",
+			context == exemptInit && scope.Function == "init":
+			return true
+		}
+	}
+	return false
+}
@@ -25,6 +25,19 @@ func (fc *FileChecker) Type() models.VibeType                    { return models
 func (fc *FileChecker) Configure(config models.VibeConfig) error { fc.config = config; return nil }
 func (fc *FileChecker) Supports(filename string) bool            { return true }
 
+// DefaultConfig returns the default file checker configuration
+func (fc *FileChecker) DefaultConfig() models.VibeConfig {
+	return models.VibeConfig{Enabled: true, Level: "strict"}
+}
+
+// Rules returns the rules emitted by the file checker
+func (fc *FileChecker) Rules() []RuleInfo {
+	return []RuleInfo{
+		{ID: "system-junk-files", Title: "System junk file", Description: "OS metadata files such as .DS_Store", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "large-file-size", Title: "Large file detected", Description: "Files larger than 10MB", Severity: models.SeverityWarning},
+	}
+}
+
 func (fc *FileChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
 	var issues []models.Issue
 
@@ -36,6 +49,11 @@ func (fc *FileChecker) Check(ctx context.Context, files []string) ([]models.Issu
 	return issues, nil
 }
 
+// CheckFile performs file checks on one file
+func (fc *FileChecker) CheckFile(ctx context.Context, file string) ([]models.Issue, error) {
+	return fc.checkFile(file), nil
+}
+
 func (fc *FileChecker) checkFile(filename string) []models.Issue {
 	var issues []models.Issue
 
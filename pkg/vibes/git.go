@@ -16,6 +16,18 @@ func (gc *GitChecker) Name() string                             { return "GitVib
 func (gc *GitChecker) Type() models.VibeType                    { return models.VibeTypeGit }
 func (gc *GitChecker) Configure(config models.VibeConfig) error { gc.config = config; return nil }
 func (gc *GitChecker) Supports(filename string) bool            { return true }
+func (gc *GitChecker) Rules() []RuleInfo                        { return []RuleInfo{} }
+
+// DefaultConfig returns the default git checker configuration
+func (gc *GitChecker) DefaultConfig() models.VibeConfig {
+	return models.VibeConfig{
+		Enabled: true,
+		Level:   "moderate",
+		Settings: map[string]interface{}{
+			"min_commit_message_length": 10,
+		},
+	}
+}
 
 func (gc *GitChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
 	// Git checks would typically examine commit messages, branch names, etc.
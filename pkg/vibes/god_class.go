@@ -0,0 +1,398 @@
+package vibes
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// Default God class thresholds; a setting of 0 turns its limit off
+const (
+	defaultMaxMethods    = 20
+	defaultMaxFields     = 15
+	defaultMaxClassLines = 500
+	defaultMaxFileLines  = 1000
+)
+
+// classSize is what the god-class rule measures of one class, or of one Go
+// struct type together with the methods declared on it in the same file
+type classSize struct {
+	Name    string
+	Line    int
+	Lines   int
+	Methods int
+	Fields  int
+}
+
+var (
+	braceClassPattern  = regexp.MustCompile(`\b(?:class|interface|enum|record)\s+([A-Za-z_$][\w$]*)`)
+	pythonClassPattern = regexp.MustCompile(`^(\s*)class\s+(\w+)`)
+	pythonMethod       = regexp.MustCompile(`^(?:async\s+)?def\s+\w+`)
+	pythonClassField   = regexp.MustCompile(`^\w+\s*(?::[^=]+)?=(?:[^=]|$)`)
+	// memberAnnotations are the Java annotations and TypeScript decorators before a member
+	memberAnnotations = regexp.MustCompile(`^(?:@[\w.]+(?:\([^)]*\))?\s*)+`)
+	pythonSelfField   = regexp.MustCompile(`\bself\.(\w+)\s*(?::[^=]+)?=[^=]`)
+)
+
+// configureGodClasses reads the God class and file thresholds
+func (cc *CodeChecker) configureGodClasses(settings map[string]interface{}) error {
+	var err error
+	if cc.maxMethods, err = settingInt(settings, "max_methods", defaultMaxMethods); err != nil {
+		return err
+	}
+	if cc.maxFields, err = settingInt(settings, "max_fields", defaultMaxFields); err != nil {
+		return err
+	}
+	if cc.maxClassLines, err = settingInt(settings, "max_class_lines", defaultMaxClassLines); err != nil {
+		return err
+	}
+	if cc.maxFileLines, err = settingInt(settings, "max_file_lines", defaultMaxFileLines); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkGodClasses flags classes with more methods, fields or lines than
+// allowed. Go struct types are measured from the syntax tree, counting the
+// methods declared on them in the same file; Java, JavaScript and TypeScript
+// classes by following their braces; Python classes by their indentation.
+func (cc *CodeChecker) checkGodClasses(filename string, lines []string) []models.Issue {
+	if cc.maxMethods == 0 && cc.maxFields == 0 && cc.maxClassLines == 0 {
+		return nil
+	}
+
+	var classes []classSize
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".go":
+		classes = cc.goClassSizes(filename, lines)
+	case ".java":
+		classes = braceClassSizes(lines, false)
+	case ".js", ".jsx", ".ts", ".tsx":
+		classes = braceClassSizes(lines, true)
+	case ".py":
+		classes = pythonClassSizes(lines)
+	}
+
+	var issues []models.Issue
+	for _, class := range classes {
+		var excess []string
+		if cc.maxMethods > 0 && class.Methods > cc.maxMethods {
+			excess = append(excess, fmt.Sprintf("%d methods (max %d)", class.Methods, cc.maxMethods))
+		}
+		if cc.maxFields > 0 && class.Fields > cc.maxFields {
+			excess = append(excess, fmt.Sprintf("%d fields (max %d)", class.Fields, cc.maxFields))
+		}
+		if cc.maxClassLines > 0 && class.Lines > cc.maxClassLines {
+			excess = append(excess, fmt.Sprintf("%d lines (max %d)", class.Lines, cc.maxClassLines))
+		}
+		if len(excess) == 0 {
+			continue
+		}
+
+		context := ""
+		if class.Line > 0 && class.Line <= len(lines) {
+			context = utils.TruncateString(strings.TrimSpace(lines[class.Line-1]), 100)
+		}
+		issues = append(issues, models.Issue{
+			Type:          models.VibeTypeCode,
+			Severity:      models.SeverityWarning,
+			Title:         "Class too large",
+			Message:       fmt.Sprintf("%s has %s", class.Name, strings.Join(excess, ", ")),
+			File:          filename,
+			Line:          class.Line,
+			Rule:          "god-class",
+			Category:      models.CategoryMaintainability,
+			Context:       context,
+			FixSuggestion: "Split the class by responsibility, moving groups of related fields and methods into their own types",
+			Confidence:    0.8,
+			Metadata: map[string]interface{}{
+				"class":   class.Name,
+				"methods": class.Methods,
+				"fields":  class.Fields,
+				"lines":   class.Lines,
+			},
+		})
+	}
+	return issues
+}
+
+// checkOversizedFile flags a file with more lines than max_file_lines
+func (cc *CodeChecker) checkOversizedFile(filename string, lines []string) []models.Issue {
+	if cc.maxFileLines == 0 || len(lines) <= cc.maxFileLines {
+		return nil
+	}
+	return []models.Issue{{
+		Type:          models.VibeTypeCode,
+		Severity:      models.SeverityWarning,
+		Title:         "File too large",
+		Message:       fmt.Sprintf("File has %d lines, exceeds maximum of %d", len(lines), cc.maxFileLines),
+		File:          filename,
+		Line:          1,
+		Rule:          "oversized-file",
+		Category:      models.CategoryMaintainability,
+		FixSuggestion: "Split the file into smaller files by responsibility",
+		Confidence:    0.9,
+		Metadata:      map[string]interface{}{"lines": len(lines)},
+	}}
+}
+
+// goClassSizes measures the struct types declared in a Go file
+func (cc *CodeChecker) goClassSizes(filename string, lines []string) []classSize {
+	fset, file, err := cc.goASTs.Parse(filename, []byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return nil
+	}
+
+	var classes []*classSize
+	byName := make(map[string]*classSize)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			fields := 0
+			for _, field := range structType.Fields.List {
+				fields += max(len(field.Names), 1)
+			}
+			start, end := fset.Position(typeSpec.Pos()).Line, fset.Position(typeSpec.End()).Line
+			class := &classSize{Name: typeSpec.Name.Name, Line: start, Lines: end - start + 1, Fields: fields}
+			classes = append(classes, class)
+			byName[class.Name] = class
+		}
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		class := byName[receiverTypeName(fn.Recv.List[0].Type)]
+		if class == nil {
+			continue
+		}
+		class.Methods++
+		class.Lines += fset.Position(fn.End()).Line - fset.Position(fn.Pos()).Line + 1
+	}
+
+	sizes := make([]classSize, len(classes))
+	for i, class := range classes {
+		sizes[i] = *class
+	}
+	return sizes
+}
+
+// receiverTypeName returns the type name of a method receiver such as *T or T[K]
+func receiverTypeName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// braceClass is a class whose body is being read by braceClassSizes
+type braceClass struct {
+	size classSize
+	// depth is the brace depth of the class body
+	depth int
+}
+
+// braceClassSizes measures the classes of a brace-delimited language by
+// following its braces, skipping strings and comments. In a class body a
+// member with a parameter list before its body or semicolon is a method and
+// any other member a field. With newlineEnds, as in JavaScript, a line break
+// can end a member.
+func braceClassSizes(lines []string, newlineEnds bool) []classSize {
+	var (
+		sizes      []classSize
+		open       []*braceClass
+		depth      int
+		parens     int
+		member     strings.Builder
+		memberLine int
+		inBlock    bool
+	)
+
+	innermost := func() *braceClass {
+		if len(open) > 0 && open[len(open)-1].depth == depth {
+			return open[len(open)-1]
+		}
+		return nil
+	}
+	endMember := func(opensBody bool) {
+		text := memberAnnotations.ReplaceAllString(strings.TrimSpace(member.String()), "")
+		member.Reset()
+		class := innermost()
+		if class == nil || text == "" {
+			return
+		}
+		// A parameter list before any initializer or type annotation makes a method
+		paren, assign := strings.Index(text, "("), strings.IndexAny(text, "=:")
+		switch {
+		case paren >= 0 && (assign < 0 || paren < assign):
+			class.size.Methods++
+		case opensBody && assign < 0:
+			// static initializers and nested blocks are neither
+		default:
+			class.size.Fields++
+		}
+	}
+
+	for i, line := range lines {
+		for j := 0; j < len(line); j++ {
+			c := line[j]
+			if inBlock {
+				if c == '*' && j+1 < len(line) && line[j+1] == '/' {
+					inBlock = false
+					j++
+				}
+				continue
+			}
+			switch {
+			case c == '/' && j+1 < len(line) && line[j+1] == '/':
+				j = len(line)
+				continue
+			case c == '/' && j+1 < len(line) && line[j+1] == '*':
+				inBlock = true
+				j++
+				continue
+			case c == '"' || c == '\'' || c == '`':
+				member.WriteString(`""`)
+				j = closingQuote(line, j)
+				continue
+			}
+
+			switch c {
+			case '(':
+				parens++
+			case ')':
+				parens = max(parens-1, 0)
+			case '{':
+				text := member.String()
+				if match := braceClassPattern.FindStringSubmatchIndex(text); match != nil && !strings.Contains(text[:match[0]], "(") {
+					member.Reset()
+					open = append(open, &braceClass{size: classSize{Name: text[match[2]:match[3]], Line: memberLine + 1}, depth: depth + 1})
+				} else {
+					endMember(true)
+				}
+				depth++
+				continue
+			case '}':
+				member.Reset()
+				if class := innermost(); class != nil {
+					class.size.Lines = i + 1 - class.size.Line + 1
+					sizes = append(sizes, class.size)
+					open = open[:len(open)-1]
+				}
+				depth = max(depth-1, 0)
+				continue
+			case ';':
+				if parens == 0 {
+					endMember(false)
+					continue
+				}
+			}
+			if strings.TrimSpace(member.String()) == "" && c != ' ' && c != '\t' {
+				memberLine = i
+			}
+			member.WriteByte(c)
+		}
+
+		if newlineEnds && parens == 0 && innermost() != nil {
+			// A line holding only decorators, or ending in an operator, continues
+			text := memberAnnotations.ReplaceAllString(strings.TrimSpace(member.String()), "")
+			if text != "" && !strings.ContainsAny(text[len(text)-1:], "=,(+-*/&|?:.<>@") {
+				endMember(false)
+			}
+		}
+		member.WriteByte('\n')
+	}
+	return sizes
+}
+
+// closingQuote returns the index of the quote closing the string that opens
+// at start, or the end of the line for strings that continue past it
+func closingQuote(line string, start int) int {
+	quote := line[start]
+	for j := start + 1; j < len(line); j++ {
+		switch line[j] {
+		case '\\':
+			j++
+		case quote:
+			return j
+		}
+	}
+	return len(line) - 1
+}
+
+// pythonClassSizes measures Python classes by their indentation. Methods are
+// the defs directly in the class body; fields are the class attributes and
+// the distinct self.x attributes its methods assign.
+func pythonClassSizes(lines []string) []classSize {
+	var sizes []classSize
+	for i, line := range lines {
+		match := pythonClassPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		indent := len(match[1])
+		class := classSize{Name: match[2], Line: i + 1, Lines: 1}
+		bodyIndent := -1
+		fields := make(map[string]bool)
+
+		for j := i + 1; j < len(lines); j++ {
+			trimmed := strings.TrimSpace(lines[j])
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			lineIndent := len(lines[j]) - len(strings.TrimLeft(lines[j], " \t"))
+			if lineIndent <= indent {
+				break
+			}
+			class.Lines = j - i + 1
+			if bodyIndent < 0 {
+				bodyIndent = lineIndent
+			}
+
+			if lineIndent == bodyIndent {
+				switch {
+				case pythonMethod.MatchString(trimmed):
+					class.Methods++
+				case pythonClassField.MatchString(trimmed):
+					fields[strings.TrimSpace(strings.FieldsFunc(trimmed, func(r rune) bool { return r == '=' || r == ':' })[0])] = true
+				}
+			}
+			for _, self := range pythonSelfField.FindAllStringSubmatch(trimmed, -1) {
+				fields[self[1]] = true
+			}
+		}
+		class.Fields = len(fields)
+		sizes = append(sizes, class)
+	}
+	return sizes
+}
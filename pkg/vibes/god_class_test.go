@@ -0,0 +1,137 @@
+package vibes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestBraceClassSizes_Java(t *testing.T) {
+	source := `package app;
+
+/* class Commented { } */
+@Service
+public class UserService extends Base {
+    private final Map<String, User> users = new HashMap<>();
+    private int count;
+    private String name = "class Fake { }";
+
+    static {
+        init();
+    }
+
+    @Override
+    public String toString() {
+        return name;
+    }
+
+    public abstract void save(User user);
+
+    private static class Cache {
+        private Object value;
+
+        Object get() { return value; }
+    }
+
+    public void load(String path) {
+        Runnable r = new Runnable() {
+            public void run() {}
+        };
+    }
+}
+`
+	sizes := braceClassSizes(strings.Split(source, "\n"), false)
+	require.Len(t, sizes, 2)
+
+	cache, service := sizes[0], sizes[1]
+	assert.Equal(t, classSize{Name: "Cache", Line: 21, Lines: 5, Methods: 1, Fields: 1}, cache)
+	assert.Equal(t, "UserService", service.Name)
+	assert.Equal(t, 4, service.Line, "annotations start the class")
+	assert.Equal(t, 29, service.Lines)
+	assert.Equal(t, 3, service.Methods)
+	assert.Equal(t, 3, service.Fields)
+}
+
+func TestBraceClassSizes_TypeScript(t *testing.T) {
+	source := `export class Store {
+  count = 0
+  private readonly name: string
+  onChange: (value: number) => void
+  @Input() label: string
+
+  constructor(name: string) {
+    this.name = name
+  }
+
+  get total() { return this.count }
+
+  increment = () => {
+    this.count++
+  }
+
+  reset(): void {
+    this.count = 0
+  }
+}
+`
+	sizes := braceClassSizes(strings.Split(source, "\n"), true)
+	require.Len(t, sizes, 1)
+	assert.Equal(t, classSize{Name: "Store", Line: 1, Lines: 20, Methods: 3, Fields: 5}, sizes[0])
+}
+
+func TestPythonClassSizes(t *testing.T) {
+	source := `class Report:
+    title = "report"
+    limit: int = 10
+
+    def __init__(self, rows):
+        self.rows = rows
+        self.total = 0
+
+    async def render(self):
+        def helper():
+            pass
+        self.total = len(self.rows)
+        self.cache = {}
+
+def outside():
+    pass
+`
+	sizes := pythonClassSizes(strings.Split(source, "\n"))
+	require.Len(t, sizes, 1)
+	assert.Equal(t, classSize{Name: "Report", Line: 1, Lines: 13, Methods: 2, Fields: 5}, sizes[0])
+}
+
+func TestCodeChecker_GodClass(t *testing.T) {
+	checker := NewCodeChecker()
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"max_methods": 3, "max_fields": 2, "max_class_lines": 0, "max_file_lines": 30,
+	}}))
+
+	var source strings.Builder
+	source.WriteString("package app\n\ntype Server struct {\n\taddr, name string\n\tport int\n}\n\ntype small struct{ a int }\n")
+	for i := 0; i < 4; i++ {
+		fmt.Fprintf(&source, "\nfunc (s *Server) Method%d() {}\n", i)
+	}
+	source.WriteString("\nfunc (s small) Only() {}\n")
+	lines := strings.Split(source.String(), "\n")
+
+	issues := checker.checkGodClasses("server.go", lines)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "god-class", issues[0].Rule)
+	assert.Equal(t, 3, issues[0].Line)
+	assert.Equal(t, "Server has 4 methods (max 3), 3 fields (max 2)", issues[0].Message)
+	assert.Equal(t, 4, issues[0].Metadata["methods"])
+
+	assert.Empty(t, checker.checkOversizedFile("server.go", lines))
+	oversized := checker.checkOversizedFile("server.go", make([]string, 31))
+	require.Len(t, oversized, 1)
+	assert.Equal(t, "oversized-file", oversized[0].Rule)
+
+	assert.Error(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"max_methods": "many"}}))
+}
@@ -0,0 +1,229 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// insecureRandomContextLines is how many preceding lines are searched for an enclosing function
+const insecureRandomContextLines = 5
+
+// defaultSensitiveIdentifiers are name fragments that suggest a random value must be unpredictable
+var defaultSensitiveIdentifiers = []string{
+	"token", "secret", "key", "nonce", "salt", "password", "passwd", "otp", "csrf", "session", "auth", "credential",
+}
+
+// insecureRandomRule describes non-cryptographic random APIs for one language
+type insecureRandomRule struct {
+	Extensions []string
+	// Requires, when set, must match somewhere in the file (e.g. an import) for the calls to count
+	Requires   *regexp.Regexp
+	Calls      []*regexp.Regexp
+	Suggestion string
+}
+
+// defaultInsecureRandomRules returns the built-in rules keyed by language name
+func defaultInsecureRandomRules() map[string]*insecureRandomRule {
+	jsCalls := []*regexp.Regexp{regexp.MustCompile(`\bMath\.random\s*\(`)}
+	jsSuggestion := "Use crypto.randomBytes() or crypto.getRandomValues() for security-sensitive values"
+
+	return map[string]*insecureRandomRule{
+		"go": {
+			Extensions: []string{".go"},
+			Requires:   regexp.MustCompile(`"math/rand(/v2)?"`),
+			Calls: []*regexp.Regexp{
+				regexp.MustCompile(`\brand\.(Int|Intn|Int31|Int31n|Int63|Int63n|IntN|Int32|Int32N|Int64|Int64N|Uint32|Uint64|Float32|Float64|Read|Perm|Shuffle)\s*\(`),
+			},
+			Suggestion: "Use crypto/rand for security-sensitive values",
+		},
+		"javascript": {
+			Extensions: []string{".js", ".jsx", ".mjs", ".cjs"},
+			Calls:      jsCalls,
+			Suggestion: jsSuggestion,
+		},
+		"typescript": {
+			Extensions: []string{".ts", ".tsx"},
+			Calls:      jsCalls,
+			Suggestion: jsSuggestion,
+		},
+		"python": {
+			Extensions: []string{".py"},
+			Calls: []*regexp.Regexp{
+				regexp.MustCompile(`\brandom\.(random|randint|randrange|choice|choices|sample|getrandbits|uniform|shuffle)\s*\(`),
+			},
+			Suggestion: "Use the secrets module (secrets.token_hex, secrets.choice) for security-sensitive values",
+		},
+	}
+}
+
+// configureInsecureRandom applies insecure randomness settings on top of the defaults
+func (sc *SecurityChecker) configureInsecureRandom(settings map[string]interface{}) error {
+	sc.insecureRandomRules = defaultInsecureRandomRules()
+	sc.sensitiveIdentifiers = defaultSensitiveIdentifiers
+
+	if identifiers, exists := settingStrings(settings, "sensitive_identifiers"); exists {
+		sc.sensitiveIdentifiers = identifiers
+	} else if _, present := settings["sensitive_identifiers"]; present {
+		return fmt.Errorf("setting sensitive_identifiers must be a list of strings")
+	}
+
+	calls, err := settingStringLists(settings, "insecure_random_calls")
+	if err != nil {
+		return err
+	}
+	for language, patterns := range calls {
+		rule, known := sc.insecureRandomRules[language]
+		if !known {
+			return fmt.Errorf("unknown language %q in insecure_random_calls", language)
+		}
+		compiled, err := compilePatterns(patterns)
+		if err != nil {
+			return fmt.Errorf("invalid insecure_random_calls for %s: %w", language, err)
+		}
+		rule.Calls = compiled
+	}
+
+	return nil
+}
+
+// insecureRandomRuleFor returns the rule that applies to a file, if any
+func (sc *SecurityChecker) insecureRandomRuleFor(filename string, lines []string) *insecureRandomRule {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, rule := range sc.insecureRandomRules {
+		if !utils.ContainsString(rule.Extensions, ext) {
+			continue
+		}
+		if rule.Requires != nil && !anyLineMatches(rule.Requires, lines) {
+			return nil
+		}
+		return rule
+	}
+	return nil
+}
+
+// checkLineForInsecureRandom flags non-cryptographic randomness used near security-sensitive names
+func (sc *SecurityChecker) checkLineForInsecureRandom(filename string, rule *insecureRandomRule, lines []string, index int) []models.Issue {
+	var issues []models.Issue
+
+	line := lines[index]
+	trimmed := strings.TrimSpace(line)
+	if IsCommentLine(trimmed) {
+		return issues
+	}
+
+	for _, call := range rule.Calls {
+		match := call.FindString(line)
+		if match == "" {
+			continue
+		}
+
+		name := sensitiveIdentifier(line, sc.sensitiveIdentifiers)
+		if name == "" {
+			name = sensitiveIdentifier(enclosingFunction(lines, index), sc.sensitiveIdentifiers)
+		}
+		if name == "" {
+			continue
+		}
+
+		issue := models.Issue{
+			Type:          models.VibeTypeSecurity,
+			Severity:      models.SeverityWarning,
+			Title:         "Insecure randomness",
+			Message:       fmt.Sprintf("'%s' is not cryptographically secure but appears to generate a %s", strings.TrimSpace(match), name),
+			File:          filename,
+			Line:          index + 1,
+			Rule:          "insecure-randomness",
+			Category:      models.CategorySecurity,
+			Context:       utils.TruncateString(line, 100),
+			Fixable:       false,
+			FixSuggestion: rule.Suggestion,
+			Confidence:    0.7,
+			Metadata: map[string]interface{}{
+				"call":       strings.TrimSpace(match),
+				"identifier": name,
+			},
+		}
+		issues = append(issues, issue)
+		break
+	}
+
+	return issues
+}
+
+var (
+	identifierPattern   = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+	functionDeclPattern = regexp.MustCompile(`^\s*(func|def|function|async\s+function|async\s+def)\b`)
+)
+
+// enclosingFunction returns the nearest function declaration shortly above a line,
+// so values returned straight from e.g. newSessionToken() are attributed to it
+func enclosingFunction(lines []string, index int) string {
+	for i := index - 1; i >= 0 && i >= index-insecureRandomContextLines; i-- {
+		if functionDeclPattern.MatchString(lines[i]) {
+			return lines[i]
+		}
+	}
+	return ""
+}
+
+// sensitiveIdentifier returns the first sensitive name found among the
+// identifiers in text. Identifiers are split into camelCase and snake_case
+// words so "apiKey" and "session_id" match while "keyboard" does not.
+func sensitiveIdentifier(text string, names []string) string {
+	for _, identifier := range identifierPattern.FindAllString(text, -1) {
+		for _, word := range identifierWords(identifier) {
+			for _, name := range names {
+				name = strings.ToLower(name)
+				if word == name || word == name+"s" {
+					return name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// identifierWords splits an identifier into lowercase camelCase and snake_case words
+func identifierWords(identifier string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(identifier)
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+
+	return words
+}
+
+func anyLineMatches(pattern *regexp.Regexp, lines []string) bool {
+	for _, line := range lines {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+var (
+	// debuggerStatement matches a debugger statement, not identifiers or
+	// properties that contain the word
+	debuggerStatement = regexp.MustCompile(`(?:^|[^\w$.])debugger\s*(?:[;}]|$)`)
+	// browserDialogCall matches alert(), confirm() and prompt() called as
+	// globals or on window
+	browserDialogCall = regexp.MustCompile(`(?:^|[^\w$.]|\bwindow\.)(alert|confirm|prompt)\s*\(`)
+	// browserDialogDefinition matches functions and methods that merely share a dialog's name
+	browserDialogDefinition = regexp.MustCompile(`(?:\bfunction\s+(?:alert|confirm|prompt)\b|^\s*(?:async\s+)?(?:alert|confirm|prompt)\s*\([^)]*\)\s*\{)`)
+)
+
+// checkJavaScriptStatements flags debugger statements and browser dialogs in
+// JavaScript and TypeScript. Strings, template literals and comments are
+// masked first, across lines, so text that merely mentions them is not flagged.
+func (cc *CodeChecker) checkJavaScriptStatements(filename string, lines []string) []models.Issue {
+	var issues []models.Issue
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx":
+	default:
+		return issues
+	}
+
+	masked := strings.Split(MaskCode(strings.Join(lines, "\n")), "\n")
+	for index, code := range masked {
+		if index >= len(lines) {
+			break
+		}
+		line := lines[index]
+
+		if debuggerStatement.MatchString(code) {
+			issues = append(issues, models.Issue{
+				Type:          models.VibeTypeCode,
+				Severity:      models.SeverityError,
+				Title:         "Debugger statement found",
+				Message:       "debugger statements pause execution wherever developer tools are open, including in production",
+				File:          filename,
+				Line:          index + 1,
+				Rule:          "no-debugger",
+				Category:      models.CategoryBestPractices,
+				Context:       utils.TruncateString(line, 100),
+				Fixable:       true,
+				FixSuggestion: "Remove the debugger statement; use a breakpoint in your developer tools instead",
+				Confidence:    1.0,
+			})
+		}
+
+		if match := browserDialogCall.FindStringSubmatch(code); match != nil && !browserDialogDefinition.MatchString(code) {
+			issues = append(issues, models.Issue{
+				Type:          models.VibeTypeCode,
+				Severity:      models.SeverityWarning,
+				Title:         "Browser dialog found",
+				Message:       fmt.Sprintf("%s() blocks the page and is usually left over from debugging", match[1]),
+				File:          filename,
+				Line:          index + 1,
+				Rule:          "no-browser-dialogs",
+				Category:      models.CategoryBestPractices,
+				Context:       utils.TruncateString(line, 100),
+				Fixable:       true,
+				FixSuggestion: "Remove the dialog, or replace it with an in-page modal or notification",
+				Confidence:    0.9,
+			})
+		}
+	}
+
+	return issues
+}
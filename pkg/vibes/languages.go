@@ -0,0 +1,146 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"kodevibe/internal/utils"
+)
+
+// codeLanguageExtensions are the extensions the code checker analyzes, grouped by language
+var codeLanguageExtensions = map[string][]string{
+	"c":           {".c", ".h"},
+	"cpp":         {".cpp", ".hpp"},
+	"csharp":      {".cs"},
+	"dart":        {".dart"},
+	"go":          {".go"},
+	"groovy":      {".groovy"},
+	"java":        {".java"},
+	"javascript":  {".js", ".jsx"},
+	"kotlin":      {".kt"},
+	"lua":         {".lua"},
+	"matlab":      {".matlab"},
+	"perl":        {".perl"},
+	"php":         {".php"},
+	"python":      {".py"},
+	"r":           {".r"},
+	"ruby":        {".rb"},
+	"rust":        {".rs"},
+	"scala":       {".scala"},
+	"shell":       {".sh", ".bash", ".zsh"},
+	"swift":       {".swift"},
+	"typescript":  {".ts", ".tsx"},
+	"visualbasic": {".vb"},
+}
+
+// languageAliases maps common short names to language names
+var languageAliases = map[string]string{
+	"golang": "go",
+	"js":     "javascript",
+	"ts":     "typescript",
+	"py":     "python",
+	"rb":     "ruby",
+	"rs":     "rust",
+	"kt":     "kotlin",
+	"cs":     "csharp",
+	"c#":     "csharp",
+	"c++":    "cpp",
+	"sh":     "shell",
+	"bash":   "shell",
+	"vb":     "visualbasic",
+}
+
+// Language describes a language KodeVibe can analyze
+type Language struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+	// LanguageRules is true when language-specific rules run on top of the generic code checks
+	LanguageRules bool `json:"language_rules"`
+}
+
+// SupportedLanguages returns every analyzable language sorted by name, merging
+// the code checker's extensions with the multi-language rule sets
+func SupportedLanguages() []Language {
+	languages := make(map[string]*Language)
+	add := func(name string, extensions []string, languageRules bool) {
+		language, exists := languages[name]
+		if !exists {
+			language = &Language{Name: name}
+			languages[name] = language
+		}
+		for _, ext := range extensions {
+			if !utils.ContainsString(language.Extensions, ext) {
+				language.Extensions = append(language.Extensions, ext)
+			}
+		}
+		language.LanguageRules = language.LanguageRules || languageRules
+	}
+
+	for name, extensions := range codeLanguageExtensions {
+		add(name, extensions, false)
+	}
+	multi := NewMultiLanguageChecker()
+	for _, name := range multi.GetSupportedLanguages() {
+		add(name, multi.supportedLanguages[name].Extensions, true)
+	}
+
+	result := make([]Language, 0, len(languages))
+	for _, language := range languages {
+		sort.Strings(language.Extensions)
+		result = append(result, *language)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
+
+// LanguageExtensions resolves language names or aliases (e.g. "go", "ts") to
+// the set of file extensions they cover
+func LanguageExtensions(names []string) (map[string]bool, error) {
+	byName := make(map[string]Language)
+	var available []string
+	for _, language := range SupportedLanguages() {
+		byName[language.Name] = language
+		available = append(available, language.Name)
+	}
+
+	extensions := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if alias, exists := languageAliases[name]; exists {
+			name = alias
+		}
+
+		language, exists := byName[name]
+		if !exists {
+			return nil, fmt.Errorf("unknown language %q (available: %s)", name, strings.Join(available, ", "))
+		}
+		for _, ext := range language.Extensions {
+			extensions[ext] = true
+		}
+	}
+
+	return extensions, nil
+}
+
+// HasExtension reports whether a file's extension is in extensions
+func HasExtension(filename string, extensions map[string]bool) bool {
+	return extensions[strings.ToLower(filepath.Ext(filename))]
+}
+
+// IsCommentLine reports whether a trimmed line starts with a comment: a
+// line comment, the start of a block comment, or a block comment's "*"
+// continuation line
+func IsCommentLine(trimmed string) bool {
+	for _, prefix := range []string{"//", "#", "/*", "*"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
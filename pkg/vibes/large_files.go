@@ -0,0 +1,70 @@
+package vibes
+
+import (
+	"fmt"
+
+	"kodevibe/internal/models"
+)
+
+// defaultMaxLinesPerFile is the line count above which multi-line checks are skipped
+const defaultMaxLinesPerFile = 20000
+
+// largeFileRule is emitted by a vibe when it skipped heavy checks for a file
+func largeFileRule(vibeType models.VibeType) RuleInfo {
+	return RuleInfo{
+		ID:          fmt.Sprintf("%s-large-file-skipped", vibeType),
+		Title:       "Multi-line analysis skipped",
+		Description: "Files longer than max_lines_per_file only get per-line checks",
+		Severity:    models.SeverityInfo,
+	}
+}
+
+// maxLinesPerFileSetting reads max_lines_per_file; 0 disables the limit
+func maxLinesPerFileSetting(settings map[string]interface{}) (int, error) {
+	value, exists := settings["max_lines_per_file"]
+	if !exists {
+		return defaultMaxLinesPerFile, nil
+	}
+
+	var limit int
+	switch v := value.(type) {
+	case int:
+		limit = v
+	case int64:
+		limit = int(v)
+	case float64:
+		limit = int(v)
+	default:
+		return 0, fmt.Errorf("setting max_lines_per_file must be a number")
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("setting max_lines_per_file must not be negative")
+	}
+	return limit, nil
+}
+
+// exceedsMaxLines reports whether multi-line checks should be skipped for a file
+func exceedsMaxLines(lineCount, limit int) bool {
+	return limit > 0 && lineCount > limit
+}
+
+// largeFileIssue notes that multi-line checks were skipped so the gap is visible in reports
+func largeFileIssue(vibeType models.VibeType, filename string, lineCount, limit int) models.Issue {
+	rule := largeFileRule(vibeType)
+	return models.Issue{
+		Type:          vibeType,
+		Severity:      rule.Severity,
+		Title:         rule.Title,
+		Message:       fmt.Sprintf("File has %d lines (max_lines_per_file is %d); only per-line %s checks were run", lineCount, limit, vibeType),
+		File:          filename,
+		Line:          1,
+		Rule:          rule.ID,
+		Category:      models.DefaultCategory(vibeType),
+		FixSuggestion: "Exclude generated files, split the file, or raise max_lines_per_file",
+		Confidence:    1.0,
+		Metadata: map[string]interface{}{
+			"lines":              lineCount,
+			"max_lines_per_file": limit,
+		},
+	}
+}
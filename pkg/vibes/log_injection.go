@@ -0,0 +1,241 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// logInjectionExtensions are the source files searched for log injection
+var logInjectionExtensions = []string{
+	".go", ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".py", ".java", ".kt", ".scala", ".rb", ".php", ".cs",
+}
+
+// logInjectionLiterals are lowercase substrings of which every logger call contains one
+var logInjectionLiterals = []string{"log", "console", "sugar"}
+
+// logCallPattern matches calls of loggers such as log.Printf, logger.info,
+// console.log, LOG.warn and s.logger.Errorf
+var logCallPattern = regexp.MustCompile(`(?i)\b(console|logging|logrus|slog|klog|glog|sugar|\w*log(?:ger)?)\.(printf|println|print|log|infof?|warn(?:ing)?f?|errorf?|debugf?|tracef?|fatalf?|panicf?|critical|exception)\s*\(`)
+
+// defaultLogInjectionSources match expressions holding user-controlled input
+var defaultLogInjectionSources = []string{
+	// net/http, Express, Flask, Django and friends
+	`\b(?:r|req|request)\.(?:URL|Form|PostForm|Header|Body|FormValue|PostFormValue|Cookie|UserAgent|Referer|RequestURI|Host)\b`,
+	`\b(?:req|request)\.(?:body|query|params|headers|cookies|args|form|values|json|data|files|path|url|get_json|GET|POST|COOKIES|META)\b`,
+	// gin and echo handlers
+	`\b(?:c|ctx)\.(?:Query|DefaultQuery|QueryParam|Param|PostForm|FormValue|GetHeader|GetRawData)\s*\(`,
+	`\$_(?:GET|POST|REQUEST|COOKIE|SERVER)\b`,
+	`\bparams\[`,
+	`\.(?:getParameter|getHeader|getQueryString|getRequestURI)\s*\(`,
+	`\b(?:input|raw_input)\s*\(`,
+	`\b(?:os\.Args|sys\.argv|process\.argv)\b`,
+	`\blocation\.(?:search|hash)\b`,
+	`(?i)\buser_?input\b`,
+}
+
+// defaultLogInjectionSanitizers match calls that make input safe to log
+var defaultLogInjectionSanitizers = []string{
+	`(?i)sanitiz|escape|quote\s*\(`,
+	`(?i)\breplace(?:all)?\s*\(`,
+	`\bencodeURIComponent\s*\(`,
+}
+
+var (
+	logStringLiteral = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"|'(?:[^'\\\\]|\\\\.)*'|`[^`]*`")
+	// logStringVerb matches placeholders that format a value as it is; %q,
+	// %r and !r escape line breaks and so are left alone
+	logStringVerb       = regexp.MustCompile(`%[-+# 0-9.]*[sv]|\{\d*\}`)
+	logFormatCall       = regexp.MustCompile(`(?i)(?:sprintf|\.format|format!?)\s*\(|%`)
+	templateInterpolate = regexp.MustCompile(`\$\{([^}]*)\}`)
+	rubyInterpolate     = regexp.MustCompile(`#\{([^}]*)\}`)
+	fStringInterpolate  = regexp.MustCompile(`\{([^{}]+)\}`)
+)
+
+// configureLogInjection applies the log injection settings on top of the defaults
+func (sc *SecurityChecker) configureLogInjection(settings map[string]interface{}) error {
+	var err error
+	if sc.logInjection, err = settingBool(settings, "log_injection", true); err != nil {
+		return err
+	}
+
+	sources := defaultLogInjectionSources
+	if configured, exists := settingStrings(settings, "log_injection_sources"); exists {
+		sources = configured
+	} else if _, present := settings["log_injection_sources"]; present {
+		return fmt.Errorf("setting log_injection_sources must be a list of strings")
+	}
+	if sc.logInjectionSources, err = compilePatterns(sources); err != nil {
+		return fmt.Errorf("invalid log_injection_sources: %w", err)
+	}
+
+	sanitizers := defaultLogInjectionSanitizers
+	if configured, exists := settingStrings(settings, "log_injection_sanitizers"); exists {
+		sanitizers = configured
+	} else if _, present := settings["log_injection_sanitizers"]; present {
+		return fmt.Errorf("setting log_injection_sanitizers must be a list of strings")
+	}
+	if sc.logInjectionSanitizers, err = compilePatterns(sanitizers); err != nil {
+		return fmt.Errorf("invalid log_injection_sanitizers: %w", err)
+	}
+
+	return nil
+}
+
+// checkLineForLogInjection flags user input formatted into a log message,
+// where a line break in it can forge log entries. Constant messages, values
+// passed as structured fields and escaped or sanitized input are left alone.
+func (sc *SecurityChecker) checkLineForLogInjection(filename, line string, lineNumber int) []models.Issue {
+	if !utils.ContainsString(logInjectionExtensions, strings.ToLower(filepath.Ext(filename))) {
+		return nil
+	}
+	trimmed := strings.TrimSpace(line)
+	if IsCommentLine(trimmed) {
+		return nil
+	}
+
+	var issues []models.Issue
+	for _, call := range logCallPattern.FindAllStringSubmatchIndex(line, -1) {
+		receiver := strings.ToLower(line[call[2]:call[3]])
+		method := strings.ToLower(line[call[4]:call[5]])
+		literals, code := splitLogArguments(callArguments(line[call[1]:]))
+
+		source := sc.logInjectionSource(code)
+		if source == "" || anyPatternMatches(sc.logInjectionSanitizers, code) {
+			continue
+		}
+		if !formatsIntoMessage(receiver, method, literals, code) {
+			continue
+		}
+
+		issues = append(issues, models.Issue{
+			Type:          models.VibeTypeSecurity,
+			Severity:      models.SeverityWarning,
+			Title:         "Potential log injection",
+			Message:       fmt.Sprintf("User input '%s' is formatted into a log message; line breaks in it can forge log entries", source),
+			File:          filename,
+			Line:          lineNumber,
+			Column:        call[0] + 1,
+			Rule:          "log-injection",
+			Category:      models.CategorySecurity,
+			Context:       utils.TruncateString(line, 100),
+			Fixable:       false,
+			FixSuggestion: "Strip CR/LF from user input before logging it, or log it as a structured field (e.g. slog key/value pairs) rather than part of the message",
+			Confidence:    0.6,
+			Metadata: map[string]interface{}{
+				"source": source,
+			},
+		})
+	}
+
+	return issues
+}
+
+// logInjectionSource returns the first user-controlled expression in code
+func (sc *SecurityChecker) logInjectionSource(code string) string {
+	for _, source := range sc.logInjectionSources {
+		if match := source.FindString(code); match != "" {
+			return strings.TrimRight(match, "([ ")
+		}
+	}
+	return ""
+}
+
+// formatsIntoMessage reports whether a log call builds its message from its
+// values: by interpolation, concatenation, a format call or placeholder, or
+// by printing its arguments as console.log and Println do
+func formatsIntoMessage(receiver, method string, literals []string, code string) bool {
+	if receiver == "console" || method == "print" || method == "println" {
+		return true
+	}
+	if strings.Contains(code, "+") || logFormatCall.MatchString(code) {
+		return true
+	}
+	for _, literal := range literals {
+		if logStringVerb.MatchString(literal) {
+			return true
+		}
+	}
+	return false
+}
+
+// callArguments returns the arguments of a call up to its closing parenthesis,
+// or the rest of the line when the call continues on the next one
+func callArguments(rest string) string {
+	depth := 0
+	var quote rune
+	escaped := false
+	for i, r := range rest {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if r == '\\' && quote != '`' {
+				escaped = true
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth == 0 {
+				return rest[:i]
+			}
+			depth--
+		}
+	}
+	return rest
+}
+
+// splitLogArguments separates the string literals of log call arguments from
+// the code between them. Interpolated expressions, as in f"{x}", `${x}` and
+// "#{x}", belong to the code.
+func splitLogArguments(args string) ([]string, string) {
+	var literals []string
+	var code strings.Builder
+	last := 0
+	for _, bounds := range logStringLiteral.FindAllStringIndex(args, -1) {
+		start, end := bounds[0], bounds[1]
+		literal := args[start:end]
+		code.WriteString(args[last:start])
+		code.WriteString(" ")
+		last = end
+
+		var interpolations [][]string
+		switch {
+		case literal[0] == '`':
+			interpolations = templateInterpolate.FindAllStringSubmatch(literal, -1)
+		case start > 0 && (args[start-1] == 'f' || args[start-1] == 'F'):
+			interpolations = fStringInterpolate.FindAllStringSubmatch(strings.ReplaceAll(literal, "{{", ""), -1)
+		case literal[0] == '"':
+			interpolations = rubyInterpolate.FindAllStringSubmatch(literal, -1)
+		}
+		for _, interpolation := range interpolations {
+			code.WriteString(interpolation[1])
+			code.WriteString(" ")
+		}
+		if len(interpolations) == 0 {
+			literals = append(literals, literal)
+		} else {
+			// An interpolated string formats its values into the message
+			code.WriteString("+ ")
+		}
+	}
+	code.WriteString(args[last:])
+	return literals, code.String()
+}
+
+func anyPatternMatches(patterns []*regexp.Regexp, text string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
 )
 
 // MultiLanguageChecker provides enhanced language-specific analysis
@@ -118,7 +120,7 @@ func (m *MultiLanguageChecker) detectLanguage(filePath string) *LanguageConfig {
 // checkSecurityRules applies security rules for the detected language
 func (m *MultiLanguageChecker) checkSecurityRules(content, filePath string, lang *LanguageConfig) []models.Issue {
 	var issues []models.Issue
-	lines := strings.Split(content, "\n")
+	lines := utils.SplitLines(content)
 
 	for _, rule := range lang.SecurityRules {
 		for lineNum, line := range lines {
@@ -142,7 +144,7 @@ func (m *MultiLanguageChecker) checkSecurityRules(content, filePath string, lang
 // checkQualityRules applies code quality rules
 func (m *MultiLanguageChecker) checkQualityRules(content, filePath string, lang *LanguageConfig) []models.Issue {
 	var issues []models.Issue
-	lines := strings.Split(content, "\n")
+	lines := utils.SplitLines(content)
 
 	for _, rule := range lang.QualityRules {
 		for lineNum, line := range lines {
@@ -172,7 +174,7 @@ func (m *MultiLanguageChecker) checkComplexity(content, filePath string, lang *L
 
 	for scanner.Scan() {
 		lineNum++
-		line := scanner.Text()
+		line := utils.TrimLineEnding(scanner.Text())
 
 		for _, rule := range lang.ComplexityRules {
 			if rule.Pattern.MatchString(line) {
@@ -578,11 +580,12 @@ func (m *MultiLanguageChecker) createRubyConfig() *LanguageConfig {
 	}
 }
 
-// GetSupportedLanguages returns a list of supported programming languages
+// GetSupportedLanguages returns the supported programming languages sorted by name
 func (m *MultiLanguageChecker) GetSupportedLanguages() []string {
 	var languages []string
 	for name := range m.supportedLanguages {
 		languages = append(languages, name)
 	}
+	sort.Strings(languages)
 	return languages
 }
@@ -0,0 +1,304 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// defaultNestedLoopMethods are the array methods that count as loops for the
+// nested-loops rule
+var defaultNestedLoopMethods = []string{"forEach", "map", "filter", "flatMap", "reduce"}
+
+var (
+	// braceLoopExtensions scope loop bodies by braces and parentheses
+	braceLoopExtensions = []string{".js", ".jsx", ".ts", ".tsx", ".go", ".java", ".c", ".cpp", ".cs", ".php"}
+	// indentLoopExtensions scope loop bodies by indentation
+	indentLoopExtensions = []string{".py", ".rb"}
+
+	// loopKeywordPattern matches loop statements in brace languages
+	loopKeywordPattern = regexp.MustCompile(`\b(?:for(?:\s+await)?|foreach|while)\b`)
+
+	// indentLoopPatterns match the first line of a loop, by extension
+	indentLoopPatterns = map[string]*regexp.Regexp{
+		".py": regexp.MustCompile(`^\s*(?:async\s+)?(?:for|while)\b[^#]*:\s*(?:#.*)?$`),
+		".rb": regexp.MustCompile(`^\s*(?:for|while|until)\b|\.(?:each\w*|map|flat_map|select|reject|times|upto|downto|step)\b[^#]*\bdo\b(?:\s*\|[^|]*\|)?\s*$`),
+	}
+)
+
+// loopScope is a loop and the extent of its body in the scanned source
+type loopScope struct {
+	start     int // where the loop keyword or method call starts
+	bodyStart int
+	bodyEnd   int
+}
+
+// configureNestedLoops reads nested_loop_methods, the array methods whose
+// callbacks count as loop bodies; an empty list leaves only loop statements
+func (pc *PerformanceChecker) configureNestedLoops(settings map[string]interface{}) error {
+	methods := defaultNestedLoopMethods
+	if _, exists := settings["nested_loop_methods"]; exists {
+		configured, ok := settingStrings(settings, "nested_loop_methods")
+		if !ok {
+			return fmt.Errorf("setting nested_loop_methods must be a list of strings")
+		}
+		methods = configured
+	}
+
+	pc.loopMethodPattern = nil
+	if len(methods) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(methods))
+	for i, method := range methods {
+		quoted[i] = regexp.QuoteMeta(method)
+	}
+	pc.loopMethodPattern = regexp.MustCompile(`\.(?:` + strings.Join(quoted, "|") + `)\s*\(`)
+	return nil
+}
+
+// checkNestedLoops detects loops whose body contains another loop, which can
+// cause O(n²) complexity. Bodies are scoped by braces and parentheses, or by
+// indentation in Python and Ruby, so chained calls such as
+// items.map(f).filter(g) run one after the other and are not nested.
+func (pc *PerformanceChecker) checkNestedLoops(filename string, lines []string) []models.Issue {
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	var (
+		loops []loopScope
+		// lineOf maps a loop's start to its 1-based line
+		lineOf func(offset int) int
+	)
+	switch {
+	case utils.ContainsString(braceLoopExtensions, ext):
+		content := strings.Join(lines, "\n")
+		loops = braceLoops(MaskCode(content), ext == ".go", pc.loopMethodPattern)
+		lineOf = func(offset int) int { return strings.Count(content[:offset], "\n") + 1 }
+	case utils.ContainsString(indentLoopExtensions, ext):
+		loops = indentLoops(lines, indentLoopPatterns[ext])
+		lineOf = func(offset int) int { return offset + 1 }
+	default:
+		return nil
+	}
+
+	sort.Slice(loops, func(i, j int) bool { return loops[i].start < loops[j].start })
+	depths := loopDepths(loops)
+
+	var issues []models.Issue
+	for i, loop := range loops {
+		if depths[i] < 2 || insideAnyLoop(loops, loop.start) {
+			continue
+		}
+		complexity := "O(n²)"
+		if depths[i] > 2 {
+			complexity = fmt.Sprintf("O(n^%d)", depths[i])
+		}
+		lineNumber := lineOf(loop.start)
+		issues = append(issues, models.Issue{
+			Type:          models.VibeTypePerformance,
+			Severity:      models.SeverityWarning,
+			Title:         "Nested loops detected",
+			Message:       fmt.Sprintf("Nested loops (%d levels deep) can cause %s complexity", depths[i], complexity),
+			File:          filename,
+			Line:          lineNumber,
+			Rule:          "nested-loops",
+			Context:       utils.TruncateString(lines[lineNumber-1], 100),
+			Fixable:       true,
+			FixSuggestion: "Consider algorithm optimization or caching",
+			Confidence:    0.8,
+		})
+	}
+	return issues
+}
+
+// braceLoops finds the loop statements and loop method calls of masked source
+func braceLoops(src string, goSyntax bool, methodPattern *regexp.Regexp) []loopScope {
+	var loops []loopScope
+	for _, match := range loopKeywordPattern.FindAllStringIndex(src, -1) {
+		if loop, ok := keywordLoop(src, match, goSyntax); ok {
+			loops = append(loops, loop)
+		}
+	}
+	if methodPattern != nil {
+		for _, match := range methodPattern.FindAllStringIndex(src, -1) {
+			// The body is the call's arguments, e.g. the callback
+			open := match[1] - 1
+			if end := matchingClose(src, open); end > 0 {
+				loops = append(loops, loopScope{start: match[0], bodyStart: open, bodyEnd: end})
+			}
+		}
+	}
+	return loops
+}
+
+// keywordLoop scopes the body of a for, foreach or while statement
+func keywordLoop(src string, match []int, goSyntax bool) (loopScope, bool) {
+	loop := loopScope{start: match[0]}
+	next := skipSpace(src, match[1])
+
+	if goSyntax {
+		// for i := 0; i < n; i++ { — the body opens at the end of the line
+		end := strings.IndexByte(src[next:], '\n')
+		if end < 0 {
+			end = len(src) - next
+		}
+		header := strings.TrimRight(src[next:next+end], " \t\r")
+		if !strings.HasSuffix(header, "{") {
+			return loop, false
+		}
+		loop.bodyStart = next + len(header) - 1
+		loop.bodyEnd = matchingClose(src, loop.bodyStart)
+		return loop, loop.bodyEnd > 0
+	}
+
+	if next >= len(src) || src[next] != '(' {
+		// Not a loop, e.g. the for attribute of an HTML label in JSX
+		return loop, false
+	}
+	headerEnd := matchingClose(src, next)
+	if headerEnd < 0 {
+		return loop, false
+	}
+	body := skipSpace(src, headerEnd+1)
+	switch {
+	case body < len(src) && src[body] == '{':
+		loop.bodyStart = body
+		loop.bodyEnd = matchingClose(src, body)
+		return loop, loop.bodyEnd > 0
+	default:
+		// A single statement, or none for the while of a do-while
+		end := strings.IndexAny(src[body:], ";\n")
+		if end < 0 {
+			end = len(src) - body
+		}
+		loop.bodyStart, loop.bodyEnd = body, body+end
+		return loop, true
+	}
+}
+
+// indentLoops finds loops whose body is the following, more indented lines
+func indentLoops(lines []string, pattern *regexp.Regexp) []loopScope {
+	var loops []loopScope
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") || !pattern.MatchString(line) {
+			continue
+		}
+		indent := indentation(line)
+		end := i + 1
+		for j := i + 1; j < len(lines); j++ {
+			trimmed := strings.TrimSpace(lines[j])
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			if indentation(lines[j]) <= indent {
+				break
+			}
+			end = j + 1
+		}
+		// Offsets are line indexes: the body is the lines after the loop
+		loops = append(loops, loopScope{start: i, bodyStart: i, bodyEnd: end})
+	}
+	return loops
+}
+
+// loopDepths returns how many loops deep each loop goes, counting itself;
+// loops is sorted by start
+func loopDepths(loops []loopScope) []int {
+	depths := make([]int, len(loops))
+	for i := len(loops) - 1; i >= 0; i-- {
+		depths[i] = 1
+		for j := i + 1; j < len(loops) && loops[j].start < loops[i].bodyEnd; j++ {
+			if loops[j].start > loops[i].bodyStart && depths[j]+1 > depths[i] {
+				depths[i] = depths[j] + 1
+			}
+		}
+	}
+	return depths
+}
+
+// insideAnyLoop reports whether offset lies in the body of one of the loops
+func insideAnyLoop(loops []loopScope, offset int) bool {
+	for _, loop := range loops {
+		if offset > loop.bodyStart && offset < loop.bodyEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// matchingClose returns the index of the bracket closing the one at open,
+// or -1 if it is never closed
+func matchingClose(src string, open int) int {
+	closer := map[byte]byte{'(': ')', '{': '}', '[': ']'}[src[open]]
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case src[open]:
+			depth++
+		case closer:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func skipSpace(src string, i int) int {
+	for i < len(src) && strings.IndexByte(" \t\r\n", src[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// MaskCode blanks out the contents of strings and comments, keeping newlines
+// and offsets, so brackets inside them are not mistaken for code
+func MaskCode(src string) string {
+	masked := []byte(src)
+	for i := 0; i < len(masked); i++ {
+		switch {
+		case strings.HasPrefix(src[i:], "//"):
+			for ; i < len(masked) && masked[i] != '\n'; i++ {
+				masked[i] = ' '
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			stop := len(masked)
+			if end >= 0 {
+				stop = i + 2 + end + 2
+			}
+			for ; i < stop; i++ {
+				if masked[i] != '\n' {
+					masked[i] = ' '
+				}
+			}
+			i--
+		case src[i] == '"' || src[i] == '\'' || src[i] == '`':
+			quote := src[i]
+			for i++; i < len(masked) && src[i] != quote; i++ {
+				if src[i] == '\\' && i+1 < len(masked) {
+					masked[i] = ' '
+					i++
+				}
+				if src[i] == '\n' && quote != '`' {
+					// An unterminated string ends with its line
+					break
+				}
+				if masked[i] != '\n' {
+					masked[i] = ' '
+				}
+			}
+		}
+	}
+	return string(masked)
+}
@@ -0,0 +1,116 @@
+package vibes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestPerformanceChecker_checkNestedLoops(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		source   string
+		lines    []int
+	}{
+		{
+			name:     "chained array methods are not nested",
+			filename: "app.ts",
+			source: `const names = users
+  .filter(u => u.active)
+  .map(u => u.name)
+  .filter(Boolean);
+const ids = items.map(i => i.id).filter(id => id > 0);
+for (const id of ids) {
+  console.log("for (;;) { nested }");
+}
+while (queue.length) process(queue.shift());`,
+		},
+		{
+			name:     "nested callbacks and loop bodies",
+			filename: "app.js",
+			source: `const grid = rows.map(row =>
+  row.map(cell => cell * 2)
+);
+for (let i = 0; i < n; i++) {
+  // for (const x of y) in a comment
+  for (let j = 0; j < n; j++) {
+    total += i * j;
+  }
+}
+do { step(); } while (pending());`,
+			lines: []int{1, 4},
+		},
+		{
+			name:     "go for loops",
+			filename: "main.go",
+			source: `package main
+
+func pairs(xs []int) {
+	for _, a := range xs {
+		for _, b := range xs {
+			use(a, b)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		use(i, i)
+	}
+}`,
+			lines: []int{4},
+		},
+		{
+			name:     "python indentation",
+			filename: "app.py",
+			source: `for row in rows:
+    total += sum(row)
+for row in rows:
+    # for in a comment
+    for cell in row:
+        print(cell)
+while True:
+    break`,
+			lines: []int{3},
+		},
+	}
+
+	checker := NewPerformanceChecker()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issues := checker.checkNestedLoops(test.filename, strings.Split(test.source, "\n"))
+			var lines []int
+			for _, issue := range issues {
+				lines = append(lines, issue.Line)
+			}
+			assert.Equal(t, test.lines, lines)
+		})
+	}
+}
+
+func TestPerformanceChecker_checkNestedLoopsDepth(t *testing.T) {
+	checker := NewPerformanceChecker()
+	source := `for (const a of as) {
+  for (const b of bs) {
+    cs.forEach(c => use(a, b, c));
+  }
+}`
+	issues := checker.checkNestedLoops("app.js", strings.Split(source, "\n"))
+	require.Len(t, issues, 1, "only the outermost loop is reported")
+	assert.Equal(t, "Nested loops (3 levels deep) can cause O(n^3) complexity", issues[0].Message)
+}
+
+func TestPerformanceChecker_nestedLoopMethods(t *testing.T) {
+	checker := NewPerformanceChecker()
+	source := []string{"const grid = rows.map(row => row.map(cell => cell * 2));"}
+	require.Len(t, checker.checkNestedLoops("app.js", source), 1)
+
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"nested_loop_methods": []interface{}{},
+	}}))
+	assert.Empty(t, checker.checkNestedLoops("app.js", source), "only loop statements count")
+
+	assert.Error(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{"nested_loop_methods": "map"}}))
+}
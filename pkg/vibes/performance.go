@@ -2,6 +2,7 @@ package vibes
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -18,6 +19,9 @@ type PerformanceChecker struct {
 	config           models.VibeConfig
 	maxBundleSize    int64
 	performanceRules map[string]*PerformanceRules
+	maxLinesPerFile  int
+	// loopMethodPattern matches calls of the array methods that count as loops
+	loopMethodPattern *regexp.Regexp
 }
 
 // PerformanceRules contains language-specific performance rules
@@ -44,9 +48,11 @@ func NewPerformanceChecker() *PerformanceChecker {
 	checker := &PerformanceChecker{
 		maxBundleSize:    2 * 1024 * 1024, // 2MB
 		performanceRules: make(map[string]*PerformanceRules),
+		maxLinesPerFile:  defaultMaxLinesPerFile,
 	}
 
 	checker.initializePerformanceRules()
+	_ = checker.configureNestedLoops(nil)
 	return checker
 }
 
@@ -73,7 +79,13 @@ func (pc *PerformanceChecker) Configure(config models.VibeConfig) error {
 		}
 	}
 
-	return nil
+	maxLines, err := maxLinesPerFileSetting(config.Settings)
+	if err != nil {
+		return err
+	}
+	pc.maxLinesPerFile = maxLines
+
+	return pc.configureNestedLoops(config.Settings)
 }
 
 // Supports returns true if the checker supports the given file
@@ -93,6 +105,37 @@ func (pc *PerformanceChecker) Supports(filename string) bool {
 	return false
 }
 
+// DefaultConfig returns the default performance checker configuration
+func (pc *PerformanceChecker) DefaultConfig() models.VibeConfig {
+	return models.VibeConfig{
+		Enabled: true,
+		Level:   "moderate",
+		Settings: map[string]interface{}{
+			"max_bundle_size":    "2MB",
+			"max_lines_per_file": defaultMaxLinesPerFile,
+		},
+	}
+}
+
+// Rules returns the rules emitted by the performance checker
+func (pc *PerformanceChecker) Rules() []RuleInfo {
+	return []RuleInfo{
+		{ID: "large-bundle-size", Title: "Large bundle file", Description: "Bundles larger than max_bundle_size", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "sync-file-operations", Title: "Synchronous file operation", Description: "Blocking file system calls", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "inefficient-array-ops", Title: "Inefficient array operation", Description: "Array operations with a cheaper alternative", Severity: models.SeverityInfo, Fixable: true},
+		{ID: "dom-query-performance", Title: "DOM query detected", Description: "Repeated DOM queries that could be cached", Severity: models.SeverityInfo, Fixable: true},
+		{ID: "memory-leak-potential", Title: "Potential memory leak", Description: "Listeners and timers that are never released", Severity: models.SeverityWarning},
+		{ID: "string-concat-performance", Title: "Inefficient string concatenation", Description: "String concatenation in loops", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "global-variable-performance", Title: "Global variable access", Description: "Global variable use in Python", Severity: models.SeverityInfo, Fixable: true},
+		{ID: "defer-in-loop", Title: "Defer in potential loop", Description: "defer statements inside Go loops", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "select-star-performance", Title: "SELECT * query", Description: "Queries that select every column", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "delete-without-where", Title: "DELETE without WHERE", Description: "DELETE statements without a WHERE clause", Severity: models.SeverityError},
+		{ID: "nested-loops", Title: "Nested loops detected", Description: "Loops nested inside other loops", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "n-plus-one-query", Title: "Potential N+1 query", Description: "Queries issued inside loops", Severity: models.SeverityError, Fixable: true},
+		largeFileRule(models.VibeTypePerformance),
+	}
+}
+
 // Check performs performance checks on the provided files
 func (pc *PerformanceChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
 	var issues []models.Issue
@@ -102,7 +145,7 @@ func (pc *PerformanceChecker) Check(ctx context.Context, files []string) ([]mode
 			continue
 		}
 
-		fileIssues, err := pc.checkFile(file)
+		fileIssues, err := pc.checkFile(ctx, file)
 		if err != nil {
 			continue
 		}
@@ -119,8 +162,16 @@ func (pc *PerformanceChecker) Check(ctx context.Context, files []string) ([]mode
 	return issues, nil
 }
 
+// CheckFile performs performance checks on one file
+func (pc *PerformanceChecker) CheckFile(ctx context.Context, file string) ([]models.Issue, error) {
+	if !pc.Supports(file) {
+		return nil, nil
+	}
+	return pc.checkFile(ctx, file)
+}
+
 // checkFile performs performance checks on a single file
-func (pc *PerformanceChecker) checkFile(filename string) ([]models.Issue, error) {
+func (pc *PerformanceChecker) checkFile(ctx context.Context, filename string) ([]models.Issue, error) {
 	var issues []models.Issue
 
 	// Check file size first
@@ -145,19 +196,18 @@ func (pc *PerformanceChecker) checkFile(filename string) ([]models.Issue, error)
 		}
 	}
 
-	file, err := os.Open(filename)
+	content, err := readFile(ctx, filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
 	lines := []string{}
 	lineNumber := 0
 
 	for scanner.Scan() {
 		lineNumber++
-		line := scanner.Text()
+		line := utils.TrimLineEnding(scanner.Text())
 		lines = append(lines, line)
 
 		lineIssues := pc.checkLine(filename, line, lineNumber)
@@ -168,6 +218,10 @@ func (pc *PerformanceChecker) checkFile(filename string) ([]models.Issue, error)
 		return issues, fmt.Errorf("error reading file: %w", err)
 	}
 
+	if exceedsMaxLines(len(lines), pc.maxLinesPerFile) {
+		return append(issues, largeFileIssue(models.VibeTypePerformance, filename, len(lines), pc.maxLinesPerFile)), nil
+	}
+
 	multiLineIssues := pc.checkMultiLine(filename, lines)
 	issues = append(issues, multiLineIssues...)
 
@@ -433,51 +487,6 @@ func (pc *PerformanceChecker) checkSQLLine(filename, line string, lineNumber int
 	return issues
 }
 
-// checkNestedLoops detects nested loops that could cause O(n²) complexity
-func (pc *PerformanceChecker) checkNestedLoops(filename string, lines []string) []models.Issue {
-	var issues []models.Issue
-
-	loopPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`\bfor\b`),
-		regexp.MustCompile(`\bwhile\b`),
-		regexp.MustCompile(`\.forEach\b`),
-		regexp.MustCompile(`\.map\b`),
-		regexp.MustCompile(`\.filter\b`),
-	}
-
-	for i, line := range lines {
-		for _, pattern := range loopPatterns {
-			if pattern.MatchString(line) {
-				// Check for nested loops in the next few lines
-				for j := i + 1; j < len(lines) && j < i+10; j++ {
-					for _, nestedPattern := range loopPatterns {
-						if nestedPattern.MatchString(lines[j]) {
-							issue := models.Issue{
-								Type:          models.VibeTypePerformance,
-								Severity:      models.SeverityWarning,
-								Title:         "Nested loops detected",
-								Message:       "Nested loops can cause O(n²) complexity",
-								File:          filename,
-								Line:          i + 1,
-								Rule:          "nested-loops",
-								Context:       utils.TruncateString(line, 100),
-								Fixable:       true,
-								FixSuggestion: "Consider algorithm optimization or caching",
-								Confidence:    0.8,
-							}
-							issues = append(issues, issue)
-							goto nextLine
-						}
-					}
-				}
-			nextLine:
-			}
-		}
-	}
-
-	return issues
-}
-
 // checkN1Queries detects potential N+1 query patterns
 func (pc *PerformanceChecker) checkN1Queries(filename string, lines []string) []models.Issue {
 	var issues []models.Issue
@@ -0,0 +1,168 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+var (
+	// stringLiteralPattern matches double-quoted, single-quoted and backtick string literals
+	stringLiteralPattern = regexp.MustCompile("\"(?:\\\\.|[^\"\\\\])*\"|'(?:\\\\.|[^'\\\\])*'|`[^`]*`")
+
+	// unixTempPathPattern matches /tmp and /var/tmp paths
+	unixTempPathPattern = regexp.MustCompile(`^(?:/var)?/tmp(?:/|$)`)
+	// windowsPathPattern matches drive-letter paths such as C:\ or C:/ with single or escaped backslashes
+	windowsPathPattern = regexp.MustCompile(`^[A-Za-z]:(?:\\\\|\\|/)`)
+	// windowsTempPathPattern matches temp directories on a drive, e.g. C:\Temp or C:\Windows\Temp
+	windowsTempPathPattern = regexp.MustCompile(`(?i)^[a-z]:(?:\\\\|\\|/)(?:windows(?:\\\\|\\|/))?te?mp(?:\\|/|$)`)
+	// backslashPathPattern matches relative paths joined with escaped backslashes, e.g. config\\app.json
+	backslashPathPattern = regexp.MustCompile(`^[\w.-]+(?:\\\\[\w.-]+)+$`)
+	// separatorConcatPattern matches "/" or '/' concatenated onto a path with +
+	separatorConcatPattern = regexp.MustCompile(`\+\s*["']/["']|["']/["']\s*\+`)
+)
+
+// nonPortablePathAPIs are the portable temp-directory and path-joining APIs suggested per language
+var nonPortablePathAPIs = map[string]struct{ temp, join string }{
+	"go":         {"os.TempDir() or os.MkdirTemp", "filepath.Join"},
+	"python":     {"tempfile.gettempdir() or tempfile.mkstemp()", "os.path.join or pathlib.Path"},
+	"javascript": {"os.tmpdir() or fs.mkdtemp()", "path.join"},
+	"typescript": {"os.tmpdir() or fs.mkdtemp()", "path.join"},
+	"java":       {`Files.createTempFile() or System.getProperty("java.io.tmpdir")`, "Path.of or Path.resolve"},
+	"kotlin":     {`Files.createTempFile() or System.getProperty("java.io.tmpdir")`, "Path.of or Path.resolve"},
+	"scala":      {`Files.createTempFile() or System.getProperty("java.io.tmpdir")`, "Path.of or Path.resolve"},
+	"groovy":     {`Files.createTempFile() or System.getProperty("java.io.tmpdir")`, "Path.of or Path.resolve"},
+	"csharp":     {"Path.GetTempPath()", "Path.Combine"},
+	"ruby":       {"Dir.tmpdir or Dir.mktmpdir", "File.join"},
+	"php":        {"sys_get_temp_dir()", "DIRECTORY_SEPARATOR"},
+	"rust":       {"std::env::temp_dir()", "Path::join"},
+}
+
+// nonPortablePathLanguages are the languages the non-portable-path rule checks by default
+func nonPortablePathLanguages() []string {
+	return []string{"go", "python", "javascript", "typescript", "java", "kotlin", "scala", "groovy",
+		"csharp", "ruby", "php", "rust", "swift", "dart", "c", "cpp"}
+}
+
+// configureNonPortablePaths reads non_portable_path_languages, which limits
+// the rule to some languages, and non_portable_path_allowed, path prefixes
+// that are never reported
+func (cc *CodeChecker) configureNonPortablePaths(settings map[string]interface{}) error {
+	languages := nonPortablePathLanguages()
+	if _, exists := settings["non_portable_path_languages"]; exists {
+		configured, ok := settingStrings(settings, "non_portable_path_languages")
+		if !ok {
+			return fmt.Errorf("setting non_portable_path_languages must be a list of strings")
+		}
+		languages = configured
+	}
+
+	extensions, err := LanguageExtensions(languages)
+	if err != nil {
+		return fmt.Errorf("invalid non_portable_path_languages: %w", err)
+	}
+	cc.nonPortablePathExtensions = extensions
+
+	cc.nonPortablePathAllowed = nil
+	if _, exists := settings["non_portable_path_allowed"]; exists {
+		allowed, ok := settingStrings(settings, "non_portable_path_allowed")
+		if !ok {
+			return fmt.Errorf("setting non_portable_path_allowed must be a list of strings")
+		}
+		cc.nonPortablePathAllowed = allowed
+	}
+	return nil
+}
+
+// checkNonPortablePaths flags string literals holding hardcoded temp
+// directories, Windows drive paths or backslash-separated paths, and "/"
+// concatenated onto paths, where a portable API should be used
+func (cc *CodeChecker) checkNonPortablePaths(filename, line string, lineNumber int) []models.Issue {
+	if !HasExtension(filename, cc.nonPortablePathExtensions) {
+		return nil
+	}
+	trimmed := strings.TrimSpace(line)
+	if IsCommentLine(trimmed) {
+		return nil
+	}
+
+	apis := nonPortablePathAPIs[languageOf(filename)]
+	if apis.temp == "" {
+		apis.temp, apis.join = "the platform's temp directory API", "a path-joining API"
+	}
+
+	var issues []models.Issue
+	for _, literal := range stringLiteralPattern.FindAllString(line, -1) {
+		value := literal[1 : len(literal)-1]
+		if cc.nonPortablePathIsAllowed(value) {
+			continue
+		}
+
+		switch {
+		case unixTempPathPattern.MatchString(value) || windowsTempPathPattern.MatchString(value):
+			issues = append(issues, nonPortablePathIssue(filename, line, lineNumber, models.SeverityWarning,
+				fmt.Sprintf("Hardcoded temp directory %s does not exist on every platform", literal),
+				"Use "+apis.temp, value))
+		case windowsPathPattern.MatchString(value):
+			issues = append(issues, nonPortablePathIssue(filename, line, lineNumber, models.SeverityWarning,
+				fmt.Sprintf("Windows drive path %s only works on Windows", literal),
+				"Build the path from a configurable base directory with "+apis.join, value))
+		case backslashPathPattern.MatchString(value):
+			issues = append(issues, nonPortablePathIssue(filename, line, lineNumber, models.SeverityWarning,
+				fmt.Sprintf("Path %s uses Windows backslash separators", literal),
+				"Join the path components with "+apis.join, value))
+		}
+	}
+
+	if len(issues) == 0 && separatorConcatPattern.MatchString(line) {
+		// "/" is also how URLs are built, so this is only a hint
+		issue := nonPortablePathIssue(filename, line, lineNumber, models.SeverityInfo,
+			"Path built by concatenating \"/\" assumes the platform's separator", "Join the path components with "+apis.join, "/")
+		issue.Confidence = 0.5
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func (cc *CodeChecker) nonPortablePathIsAllowed(value string) bool {
+	for _, prefix := range cc.nonPortablePathAllowed {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// languageOf returns the language name of a file from its extension, or ""
+func languageOf(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for language, extensions := range codeLanguageExtensions {
+		if utils.ContainsString(extensions, ext) {
+			return language
+		}
+	}
+	return ""
+}
+
+func nonPortablePathIssue(filename, line string, lineNumber int, severity models.SeverityLevel, message, suggestion, path string) models.Issue {
+	return models.Issue{
+		Type:          models.VibeTypeCode,
+		Severity:      severity,
+		Title:         "Non-portable path",
+		Message:       message,
+		File:          filename,
+		Line:          lineNumber,
+		Rule:          "non-portable-path",
+		Category:      models.CategoryMaintainability,
+		Context:       utils.TruncateString(strings.TrimSpace(line), 100),
+		FixSuggestion: suggestion,
+		Confidence:    0.8,
+		Metadata: map[string]interface{}{
+			"path": path,
+		},
+	}
+}
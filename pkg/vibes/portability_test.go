@@ -0,0 +1,62 @@
+package vibes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestCodeChecker_checkNonPortablePaths(t *testing.T) {
+	checker := NewCodeChecker()
+
+	tests := []struct {
+		filename   string
+		line       string
+		severity   models.SeverityLevel
+		suggestion string
+	}{
+		{"cache.go", `dir := "/tmp/kodevibe-cache"`, models.SeverityWarning, "os.TempDir()"},
+		{"cache.py", `path = '/var/tmp/app.lock'`, models.SeverityWarning, "tempfile"},
+		{"Cache.java", `File dir = new File("C:\\Temp\\cache");`, models.SeverityWarning, "java.io.tmpdir"},
+		{"paths.py", `DATA = r"D:\data\input.csv"`, models.SeverityWarning, "os.path.join"},
+		{"config.ts", `const file = "config\\settings.json";`, models.SeverityWarning, "path.join"},
+		{"Program.cs", `var path = dir + "/" + name;`, models.SeverityInfo, "Path.Combine"},
+	}
+	for _, test := range tests {
+		issues := checker.checkNonPortablePaths(test.filename, test.line, 3)
+		require.Len(t, issues, 1, test.line)
+		assert.Equal(t, "non-portable-path", issues[0].Rule)
+		assert.Equal(t, test.severity, issues[0].Severity, test.line)
+		assert.Contains(t, issues[0].FixSuggestion, test.suggestion, test.line)
+	}
+
+	for _, line := range []string{
+		`dir := os.TempDir()`,
+		`url := "https://example.com/tmp/x"`,
+		`// files go to /tmp/cache`,
+		`name := "tmp/output"`,
+		`sep := "\n"`,
+	} {
+		assert.Empty(t, checker.checkNonPortablePaths("main.go", line, 1), line)
+	}
+	assert.Empty(t, checker.checkNonPortablePaths("build.sh", `cd "/tmp/build"`, 1), "shell scripts are not checked by default")
+}
+
+func TestCodeChecker_NonPortablePathSettings(t *testing.T) {
+	checker := NewCodeChecker()
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"non_portable_path_languages": []interface{}{"py"},
+		"non_portable_path_allowed":   []interface{}{"/tmp/.X11-unix"},
+	}}))
+
+	assert.Empty(t, checker.checkNonPortablePaths("main.go", `dir := "/tmp/cache"`, 1))
+	assert.Len(t, checker.checkNonPortablePaths("main.py", `d = "/tmp/cache"`, 1), 1)
+	assert.Empty(t, checker.checkNonPortablePaths("main.py", `s = "/tmp/.X11-unix/X0"`, 1))
+
+	assert.Error(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"non_portable_path_languages": []interface{}{"cobol"},
+	}}))
+}
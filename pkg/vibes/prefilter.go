@@ -0,0 +1,65 @@
+package vibes
+
+import (
+	"strings"
+)
+
+// securityPrefilter is the subset of per-line security checks that can match
+// a file. Most files are clean, and a substring search of the whole file is far
+// cheaper than running every regex on every line.
+type securityPrefilter struct {
+	secretPatterns      []*SecretPattern
+	vulnerabilityChecks []vulnerabilityCheck
+	credentials         bool
+	entropy             bool
+	logInjection        bool
+	weakCrypto          bool
+}
+
+// prefilter decides which per-line checks to run on a file from the literals
+// their matches must contain. Secret patterns are kept when their literal
+// prefix (e.g. "ghp_" or "AKIA") appears, or when they have none.
+func (sc *SecurityChecker) prefilter(lines []string) securityPrefilter {
+	if sc.noPrefilter {
+		return securityPrefilter{
+			secretPatterns:      sc.secretPatterns,
+			vulnerabilityChecks: vulnerabilityChecks,
+			credentials:         true,
+			entropy:             true,
+			logInjection:        sc.logInjection,
+			weakCrypto:          sc.weakCrypto,
+		}
+	}
+
+	content := strings.Join(lines, "\n")
+	lower := strings.ToLower(content)
+
+	var filter securityPrefilter
+	for _, pattern := range sc.secretPatterns {
+		if prefix, _ := pattern.Pattern.LiteralPrefix(); prefix == "" || strings.Contains(content, prefix) {
+			filter.secretPatterns = append(filter.secretPatterns, pattern)
+		}
+	}
+	for _, check := range vulnerabilityChecks {
+		if containsAny(lower, check.literals) {
+			filter.vulnerabilityChecks = append(filter.vulnerabilityChecks, check)
+		}
+	}
+	filter.credentials = containsAny(lower, credentialLiterals)
+	// High entropy strings are only looked for inside quotes
+	filter.entropy = strings.ContainsAny(content, `"'`)
+	filter.logInjection = sc.logInjection && containsAny(lower, logInjectionLiterals)
+	filter.weakCrypto = sc.weakCrypto && containsAny(lower, weakCryptoLiterals)
+
+	return filter
+}
+
+// containsAny reports whether s contains any of the substrings
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
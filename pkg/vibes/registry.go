@@ -6,12 +6,21 @@ package vibes
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"kodevibe/internal/models"
 )
 
-// Checker interface defines the contract for all vibe checkers
+// Checker interface defines the contract for all vibe checkers. It is public
+// API: programs embedding KodeVibe implement it to add their own vibes and
+// add them with Registry.Register.
+//
+// The scanner passes Check every file of the scan, so it should skip the files
+// it does not Support. Different vibes run concurrently and concurrent scans
+// may share a checker, so Check must not keep per-call state on the checker. Check should return early with the
+// issues found so far when ctx is done. Issues need at least Type, Severity,
+// Rule, Message, File and Line; the scanner assigns IDs and timestamps.
 type Checker interface {
 	// Check performs the vibe check on the provided files
 	Check(ctx context.Context, files []string) ([]models.Issue, error)
@@ -27,6 +36,35 @@ type Checker interface {
 
 	// Supports returns true if the checker supports the given file
 	Supports(filename string) bool
+
+	// Rules returns metadata for every rule the checker can emit
+	Rules() []RuleInfo
+
+	// DefaultConfig returns the configuration the checker uses when none is provided
+	DefaultConfig() models.VibeConfig
+}
+
+// PerFileChecker is a Checker whose files can be checked independently of
+// each other. The scanner spreads such a checker's files over its workers and
+// calls CheckFile for each instead of calling Check, so CheckFile must be safe
+// to call concurrently. It returns no issues for files the checker does not
+// Support, and an error makes the scanner skip the file.
+type PerFileChecker interface {
+	Checker
+
+	// CheckFile performs the vibe check on one file
+	CheckFile(ctx context.Context, file string) ([]models.Issue, error)
+}
+
+// RuleInfo describes a rule that a checker can emit
+type RuleInfo struct {
+	ID          string               `json:"id" yaml:"id"`
+	Vibe        models.VibeType      `json:"vibe" yaml:"vibe"`
+	Title       string               `json:"title" yaml:"title"`
+	Description string               `json:"description" yaml:"description"`
+	Severity    models.SeverityLevel `json:"severity" yaml:"severity"`
+	Fixable     bool                 `json:"fixable" yaml:"fixable"`
+	CWE         string               `json:"cwe,omitempty" yaml:"cwe,omitempty"`
 }
 
 // Registry manages all available vibe checkers
@@ -42,6 +80,13 @@ func NewRegistry() *Registry {
 	}
 }
 
+// Register adds a checker, such as an embedder's own Checker implementation,
+// alongside the built-in ones. Each vibe type holds one checker, so to
+// replace a built-in vibe call UnregisterChecker with its type first.
+func (r *Registry) Register(checker Checker) error {
+	return r.RegisterChecker(checker)
+}
+
 // RegisterChecker registers a vibe checker
 func (r *Registry) RegisterChecker(checker Checker) error {
 	r.mu.Lock()
@@ -99,6 +144,56 @@ func (r *Registry) ListAvailableVibes() []models.VibeType {
 	return vibes
 }
 
+// RuleCatalog returns the rules of all registered checkers, sorted by vibe and rule ID
+func (r *Registry) RuleCatalog() []RuleInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rules []RuleInfo
+	for vibeType, checker := range r.checkers {
+		for _, rule := range checker.Rules() {
+			if rule.Vibe == "" {
+				rule.Vibe = vibeType
+			}
+			if rule.CWE == "" {
+				rule.CWE = RuleCWE(rule.ID)
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Vibe != rules[j].Vibe {
+			return rules[i].Vibe < rules[j].Vibe
+		}
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules
+}
+
+// BuiltinCheckers returns new, unconfigured instances of all built-in checkers
+func BuiltinCheckers() []Checker {
+	return []Checker{
+		NewSecurityChecker(),
+		NewCodeChecker(),
+		NewPerformanceChecker(),
+		NewFileChecker(),
+		NewGitChecker(),
+		NewDependencyChecker(),
+		NewDocumentationChecker(),
+	}
+}
+
+// DefaultVibeConfigs returns the default configuration of every built-in checker
+func DefaultVibeConfigs() map[models.VibeType]models.VibeConfig {
+	configs := make(map[models.VibeType]models.VibeConfig)
+	for _, checker := range BuiltinCheckers() {
+		configs[checker.Type()] = checker.DefaultConfig()
+	}
+	return configs
+}
+
 // RegisterAllVibes registers all built-in vibe checkers
 func (r *Registry) RegisterAllVibes(config *models.Configuration) error {
 	// Register Security Vibe
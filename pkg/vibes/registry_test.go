@@ -0,0 +1,99 @@
+package vibes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestRegistry_RuleCatalog(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterAllVibes(&models.Configuration{}))
+
+	rules := registry.RuleCatalog()
+	require.NotEmpty(t, rules)
+
+	seen := make(map[string]bool)
+	for i, rule := range rules {
+		assert.NotEmpty(t, rule.ID)
+		assert.NotEmpty(t, rule.Vibe, "rule %s has no vibe", rule.ID)
+		assert.NotEmpty(t, rule.Severity, "rule %s has no severity", rule.ID)
+		assert.False(t, seen[rule.ID], "duplicate rule %s", rule.ID)
+		seen[rule.ID] = true
+
+		if i > 0 && rules[i-1].Vibe == rule.Vibe {
+			assert.Less(t, rules[i-1].ID, rule.ID)
+		}
+	}
+
+	assert.True(t, seen["skipped-test"])
+	assert.True(t, seen["secret-detection-aws-access-key"])
+	assert.True(t, seen["nested-loops"])
+
+	cwes := make(map[string]string)
+	for _, rule := range rules {
+		cwes[rule.ID] = rule.CWE
+	}
+	assert.Equal(t, "CWE-89", cwes["sql-injection-risk"])
+	assert.Equal(t, "CWE-798", cwes["secret-detection-aws-access-key"])
+	assert.Empty(t, cwes["nested-loops"])
+	for id, cwe := range ruleCWEs {
+		assert.Contains(t, cwes, id, "the CWE mapping names an unknown rule")
+		assert.NoError(t, ValidateCWE(cwe))
+	}
+	assert.Error(t, ValidateCWE("89"))
+}
+
+func TestDefaultVibeConfigs(t *testing.T) {
+	configs := DefaultVibeConfigs()
+
+	assert.Len(t, configs, len(BuiltinCheckers()))
+	assert.True(t, configs[models.VibeTypeSecurity].Enabled)
+	assert.False(t, configs[models.VibeTypeDocumentation].Enabled)
+	assert.Equal(t, 50, configs[models.VibeTypeCode].Settings["max_function_length"])
+
+	// Defaults must be accepted by the checkers that produced them
+	for _, checker := range BuiltinCheckers() {
+		assert.NoError(t, checker.Configure(checker.DefaultConfig()), checker.Name())
+	}
+}
+
+func TestSupportedLanguages(t *testing.T) {
+	languages := SupportedLanguages()
+
+	byName := make(map[string]Language)
+	for i, language := range languages {
+		if i > 0 {
+			assert.Less(t, languages[i-1].Name, language.Name)
+		}
+		byName[language.Name] = language
+	}
+
+	for _, name := range NewMultiLanguageChecker().GetSupportedLanguages() {
+		assert.True(t, byName[name].LanguageRules, "%s has language rules", name)
+	}
+	assert.False(t, byName["shell"].LanguageRules)
+	assert.Equal(t, []string{".js", ".jsx", ".mjs"}, byName["javascript"].Extensions)
+
+	code := NewCodeChecker()
+	for name, extensions := range codeLanguageExtensions {
+		for _, ext := range extensions {
+			assert.True(t, code.Supports("file"+ext), "code checker supports %s", ext)
+			assert.Contains(t, byName[name].Extensions, ext)
+		}
+	}
+}
+
+func TestLanguageExtensions(t *testing.T) {
+	extensions, err := LanguageExtensions([]string{"go", " TS "})
+	require.NoError(t, err)
+	assert.True(t, HasExtension("main.go", extensions))
+	assert.True(t, HasExtension("App.TSX", extensions))
+	assert.False(t, HasExtension("app.js", extensions))
+
+	_, err = LanguageExtensions([]string{"cobol"})
+	assert.ErrorContains(t, err, `unknown language "cobol"`)
+}
@@ -0,0 +1,147 @@
+package vibes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultCustomSecretConfidence is the confidence of a secret_patterns entry that sets none
+const defaultCustomSecretConfidence = 0.8
+
+// configureSecretPatterns rebuilds the secret patterns from the built-in
+// ones, minus those named in disabled_secret_patterns, plus the entries of
+// secret_patterns. An entry named like a built-in pattern replaces it.
+func (sc *SecurityChecker) configureSecretPatterns(settings map[string]interface{}) error {
+	sc.initializeSecretPatterns()
+
+	if _, exists := settings["disabled_secret_patterns"]; exists {
+		disabled, ok := settingStrings(settings, "disabled_secret_patterns")
+		if !ok {
+			return fmt.Errorf("setting disabled_secret_patterns must be a list of strings")
+		}
+		for _, name := range disabled {
+			index := sc.secretPatternIndex(name)
+			if index < 0 {
+				return fmt.Errorf("disabled_secret_patterns: unknown secret pattern %q", name)
+			}
+			sc.secretPatterns = append(sc.secretPatterns[:index], sc.secretPatterns[index+1:]...)
+		}
+	}
+
+	value, exists := settings["secret_patterns"]
+	if !exists {
+		return nil
+	}
+	entries, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("setting secret_patterns must be a list")
+	}
+
+	custom := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("secret_patterns[%d] must be a map", i)
+		}
+		pattern, err := secretPatternFromSettings(fields)
+		if err != nil {
+			return fmt.Errorf("secret_patterns[%d]: %w", i, err)
+		}
+
+		id := secretRuleID(pattern.Name)
+		if custom[id] {
+			return fmt.Errorf("secret_patterns[%d]: duplicate name %q", i, pattern.Name)
+		}
+		custom[id] = true
+		if index := sc.secretPatternIndex(pattern.Name); index >= 0 {
+			sc.secretPatterns[index] = pattern
+			continue
+		}
+		sc.secretPatterns = append(sc.secretPatterns, pattern)
+	}
+
+	return nil
+}
+
+// ValidateSecretPatterns reports the first problem with the secret_patterns
+// and disabled_secret_patterns of security vibe settings, such as a regex
+// that doesn't compile or an unknown built-in pattern
+func ValidateSecretPatterns(settings map[string]interface{}) error {
+	return NewSecurityChecker().configureSecretPatterns(settings)
+}
+
+// secretPatternIndex finds a secret pattern by its name or rule ID, ignoring
+// case, and returns -1 if there is none
+func (sc *SecurityChecker) secretPatternIndex(name string) int {
+	for i, pattern := range sc.secretPatterns {
+		if strings.EqualFold(pattern.Name, name) || secretRuleID(pattern.Name) == strings.ToLower(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func secretPatternFromSettings(fields map[string]interface{}) (*SecretPattern, error) {
+	str := func(key string) (string, error) {
+		value, exists := fields[key]
+		if !exists {
+			return "", nil
+		}
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%s must be a string", key)
+		}
+		return strings.TrimSpace(s), nil
+	}
+
+	name, err := str("name")
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	expr, err := str("regex")
+	if err != nil {
+		return nil, err
+	}
+	if expr == "" {
+		return nil, fmt.Errorf("pattern %q: regex is required", name)
+	}
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("pattern %q: invalid regex: %w", name, err)
+	}
+
+	pattern := &SecretPattern{
+		Name:        name,
+		Pattern:     regex,
+		Confidence:  defaultCustomSecretConfidence,
+		Description: fmt.Sprintf("%s detected", name),
+	}
+	if description, err := str("description"); err != nil {
+		return nil, err
+	} else if description != "" {
+		pattern.Description = description
+	}
+
+	if value, exists := fields["confidence"]; exists {
+		var confidence float64
+		switch v := value.(type) {
+		case float64:
+			confidence = v
+		case int:
+			confidence = float64(v)
+		default:
+			return nil, fmt.Errorf("pattern %q: confidence must be a number", name)
+		}
+		if confidence <= 0 || confidence > 1 {
+			return nil, fmt.Errorf("pattern %q: confidence must be above 0 and at most 1", name)
+		}
+		pattern.Confidence = confidence
+	}
+
+	return pattern, nil
+}
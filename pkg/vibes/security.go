@@ -2,10 +2,10 @@ package vibes
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"math"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -21,6 +21,26 @@ type SecurityChecker struct {
 	vulnerabilityDB  *VulnerabilityDB
 	entropyThreshold float64
 	testContent      map[string]string // For testing purposes
+
+	insecureRandomRules  map[string]*insecureRandomRule
+	sensitiveIdentifiers []string
+
+	ignoreURLs   bool
+	ignoreEmails bool
+
+	dropInvalidSecrets bool
+
+	bannedSymbols []*bannedSymbol
+
+	logInjection           bool
+	logInjectionSources    []*regexp.Regexp
+	logInjectionSanitizers []*regexp.Regexp
+
+	weakCrypto       bool
+	weakCryptoChecks []*weakCryptoCheck
+
+	// noPrefilter runs every per-line check on every file; benchmarks use it as the baseline
+	noPrefilter bool
 }
 
 // SecretPattern represents a pattern for detecting secrets
@@ -30,6 +50,10 @@ type SecretPattern struct {
 	Confidence  float64
 	Description string
 	Examples    []string
+	// Validate, if set, confirms a match really is such a secret. Matches it
+	// rejects are reported with low confidence, or not at all with
+	// drop_invalid_secrets.
+	Validate func(match string) bool
 }
 
 // VulnerabilityDB represents a vulnerability database
@@ -40,12 +64,19 @@ type VulnerabilityDB struct {
 // NewSecurityChecker creates a new security checker
 func NewSecurityChecker() *SecurityChecker {
 	checker := &SecurityChecker{
-		entropyThreshold: 4.5,
-		vulnerabilityDB:  NewVulnerabilityDB(),
-		testContent:      make(map[string]string),
+		entropyThreshold:     4.5,
+		vulnerabilityDB:      NewVulnerabilityDB(),
+		testContent:          make(map[string]string),
+		insecureRandomRules:  defaultInsecureRandomRules(),
+		sensitiveIdentifiers: defaultSensitiveIdentifiers,
+		ignoreURLs:           true,
+		ignoreEmails:         true,
 	}
 
 	checker.initializeSecretPatterns()
+	// The defaults always compile
+	_ = checker.configureLogInjection(nil)
+	_ = checker.configureWeakCrypto(nil)
 	return checker
 }
 
@@ -70,6 +101,37 @@ func (sc *SecurityChecker) Configure(config models.VibeConfig) error {
 		}
 	}
 
+	if err := sc.configureInsecureRandom(config.Settings); err != nil {
+		return err
+	}
+
+	if err := sc.configureBannedSymbols(config.Settings); err != nil {
+		return err
+	}
+
+	if err := sc.configureSecretPatterns(config.Settings); err != nil {
+		return err
+	}
+
+	if err := sc.configureLogInjection(config.Settings); err != nil {
+		return err
+	}
+
+	if err := sc.configureWeakCrypto(config.Settings); err != nil {
+		return err
+	}
+
+	var err error
+	if sc.ignoreURLs, err = settingBool(config.Settings, "ignore_urls", true); err != nil {
+		return err
+	}
+	if sc.ignoreEmails, err = settingBool(config.Settings, "ignore_emails", true); err != nil {
+		return err
+	}
+	if sc.dropInvalidSecrets, err = settingBool(config.Settings, "drop_invalid_secrets", false); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -94,6 +156,52 @@ func (sc *SecurityChecker) Supports(filename string) bool {
 	return ext == ""
 }
 
+// DefaultConfig returns the default security checker configuration
+func (sc *SecurityChecker) DefaultConfig() models.VibeConfig {
+	return models.VibeConfig{
+		Enabled: true,
+		Level:   "strict",
+		Settings: map[string]interface{}{
+			"entropy_threshold":     4.5,
+			"sensitive_identifiers": defaultSensitiveIdentifiers,
+			"ignore_urls":           true,
+			"ignore_emails":         true,
+			"drop_invalid_secrets":  false,
+			"log_injection":         true,
+			"weak_crypto":           true,
+		},
+	}
+}
+
+// Rules returns the rules emitted by the security checker
+func (sc *SecurityChecker) Rules() []RuleInfo {
+	rules := []RuleInfo{
+		{ID: "sql-injection-risk", Title: "Potential SQL Injection vulnerability", Description: "Queries built from unescaped input", Severity: models.SeverityError, Fixable: true},
+		{ID: "xss-risk", Title: "Potential XSS vulnerability", Description: "Unescaped HTML written to the page", Severity: models.SeverityError, Fixable: true},
+		{ID: "command-injection-risk", Title: "Potential Command Injection vulnerability", Description: "Shell commands built from unescaped input", Severity: models.SeverityError, Fixable: true},
+		{ID: "eval-usage", Title: "Dangerous eval() usage", Description: "Dynamic code evaluation", Severity: models.SeverityWarning, Fixable: true},
+		{ID: "hardcoded-credentials", Title: "Hardcoded credentials detected", Description: "Passwords and keys assigned to literals", Severity: models.SeverityError, Fixable: true},
+		{ID: "high-entropy-string", Title: "High entropy string detected", Description: "Random-looking strings that may be secrets", Severity: models.SeverityWarning},
+		{ID: "insecure-randomness", Title: "Insecure randomness", Description: "Non-cryptographic random APIs used for tokens, keys or salts", Severity: models.SeverityWarning},
+		{ID: "log-injection", Title: "Potential log injection", Description: "User input formatted into log messages", Severity: models.SeverityWarning},
+		{ID: "weak-cipher", Title: "Weak cipher", Description: "DES, 3DES, RC4, Blowfish and ECB mode encryption", Severity: models.SeverityWarning},
+		{ID: "insecure-tls-verify", Title: "TLS certificate verification disabled", Description: "TLS clients that skip certificate or hostname verification", Severity: models.SeverityError},
+		{ID: "weak-tls-version", Title: "Weak TLS version", Description: "SSL, TLS 1.0 and TLS 1.1 allowed or required", Severity: models.SeverityWarning},
+		{ID: "banned-symbol", Title: "Banned symbol", Description: "Imports or usages of functions and packages listed in banned_symbols", Severity: models.SeverityWarning},
+	}
+
+	for _, pattern := range sc.secretPatterns {
+		rules = append(rules, RuleInfo{
+			ID:          secretRuleID(pattern.Name),
+			Title:       fmt.Sprintf("Potential %s detected", pattern.Name),
+			Description: pattern.Description,
+			Severity:    models.SeverityError,
+		})
+	}
+
+	return rules
+}
+
 // Check performs security checks on the provided files
 func (sc *SecurityChecker) Check(ctx context.Context, files []string) ([]models.Issue, error) {
 	var issues []models.Issue
@@ -103,7 +211,7 @@ func (sc *SecurityChecker) Check(ctx context.Context, files []string) ([]models.
 			continue
 		}
 
-		fileIssues, err := sc.checkFile(file)
+		fileIssues, err := sc.checkFile(ctx, file)
 		if err != nil {
 			// Log error but continue with other files
 			continue
@@ -122,24 +230,31 @@ func (sc *SecurityChecker) Check(ctx context.Context, files []string) ([]models.
 	return issues, nil
 }
 
+// CheckFile performs security checks on one file
+func (sc *SecurityChecker) CheckFile(ctx context.Context, file string) ([]models.Issue, error) {
+	if !sc.Supports(file) {
+		return nil, nil
+	}
+	return sc.checkFile(ctx, file)
+}
+
 // checkFile performs security checks on a single file
-func (sc *SecurityChecker) checkFile(filename string) ([]models.Issue, error) {
+func (sc *SecurityChecker) checkFile(ctx context.Context, filename string) ([]models.Issue, error) {
 	var issues []models.Issue
 	var lines []string
 
 	// Check if we have test content for this file
 	if sc.testContent != nil && sc.testContent[filename] != "" {
-		lines = strings.Split(sc.testContent[filename], "\n")
+		lines = utils.SplitLines(sc.testContent[filename])
 	} else {
-		file, err := os.Open(filename)
+		content, err := readFile(ctx, filename)
 		if err != nil {
-			return nil, fmt.Errorf("failed to open file: %w", err)
+			return nil, err
 		}
-		defer file.Close()
 
-		scanner := bufio.NewScanner(file)
+		scanner := bufio.NewScanner(bytes.NewReader(content))
 		for scanner.Scan() {
-			lines = append(lines, scanner.Text())
+			lines = append(lines, utils.TrimLineEnding(scanner.Text()))
 		}
 
 		if err := scanner.Err(); err != nil {
@@ -147,34 +262,84 @@ func (sc *SecurityChecker) checkFile(filename string) ([]models.Issue, error) {
 		}
 	}
 
+	randomRule := sc.insecureRandomRuleFor(filename, lines)
+	cryptoLanguage := weakCryptoLanguageFor(filename)
+
+	// Skip the per-line checks that cannot match anything in this file
+	filter := sc.prefilter(lines)
+	filter.weakCrypto = filter.weakCrypto && cryptoLanguage != ""
+
+	// Check for banned imports and usages, which tracks import blocks across lines
+	issues = append(issues, sc.checkBannedSymbols(filename, lines)...)
+
 	for lineNumber, line := range lines {
+		// Check for insecure randomness, which needs the surrounding lines
+		if randomRule != nil {
+			randomIssues := sc.checkLineForInsecureRandom(filename, randomRule, lines, lineNumber)
+			issues = append(issues, randomIssues...)
+		}
+
 		lineNumber++ // Make it 1-based
 
 		// Check for secrets
-		secretIssues := sc.checkLineForSecrets(filename, line, lineNumber)
-		issues = append(issues, secretIssues...)
+		if len(filter.secretPatterns) > 0 {
+			secretIssues := sc.checkLineForSecretPatterns(filter.secretPatterns, filename, line, lineNumber)
+			issues = append(issues, secretIssues...)
+		}
 
 		// Check for vulnerabilities
-		vulnIssues := sc.checkLineForVulnerabilities(filename, line, lineNumber)
-		issues = append(issues, vulnIssues...)
+		if len(filter.vulnerabilityChecks) > 0 {
+			vulnIssues := sc.checkLineForVulnerabilityChecks(filter.vulnerabilityChecks, filename, line, lineNumber)
+			issues = append(issues, vulnIssues...)
+		}
 
 		// Check for hardcoded credentials
-		credIssues := sc.checkLineForHardcodedCredentials(filename, line, lineNumber)
-		issues = append(issues, credIssues...)
+		if filter.credentials {
+			credIssues := sc.checkLineForHardcodedCredentials(filename, line, lineNumber)
+			issues = append(issues, credIssues...)
+		}
+
+		// Check for user input formatted into log messages
+		if filter.logInjection {
+			issues = append(issues, sc.checkLineForLogInjection(filename, line, lineNumber)...)
+		}
+
+		// Check for weak ciphers and TLS configuration
+		if filter.weakCrypto {
+			issues = append(issues, sc.checkLineForWeakCrypto(filename, cryptoLanguage, line, lineNumber)...)
+		}
 
 		// Check for high entropy strings
-		entropyIssues := sc.checkLineForHighEntropy(filename, line, lineNumber)
-		issues = append(issues, entropyIssues...)
+		if filter.entropy {
+			entropyIssues := sc.checkLineForHighEntropy(filename, line, lineNumber)
+			issues = append(issues, entropyIssues...)
+		}
 	}
 
 	return issues, nil
 }
 
+// CheckSecretsInLine runs the secret patterns and hardcoded credential checks
+// on one line of a file that need not exist on disk, such as a line added in
+// an old commit
+func (sc *SecurityChecker) CheckSecretsInLine(filename, line string, lineNumber int) []models.Issue {
+	if !sc.Supports(filename) {
+		return nil
+	}
+	issues := sc.checkLineForSecrets(filename, line, lineNumber)
+	return append(issues, sc.checkLineForHardcodedCredentials(filename, line, lineNumber)...)
+}
+
 // checkLineForSecrets checks a line for known secret patterns
 func (sc *SecurityChecker) checkLineForSecrets(filename, line string, lineNumber int) []models.Issue {
+	return sc.checkLineForSecretPatterns(sc.secretPatterns, filename, line, lineNumber)
+}
+
+// checkLineForSecretPatterns checks a line for the given secret patterns
+func (sc *SecurityChecker) checkLineForSecretPatterns(patterns []*SecretPattern, filename, line string, lineNumber int) []models.Issue {
 	var issues []models.Issue
 
-	for _, pattern := range sc.secretPatterns {
+	for _, pattern := range patterns {
 		matches := pattern.Pattern.FindAllStringSubmatch(line, -1)
 		for _, match := range matches {
 			if len(match) > 0 {
@@ -182,6 +347,14 @@ func (sc *SecurityChecker) checkLineForSecrets(filename, line string, lineNumber
 				if sc.isFalsePositive(filename, line, pattern.Name) {
 					continue
 				}
+				confidence := pattern.Confidence
+				valid := pattern.Validate == nil || pattern.Validate(match[0])
+				if !valid {
+					if sc.dropInvalidSecrets {
+						continue
+					}
+					confidence = invalidSecretConfidence
+				}
 
 				issue := models.Issue{
 					Type:       models.VibeTypeSecurity,
@@ -190,16 +363,20 @@ func (sc *SecurityChecker) checkLineForSecrets(filename, line string, lineNumber
 					Message:    fmt.Sprintf("Found potential %s: %s", pattern.Name, pattern.Description),
 					File:       filename,
 					Line:       lineNumber,
-					Rule:       fmt.Sprintf("secret-detection-%s", strings.ToLower(strings.ReplaceAll(pattern.Name, " ", "-"))),
+					Rule:       secretRuleID(pattern.Name),
+					Category:   models.CategorySecurity,
 					Pattern:    pattern.Pattern.String(),
 					Context:    utils.TruncateString(line, 100),
 					Fixable:    false,
-					Confidence: pattern.Confidence,
+					Confidence: confidence,
 					Metadata: map[string]interface{}{
 						"secret_type": pattern.Name,
 						"match":       match[0],
 					},
 				}
+				if !valid {
+					issue.Metadata["secret_validation"] = "failed"
+				}
 
 				issues = append(issues, issue)
 			}
@@ -209,135 +386,137 @@ func (sc *SecurityChecker) checkLineForSecrets(filename, line string, lineNumber
 	return issues
 }
 
-// checkLineForVulnerabilities checks a line for security vulnerabilities
-func (sc *SecurityChecker) checkLineForVulnerabilities(filename, line string, lineNumber int) []models.Issue {
-	var issues []models.Issue
-
-	// SQL Injection patterns
-	sqlInjectionPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i).*query.*\+.*\$`),
-		regexp.MustCompile(`(?i)SELECT.*\+.*WHERE`),
-		regexp.MustCompile(`(?i)INSERT.*\+.*VALUES`),
-		regexp.MustCompile(`(?i)UPDATE.*\+.*SET`),
-		regexp.MustCompile(`(?i)DELETE.*\+.*FROM`),
-	}
-
-	for _, pattern := range sqlInjectionPatterns {
-		if pattern.MatchString(line) {
-			issue := models.Issue{
-				Type:          models.VibeTypeSecurity,
-				Severity:      models.SeverityError,
-				Title:         "Potential SQL Injection vulnerability",
-				Message:       "SQL query appears to use string concatenation which may lead to SQL injection",
-				File:          filename,
-				Line:          lineNumber,
-				Rule:          "sql-injection-risk",
-				Context:       utils.TruncateString(line, 100),
-				Fixable:       true,
-				FixSuggestion: "Use parameterized queries or prepared statements",
-				Confidence:    0.8,
-			}
-			issues = append(issues, issue)
-		}
-	}
-
-	// XSS patterns
-	xssPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)innerHTML\s*=\s*.*\+`),
-		regexp.MustCompile(`(?i)outerHTML\s*=\s*.*\+`),
-		regexp.MustCompile(`(?i)document\.write\s*\(`),
-	}
-
-	for _, pattern := range xssPatterns {
-		if pattern.MatchString(line) {
-			issue := models.Issue{
-				Type:          models.VibeTypeSecurity,
-				Severity:      models.SeverityError,
-				Title:         "Potential XSS vulnerability",
-				Message:       "Direct DOM manipulation with user input may lead to XSS attacks",
-				File:          filename,
-				Line:          lineNumber,
-				Rule:          "xss-risk",
-				Context:       utils.TruncateString(line, 100),
-				Fixable:       true,
-				FixSuggestion: "Use safe DOM manipulation methods or sanitize input",
-				Confidence:    0.7,
-			}
-			issues = append(issues, issue)
-		}
-	}
+// vulnerabilityCheck is a group of patterns that report the same vulnerability
+type vulnerabilityCheck struct {
+	// literals are lowercase substrings of which every match contains one
+	literals []string
+	patterns []*regexp.Regexp
+	issue    models.Issue
+}
 
-	// Command Injection patterns
-	cmdInjectionPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)exec\s*\(\s*[^)]*\+`),
-		regexp.MustCompile(`(?i)system\s*\(\s*[^)]*\+`),
-		regexp.MustCompile(`(?i)Runtime\.exec\s*\(`),
-		regexp.MustCompile(`(?i)ProcessBuilder\s*\(`),
-	}
+// vulnerabilityChecks are the per-line vulnerability patterns
+var vulnerabilityChecks = []vulnerabilityCheck{
+	{
+		literals: []string{"query", "select", "insert", "update", "delete"},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i).*query.*\+.*\$`),
+			regexp.MustCompile(`(?i)SELECT.*\+.*WHERE`),
+			regexp.MustCompile(`(?i)INSERT.*\+.*VALUES`),
+			regexp.MustCompile(`(?i)UPDATE.*\+.*SET`),
+			regexp.MustCompile(`(?i)DELETE.*\+.*FROM`),
+		},
+		issue: models.Issue{
+			Severity:      models.SeverityError,
+			Title:         "Potential SQL Injection vulnerability",
+			Message:       "SQL query appears to use string concatenation which may lead to SQL injection",
+			Rule:          "sql-injection-risk",
+			FixSuggestion: "Use parameterized queries or prepared statements",
+			Confidence:    0.8,
+		},
+	},
+	{
+		literals: []string{"innerhtml", "outerhtml", "document.write"},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)innerHTML\s*=\s*.*\+`),
+			regexp.MustCompile(`(?i)outerHTML\s*=\s*.*\+`),
+			regexp.MustCompile(`(?i)document\.write\s*\(`),
+		},
+		issue: models.Issue{
+			Severity:      models.SeverityError,
+			Title:         "Potential XSS vulnerability",
+			Message:       "Direct DOM manipulation with user input may lead to XSS attacks",
+			Rule:          "xss-risk",
+			FixSuggestion: "Use safe DOM manipulation methods or sanitize input",
+			Confidence:    0.7,
+		},
+	},
+	{
+		literals: []string{"exec", "system", "processbuilder"},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)exec\s*\(\s*[^)]*\+`),
+			regexp.MustCompile(`(?i)system\s*\(\s*[^)]*\+`),
+			regexp.MustCompile(`(?i)Runtime\.exec\s*\(`),
+			regexp.MustCompile(`(?i)ProcessBuilder\s*\(`),
+		},
+		issue: models.Issue{
+			Severity:      models.SeverityError,
+			Title:         "Potential Command Injection vulnerability",
+			Message:       "Command execution with user input may lead to command injection",
+			Rule:          "command-injection-risk",
+			FixSuggestion: "Validate and sanitize input, use safe command execution methods",
+			Confidence:    0.8,
+		},
+	},
+	{
+		literals: []string{"eval", "function", "settimeout", "setinterval"},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\beval\s*\(`),
+			regexp.MustCompile(`(?i)Function\s*\(`),
+			regexp.MustCompile(`(?i)setTimeout\s*\(\s*['"]\s*[^'"]*\+`),
+			regexp.MustCompile(`(?i)setInterval\s*\(\s*['"]\s*[^'"]*\+`),
+		},
+		issue: models.Issue{
+			Severity:      models.SeverityWarning,
+			Title:         "Dangerous eval() usage",
+			Message:       "Using eval() or similar functions can lead to code injection vulnerabilities",
+			Rule:          "eval-usage",
+			FixSuggestion: "Avoid eval(), use safer alternatives like JSON.parse() for data",
+			Confidence:    0.9,
+		},
+	},
+}
 
-	for _, pattern := range cmdInjectionPatterns {
-		if pattern.MatchString(line) {
-			issue := models.Issue{
-				Type:          models.VibeTypeSecurity,
-				Severity:      models.SeverityError,
-				Title:         "Potential Command Injection vulnerability",
-				Message:       "Command execution with user input may lead to command injection",
-				File:          filename,
-				Line:          lineNumber,
-				Rule:          "command-injection-risk",
-				Context:       utils.TruncateString(line, 100),
-				Fixable:       true,
-				FixSuggestion: "Validate and sanitize input, use safe command execution methods",
-				Confidence:    0.8,
-			}
-			issues = append(issues, issue)
-		}
-	}
+// checkLineForVulnerabilities checks a line for security vulnerabilities
+func (sc *SecurityChecker) checkLineForVulnerabilities(filename, line string, lineNumber int) []models.Issue {
+	return sc.checkLineForVulnerabilityChecks(vulnerabilityChecks, filename, line, lineNumber)
+}
 
-	// Eval usage patterns
-	evalPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)\beval\s*\(`),
-		regexp.MustCompile(`(?i)Function\s*\(`),
-		regexp.MustCompile(`(?i)setTimeout\s*\(\s*['"]\s*[^'"]*\+`),
-		regexp.MustCompile(`(?i)setInterval\s*\(\s*['"]\s*[^'"]*\+`),
-	}
+// checkLineForVulnerabilityChecks reports every pattern of checks that matches the line
+func (sc *SecurityChecker) checkLineForVulnerabilityChecks(checks []vulnerabilityCheck, filename, line string, lineNumber int) []models.Issue {
+	var issues []models.Issue
 
-	for _, pattern := range evalPatterns {
-		if pattern.MatchString(line) {
-			issue := models.Issue{
-				Type:          models.VibeTypeSecurity,
-				Severity:      models.SeverityWarning,
-				Title:         "Dangerous eval() usage",
-				Message:       "Using eval() or similar functions can lead to code injection vulnerabilities",
-				File:          filename,
-				Line:          lineNumber,
-				Rule:          "eval-usage",
-				Context:       utils.TruncateString(line, 100),
-				Fixable:       true,
-				FixSuggestion: "Avoid eval(), use safer alternatives like JSON.parse() for data",
-				Confidence:    0.9,
+	for _, check := range checks {
+		for _, pattern := range check.patterns {
+			if pattern.MatchString(line) {
+				issue := check.issue
+				issue.Type = models.VibeTypeSecurity
+				issue.File = filename
+				issue.Line = lineNumber
+				issue.Category = models.CategorySecurity
+				issue.Context = utils.TruncateString(line, 100)
+				issue.Fixable = true
+				issues = append(issues, issue)
 			}
-			issues = append(issues, issue)
 		}
 	}
 
 	return issues
 }
 
+// passwordPatterns match hardcoded passwords, keys and tokens, capturing the value second
+var passwordPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(password|pwd|pass)\s*[=:]\s*['"]([^'"]{8,})['"]`),
+	regexp.MustCompile(`(?i)(secret|key)\s*[=:]\s*['"]([^'"]{16,})['"]`),
+	regexp.MustCompile(`(?i)(token)\s*[=:]\s*['"]([^'"]{20,})['"]`),
+	regexp.MustCompile(`(?i)(auth|authorization)\s*[=:]\s*['"]([^'"]{10,})['"]`),
+}
+
+// credentialLiterals are lowercase substrings of which every hardcoded or URL credential contains one
+var credentialLiterals = []string{"pass", "pwd", "secret", "key", "token", "auth", "://"}
+
 // checkLineForHardcodedCredentials checks for hardcoded passwords and credentials
 func (sc *SecurityChecker) checkLineForHardcodedCredentials(filename, line string, lineNumber int) []models.Issue {
 	var issues []models.Issue
 
-	// Hardcoded password patterns
-	passwordPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)(password|pwd|pass)\s*[=:]\s*['"][^'"]{8,}['"]`),
-		regexp.MustCompile(`(?i)(secret|key)\s*[=:]\s*['"][^'"]{16,}['"]`),
-		regexp.MustCompile(`(?i)(token)\s*[=:]\s*['"][^'"]{20,}['"]`),
-		regexp.MustCompile(`(?i)(auth|authorization)\s*[=:]\s*['"][^'"]{10,}['"]`),
+	if issue := sc.checkLineForURLCredentials(filename, line, lineNumber); issue != nil {
+		return append(issues, *issue)
 	}
 
+	// URLs and emails are rarely secrets; mask them so e.g. endpoint: "https://..." is not flagged
+	masked := sc.maskURLsAndEmails(line)
+
 	for _, pattern := range passwordPatterns {
-		if pattern.MatchString(line) {
+		if match := pattern.FindStringSubmatch(masked); match != nil {
 			// Check if it's obviously a placeholder
 			if sc.isPlaceholder(line) {
 				continue
@@ -351,10 +530,14 @@ func (sc *SecurityChecker) checkLineForHardcodedCredentials(filename, line strin
 				File:          filename,
 				Line:          lineNumber,
 				Rule:          "hardcoded-credentials",
+				Category:      models.CategorySecurity,
 				Context:       utils.TruncateString(line, 100),
 				Fixable:       true,
 				FixSuggestion: "Use environment variables or secure configuration management",
 				Confidence:    0.8,
+				Metadata: map[string]interface{}{
+					"secret": match[2],
+				},
 			}
 			issues = append(issues, issue)
 		}
@@ -363,13 +546,15 @@ func (sc *SecurityChecker) checkLineForHardcodedCredentials(filename, line strin
 	return issues
 }
 
+// quotedTokenPattern matches quoted base64-like strings
+var quotedTokenPattern = regexp.MustCompile(`['"]([A-Za-z0-9+/=]{20,})['"]`)
+
 // checkLineForHighEntropy checks for high entropy strings that might be secrets
 func (sc *SecurityChecker) checkLineForHighEntropy(filename, line string, lineNumber int) []models.Issue {
 	var issues []models.Issue
 
 	// Find quoted strings
-	stringPattern := regexp.MustCompile(`['"]([A-Za-z0-9+/=]{20,})['"]`)
-	matches := stringPattern.FindAllStringSubmatch(line, -1)
+	matches := quotedTokenPattern.FindAllStringSubmatch(sc.maskURLsAndEmails(line), -1)
 
 	for _, match := range matches {
 		if len(match) > 1 {
@@ -388,6 +573,7 @@ func (sc *SecurityChecker) checkLineForHighEntropy(filename, line string, lineNu
 					File:       filename,
 					Line:       lineNumber,
 					Rule:       "high-entropy-string",
+					Category:   models.CategorySecurity,
 					Context:    utils.TruncateString(line, 100),
 					Fixable:    false,
 					Confidence: 0.6,
@@ -551,6 +737,7 @@ func (sc *SecurityChecker) initializeSecretPatterns() {
 			Pattern:     regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
 			Confidence:  0.9,
 			Description: "AWS access key ID detected",
+			Validate:    validAWSAccessKeyID,
 		},
 		{
 			Name:        "Google API Key",
@@ -627,3 +814,8 @@ func NewVulnerabilityDB() *VulnerabilityDB {
 		patterns: make(map[string]*regexp.Regexp),
 	}
 }
+
+// secretRuleID returns the rule ID used for findings of a secret pattern
+func secretRuleID(name string) string {
+	return fmt.Sprintf("secret-detection-%s", strings.ToLower(strings.ReplaceAll(name, " ", "-")))
+}
@@ -0,0 +1,139 @@
+package vibes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// settingStrings reads a list of strings from vibe settings.
+// YAML-decoded lists arrive as []interface{}, so both forms are accepted.
+func settingStrings(settings map[string]interface{}, key string) ([]string, bool) {
+	value, exists := settings[key]
+	if !exists {
+		return nil, false
+	}
+	return toStrings(value)
+}
+
+// settingStringLists reads a map of string lists (e.g. language -> patterns) from vibe settings
+func settingStringLists(settings map[string]interface{}, key string) (map[string][]string, error) {
+	value, exists := settings[key]
+	if !exists {
+		return nil, nil
+	}
+
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("setting %s must be a map of lists", key)
+	}
+
+	result := make(map[string][]string, len(raw))
+	for name, entry := range raw {
+		list, ok := toStrings(entry)
+		if !ok {
+			return nil, fmt.Errorf("setting %s.%s must be a list of strings", key, name)
+		}
+		result[name] = list
+	}
+
+	return result, nil
+}
+
+// settingBool reads a boolean from vibe settings, returning fallback when it is not set
+func settingBool(settings map[string]interface{}, key string, fallback bool) (bool, error) {
+	value, exists := settings[key]
+	if !exists {
+		return fallback, nil
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return fallback, fmt.Errorf("setting %s must be true or false", key)
+	}
+	return b, nil
+}
+
+// settingInt reads a non-negative number from vibe settings, returning
+// fallback when it is not set
+func settingInt(settings map[string]interface{}, key string, fallback int) (int, error) {
+	value, exists := settings[key]
+	if !exists {
+		return fallback, nil
+	}
+
+	var n int
+	switch v := value.(type) {
+	case int:
+		n = v
+	case int64:
+		n = int(v)
+	case float64:
+		n = int(v)
+	default:
+		return fallback, fmt.Errorf("setting %s must be a number", key)
+	}
+	if n < 0 {
+		return fallback, fmt.Errorf("setting %s must not be negative", key)
+	}
+	return n, nil
+}
+
+func toStrings(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, s)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// settingDuration reads a duration such as "90d", "2w" or "36h" from vibe settings.
+// Days and weeks are accepted on top of Go duration units; an unset value returns 0.
+func settingDuration(settings map[string]interface{}, key string) (time.Duration, error) {
+	value, exists := settings[key]
+	if !exists {
+		return 0, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("setting %s must be a duration such as 90d or 36h", key)
+	}
+	duration, err := parseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("setting %s: %w", key, err)
+	}
+	return duration, nil
+}
+
+// parseDuration parses Go durations plus whole days (d) and weeks (w)
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, found := strings.CutSuffix(s, suffix); found {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return duration, nil
+}
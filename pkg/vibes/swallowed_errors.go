@@ -0,0 +1,246 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// errorBlockPatterns match the start of a catch or error-handling block up to
+// and including its opening brace, by language
+var errorBlockPatterns = map[string][]*regexp.Regexp{
+	"go": {
+		// if err != nil {, including if err := f(); err != nil {
+		regexp.MustCompile(`\bif\s+(?:[^{;]*;\s*)?\w*(?:err|Err)\w*\s*!=\s*nil\s*\{`),
+	},
+	"javascript": jsErrorBlockPatterns,
+	"typescript": jsErrorBlockPatterns,
+	"java":       catchBlockPatterns,
+	"csharp":     catchBlockPatterns,
+	"kotlin":     catchBlockPatterns,
+	"scala":      catchBlockPatterns,
+	"groovy":     catchBlockPatterns,
+	"dart":       catchBlockPatterns,
+	"php":        catchBlockPatterns,
+	"swift":      catchBlockPatterns,
+	"cpp":        catchBlockPatterns,
+}
+
+var (
+	// catchBlockPatterns match try/catch with or without a caught variable
+	catchBlockPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?:^|[^.\w])catch\s*(?:\([^)]*\))?\s*\{`),
+	}
+
+	// jsErrorBlockPatterns also match promise .catch() handlers
+	jsErrorBlockPatterns = []*regexp.Regexp{
+		catchBlockPatterns[0],
+		regexp.MustCompile(`\.catch\(\s*(?:async\s+)?(?:\([^)]*\)|\w+)\s*=>\s*\{`),
+		regexp.MustCompile(`\.catch\(\s*(?:async\s+)?function\s*\w*\s*\([^)]*\)\s*\{`),
+	}
+
+	// pythonExceptPattern matches an except clause and captures its indentation and what follows the colon
+	pythonExceptPattern = regexp.MustCompile(`^(\s*)except\b[^:]*:(.*)$`)
+)
+
+// swallowedErrorLanguages are the languages the swallowed-error rule understands
+func swallowedErrorLanguages() []string {
+	languages := []string{"python"}
+	for language := range errorBlockPatterns {
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+// configureSwallowedErrors reads swallowed_error_languages, which limits the
+// rule to some languages, and swallowed_error_allow_comments, which accepts
+// blocks that only hold a comment explaining why the error is ignored
+func (cc *CodeChecker) configureSwallowedErrors(settings map[string]interface{}) error {
+	languages := swallowedErrorLanguages()
+	if _, exists := settings["swallowed_error_languages"]; exists {
+		configured, ok := settingStrings(settings, "swallowed_error_languages")
+		if !ok {
+			return fmt.Errorf("setting swallowed_error_languages must be a list of strings")
+		}
+		languages = configured
+	}
+
+	extensions, err := LanguageExtensions(languages)
+	if err != nil {
+		return fmt.Errorf("invalid swallowed_error_languages: %w", err)
+	}
+	cc.swallowedErrorExtensions = extensions
+
+	cc.swallowedErrorAllowComments, err = settingBool(settings, "swallowed_error_allow_comments", false)
+	return err
+}
+
+// checkSwallowedErrors flags catch blocks, Go "if err != nil" blocks and
+// Python except clauses that are empty or only hold comments or pass
+func (cc *CodeChecker) checkSwallowedErrors(filename string, lines []string) []models.Issue {
+	if !HasExtension(filename, cc.swallowedErrorExtensions) {
+		return nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == ".py" {
+		return cc.checkPythonSwallowedErrors(filename, lines)
+	}
+
+	var patterns []*regexp.Regexp
+	for language, languagePatterns := range errorBlockPatterns {
+		if utils.ContainsString(codeLanguageExtensions[language], ext) {
+			patterns = languagePatterns
+			break
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	content := strings.Join(lines, "\n")
+	reported := make(map[int]bool)
+	var issues []models.Issue
+	for _, pattern := range patterns {
+		for _, match := range pattern.FindAllStringIndex(content, -1) {
+			empty, commented := emptyBraceBlock(content[match[1]:])
+			if !empty || (commented && cc.swallowedErrorAllowComments) {
+				continue
+			}
+
+			// The match may start with the character before "catch"
+			start := match[0] + strings.IndexFunc(content[match[0]:], func(r rune) bool { return r != '\n' && r != ' ' && r != '\t' && r != '}' })
+			lineNumber := strings.Count(content[:start], "\n") + 1
+			if reported[lineNumber] {
+				continue
+			}
+			reported[lineNumber] = true
+
+			message := "Empty catch block swallows the exception"
+			if ext == ".go" {
+				message = "Empty 'if err != nil' block ignores the error"
+			}
+			issues = append(issues, swallowedErrorIssue(filename, lines[lineNumber-1], lineNumber, message, commented))
+		}
+	}
+	return issues
+}
+
+// emptyBraceBlock reports whether the block whose body starts at body closes
+// with nothing but whitespace and comments, and whether it held a comment
+func emptyBraceBlock(body string) (empty, commented bool) {
+	for {
+		body = strings.TrimLeft(body, " \t\r\n")
+		switch {
+		case strings.HasPrefix(body, "}"):
+			return true, commented
+		case strings.HasPrefix(body, "//"):
+			commented = true
+			end := strings.IndexByte(body, '\n')
+			if end < 0 {
+				return false, commented
+			}
+			body = body[end:]
+		case strings.HasPrefix(body, "/*"):
+			commented = true
+			end := strings.Index(body, "*/")
+			if end < 0 {
+				return false, commented
+			}
+			body = body[end+2:]
+		default:
+			return false, commented
+		}
+	}
+}
+
+// checkPythonSwallowedErrors flags except clauses whose body is only pass, ... or comments
+func (cc *CodeChecker) checkPythonSwallowedErrors(filename string, lines []string) []models.Issue {
+	var issues []models.Issue
+	for i, line := range lines {
+		match := pythonExceptPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		indent := len(match[1])
+
+		empty, commented := pythonNoOp(match[2])
+		if !empty {
+			continue
+		}
+		if strings.TrimSpace(stripPythonComment(match[2])) == "" {
+			// The body is on the following, more indented lines
+			body := false
+			for _, next := range lines[i+1:] {
+				trimmed := strings.TrimSpace(next)
+				if trimmed == "" {
+					continue
+				}
+				if len(next)-len(strings.TrimLeft(next, " \t")) <= indent {
+					break
+				}
+				body = true
+				nextEmpty, nextCommented := pythonNoOp(next)
+				commented = commented || nextCommented
+				if !nextEmpty {
+					empty = false
+					break
+				}
+			}
+			if !body {
+				continue
+			}
+		}
+		if !empty || (commented && cc.swallowedErrorAllowComments) {
+			continue
+		}
+
+		issues = append(issues, swallowedErrorIssue(filename, line, i+1, "except block only passes, swallowing the exception", commented))
+	}
+	return issues
+}
+
+// pythonNoOp reports whether a statement is only pass, ..., a comment or
+// nothing, and whether it has a comment
+func pythonNoOp(statement string) (noOp, commented bool) {
+	code := stripPythonComment(statement)
+	commented = code != statement
+	switch strings.TrimSpace(code) {
+	case "", "pass", "...":
+		return true, commented
+	}
+	return false, commented
+}
+
+func stripPythonComment(line string) string {
+	if index := strings.Index(line, "#"); index >= 0 {
+		return line[:index]
+	}
+	return line
+}
+
+func swallowedErrorIssue(filename, line string, lineNumber int, message string, commented bool) models.Issue {
+	confidence := 0.9
+	if commented {
+		// A comment often explains why ignoring the error is safe
+		confidence = 0.7
+		message += " (the block only holds a comment)"
+	}
+	return models.Issue{
+		Type:          models.VibeTypeCode,
+		Severity:      models.SeverityWarning,
+		Title:         "Swallowed error",
+		Message:       message,
+		File:          filename,
+		Line:          lineNumber,
+		Rule:          "swallowed-error",
+		Category:      models.CategoryErrorHandling,
+		Context:       utils.TruncateString(strings.TrimSpace(line), 100),
+		FixSuggestion: "Handle the error, log it, or return it to the caller",
+		Confidence:    confidence,
+	}
+}
@@ -0,0 +1,141 @@
+package vibes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func swallowedErrorLines(issues []models.Issue) []int {
+	var lines []int
+	for _, issue := range issues {
+		if issue.Rule == "swallowed-error" {
+			lines = append(lines, issue.Line)
+		}
+	}
+	return lines
+}
+
+func TestCodeChecker_checkSwallowedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		source   string
+		lines    []int
+	}{
+		{
+			name:     "go empty error blocks",
+			filename: "app.go",
+			source: `package app
+
+func run() error {
+	if err := save(); err != nil {
+	}
+	if err != nil { /* ignored */ }
+	if readErr != nil {
+		return readErr
+	}
+	if err == nil {
+	}
+	return nil
+}`,
+			lines: []int{4, 6},
+		},
+		{
+			name:     "javascript catch blocks and promise handlers",
+			filename: "app.js",
+			source: `try {
+  load();
+} catch (e) {}
+try { load(); } catch {
+  // nothing to do
+}
+try { load(); } catch (e) { report(e); }
+fetch(url).catch(() => {});
+fetch(url).catch(function (err) {
+});
+fetch(url).catch(err => console.error(err));`,
+			lines: []int{3, 4, 8, 9},
+		},
+		{
+			name:     "java empty catch",
+			filename: "App.java",
+			source: `class App {
+  void run() {
+    try {
+      load();
+    } catch (IOException e) {
+    }
+    try { load(); } catch (Exception e) { log.warn("failed", e); }
+  }
+}`,
+			lines: []int{5},
+		},
+		{
+			name:     "python except that only passes",
+			filename: "app.py",
+			source: `try:
+    load()
+except ValueError:
+    pass
+try:
+    load()
+except KeyError: pass
+try:
+    load()
+except Exception as e:  # keep going
+    log.warning(e)
+try:
+    load()
+except:
+    # best effort
+    ...
+print("done")`,
+			lines: []int{3, 7, 14},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewCodeChecker()
+			issues := checker.checkSwallowedErrors(tt.filename, strings.Split(tt.source, "\n"))
+			assert.Equal(t, tt.lines, swallowedErrorLines(issues))
+			for _, issue := range issues {
+				assert.Equal(t, models.SeverityWarning, issue.Severity)
+				assert.Equal(t, models.CategoryErrorHandling, issue.Category)
+			}
+		})
+	}
+}
+
+func TestCodeChecker_SwallowedErrorSettings(t *testing.T) {
+	source := strings.Split(`try { load(); } catch (e) {
+  // the cache is optional
+}
+try { load(); } catch (e) {}`, "\n")
+
+	checker := NewCodeChecker()
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"swallowed_error_allow_comments": true,
+	}}))
+	assert.Equal(t, []int{4}, swallowedErrorLines(checker.checkSwallowedErrors("app.ts", source)))
+
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"swallowed_error_languages": []interface{}{"go", "py"},
+	}}))
+	assert.Empty(t, checker.checkSwallowedErrors("app.ts", source))
+
+	err := checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"swallowed_error_languages": []interface{}{"cobol"},
+	}})
+	assert.ErrorContains(t, err, "invalid swallowed_error_languages")
+
+	err = checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"swallowed_error_allow_comments": "yes",
+	}})
+	assert.Error(t, err)
+}
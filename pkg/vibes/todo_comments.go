@@ -0,0 +1,187 @@
+package vibes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+var (
+	// todoPattern matches a TODO-style marker and its optional (owner/ticket) annotation
+	todoPattern = regexp.MustCompile(`(?i)\b(TODO|FIXME|HACK|XXX|BUG)\b(?:\(([^)]*)\))?`)
+	// todoTicketPattern matches tracker references such as JIRA-123, #42 or gh-42
+	todoTicketPattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9]*-\d+|#\d+)$`)
+)
+
+// parseTodoAnnotation splits a TODO(...) annotation into an owner and a ticket,
+// e.g. "alice", "JIRA-123" or "@alice, #42"
+func parseTodoAnnotation(annotation string) (owner, ticket string) {
+	for _, part := range strings.FieldsFunc(annotation, func(r rune) bool { return r == ',' || r == ' ' }) {
+		switch {
+		case ticket == "" && todoTicketPattern.MatchString(part):
+			ticket = part
+		case owner == "":
+			owner = strings.TrimPrefix(part, "@")
+		}
+	}
+	return owner, ticket
+}
+
+// checkTodoComment flags a TODO-style marker on a line, recording who owns it
+// and which ticket tracks it when the marker is annotated
+func checkTodoComment(filename, line string, lineNumber int) *models.Issue {
+	match := todoPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	marker := strings.ToUpper(match[1])
+	owner, ticket := parseTodoAnnotation(match[2])
+
+	metadata := map[string]interface{}{"marker": marker}
+	message := fmt.Sprintf("%s comments should be tracked in issues", marker)
+	suggestion := "Create an issue to track this task"
+	if owner != "" {
+		metadata["owner"] = owner
+		message = fmt.Sprintf("%s owned by %s should be tracked in an issue", marker, owner)
+	}
+	if ticket != "" {
+		metadata["ticket"] = ticket
+		message = fmt.Sprintf("%s is tracked in %s", marker, ticket)
+		suggestion = fmt.Sprintf("Resolve %s and remove the comment", ticket)
+	}
+
+	return &models.Issue{
+		Type:          models.VibeTypeCode,
+		Severity:      models.SeverityInfo,
+		Title:         "TODO/FIXME comment found",
+		Message:       message,
+		File:          filename,
+		Line:          lineNumber,
+		Rule:          "todo-comments",
+		Category:      models.CategoryMaintainability,
+		Context:       utils.TruncateString(line, 100),
+		Fixable:       false,
+		FixSuggestion: suggestion,
+		Confidence:    1.0,
+		Metadata:      metadata,
+	}
+}
+
+// todoBlameTimeout bounds the git blame run for one file
+const todoBlameTimeout = 10 * time.Second
+
+// blameLine is who last changed a line and when
+type blameLine struct {
+	Author string
+	Time   time.Time
+}
+
+// applyTodoAges dates todo-comments issues with git blame and escalates those
+// older than todo_max_age to warnings. Files outside a git repository are left as they are.
+func (cc *CodeChecker) applyTodoAges(ctx context.Context, filename string, issues []models.Issue) {
+	if cc.todoMaxAge <= 0 {
+		return
+	}
+
+	hasTodos := false
+	for _, issue := range issues {
+		if issue.Rule == "todo-comments" {
+			hasTodos = true
+			break
+		}
+	}
+	if !hasTodos {
+		return
+	}
+
+	blame, err := gitBlame(ctx, filename)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Rule != "todo-comments" {
+			continue
+		}
+		line, ok := blame[issue.Line]
+		if !ok || line.Time.IsZero() {
+			continue
+		}
+
+		age := now.Sub(line.Time)
+		days := int(age.Hours() / 24)
+		issue.Metadata["age_days"] = days
+		issue.Metadata["blame_author"] = line.Author
+
+		if age > cc.todoMaxAge {
+			issue.Severity = models.SeverityWarning
+			issue.Message = fmt.Sprintf("%s (%d days old, max %d)", issue.Message, days, int(cc.todoMaxAge.Hours()/24))
+		}
+	}
+}
+
+// gitBlame returns the author and commit time of every committed line of a file
+func gitBlame(ctx context.Context, filename string) (map[int]blameLine, error) {
+	ctx, cancel := context.WithTimeout(ctx, todoBlameTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "-C", filepath.Dir(filename), "blame", "--line-porcelain", "--", filepath.Base(filename))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git blame failed: %w", err)
+	}
+
+	return parseBlame(output), nil
+}
+
+// parseBlame reads git blame --line-porcelain output. Lines that are not
+// committed yet have an all-zero commit hash and are skipped.
+func parseBlame(output []byte) map[int]blameLine {
+	lines := make(map[int]blameLine)
+
+	var lineNumber int
+	var current blameLine
+	committed := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			// The line's content ends each entry
+			if committed {
+				lines[lineNumber] = current
+			}
+			lineNumber, current = 0, blameLine{}
+		case strings.HasPrefix(text, "author "):
+			current.Author = strings.TrimPrefix(text, "author ")
+		case strings.HasPrefix(text, "author-time "):
+			if seconds, err := strconv.ParseInt(strings.TrimPrefix(text, "author-time "), 10, 64); err == nil {
+				current.Time = time.Unix(seconds, 0)
+			}
+		case lineNumber == 0:
+			// Header: <sha> <original line> <final line> [<group size>]
+			fields := strings.Fields(text)
+			if len(fields) >= 3 && len(fields[0]) >= 40 {
+				lineNumber, _ = strconv.Atoi(fields[2])
+				committed = strings.Trim(fields[0], "0") != ""
+			}
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,325 @@
+package vibes
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// defaultUncheckedErrorExemptions are calls whose errors may be ignored unless
+// unchecked_error_exemptions replaces them. Deferred calls are matched with a "defer " prefix.
+var defaultUncheckedErrorExemptions = []string{"fmt.Print*", "fmt.Fprint*", "defer *.Close"}
+
+// knownErrorFuncs are standard library and common package functions whose last result is an error, keyed by import path
+var knownErrorFuncs = map[string][]string{
+	"os":               {"Chdir", "Chmod", "Chown", "Link", "Mkdir", "MkdirAll", "Remove", "RemoveAll", "Rename", "Setenv", "Symlink", "Truncate", "Unsetenv", "WriteFile"},
+	"fmt":              {"Fprint", "Fprintf", "Fprintln", "Print", "Printf", "Println"},
+	"io":               {"Copy", "CopyN", "ReadFull", "WriteString"},
+	"encoding/json":    {"Unmarshal"},
+	"encoding/xml":     {"Unmarshal"},
+	"gopkg.in/yaml.v2": {"Unmarshal"},
+	"gopkg.in/yaml.v3": {"Unmarshal"},
+	"net/http":         {"ListenAndServe", "ListenAndServeTLS"},
+}
+
+// knownErrorMethods are method names that conventionally return an error
+// (io.Closer, bufio.Writer, http.Server, sql.Tx, encoders and decoders)
+var knownErrorMethods = []string{"Close", "Commit", "Decode", "Encode", "Flush", "Rollback", "Shutdown", "Sync"}
+
+// knownCommaOkCalls return a value and a bool rather than an error, so "v, _ := f()" is fine
+var knownCommaOkCalls = []string{"Load", "LoadAndDelete", "LoadOrStore", "LookupEnv", "DecodeRune", "DecodeRuneInString", "DecodeLastRune", "DecodeLastRuneInString"}
+
+var (
+	// moduleMajorVersion matches the last element of module paths such as example.com/mod/v2
+	moduleMajorVersion = regexp.MustCompile(`^v\d+$`)
+	// gopkgVersionSuffix matches the version suffix of paths such as gopkg.in/yaml.v3
+	gopkgVersionSuffix = regexp.MustCompile(`\.v\d+$`)
+)
+
+// goPackageFuncs records which functions and methods declared in a Go package return an error
+type goPackageFuncs struct {
+	funcs   map[string]bool
+	methods map[string]bool
+}
+
+// goPackageCache memoizes goPackageFuncs per directory and package name
+type goPackageCache struct {
+	mutex    sync.Mutex
+	packages map[string]*goPackageFuncs
+}
+
+func newGoPackageCache() *goPackageCache {
+	return &goPackageCache{packages: make(map[string]*goPackageFuncs)}
+}
+
+// configureUncheckedErrors reads unchecked_error_exemptions, which replaces the default exemptions
+func (cc *CodeChecker) configureUncheckedErrors(settings map[string]interface{}) error {
+	cc.uncheckedErrorExemptions = defaultUncheckedErrorExemptions
+
+	if _, exists := settings["unchecked_error_exemptions"]; !exists {
+		return nil
+	}
+	exemptions, ok := settingStrings(settings, "unchecked_error_exemptions")
+	if !ok {
+		return fmt.Errorf("setting unchecked_error_exemptions must be a list of strings")
+	}
+	for _, exemption := range exemptions {
+		if _, err := path.Match(exemption, ""); err != nil {
+			return fmt.Errorf("invalid unchecked_error_exemptions pattern %q: %w", exemption, err)
+		}
+	}
+	cc.uncheckedErrorExemptions = exemptions
+
+	return nil
+}
+
+// checkUncheckedErrors parses a Go file and flags errors that are discarded with _
+// or dropped by calling an error-returning function as a statement. Without type
+// information, calls are known to return errors from the package's own declarations
+// and a list of common standard library functions and methods.
+func (cc *CodeChecker) checkUncheckedErrors(filename string, lines []string) []models.Issue {
+	if strings.ToLower(filepath.Ext(filename)) != ".go" {
+		return nil
+	}
+
+	fset, file, err := cc.goASTs.Parse(filename, []byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return nil
+	}
+
+	resolver := &errorCallResolver{
+		local:   cc.goPackages.lookup(filename, file),
+		imports: importNames(file),
+	}
+
+	var issues []models.Issue
+	report := func(node ast.Node, call *ast.CallExpr, name, message string, confidence float64) {
+		if cc.uncheckedErrorExempt(name) {
+			return
+		}
+		position := fset.Position(node.Pos())
+		line := ""
+		if position.Line > 0 && position.Line <= len(lines) {
+			line = lines[position.Line-1]
+		}
+		issues = append(issues, models.Issue{
+			Type:          models.VibeTypeCode,
+			Severity:      models.SeverityWarning,
+			Title:         "Unchecked error",
+			Message:       message,
+			File:          filename,
+			Line:          position.Line,
+			Column:        position.Column,
+			Rule:          "unchecked-error",
+			Category:      models.CategoryErrorHandling,
+			Context:       utils.TruncateString(strings.TrimSpace(line), 100),
+			FixSuggestion: "Handle the error, return it, or log why it can be ignored",
+			Confidence:    confidence,
+			Metadata:      map[string]interface{}{"call": types.ExprString(call.Fun)},
+		})
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch stmt := node.(type) {
+		case *ast.ExprStmt:
+			call, ok := stmt.X.(*ast.CallExpr)
+			if ok && resolver.returnsError(call) == resolvedError {
+				name := types.ExprString(call.Fun)
+				report(stmt, call, name, fmt.Sprintf("Error returned by %s is not checked", name), 0.9)
+			}
+		case *ast.DeferStmt:
+			if resolver.returnsError(stmt.Call) == resolvedError {
+				name := types.ExprString(stmt.Call.Fun)
+				report(stmt, stmt.Call, "defer "+name, fmt.Sprintf("Error returned by deferred %s is not checked", name), 0.8)
+			}
+		case *ast.AssignStmt:
+			if len(stmt.Rhs) != 1 || !isBlank(stmt.Lhs[len(stmt.Lhs)-1]) {
+				return true
+			}
+			call, ok := stmt.Rhs[0].(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			name := types.ExprString(call.Fun)
+			switch resolver.returnsError(call) {
+			case resolvedError:
+				report(stmt, call, name, fmt.Sprintf("Error returned by %s is discarded with _", name), 0.9)
+			case unresolved:
+				// "_ = f()" is only needed to silence an ignored error; "v, _ := f()" usually is one
+				confidence := 0.8
+				if len(stmt.Lhs) > 1 {
+					confidence = 0.6
+				}
+				report(stmt, call, name, fmt.Sprintf("Error returned by %s is discarded with _", name), confidence)
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// uncheckedErrorExempt reports whether a call name matches unchecked_error_exemptions
+func (cc *CodeChecker) uncheckedErrorExempt(name string) bool {
+	for _, exemption := range cc.uncheckedErrorExemptions {
+		if matched, _ := path.Match(exemption, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// errorResolution is what is known about a call's last result
+type errorResolution int
+
+const (
+	unresolved errorResolution = iota
+	resolvedError
+	resolvedNoError
+)
+
+// errorCallResolver works out whether calls in one file return an error
+type errorCallResolver struct {
+	local *goPackageFuncs
+	// imports maps the names packages are imported under to their import paths
+	imports map[string]string
+}
+
+func (r *errorCallResolver) returnsError(call *ast.CallExpr) errorResolution {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if returnsErr, declared := r.local.funcs[fun.Name]; declared {
+			return resolution(returnsErr)
+		}
+	case *ast.SelectorExpr:
+		method := fun.Sel.Name
+		if pkg, ok := fun.X.(*ast.Ident); ok {
+			if importPath, imported := r.imports[pkg.Name]; imported {
+				if utils.ContainsString(knownCommaOkCalls, method) {
+					return resolvedNoError
+				}
+				if utils.ContainsString(knownErrorFuncs[importPath], method) {
+					return resolvedError
+				}
+				return unresolved
+			}
+		}
+		if returnsErr, declared := r.local.methods[method]; declared {
+			return resolution(returnsErr)
+		}
+		if utils.ContainsString(knownErrorMethods, method) {
+			return resolvedError
+		}
+		if utils.ContainsString(knownCommaOkCalls, method) {
+			return resolvedNoError
+		}
+	}
+	return unresolved
+}
+
+func resolution(returnsErr bool) errorResolution {
+	if returnsErr {
+		return resolvedError
+	}
+	return resolvedNoError
+}
+
+// lookup returns the declarations of the package file belongs to, parsing its
+// sibling files the first time the package is seen
+func (c *goPackageCache) lookup(filename string, file *ast.File) *goPackageFuncs {
+	dir := filepath.Dir(filename)
+	key := dir + "\x00" + file.Name.Name
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if funcs, ok := c.packages[key]; ok {
+		return funcs
+	}
+
+	funcs := &goPackageFuncs{funcs: make(map[string]bool), methods: make(map[string]bool)}
+	funcs.add(file)
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		sibling := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" || sibling == filename {
+			continue
+		}
+		parsed, err := parser.ParseFile(token.NewFileSet(), sibling, nil, parser.SkipObjectResolution)
+		if err != nil || parsed.Name.Name != file.Name.Name {
+			continue
+		}
+		funcs.add(parsed)
+	}
+
+	c.packages[key] = funcs
+	return funcs
+}
+
+// add records the functions and methods declared in file. A method name counts
+// as error-returning only if every declaration of it returns an error.
+func (p *goPackageFuncs) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		returnsErr := lastResultIsError(fn.Type)
+		if fn.Recv == nil {
+			p.funcs[fn.Name.Name] = returnsErr
+			continue
+		}
+		if previous, seen := p.methods[fn.Name.Name]; seen {
+			returnsErr = returnsErr && previous
+		}
+		p.methods[fn.Name.Name] = returnsErr
+	}
+}
+
+func lastResultIsError(fn *ast.FuncType) bool {
+	if fn.Results == nil || len(fn.Results.List) == 0 {
+		return false
+	}
+	ident, ok := fn.Results.List[len(fn.Results.List)-1].Type.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+// importNames maps the names a file's imports are used under to their paths
+func importNames(file *ast.File) map[string]string {
+	names := make(map[string]string, len(file.Imports))
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			names[spec.Name.Name] = importPath
+			continue
+		}
+		elements := strings.Split(importPath, "/")
+		name := elements[len(elements)-1]
+		if len(elements) > 1 && moduleMajorVersion.MatchString(name) {
+			name = elements[len(elements)-2]
+		}
+		names[gopkgVersionSuffix.ReplaceAllString(name, "")] = importPath
+	}
+	return names
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
@@ -0,0 +1,114 @@
+package vibes
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestCodeChecker_checkUncheckedErrors(t *testing.T) {
+	dir := t.TempDir()
+	helper := `package app
+
+func save() error { return nil }
+
+func count() (int, bool) { return 0, true }
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "helper.go"), []byte(helper), 0644))
+
+	source := `package app
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+func run(f *os.File) error {
+	defer f.Close()
+	save()
+	_ = save()
+	os.Remove("tmp")
+	n, _ := strconv.Atoi("1")
+	c, _ := count()
+	v, _ := os.LookupEnv("HOME")
+	fmt.Println(n, c, v)
+	f.Close()
+	if err := save(); err != nil {
+		return err
+	}
+	return nil
+}
+`
+	filename := filepath.Join(dir, "app.go")
+	require.NoError(t, os.WriteFile(filename, []byte(source), 0644))
+
+	checker := NewCodeChecker()
+	issues := checker.checkUncheckedErrors(filename, strings.Split(source, "\n"))
+
+	lines := map[int]string{}
+	for _, issue := range issues {
+		assert.Equal(t, "unchecked-error", issue.Rule)
+		assert.Equal(t, models.SeverityWarning, issue.Severity)
+		lines[issue.Line] = issue.Message
+	}
+	assert.Equal(t, map[int]string{
+		11: "Error returned by save is not checked",
+		12: "Error returned by save is discarded with _",
+		13: "Error returned by os.Remove is not checked",
+		14: "Error returned by strconv.Atoi is discarded with _",
+		18: "Error returned by f.Close is not checked",
+	}, lines)
+
+	// Replacing the exemptions reports deferred Close and fmt.Println too
+	require.NoError(t, checker.Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"unchecked_error_exemptions": []interface{}{"os.*", "strconv.*"},
+	}}))
+	lines = map[int]string{}
+	for _, issue := range checker.checkUncheckedErrors(filename, strings.Split(source, "\n")) {
+		lines[issue.Line] = issue.Message
+	}
+	assert.Contains(t, lines, 10)
+	assert.Contains(t, lines, 17)
+	assert.NotContains(t, lines, 13)
+	assert.NotContains(t, lines, 14)
+
+	assert.Error(t, NewCodeChecker().Configure(models.VibeConfig{Settings: map[string]interface{}{
+		"unchecked_error_exemptions": []interface{}{"[bad"},
+	}}))
+}
+
+func TestCodeChecker_checkUncheckedErrorsIgnoresOtherFiles(t *testing.T) {
+	checker := NewCodeChecker()
+	assert.Empty(t, checker.checkUncheckedErrors("app.py", []string{"_ = save()"}))
+	// Files that don't parse are skipped
+	assert.Empty(t, checker.checkUncheckedErrors("broken.go", []string{"package app", "func {"}))
+}
+
+func TestImportNames(t *testing.T) {
+	source := `package app
+
+import (
+	"encoding/json"
+	yml "gopkg.in/yaml.v3"
+	"example.com/mod/v2"
+	"gopkg.in/yaml.v2"
+)
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "app.go", source, parser.ImportsOnly)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"json": "encoding/json",
+		"yml":  "gopkg.in/yaml.v3",
+		"mod":  "example.com/mod/v2",
+		"yaml": "gopkg.in/yaml.v2",
+	}, importNames(file))
+}
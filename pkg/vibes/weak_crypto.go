@@ -0,0 +1,234 @@
+package vibes
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+)
+
+// weakCryptoLanguages maps the extensions searched for weak crypto to their language
+var weakCryptoLanguages = map[string]string{
+	".go":   "go",
+	".py":   "python",
+	".java": "java",
+	".js":   "javascript",
+	".jsx":  "javascript",
+	".ts":   "javascript",
+	".tsx":  "javascript",
+}
+
+// weakCryptoCheck is one weak crypto rule and the patterns of each language
+// that break it
+type weakCryptoCheck struct {
+	Rule       string
+	Title      string
+	Severity   models.SeverityLevel
+	Confidence float64
+	Suggestion string
+	// Setting is the vibe setting whose language lists replace Patterns
+	Setting  string
+	Patterns map[string][]*regexp.Regexp
+}
+
+// weakCryptoLiterals are lowercase substrings of which every weak crypto match contains one
+var weakCryptoLiterals = []string{
+	"des", "rc4", "arc4", "rc2", "arc2", "blowfish", "createcipher", "ecb", "aes\"", "idea", "cast",
+	"insecureskipverify", "verify", "cert_none", "hostname", "trustmanager", "rejectunauthorized",
+	"ssl", "tls",
+}
+
+// defaultWeakCryptoPatterns are the built-in patterns keyed by setting, then language
+var defaultWeakCryptoPatterns = map[string]map[string][]string{
+	"weak_cipher_patterns": {
+		"go": {
+			`\b(?:des\.NewCipher|des\.NewTripleDESCipher|rc4\.NewCipher|blowfish\.NewCipher)\s*\(`,
+		},
+		"python": {
+			`\b(?:DES|DES3|ARC2|ARC4|Blowfish|CAST)\.new\s*\(`,
+			`\bMODE_ECB\b`,
+			`\balgorithms\.(?:TripleDES|ARC4|Blowfish|IDEA|CAST5)\s*\(`,
+			`\bmodes\.ECB\s*\(`,
+		},
+		"java": {
+			`\bCipher\.getInstance\s*\(\s*"(?:DES|DESede|TripleDES|RC2|RC4|ARCFOUR|Blowfish)\b`,
+			`\bCipher\.getInstance\s*\(\s*"[^"]*/ECB/`,
+			// AES alone is AES/ECB/PKCS5Padding
+			`\bCipher\.getInstance\s*\(\s*"AES"\s*\)`,
+		},
+		"javascript": {
+			`\bcreateCipher(?:iv)?\s*\(\s*['"` + "`" + `](?i:des|des-ede3?|des-ede3?-cbc|des-cbc|rc2|rc4|bf|bf-cbc|blowfish)['"` + "`" + `]`,
+			`\bcreateCipher(?:iv)?\s*\(\s*['"` + "`" + `][\w-]*-(?i:ecb)['"` + "`" + `]`,
+		},
+	},
+	"insecure_tls_verify_patterns": {
+		"go": {
+			`\bInsecureSkipVerify\s*[:=]\s*true\b`,
+		},
+		"python": {
+			`\bverify\s*=\s*False\b`,
+			`\bssl\.CERT_NONE\b`,
+			`\bssl\._create_unverified_context\s*\(`,
+			`\bcheck_hostname\s*=\s*False\b`,
+		},
+		"java": {
+			`\bNoopHostnameVerifier\b`,
+			`\bALLOW_ALL_HOSTNAME_VERIFIER\b`,
+			`\bInsecureTrustManagerFactory\b`,
+			`\bsetHostnameVerifier\s*\(\s*\(\s*\w*\s*,\s*\w*\s*\)\s*->\s*true\b`,
+		},
+		"javascript": {
+			`\brejectUnauthorized\s*:\s*false\b`,
+			`\bNODE_TLS_REJECT_UNAUTHORIZED\b\s*=\s*['"` + "`" + `]?0`,
+		},
+	},
+	"weak_tls_version_patterns": {
+		"go": {
+			`\bMinVersion\s*[:=]\s*tls\.Version(?:SSL30|TLS10|TLS11)\b`,
+			`\bMaxVersion\s*[:=]\s*tls\.Version(?:SSL30|TLS10|TLS11)\b`,
+		},
+		"python": {
+			`\bPROTOCOL_(?:SSLv2|SSLv3|TLSv1|TLSv1_1)\b`,
+			`\bTLSVersion\.(?:SSLv3|TLSv1|TLSv1_1)\b`,
+		},
+		"java": {
+			`\bSSLContext\.getInstance\s*\(\s*"(?:SSL|SSLv2|SSLv3|TLSv1|TLSv1\.1)"`,
+			`\bsetEnabledProtocols\s*\(.*"(?:SSLv2Hello|SSLv2|SSLv3|TLSv1|TLSv1\.1)"`,
+		},
+		"javascript": {
+			`\b(?:minVersion|maxVersion)\s*:\s*['"` + "`" + `](?:TLSv1|TLSv1\.1)['"` + "`" + `]`,
+			`\bsecureProtocol\s*:\s*['"` + "`" + `](?:SSLv2|SSLv3|TLSv1|TLSv1_1)_(?:client_|server_)?method['"` + "`" + `]`,
+		},
+	},
+}
+
+// newWeakCryptoChecks returns the weak crypto rules with the built-in patterns
+func newWeakCryptoChecks() []*weakCryptoCheck {
+	checks := []*weakCryptoCheck{
+		{
+			Rule:       "weak-cipher",
+			Title:      "Weak cipher",
+			Severity:   models.SeverityWarning,
+			Confidence: 0.8,
+			Suggestion: "Use AES-GCM or ChaCha20-Poly1305; DES, 3DES, RC4 and Blowfish are broken or too weak, and ECB mode leaks patterns in the plaintext",
+			Setting:    "weak_cipher_patterns",
+		},
+		{
+			Rule:       "insecure-tls-verify",
+			Title:      "TLS certificate verification disabled",
+			Severity:   models.SeverityError,
+			Confidence: 0.85,
+			Suggestion: "Keep certificate and hostname verification on; trust a private CA by adding it to the trusted roots instead",
+			Setting:    "insecure_tls_verify_patterns",
+		},
+		{
+			Rule:       "weak-tls-version",
+			Title:      "Weak TLS version",
+			Severity:   models.SeverityWarning,
+			Confidence: 0.8,
+			Suggestion: "Require TLS 1.2 or later (e.g. tls.VersionTLS12, ssl.TLSVersion.TLSv1_2, \"TLSv1.2\")",
+			Setting:    "weak_tls_version_patterns",
+		},
+	}
+	for _, check := range checks {
+		check.Patterns = make(map[string][]*regexp.Regexp)
+		for language, patterns := range defaultWeakCryptoPatterns[check.Setting] {
+			// The defaults always compile
+			check.Patterns[language], _ = compilePatterns(patterns)
+		}
+	}
+	return checks
+}
+
+// configureWeakCrypto applies the weak crypto settings on top of the defaults.
+// Each language listed in a pattern setting replaces that language's patterns.
+func (sc *SecurityChecker) configureWeakCrypto(settings map[string]interface{}) error {
+	var err error
+	if sc.weakCrypto, err = settingBool(settings, "weak_crypto", true); err != nil {
+		return err
+	}
+
+	sc.weakCryptoChecks = newWeakCryptoChecks()
+	for _, check := range sc.weakCryptoChecks {
+		patterns, err := settingStringLists(settings, check.Setting)
+		if err != nil {
+			return err
+		}
+		for language, configured := range patterns {
+			if !utils.ContainsString(weakCryptoLanguageNames(), language) {
+				return fmt.Errorf("unknown language %q in %s, expected one of %s",
+					language, check.Setting, strings.Join(weakCryptoLanguageNames(), ", "))
+			}
+			compiled, err := compilePatterns(configured)
+			if err != nil {
+				return fmt.Errorf("invalid %s for %s: %w", check.Setting, language, err)
+			}
+			check.Patterns[language] = compiled
+		}
+	}
+	return nil
+}
+
+// weakCryptoLanguageFor returns the language whose weak crypto patterns apply to a file, if any
+func weakCryptoLanguageFor(filename string) string {
+	return weakCryptoLanguages[strings.ToLower(filepath.Ext(filename))]
+}
+
+// checkLineForWeakCrypto flags weak ciphers, disabled certificate
+// verification and TLS versions below 1.2, at most once per rule and line
+func (sc *SecurityChecker) checkLineForWeakCrypto(filename, language, line string, lineNumber int) []models.Issue {
+	trimmed := strings.TrimSpace(line)
+	if IsCommentLine(trimmed) {
+		return nil
+	}
+
+	var issues []models.Issue
+	for _, check := range sc.weakCryptoChecks {
+		for _, pattern := range check.Patterns[language] {
+			bounds := pattern.FindStringIndex(line)
+			if bounds == nil {
+				continue
+			}
+			match := strings.TrimSpace(line[bounds[0]:bounds[1]])
+			issues = append(issues, models.Issue{
+				Type:          models.VibeTypeSecurity,
+				Severity:      check.Severity,
+				Title:         check.Title,
+				Message:       fmt.Sprintf("%s: '%s'", check.Title, match),
+				File:          filename,
+				Line:          lineNumber,
+				Column:        bounds[0] + 1,
+				Rule:          check.Rule,
+				Category:      models.CategorySecurity,
+				Context:       utils.TruncateString(line, 100),
+				Fixable:       false,
+				FixSuggestion: check.Suggestion,
+				Confidence:    check.Confidence,
+				Metadata: map[string]interface{}{
+					"language": language,
+					"match":    match,
+				},
+			})
+			break
+		}
+	}
+	return issues
+}
+
+// weakCryptoLanguageNames returns the languages weak crypto settings accept, sorted
+func weakCryptoLanguageNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, language := range weakCryptoLanguages {
+		if !seen[language] {
+			seen[language] = true
+			names = append(names, language)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package watch
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"kodevibe/internal/models"
+)
+
+// DefaultDebounce is how long the watcher waits for changes to settle when
+// watch.debounce is unset
+const DefaultDebounce = 300 * time.Millisecond
+
+// debouncer collects changed paths until none have arrived for its window,
+// then hands them to flush as one batch, so an editor's burst of events on
+// save becomes one scan
+type debouncer struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[string]bool
+	timer   *time.Timer
+	flush   func(paths []string)
+}
+
+// newDebouncer creates a debouncer that calls flush with the sorted paths of
+// each batch, from its own goroutine
+func newDebouncer(window time.Duration, flush func(paths []string)) *debouncer {
+	return &debouncer{window: window, pending: make(map[string]bool), flush: flush}
+}
+
+// add queues path and restarts the window
+func (d *debouncer) add(path string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pending[path] = true
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.window, d.fire)
+		return
+	}
+	d.timer.Reset(d.window)
+}
+
+// fire hands the pending paths to flush
+func (d *debouncer) fire() {
+	d.mu.Lock()
+	paths := make([]string, 0, len(d.pending))
+	for path := range d.pending {
+		paths = append(paths, path)
+	}
+	d.pending = make(map[string]bool)
+	d.timer = nil
+	d.mu.Unlock()
+
+	if len(paths) == 0 {
+		return
+	}
+	sort.Strings(paths)
+	d.flush(paths)
+}
+
+// stop drops the pending paths without flushing them
+func (d *debouncer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.pending = make(map[string]bool)
+}
+
+// issueDelta is how the issues of rescanned files changed since their last scan
+type issueDelta struct {
+	New      []models.Issue
+	Resolved []models.Issue
+}
+
+// add compares one file's previous and current issues by fingerprint, so an
+// issue that only moved lines is neither new nor resolved
+func (d *issueDelta) add(previous, current []models.Issue) {
+	unmatched := make(map[string]int)
+	for _, issue := range previous {
+		unmatched[issue.Fingerprint()]++
+	}
+	for _, issue := range current {
+		fingerprint := issue.Fingerprint()
+		if unmatched[fingerprint] > 0 {
+			unmatched[fingerprint]--
+			continue
+		}
+		d.New = append(d.New, issue)
+	}
+	for _, issue := range previous {
+		fingerprint := issue.Fingerprint()
+		if unmatched[fingerprint] > 0 {
+			unmatched[fingerprint]--
+			d.Resolved = append(d.Resolved, issue)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package watch
+
+import (
+	"bytes"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+)
+
+func TestDebouncer_CoalescesBursts(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	d := newDebouncer(50*time.Millisecond, func(paths []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, paths)
+	})
+
+	for _, path := range []string{"b.js", "a.js", "b.js"} {
+		d.add(path)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(batches) > 0
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, [][]string{{"a.js", "b.js"}}, batches)
+}
+
+func TestDebouncer_StopDropsPending(t *testing.T) {
+	flushed := make(chan []string, 1)
+	d := newDebouncer(20*time.Millisecond, func(paths []string) { flushed <- paths })
+	d.add("a.js")
+	d.stop()
+
+	select {
+	case paths := <-flushed:
+		t.Fatalf("flushed %v after stop", paths)
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func TestIssueDelta_MatchesByFingerprint(t *testing.T) {
+	kept := models.Issue{Type: models.VibeTypeCode, Rule: "no-var", File: "a.js", Line: 1, Message: "Prefer let or const over var"}
+	moved := kept
+	moved.Line = 7
+	resolved := models.Issue{Type: models.VibeTypeCode, Rule: "strict-equality", File: "a.js", Line: 2, Message: "Use === instead of =="}
+	added := models.Issue{Type: models.VibeTypeCode, Rule: "no-console-log", File: "a.js", Line: 3, Message: "console.log found"}
+
+	var delta issueDelta
+	delta.add([]models.Issue{kept, resolved}, []models.Issue{moved, added})
+	assert.Equal(t, []models.Issue{added}, delta.New)
+	assert.Equal(t, []models.Issue{resolved}, delta.Resolved)
+
+	delta = issueDelta{}
+	delta.add([]models.Issue{kept, kept}, []models.Issue{kept})
+	assert.Empty(t, delta.New)
+	assert.Equal(t, []models.Issue{kept}, delta.Resolved)
+}
+
+func TestWatcher_ScansChangedFilesAndLogsDelta(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "app.js")
+	excluded := filepath.Join(dir, "bundle.min.js")
+	writeFile(t, source, "var x = 1;\n")
+	writeFile(t, excluded, "var y = 2;\n")
+
+	var logs bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+	config := &models.Configuration{
+		Scanner: models.ScannerConfig{MaxConcurrency: 2, Timeout: 10, EnabledVibes: []string{"code"}},
+		Exclude: models.ExcludeConfig{Patterns: []string{"*.min.js"}},
+	}
+	w := NewWatcher(config, logger)
+
+	batches := make(chan []string, 1)
+	w.debouncer = newDebouncer(20*time.Millisecond, func(paths []string) { batches <- paths })
+	w.handleFileEvent(fsnotify.Event{Name: excluded, Op: fsnotify.Write})
+	w.handleFileEvent(fsnotify.Event{Name: source, Op: fsnotify.Write})
+	w.handleFileEvent(fsnotify.Event{Name: source, Op: fsnotify.Chmod})
+
+	var paths []string
+	select {
+	case paths = <-batches:
+	case <-time.After(time.Second):
+		t.Fatal("no batch was flushed")
+	}
+	assert.Equal(t, []string{source}, paths)
+
+	vibes := []models.VibeType{models.VibeTypeCode}
+	w.scanChangedFiles(paths, false, vibes)
+	require.NotEmpty(t, w.fileIssues(source))
+	assert.Equal(t, "no-var", w.fileIssues(source)[0].Rule)
+	assert.Contains(t, logs.String(), "1 new, 0 resolved")
+
+	logs.Reset()
+	writeFile(t, source, "let x = 1;\n")
+	w.scanChangedFiles(paths, false, vibes)
+	assert.Empty(t, w.fileIssues(source))
+	assert.Contains(t, logs.String(), "0 new, 1 resolved")
+}
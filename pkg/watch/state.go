@@ -0,0 +1,281 @@
+package watch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	"kodevibe/pkg/ignore"
+	"kodevibe/pkg/scanner"
+)
+
+// DefaultMaxStateBytes bounds the persisted watch state when watch.max_state_bytes is unset
+const DefaultMaxStateBytes = 4 << 20
+
+// stateVersion is bumped when the state file format changes; other versions are discarded
+const stateVersion = 2
+
+// State is what the watcher knows about each file it has scanned, persisted
+// between runs so a restarted watcher resumes instead of starting over
+type State struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+	// ConfigHash is the ConfigHash the files were scanned with
+	ConfigHash string                `json:"config_hash"`
+	Files      map[string]*FileState `json:"files"`
+}
+
+// FileState is a file as it was when last scanned, and what the scan found
+type FileState struct {
+	ModTime   time.Time      `json:"mod_time"`
+	Size      int64          `json:"size"`
+	Hash      string         `json:"hash"`
+	ScannedAt time.Time      `json:"scanned_at"`
+	Issues    []models.Issue `json:"issues,omitempty"`
+}
+
+// NewState creates an empty watch state
+func NewState() *State {
+	return &State{Version: stateVersion, Files: make(map[string]*FileState)}
+}
+
+// LoadState reads the state saved at path. A missing file, or one written by
+// another version, gives an empty state.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch state: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse watch state %s: %w", path, err)
+	}
+	if state.Version != stateVersion {
+		return NewState(), nil
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]*FileState)
+	}
+	return &state, nil
+}
+
+// Save writes the state to path through a temporary file, first dropping the
+// files scanned longest ago until it fits in maxBytes; they are rescanned on
+// the next start. maxBytes of 0 or less means DefaultMaxStateBytes.
+func (s *State) Save(path string, maxBytes int) error {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxStateBytes
+	}
+	s.SavedAt = time.Now()
+	s.trim(maxBytes)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode watch state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create watch state directory: %w", err)
+	}
+
+	// Write through a temporary file so a crash never leaves a partial state
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".watch-state-*")
+	if err != nil {
+		return fmt.Errorf("failed to create watch state file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write watch state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write watch state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save watch state: %w", err)
+	}
+	return nil
+}
+
+// trim drops the files scanned longest ago until the encoded state fits in maxBytes
+func (s *State) trim(maxBytes int) {
+	sizes := make(map[string]int, len(s.Files))
+	total := 64
+	for path, file := range s.Files {
+		encoded, _ := json.Marshal(file)
+		sizes[path] = len(path) + len(encoded) + 4
+		total += sizes[path]
+	}
+	if total <= maxBytes {
+		return
+	}
+
+	paths := make([]string, 0, len(s.Files))
+	for path := range s.Files {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return s.Files[paths[i]].ScannedAt.Before(s.Files[paths[j]].ScannedAt)
+	})
+	for _, path := range paths {
+		if total <= maxBytes {
+			break
+		}
+		total -= sizes[path]
+		delete(s.Files, path)
+	}
+}
+
+// Unchanged reports whether the file at path is as it was when last scanned.
+// A matching mtime and size are trusted; otherwise the content hash decides,
+// so a touched but unedited file is not rescanned.
+func (s *State) Unchanged(path string, info os.FileInfo) bool {
+	file, exists := s.Files[path]
+	if !exists {
+		return false
+	}
+	if file.ModTime.Equal(info.ModTime()) && file.Size == info.Size() {
+		return true
+	}
+
+	hash, err := hashFile(path)
+	if err != nil || hash != file.Hash {
+		return false
+	}
+	file.ModTime, file.Size = info.ModTime(), info.Size()
+	return true
+}
+
+// Record stores the issues a scan of the file at path found
+func (s *State) Record(path string, info os.FileInfo, issues []models.Issue) error {
+	hash, err := hashFile(path)
+	if err != nil {
+		return err
+	}
+	s.Files[path] = &FileState{
+		ModTime:   info.ModTime(),
+		Size:      info.Size(),
+		Hash:      hash,
+		ScannedAt: time.Now(),
+		Issues:    issues,
+	}
+	return nil
+}
+
+// Forget drops a file that was deleted or is no longer watched
+func (s *State) Forget(path string) {
+	delete(s.Files, path)
+}
+
+// Result returns the state as a scan result over every file it holds
+func (s *State) Result(gradeThresholds []models.GradeThreshold) *models.ScanResult {
+	paths := make([]string, 0, len(s.Files))
+	for path := range s.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	issues := []models.Issue{}
+	for _, path := range paths {
+		issues = append(issues, s.Files[path].Issues...)
+	}
+	return &models.ScanResult{
+		EndTime:      s.SavedAt,
+		Issues:       issues,
+		FilesScanned: len(paths),
+		Files:        paths,
+		Summary:      scanner.Summarize(issues, gradeThresholds),
+	}
+}
+
+// ConfigHash identifies everything besides the files themselves that the
+// findings of a watch depend on: the KodeVibe version, the vibes, the
+// configuration deciding which files are scanned and what they report, and
+// the .kodevibeignore files of the watched paths and their parents. A state
+// saved under another hash is discarded.
+func ConfigHash(config *models.Configuration, vibes []string, toolVersion string, paths []string) string {
+	lowered := make([]string, len(vibes))
+	for i, vibe := range vibes {
+		lowered[i] = strings.ToLower(vibe)
+	}
+	sort.Strings(lowered)
+	parts := []string{toolVersion, strings.Join(lowered, ",")}
+
+	// Maps marshal with sorted keys, so equal configurations give equal hashes
+	effective := struct {
+		Scanner     models.ScannerConfig
+		Vibes       map[models.VibeType]models.VibeConfig
+		Exclude     models.ExcludeConfig
+		CustomRules []models.CustomRule
+		Languages   map[string]models.LanguageConfig
+		Advanced    models.AdvancedConfig
+	}{config.Scanner, config.Vibes, config.Exclude, config.CustomRules, config.Languages, config.Advanced}
+	if data, err := json.Marshal(effective); err == nil {
+		parts = append(parts, string(data))
+	}
+
+	// Ignore files deeper in the watched paths need no hashing: the walk for
+	// changed files forgets the files they now leave out and finds the ones
+	// they let back in
+	for _, path := range parentIgnoreFiles(paths) {
+		if data, err := os.ReadFile(path); err == nil {
+			parts = append(parts, path+":"+string(data))
+		}
+	}
+	return utils.HashStrings(parts)
+}
+
+// parentIgnoreFiles returns the .kodevibeignore files in the watched
+// directories and their parents
+func parentIgnoreFiles(paths []string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, path := range paths {
+		dir, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+			dir = filepath.Dir(dir)
+		}
+		for {
+			file := filepath.Join(dir, ignore.FileName)
+			if !seen[file] {
+				seen[file] = true
+				if _, err := os.Stat(file); err == nil {
+					files = append(files, file)
+				}
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// hashFile returns the hex SHA-256 of a file's contents
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,185 @@
+package watch
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kodevibe/internal/models"
+	"kodevibe/pkg/ignore"
+)
+
+func writeFile(t *testing.T, path, content string) os.FileInfo {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info
+}
+
+func TestState_SaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, ".kodevibe", "watch-state.json")
+
+	empty, err := LoadState(statePath)
+	require.NoError(t, err)
+	assert.Empty(t, empty.Files)
+
+	source := filepath.Join(dir, "app.js")
+	info := writeFile(t, source, "var x = 1;\n")
+	state := NewState()
+	state.ConfigHash = "0123456789abcdef"
+	issues := []models.Issue{{Type: models.VibeTypeCode, Severity: models.SeverityWarning, Rule: "no-var", File: source, Line: 1}}
+	require.NoError(t, state.Record(source, info, issues))
+	require.NoError(t, state.Save(statePath, 0))
+
+	loaded, err := LoadState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", loaded.ConfigHash)
+	require.Contains(t, loaded.Files, source)
+	assert.Equal(t, "no-var", loaded.Files[source].Issues[0].Rule)
+
+	result := loaded.Result(nil)
+	assert.Equal(t, 1, result.FilesScanned)
+	assert.Equal(t, 1, result.Summary.TotalIssues)
+	assert.Equal(t, 1, result.Summary.WarningIssues)
+
+	require.NoError(t, os.WriteFile(statePath, []byte(`{"version": 99, "files": {"a": {}}}`), 0644))
+	other, err := LoadState(statePath)
+	require.NoError(t, err)
+	assert.Empty(t, other.Files, "a state of another version is discarded")
+}
+
+func TestState_Unchanged(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "main.go")
+	info := writeFile(t, source, "package main\n")
+	state := NewState()
+	assert.False(t, state.Unchanged(source, info), "unknown files are changed")
+	require.NoError(t, state.Record(source, info, nil))
+	assert.True(t, state.Unchanged(source, info))
+
+	// Touched but not edited: the hash still matches
+	later := info.ModTime().Add(time.Minute)
+	require.NoError(t, os.Chtimes(source, later, later))
+	touched, err := os.Stat(source)
+	require.NoError(t, err)
+	assert.True(t, state.Unchanged(source, touched))
+	assert.True(t, state.Files[source].ModTime.Equal(later), "the new mtime is remembered")
+
+	edited := writeFile(t, source, "package main\n\nfunc main() {}\n")
+	assert.False(t, state.Unchanged(source, edited))
+}
+
+func TestState_SaveBoundsSize(t *testing.T) {
+	dir := t.TempDir()
+	state := NewState()
+	for i, name := range []string{"old.js", "middle.js", "new.js"} {
+		path := filepath.Join(dir, name)
+		info := writeFile(t, path, name)
+		var issues []models.Issue
+		for line := 1; line <= 20; line++ {
+			issues = append(issues, models.Issue{Rule: "no-console", File: path, Line: line, Message: "Unexpected console statement"})
+		}
+		require.NoError(t, state.Record(path, info, issues))
+		state.Files[path].ScannedAt = time.Date(2024, 1, 1+i, 0, 0, 0, 0, time.UTC)
+	}
+
+	statePath := filepath.Join(dir, "watch-state.json")
+	require.NoError(t, state.Save(statePath, 12000))
+	saved, err := os.Stat(statePath)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, saved.Size(), int64(12000))
+
+	loaded, err := LoadState(statePath)
+	require.NoError(t, err)
+	assert.NotContains(t, loaded.Files, filepath.Join(dir, "old.js"), "the file scanned longest ago goes first")
+	assert.Contains(t, loaded.Files, filepath.Join(dir, "new.js"))
+}
+
+func TestWatcher_ChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept.js")
+	edited := filepath.Join(dir, "edited.js")
+	deleted := filepath.Join(dir, "deleted.js")
+	state := NewState()
+	for _, path := range []string{kept, edited, deleted} {
+		require.NoError(t, state.Record(path, writeFile(t, path, "let a = 1;\n"), nil))
+	}
+	require.NoError(t, os.Remove(deleted))
+	writeFile(t, edited, "let a = 2; let b = 3;\n")
+	added := filepath.Join(dir, "added.js")
+	writeFile(t, added, "let c;\n")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "node_modules"), 0755))
+	writeFile(t, filepath.Join(dir, "node_modules", "lib.js"), "var x;\n")
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	w := &Watcher{config: &models.Configuration{}, logger: logger}
+	changed := w.changedFiles(state, []string{dir})
+
+	assert.ElementsMatch(t, []string{edited, added}, changed)
+	assert.Contains(t, state.Files, kept)
+	assert.NotContains(t, state.Files, deleted, "deleted files are forgotten")
+}
+
+func TestConfigHash(t *testing.T) {
+	dir := t.TempDir()
+	config := &models.Configuration{Vibes: map[models.VibeType]models.VibeConfig{
+		models.VibeTypeCode: {Enabled: true, Settings: map[string]interface{}{"max_line_length": 120}},
+	}}
+	hash := ConfigHash(config, []string{"code", "security"}, "1.0.0", []string{dir})
+
+	assert.Equal(t, hash, ConfigHash(config, []string{"Security", "code"}, "1.0.0", []string{dir}))
+	assert.NotEqual(t, hash, ConfigHash(config, []string{"code"}, "1.0.0", []string{dir}), "vibes")
+	assert.NotEqual(t, hash, ConfigHash(config, []string{"code", "security"}, "1.1.0", []string{dir}), "tool version")
+
+	overridden := &models.Configuration{Vibes: map[models.VibeType]models.VibeConfig{
+		models.VibeTypeCode: {
+			Enabled:           true,
+			Settings:          map[string]interface{}{"max_line_length": 120},
+			SeverityOverrides: map[string]models.SeverityLevel{"line-length": models.SeverityError},
+		},
+	}}
+	assert.NotEqual(t, hash, ConfigHash(overridden, []string{"code", "security"}, "1.0.0", []string{dir}), "severity overrides")
+
+	writeFile(t, filepath.Join(dir, ignore.FileName), "dist/\n")
+	assert.NotEqual(t, hash, ConfigHash(config, []string{"code", "security"}, "1.0.0", []string{dir}), ".kodevibeignore")
+}
+
+func TestWatcher_ResumeDiscardsStateOfOtherConfig(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "app.js")
+	info := writeFile(t, source, "let x = 1;\n")
+	statePath := filepath.Join(t.TempDir(), "watch-state.json")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	config := &models.Configuration{
+		Scanner: models.ScannerConfig{MaxConcurrency: 2, Timeout: 10},
+		Watch:   models.WatchConfig{State: true, StateFile: statePath},
+	}
+	vibes := []string{"code"}
+
+	// The recorded issue is stale: the file has none under the current config
+	stale := NewState()
+	stale.ConfigHash = "saved-by-another-config"
+	require.NoError(t, stale.Record(source, info, []models.Issue{{Type: models.VibeTypeCode, Rule: "no-var", File: source, Line: 1}}))
+	require.NoError(t, stale.Save(statePath, 0))
+
+	w := NewWatcher(config, logger)
+	w.SetToolVersion("1.0.0")
+	w.resume([]string{dir}, false, vibes)
+	assert.Empty(t, w.fileIssues(source), "the file is rescanned")
+
+	saved, err := LoadState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, ConfigHash(config, vibes, "1.0.0", []string{dir}), saved.ConfigHash)
+	require.Contains(t, saved.Files, source)
+}
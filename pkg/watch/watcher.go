@@ -10,26 +10,41 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"kodevibe/internal/models"
+	"kodevibe/internal/utils"
+	kvconfig "kodevibe/pkg/config"
 	"kodevibe/pkg/fix"
 	"kodevibe/pkg/scanner"
 )
 
 // Watcher provides file watching and live scanning capabilities
 type Watcher struct {
-	config      *models.Configuration
-	logger      *logrus.Logger
-	scanner     *scanner.Scanner
-	fixer       *fix.Fixer
-	fsWatcher   *fsnotify.Watcher
-	isWatching  bool
-	stopChan    chan bool
-	mu          sync.RWMutex
-	lastScan    time.Time
-	debounceMap map[string]time.Time
-	debounceMu  sync.Mutex
+	config     *models.Configuration
+	logger     *logrus.Logger
+	scanner    *scanner.Scanner
+	fixer      *fix.Fixer
+	fsWatcher  *fsnotify.Watcher
+	isWatching bool
+	stopChan   chan bool
+	mu         sync.RWMutex
+	lastScan   time.Time
+	// debounce is how long changes must settle before they are scanned
+	debounce  time.Duration
+	debouncer *debouncer
+	// scanMu keeps batches of changed files from being scanned at once
+	scanMu sync.Mutex
+	// statePath is where the watch state persists; empty keeps none
+	statePath string
+	fresh     bool
+	state     *State
+	// toolVersion is part of the state's ConfigHash, so an upgrade rescans
+	toolVersion string
+	// issues are each file's issues from its last scan, to report what changed
+	issues  map[string][]models.Issue
+	stateMu sync.Mutex
 }
 
 // WatchEvent represents a file system event with scan results
@@ -45,16 +60,43 @@ func NewWatcher(config *models.Configuration, logger *logrus.Logger) *Watcher {
 	scannerInstance, _ := scanner.NewScanner(config, logger)
 	fixerInstance := fix.NewFixer(config, logger)
 
+	var statePath string
+	if config.Watch.State {
+		statePath = config.Watch.StateFile
+		if statePath == "" {
+			statePath = kvconfig.NewProjectLayout(".").WatchStatePath()
+		}
+	}
+
+	debounce := config.Watch.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
 	return &Watcher{
-		config:      config,
-		logger:      logger,
-		scanner:     scannerInstance,
-		fixer:       fixerInstance,
-		stopChan:    make(chan bool),
-		debounceMap: make(map[string]time.Time),
+		config:    config,
+		logger:    logger,
+		scanner:   scannerInstance,
+		fixer:     fixerInstance,
+		stopChan:  make(chan bool),
+		debounce:  debounce,
+		statePath: statePath,
+		issues:    make(map[string][]models.Issue),
 	}
 }
 
+// SetFresh makes the watcher ignore the state saved by its last run and
+// rescan every file when it starts
+func (w *Watcher) SetFresh(fresh bool) {
+	w.fresh = fresh
+}
+
+// SetToolVersion sets the KodeVibe version the watch state is saved with;
+// a state saved by another version is discarded
+func (w *Watcher) SetToolVersion(version string) {
+	w.toolVersion = version
+}
+
 // Watch starts watching the specified paths for changes
 func (w *Watcher) Watch(paths []string, autoFix bool, vibes []string) error {
 	w.mu.Lock()
@@ -94,6 +136,13 @@ func (w *Watcher) Watch(paths []string, autoFix bool, vibes []string) error {
 		vibeTypes = append(vibeTypes, models.VibeType(vibe))
 	}
 
+	w.resume(paths, autoFix, vibes)
+
+	w.debouncer = newDebouncer(w.debounce, func(paths []string) {
+		w.scanChangedFiles(paths, autoFix, vibeTypes)
+	})
+	defer w.debouncer.stop()
+
 	// Watch for events
 	for {
 		select {
@@ -101,7 +150,7 @@ func (w *Watcher) Watch(paths []string, autoFix bool, vibes []string) error {
 			if !ok {
 				return nil
 			}
-			w.handleFileEvent(event, autoFix, vibeTypes)
+			w.handleFileEvent(event)
 
 		case err, ok := <-w.fsWatcher.Errors:
 			if !ok {
@@ -164,6 +213,7 @@ func (w *Watcher) shouldSkipDirectory(path string) bool {
 	excludeDirs := []string{
 		".git", "node_modules", "vendor", ".vscode", ".idea",
 		"build", "dist", "coverage", ".nyc_output", ".cache",
+		kvconfig.ProjectDir,
 	}
 
 	dirName := filepath.Base(path)
@@ -175,7 +225,7 @@ func (w *Watcher) shouldSkipDirectory(path string) bool {
 
 	// Check exclude patterns from config
 	for _, pattern := range w.config.Exclude.Files {
-		if matched, err := filepath.Match(pattern, path); err == nil && matched {
+		if utils.MatchPathPattern(pattern, path) {
 			return true
 		}
 	}
@@ -183,32 +233,29 @@ func (w *Watcher) shouldSkipDirectory(path string) bool {
 	return false
 }
 
-// handleFileEvent processes a file system event
-func (w *Watcher) handleFileEvent(event fsnotify.Event, autoFix bool, vibes []models.VibeType) {
-	// Skip if not a write or create event
-	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
-		return
-	}
-
-	// Skip directories
-	if info, err := os.Stat(event.Name); err != nil || info.IsDir() {
-		return
-	}
+// handleFileEvent queues a changed file to be scanned once its changes settle
+func (w *Watcher) handleFileEvent(event fsnotify.Event) {
+	path := filepath.Clean(event.Name)
 
-	// Skip files we don't care about
-	if w.shouldSkipFile(event.Name) {
+	// Skip files we don't care about, including those matching the exclude config
+	if w.shouldSkipFile(path) {
 		return
 	}
 
-	// Debounce rapid successive events for the same file
-	if w.shouldDebounce(event.Name) {
+	switch {
+	case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+		// Deleted and renamed files resolve their issues when the batch is
+		// scanned; a rename's new name arrives as a create
+	case event.Has(fsnotify.Write) || event.Has(fsnotify.Create):
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return
+		}
+	default:
 		return
 	}
 
-	w.logger.Debugf("File changed: %s", event.Name)
-
-	// Scan the file
-	go w.scanFile(event.Name, autoFix, vibes)
+	w.logger.Debugf("File changed: %s", path)
+	w.debouncer.add(path)
 }
 
 // shouldSkipFile checks if a file should be skipped
@@ -219,16 +266,25 @@ func (w *Watcher) shouldSkipFile(filePath string) bool {
 		return true
 	}
 
-	// Skip backup files
+	// Skip the watcher's own state, which it rewrites after every scan
+	if w.statePath != "" && filepath.Clean(filePath) == filepath.Clean(w.statePath) {
+		return true
+	}
+
+	// Skip backup files, including the timestamped ones kodevibe fix writes
 	if strings.HasSuffix(filename, ".backup") ||
+		strings.Contains(filename, ".backup.") ||
 		strings.HasSuffix(filename, ".bak") ||
 		strings.HasSuffix(filename, ".orig") {
 		return true
 	}
 
-	// Check exclude patterns
+	// Skip what scans exclude, by .kodevibeignore and the exclude config
+	if w.scanner != nil && w.scanner.Excludes(filePath) {
+		return true
+	}
 	for _, pattern := range w.config.Exclude.Files {
-		if matched, err := filepath.Match(pattern, filePath); err == nil && matched {
+		if utils.MatchPathPattern(pattern, filePath) {
 			return true
 		}
 	}
@@ -236,53 +292,68 @@ func (w *Watcher) shouldSkipFile(filePath string) bool {
 	return false
 }
 
-// shouldDebounce checks if we should debounce this file event
-func (w *Watcher) shouldDebounce(filePath string) bool {
-	w.debounceMu.Lock()
-	defer w.debounceMu.Unlock()
+// scanChangedFiles scans a settled batch of changed files one at a time,
+// optionally applies fixes, and logs which issues are new and which were
+// resolved since the files were last scanned
+func (w *Watcher) scanChangedFiles(paths []string, autoFix bool, vibes []models.VibeType) {
+	w.scanMu.Lock()
+	defer w.scanMu.Unlock()
 
-	now := time.Now()
-	lastEvent, exists := w.debounceMap[filePath]
+	ctx := context.Background()
+	var delta issueDelta
+	scanned := 0
+	for _, path := range paths {
+		previous := w.fileIssues(path)
 
-	// Debounce for 500ms
-	if exists && now.Sub(lastEvent) < 500*time.Millisecond {
-		return true
+		// A deleted file's issues are resolved
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			w.forgetFile(path)
+			delta.add(previous, nil)
+			continue
+		}
+
+		issues, err := w.scanner.ScanFile(ctx, path, vibes)
+		if err != nil {
+			w.logger.Errorf("Failed to scan file %s: %v", path, err)
+			continue
+		}
+		scanned++
+		w.recordFile(path, issues)
+		delta.add(previous, issues)
+
+		if autoFix && len(issues) > 0 {
+			w.applyAutoFix(path, issues)
+		}
 	}
+	w.saveState()
 
-	w.debounceMap[filePath] = now
-	return false
-}
+	w.mu.Lock()
+	w.lastScan = time.Now()
+	w.mu.Unlock()
 
-// scanFile scans a single file and optionally applies fixes
-func (w *Watcher) scanFile(filePath string, autoFix bool, vibes []models.VibeType) {
-	ctx := context.Background()
+	w.logDelta(scanned, delta)
+}
 
-	// Scan the file
-	issues, err := w.scanner.ScanFile(ctx, filePath, vibes)
-	if err != nil {
-		w.logger.Errorf("Failed to scan file %s: %v", filePath, err)
+// logDelta logs one summary line for a batch, then the issues it added and resolved
+func (w *Watcher) logDelta(scanned int, delta issueDelta) {
+	if len(delta.New) == 0 && len(delta.Resolved) == 0 {
+		w.logger.Infof("🔄 Scanned %d changed file(s): no new or resolved issues", scanned)
 		return
 	}
 
-	if len(issues) == 0 {
-		w.logger.Debugf("✅ No issues found in %s", filePath)
-		return
+	w.logger.Infof("🔄 Scanned %d changed file(s): %d new, %d resolved", scanned, len(delta.New), len(delta.Resolved))
+	w.logIssues(delta.New)
+	for _, issue := range delta.Resolved {
+		w.logger.Infof("  ✅ %s (%s:%d)", issue.Title, issue.File, issue.Line)
 	}
+}
 
-	// Log issues found
-	w.logger.Infof("🔍 Found %d issues in %s", len(issues), filePath)
+// logIssues logs one line per issue
+func (w *Watcher) logIssues(issues []models.Issue) {
 	for _, issue := range issues {
 		icon := w.getSeverityIcon(issue.Severity)
 		w.logger.Infof("  %s %s (%s:%d)", icon, issue.Title, issue.File, issue.Line)
 	}
-
-	// Apply auto-fixes if enabled
-	if autoFix {
-		w.applyAutoFix(filePath, issues)
-	}
-
-	// Update last scan time
-	w.lastScan = time.Now()
 }
 
 // applyAutoFix applies automatic fixes to fixable issues
@@ -340,10 +411,14 @@ func (w *Watcher) WatchSingle(filePath string, autoFix bool, vibes []string) err
 	return w.Watch([]string{filePath}, autoFix, vibes)
 }
 
-// SetDebounceInterval sets the debounce interval for file events
+// SetDebounceInterval sets how long file events must settle before the
+// changed files are scanned, 0 meaning DefaultDebounce. It takes effect the
+// next time Watch starts.
 func (w *Watcher) SetDebounceInterval(interval time.Duration) {
-	// This would be configurable in a real implementation
-	w.logger.Infof("Debounce interval set to %v", interval)
+	if interval <= 0 {
+		interval = DefaultDebounce
+	}
+	w.debounce = interval
 }
 
 // GetWatchedPaths returns the list of currently watched paths
@@ -375,3 +450,180 @@ func (w *Watcher) RemovePath(path string) error {
 
 	return w.fsWatcher.Remove(path)
 }
+
+// resume loads the state the last run saved, shows what it found, and scans
+// the files added or changed since so the state is current before watching.
+// Files that are gone or no longer watched are forgotten.
+func (w *Watcher) resume(paths []string, autoFix bool, vibes []string) {
+	if w.statePath == "" {
+		return
+	}
+
+	configHash := ConfigHash(w.config, vibes, w.toolVersion, paths)
+	state := NewState()
+	if !w.fresh {
+		loaded, err := LoadState(w.statePath)
+		switch {
+		case err != nil:
+			w.logger.Warnf("Ignoring watch state: %v", err)
+		case len(loaded.Files) > 0 && loaded.ConfigHash != configHash:
+			w.logger.Info("Watch state was saved with other vibes, configuration or KodeVibe version; rescanning every file")
+		default:
+			state = loaded
+		}
+	}
+	state.ConfigHash = configHash
+
+	if len(state.Files) > 0 {
+		result := state.Result(w.config.Reporting.GradeThresholds)
+		w.logger.Infof("📋 Last known state from %s: %d issues in %d files",
+			state.SavedAt.Format(time.RFC3339), result.Summary.TotalIssues, result.FilesScanned)
+		w.logIssues(result.Issues)
+	}
+
+	changed := w.changedFiles(state, paths)
+	w.stateMu.Lock()
+	w.state = state
+	for path, file := range state.Files {
+		w.issues[path] = file.Issues
+	}
+	w.stateMu.Unlock()
+
+	if len(changed) > 0 {
+		w.logger.Infof("Scanning %d files added or changed since the last run", len(changed))
+		if err := w.scanChanged(changed, autoFix, vibes); err != nil {
+			w.logger.Errorf("Failed to scan changed files: %v", err)
+		}
+	}
+	w.saveState()
+}
+
+// changedFiles walks the watched paths for files the state does not hold as
+// they are now, and forgets the files it holds that the walk did not find
+func (w *Watcher) changedFiles(state *State, paths []string) []string {
+	seen := make(map[string]bool)
+	var changed []string
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info.IsDir() {
+				if path != root && w.shouldSkipDirectory(path) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if w.shouldSkipFile(path) {
+				return nil
+			}
+			seen[path] = true
+			if !state.Unchanged(path, info) {
+				changed = append(changed, path)
+			}
+			return nil
+		})
+		if err != nil {
+			w.logger.Warnf("Failed to walk %s: %v", root, err)
+		}
+	}
+
+	for path := range state.Files {
+		if !seen[path] {
+			state.Forget(path)
+		}
+	}
+	return changed
+}
+
+// scanChanged scans the changed files in one scan and records each one's issues
+func (w *Watcher) scanChanged(files []string, autoFix bool, vibes []string) error {
+	result, err := w.scanner.Scan(context.Background(), &models.ScanRequest{
+		ID:    uuid.New().String(),
+		Paths: files,
+		Vibes: vibes,
+	})
+	if err != nil {
+		return err
+	}
+
+	issuesByFile := make(map[string][]models.Issue)
+	for _, issue := range result.Issues {
+		file := filepath.Clean(issue.File)
+		issuesByFile[file] = append(issuesByFile[file], issue)
+	}
+	for _, file := range files {
+		issues := issuesByFile[filepath.Clean(file)]
+		w.recordFile(file, issues)
+		if len(issues) == 0 {
+			continue
+		}
+		w.logger.Infof("🔍 Found %d issues in %s", len(issues), file)
+		w.logIssues(issues)
+		if autoFix {
+			w.applyAutoFix(file, issues)
+		}
+	}
+
+	w.mu.Lock()
+	w.lastScan = time.Now()
+	w.mu.Unlock()
+	return nil
+}
+
+// recordFile stores a file's latest issues in the watch state
+func (w *Watcher) recordFile(path string, issues []models.Issue) {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	w.issues[path] = issues
+	if w.state == nil {
+		return
+	}
+
+	info, err := os.Stat(path)
+	if err == nil {
+		err = w.state.Record(path, info, issues)
+	}
+	if err != nil {
+		w.logger.Debugf("Not recording %s in the watch state: %v", path, err)
+		w.state.Forget(path)
+	}
+}
+
+// fileIssues returns a file's issues from its last scan
+func (w *Watcher) fileIssues(path string) []models.Issue {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	return w.issues[path]
+}
+
+// forgetFile drops a deleted or renamed file from the watch state
+func (w *Watcher) forgetFile(path string) {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	delete(w.issues, path)
+	if w.state == nil {
+		return
+	}
+	if _, held := w.state.Files[path]; !held {
+		return
+	}
+	w.state.Forget(path)
+	w.saveStateLocked()
+}
+
+// saveState persists the watch state
+func (w *Watcher) saveState() {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	w.saveStateLocked()
+}
+
+func (w *Watcher) saveStateLocked() {
+	if w.state == nil {
+		return
+	}
+	if err := w.state.Save(w.statePath, w.config.Watch.MaxStateBytes); err != nil {
+		w.logger.Warnf("Failed to save watch state: %v", err)
+	}
+}